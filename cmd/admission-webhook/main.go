@@ -37,6 +37,7 @@ var (
 	healthProbeBindAddress  string
 	gracefulShutdownTimeout time.Duration
 	cacheSyncTimeout        time.Duration
+	driftPreventionDefault  bool
 )
 
 func main() {
@@ -45,6 +46,7 @@ func main() {
 	flag.StringVar(&healthProbeBindAddress, "health-probe-bind-addr", fmt.Sprintf(":%d", configuration.HealthProbePort), "The address the healthz & readyz probes bind to.")
 	flag.DurationVar(&gracefulShutdownTimeout, "graceful-shutdown-timeout", configuration.GracefulShutdownTimeout, "The duration of time to wait while shutting down for all controllers to stop.")
 	flag.DurationVar(&cacheSyncTimeout, "cache-sync-timeout", configuration.CacheSyncTimeout, "The duration of time to wait while informers synchronize.")
+	flag.BoolVar(&driftPreventionDefault, "drift-prevention-default", true, "Whether drift prevention is enabled for RootSyncs, RepoSyncs and Namespaces without the configsync.gke.io/drift-prevention annotation.")
 
 	log.Setup()
 	logger := textlogger.NewLogger(textlogger.NewConfig())
@@ -79,6 +81,12 @@ func main() {
 		os.Exit(1)
 	}
 
+	setupLog.Info("registering drift prevention controller")
+	if err := webhook.AddDriftPreventionController(mgr, driftPreventionDefault); err != nil {
+		setupLog.Error(err, "unable to register drift prevention controller")
+		os.Exit(1)
+	}
+
 	validatorDone := make(chan struct{})
 	var checker healthz.Checker
 
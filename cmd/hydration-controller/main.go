@@ -29,6 +29,7 @@ import (
 	"kpt.dev/configsync/pkg/profiler"
 	"kpt.dev/configsync/pkg/reconcilermanager"
 	"kpt.dev/configsync/pkg/reconcilermanager/controllers"
+	"kpt.dev/configsync/pkg/util"
 	"kpt.dev/configsync/pkg/util/log"
 	ctrl "sigs.k8s.io/controller-runtime"
 )
@@ -72,6 +73,21 @@ var (
 
 	reconcilerSignalsDir = flag.String("reconciler-signals", "/reconciler-signals",
 		"The absolute path in the container that contains reconciler signals written by the reconciler that unblock the rendering phase, for example, the latest image digest that is ready to render.")
+
+	renderingEngine = flag.String("rendering-engine", os.Getenv(reconcilermanager.RenderingEngine),
+		"The engine used to render the source configs. Detected from the files in the sync directory if empty.")
+
+	enableShellInRendering = flag.Bool("enable-shell-in-rendering", util.EnvBool(reconcilermanager.EnableShellInRendering, false),
+		"Whether the shell is enabled in the rendering process.")
+
+	renderCacheDir = flag.String("render-cache-dir", "render-cache",
+		"the name of the rendering cache directory under --repo-root.")
+
+	renderCacheMaxBytes = flag.Int64("render-cache-max-bytes", 0,
+		"The maximum total size of the rendering cache in bytes. The least recently used entries are evicted when exceeded. "+
+			"The rendered output of unchanged inputs is reused across source commits. "+
+			"The output isn't cached if remote bases or Helm charts aren't pinned to a commit or an exact version. "+
+			"Defaults to 0, which disables the rendering cache.")
 )
 
 func main() {
@@ -83,6 +99,10 @@ func main() {
 	if err := kmetrics.RegisterKustomizeMetricsViews(); err != nil {
 		klog.Fatalf("Failed to register OpenCensus views: %v", err)
 	}
+	// Register the rendering cache metric views.
+	if err := kmetrics.RegisterRenderingCacheMetricsViews(); err != nil {
+		klog.Fatalf("Failed to register OpenCensus views: %v", err)
+	}
 
 	// Register the OC Agent exporter
 	oce, err := kmetrics.RegisterOCAgentExporter(reconcilermanager.HydrationController)
@@ -117,17 +137,25 @@ func main() {
 	relSyncDir := cmpath.RelativeOS(dir)
 
 	hydrator := &hydrate.Hydrator{
-		DonePath:            absDonePath,
-		SourceType:          configsync.SourceType(*sourceType),
-		SourceRoot:          absSourceRootDir,
-		HydratedRoot:        absHydratedRootDir,
-		SourceLink:          *sourceLinkDir,
-		HydratedLink:        *hydratedLinkDir,
-		SyncDir:             relSyncDir,
-		ReconcilerSignalDir: absReconcilerSignalDir,
-		PollingPeriod:       *pollingPeriod,
-		RehydratePeriod:     *rehydratePeriod,
-		ReconcilerName:      *reconcilerName,
+		DonePath:               absDonePath,
+		SourceType:             configsync.SourceType(*sourceType),
+		SourceRoot:             absSourceRootDir,
+		HydratedRoot:           absHydratedRootDir,
+		SourceLink:             *sourceLinkDir,
+		HydratedLink:           *hydratedLinkDir,
+		SyncDir:                relSyncDir,
+		ReconcilerSignalDir:    absReconcilerSignalDir,
+		PollingPeriod:          *pollingPeriod,
+		RehydratePeriod:        *rehydratePeriod,
+		ReconcilerName:         *reconcilerName,
+		RenderingEngine:        configsync.RenderingEngine(*renderingEngine),
+		EnableShellInRendering: *enableShellInRendering,
+	}
+	if *renderCacheMaxBytes > 0 {
+		hydrator.RenderCache = &hydrate.RenderCache{
+			Dir:      absRepoRootDir.Join(cmpath.RelativeSlash(*renderCacheDir)).OSPath(),
+			MaxBytes: *renderCacheMaxBytes,
+		}
 	}
 
 	hydrator.Run(context.Background())
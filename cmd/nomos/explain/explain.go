@@ -0,0 +1,200 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package explain
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	admissionv1 "k8s.io/api/admission/v1"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"kpt.dev/configsync/cmd/nomos/flags"
+	"kpt.dev/configsync/pkg/client/restconfig"
+	"kpt.dev/configsync/pkg/webhook"
+	"kpt.dev/configsync/pkg/webhook/configuration"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+var (
+	filename  string
+	username  string
+	groups    []string
+	operation string
+)
+
+func init() {
+	Cmd.Flags().StringVarP(&filename, "filename", "f", "",
+		"Path to a file containing the new version of the object. Required.")
+	Cmd.Flags().StringVar(&username, "as", "",
+		"Username of the user making the request. Required.")
+	Cmd.Flags().StringSliceVar(&groups, "as-group", nil,
+		"Groups of the user making the request.")
+	Cmd.Flags().StringVar(&operation, "operation", "",
+		"Operation to explain. Accepts 'CREATE', 'UPDATE' and 'DELETE'. Defaults to UPDATE if the object exists in the cluster, and CREATE otherwise.")
+	Cmd.Flags().DurationVar(&flags.ClientTimeout, "timeout", restconfig.DefaultTimeout, "Timeout for connecting to the cluster")
+	flags.AddOutputFormat(Cmd)
+}
+
+// Cmd is the Cobra object representing the nomos explain command.
+var Cmd = &cobra.Command{
+	Use:   "explain",
+	Short: "Explains whether the Config Sync admission webhook would allow a change to an object",
+	Long: `Explains whether the Config Sync admission webhook would allow a user to change an object.
+
+The file must contain the complete new version of the object. The current version is
+read from the cluster. The explanation includes which reconciler manages the object,
+which fields are declared in the source of truth, which fields would change, and which
+rule of the admission webhook would block the change.
+
+The request is evaluated locally with your credentials, like the admission webhook
+would, including whether drift prevention is enabled for the object. Requires
+permission to get the object, its Namespace and the admission-webhook
+ValidatingWebhookConfiguration.`,
+	Example: `  nomos explain -f role.yaml --as bob@example.com
+  nomos explain -f role.yaml --as bob@example.com --operation DELETE`,
+	Args: cobra.ExactArgs(0),
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		if filename == "" {
+			return fmt.Errorf("--filename is required")
+		}
+		if username == "" {
+			return fmt.Errorf("--as is required")
+		}
+		// Don't show usage on error, as argument validation passed.
+		cmd.SilenceUsage = true
+
+		obj, err := readObject(filename)
+		if err != nil {
+			return err
+		}
+		exp, err := explain(cmd.Context(), obj)
+		if err != nil {
+			return err
+		}
+		return printExplanation(exp)
+	},
+}
+
+// readObject reads a single object from a YAML or JSON file.
+func readObject(path string) (*unstructured.Unstructured, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %q: %w", path, err)
+	}
+	obj := &unstructured.Unstructured{}
+	if err := yaml.Unmarshal(data, &obj.Object); err != nil {
+		return nil, fmt.Errorf("failed to decode %q: %w", path, err)
+	}
+	if obj.GetKind() == "" || obj.GetName() == "" {
+		return nil, fmt.Errorf("%q must contain a single object with a kind and name", path)
+	}
+	return obj, nil
+}
+
+// explain reads the current version of the object from the cluster and sends
+// both versions to the admission webhook's explanation endpoint, through the
+// API server's service proxy.
+func explain(ctx context.Context, obj *unstructured.Unstructured) (*webhook.Explanation, error) {
+	cfg, err := restconfig.NewRestConfig(flags.ClientTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create rest config: %w", err)
+	}
+	c, err := client.New(cfg, client.Options{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kubernetes client: %w", err)
+	}
+
+	req := &webhook.ExplainRequest{
+		Username:  username,
+		Groups:    groups,
+		Operation: admissionv1.Operation(strings.ToUpper(operation)),
+	}
+	live := &unstructured.Unstructured{}
+	live.SetGroupVersionKind(obj.GroupVersionKind())
+	err = c.Get(ctx, client.ObjectKeyFromObject(obj), live)
+	switch {
+	case err == nil:
+		req.OldObject = live
+	case apierrors.IsNotFound(err):
+		// The object does not exist yet, so the request would create it.
+	default:
+		return nil, fmt.Errorf("failed to get object from the cluster: %w", err)
+	}
+	if req.Operation != admissionv1.Delete {
+		req.Object = obj
+	}
+	admissionReq, err := req.AdmissionRequest()
+	if err != nil {
+		return nil, err
+	}
+
+	// The webhook configuration decides whether the API server sends the
+	// request to the webhook at all.
+	webhookCfg := &admissionregistrationv1.ValidatingWebhookConfiguration{}
+	err = c.Get(ctx, client.ObjectKey{Name: configuration.Name}, webhookCfg)
+	switch {
+	case err == nil:
+	case apierrors.IsNotFound(err):
+		// The webhook is not installed, so it intercepts nothing.
+		webhookCfg = nil
+	default:
+		return nil, fmt.Errorf("failed to get the admission webhook configuration: %w", err)
+	}
+	var namespace *corev1.Namespace
+	if obj.GetNamespace() != "" {
+		namespace = &corev1.Namespace{}
+		err = c.Get(ctx, client.ObjectKey{Name: obj.GetNamespace()}, namespace)
+		switch {
+		case err == nil:
+		case apierrors.IsNotFound(err):
+			namespace = nil
+		default:
+			return nil, fmt.Errorf("failed to get the namespace of the object: %w", err)
+		}
+	}
+
+	validator, err := webhook.NewValidator(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the admission webhook validator: %w", err)
+	}
+	return validator.ExplainIntercepted(admissionReq, webhookCfg, namespace), nil
+}
+
+func printExplanation(exp *webhook.Explanation) error {
+	var out []byte
+	var err error
+	switch flags.OutputFormat {
+	case flags.OutputJSON:
+		out, err = json.MarshalIndent(exp, "", "  ")
+		out = append(out, '\n')
+	case flags.OutputYAML:
+		out, err = yaml.Marshal(exp)
+	default:
+		return fmt.Errorf("unsupported output format: %q", flags.OutputFormat)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to encode explanation: %w", err)
+	}
+	_, err = os.Stdout.Write(out)
+	return err
+}
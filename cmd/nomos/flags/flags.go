@@ -21,7 +21,10 @@ import (
 	"github.com/spf13/cobra"
 	"kpt.dev/configsync/pkg/api/configsync"
 	"kpt.dev/configsync/pkg/client/restconfig"
+	"kpt.dev/configsync/pkg/importer/analyzer/transform/selectors"
+	"kpt.dev/configsync/pkg/metadata"
 	"kpt.dev/configsync/pkg/reconcilermanager"
+	"kpt.dev/configsync/pkg/validate/variables"
 )
 
 const (
@@ -67,6 +70,17 @@ var (
 	// SourceFormat indicates the format of the Git repository.
 	SourceFormat string
 
+	// ClusterLabelsFile is the path of the file declaring the labels of the
+	// cluster matched by ClusterSelectors.
+	ClusterLabelsFile string
+
+	// VarsFile is the path of the file declaring the variables substituted in
+	// the objects.
+	VarsFile string
+
+	// RenderingEngine is the engine used to render the source configs.
+	RenderingEngine string
+
 	// OutputFormat is the format of output.
 	OutputFormat string
 
@@ -89,6 +103,22 @@ func AddClusters(cmd *cobra.Command) {
 		`Accepts a comma-separated list of Cluster names to use in multi-cluster commands. Defaults to all clusters. Use "" for no clusters.`)
 }
 
+// AddClusterLabels adds the --cluster-labels flag.
+func AddClusterLabels(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&ClusterLabelsFile, "cluster-labels", "",
+		fmt.Sprintf(`Path to a file declaring the labels of the cluster matched by ClusterSelectors, either as a map of labels or as the ConfigMap %s/%s exported from the cluster. `,
+			configsync.ControllerNamespace, selectors.ClusterLabelsConfigMapName)+
+			`They override the labels of the Cluster objects declared in the repository.`)
+}
+
+// AddVars adds the --vars flag.
+func AddVars(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&VarsFile, "vars", "",
+		fmt.Sprintf(`Path to a file declaring the variables substituted in the objects annotated with %s: %s, either as a map of variables or as the ConfigMap %s/%s exported from the cluster. `,
+			metadata.SubstituteVariablesAnnotationKey, metadata.SubstituteVariablesEnabled, configsync.ControllerNamespace, variables.ConfigMapName)+
+			fmt.Sprintf(`The built-in %s variable is set to the name of each Cluster.`, variables.ClusterName))
+}
+
 // AddPath adds the --path flag.
 func AddPath(cmd *cobra.Command) {
 	cmd.Flags().StringVar(&Path, pathFlag, PathDefault,
@@ -113,6 +143,13 @@ func AddSourceFormat(cmd *cobra.Command) {
 			configsync.SourceFormatHierarchy, configsync.SourceFormatUnstructured))
 }
 
+// AddRenderingEngine adds the --rendering-engine flag.
+func AddRenderingEngine(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&RenderingEngine, "rendering-engine", "",
+		fmt.Sprintf("Engine used to render the source configs: %s, %s, %s or %s. Detected from the files in the source directory if not set.",
+			configsync.RenderingEngineKustomize, configsync.RenderingEngineKpt, configsync.RenderingEngineJsonnet, configsync.RenderingEngineCUE))
+}
+
 // AddOutputFormat adds the --format flag.
 func AddOutputFormat(cmd *cobra.Command) {
 	cmd.Flags().StringVar(&OutputFormat, "format", "yaml",
@@ -39,9 +39,12 @@ var (
 
 func init() {
 	flags.AddClusters(Cmd)
+	flags.AddClusterLabels(Cmd)
+	flags.AddVars(Cmd)
 	flags.AddPath(Cmd)
 	flags.AddSkipAPIServerCheck(Cmd)
 	flags.AddSourceFormat(Cmd)
+	flags.AddRenderingEngine(Cmd)
 	flags.AddOutputFormat(Cmd)
 	flags.AddAPIServerTimeout(Cmd)
 	Cmd.Flags().BoolVar(&flat, "flat", false,
@@ -77,14 +80,17 @@ which you could kubectl apply -fR to the cluster, or have Config Sync sync to th
 		if sourceFormat == "" {
 			sourceFormat = configsync.SourceFormatHierarchy
 		}
-		rootDir, needsHydrate, err := hydrate.ValidateHydrateFlags(sourceFormat)
+		rootDir, renderer, err := hydrate.ValidateHydrateFlags(sourceFormat)
 		if err != nil {
 			return err
 		}
 
-		if needsHydrate {
+		// Objects rendered from the source are located relative to the source
+		// directory rather than the hydrated output.
+		policyDir := cmpath.RelativeOS(rootDir.OSPath())
+		if renderer != nil {
 			// update rootDir to point to the hydrated output for further processing.
-			if rootDir, err = hydrate.ValidateAndRunKustomize(rootDir.OSPath()); err != nil {
+			if rootDir, err = hydrate.ValidateAndRender(renderer, rootDir.OSPath()); err != nil {
 				return err
 			}
 			// delete the hydrated output directory in the end.
@@ -105,6 +111,7 @@ which you could kubectl apply -fR to the cluster, or have Config Sync sync to th
 			return err
 		}
 		validateOpts.FieldManager = util.FieldManager
+		validateOpts.PolicyDir = policyDir
 
 		if sourceFormat == configsync.SourceFormatHierarchy {
 			files = filesystem.FilterHierarchyFiles(rootDir, files)
@@ -115,7 +122,7 @@ which you could kubectl apply -fR to the cluster, or have Config Sync sync to th
 
 		filePaths := reader.FilePaths{
 			RootDir:   rootDir,
-			PolicyDir: cmpath.RelativeOS(rootDir.OSPath()),
+			PolicyDir: policyDir,
 			Files:     files,
 		}
 
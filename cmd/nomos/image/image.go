@@ -0,0 +1,237 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package image
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"kpt.dev/configsync/pkg/reconcilermanager"
+	"kpt.dev/configsync/pkg/util/imagemirror"
+	pkgversion "kpt.dev/configsync/pkg/version"
+)
+
+const (
+	// releaseManifestURL is the URL of the manifest of a released version.
+	releaseManifestURL = "https://github.com/GoogleContainerTools/kpt-config-sync/releases/download/%s/config-sync-manifest.yaml"
+
+	defaultTimeout = 30 * time.Second
+)
+
+var (
+	version  string
+	manifest string
+	mirror   string
+	timeout  time.Duration
+)
+
+func init() {
+	listCmd.Flags().StringVar(&version, "version", pkgversion.VERSION,
+		"Config Sync version to list the images of. Defaults to the version of nomos.")
+	listCmd.Flags().StringVar(&manifest, "manifest", "",
+		"Path or URL of a Config Sync manifest to list the images of, instead of the manifest of a released version.")
+	listCmd.Flags().StringVar(&mirror, "mirror", "",
+		"Registry prefix of the mirror, for example registry.example.com/config-sync. "+
+			"If set, each image is printed with its destination in the mirror.")
+	listCmd.Flags().DurationVar(&timeout, "timeout", defaultTimeout,
+		"Timeout for downloading the manifest")
+	Cmd.AddCommand(listCmd)
+}
+
+// Cmd is the Cobra object representing the nomos image command.
+var Cmd = &cobra.Command{
+	Use:   "image",
+	Short: "Lists the container images of Config Sync",
+	Args:  cobra.ExactArgs(0),
+}
+
+var listCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lists the container images required by a version of Config Sync",
+	Long: `Lists the container images required by a version of Config Sync, including the images
+of the reconciler Deployments created by the reconciler-manager, so they can be copied to a
+registry mirror before installing Config Sync in a cluster without access to the public registries.
+
+With --mirror, each line contains the image followed by its destination in the mirror, which
+is the image that the reconciler-manager pulls when started with the same
+--image-registry-mirror flag.`,
+	Example: `  nomos image list --version v1.20.0
+  nomos image list --manifest ./config-sync-manifest.yaml --mirror registry.example.com/config-sync`,
+	Args: cobra.ExactArgs(0),
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		if mirror != "" {
+			if err := imagemirror.Validate(mirror); err != nil {
+				return err
+			}
+		}
+		source := manifest
+		if source == "" {
+			if version == "" || version == "UNKNOWN" {
+				return fmt.Errorf("--version or --manifest is required")
+			}
+			source = fmt.Sprintf(releaseManifestURL, version)
+		}
+		// Don't show usage on error, as argument validation passed.
+		cmd.SilenceUsage = true
+
+		ctx, cancel := context.WithTimeout(cmd.Context(), timeout)
+		defer cancel()
+		data, err := readManifest(ctx, source)
+		if err != nil {
+			return err
+		}
+		images, err := listImages(data)
+		if err != nil {
+			return fmt.Errorf("failed to list images of %q: %w", source, err)
+		}
+		for _, image := range images {
+			if mirror != "" {
+				fmt.Fprintf(cmd.OutOrStdout(), "%s %s\n", image, imagemirror.Rewrite(image, mirror))
+			} else {
+				fmt.Fprintln(cmd.OutOrStdout(), image)
+			}
+		}
+		return nil
+	},
+}
+
+// readManifest reads the manifest from a local file or an HTTP(S) URL.
+func readManifest(ctx context.Context, source string) ([]byte, error) {
+	if !strings.HasPrefix(source, "https://") && !strings.HasPrefix(source, "http://") {
+		data, err := os.ReadFile(source)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %q: %w", source, err)
+		}
+		return data, nil
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, source, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %q: %w", source, err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %q: %w", source, err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download %q: %s", source, resp.Status)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %q: %w", source, err)
+	}
+	return data, nil
+}
+
+// listImages returns the sorted container images of the workloads in the
+// manifest, including the workloads embedded in ConfigMaps, like the reconciler
+// Deployment template of the reconciler-manager.
+func listImages(data []byte) ([]string, error) {
+	images := map[string]struct{}{}
+	if err := collectImages(data, images); err != nil {
+		return nil, err
+	}
+	// The reconciler-manager uses the hydration-controller image with a shell
+	// when enableShellInRendering is set.
+	for image := range images {
+		if strings.Contains(image, reconcilermanager.HydrationController+":") {
+			withShell := strings.ReplaceAll(image, reconcilermanager.HydrationController+":", reconcilermanager.HydrationControllerWithShell+":")
+			images[withShell] = struct{}{}
+		}
+	}
+	var result []string
+	for image := range images {
+		result = append(result, image)
+	}
+	sort.Strings(result)
+	return result, nil
+}
+
+// podSpecPaths are the paths of the pod specs of the supported workloads.
+var podSpecPaths = [][]string{
+	{"spec", "template", "spec"},
+	{"spec", "jobTemplate", "spec", "template", "spec"},
+}
+
+func collectImages(data []byte, images map[string]struct{}) error {
+	decoder := utilyaml.NewYAMLOrJSONDecoder(bytes.NewReader(data), 4096)
+	for {
+		obj := &unstructured.Unstructured{}
+		if err := decoder.Decode(&obj.Object); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		if obj.Object == nil {
+			// Empty document
+			continue
+		}
+		if obj.GetKind() == "ConfigMap" {
+			collectConfigMapImages(obj, images)
+			continue
+		}
+		for _, path := range podSpecPaths {
+			podSpec, found, err := unstructured.NestedMap(obj.Object, path...)
+			if err != nil || !found {
+				continue
+			}
+			collectPodSpecImages(podSpec, images)
+		}
+	}
+}
+
+// collectConfigMapImages collects the images of the workloads in the values of
+// the ConfigMap. Values which aren't objects are ignored.
+func collectConfigMapImages(cm *unstructured.Unstructured, images map[string]struct{}) {
+	values, _, _ := unstructured.NestedStringMap(cm.Object, "data")
+	for _, value := range values {
+		embedded := map[string]struct{}{}
+		if err := collectImages([]byte(value), embedded); err != nil {
+			continue
+		}
+		for image := range embedded {
+			images[image] = struct{}{}
+		}
+	}
+}
+
+func collectPodSpecImages(podSpec map[string]interface{}, images map[string]struct{}) {
+	for _, field := range []string{"initContainers", "containers"} {
+		containers, _, _ := unstructured.NestedSlice(podSpec, field)
+		for _, container := range containers {
+			containerMap, ok := container.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if image, ok := containerMap["image"].(string); ok && image != "" {
+				images[image] = struct{}{}
+			}
+		}
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package image
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testManifest = `
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: config-management-system
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: reconciler-manager
+  namespace: config-management-system
+spec:
+  template:
+    spec:
+      containers:
+      - name: reconciler-manager
+        image: gcr.io/config-management-release/reconciler-manager:v1.20.0
+      - name: otel-agent
+        image: gcr.io/config-management-release/otelcontribcol:v0.103.0-gke.7
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: reconciler-manager-cm
+  namespace: config-management-system
+data:
+  deployment.yaml: |
+    apiVersion: apps/v1
+    kind: Deployment
+    spec:
+      template:
+        spec:
+          containers:
+          - name: hydration-controller
+            image: gcr.io/config-management-release/hydration-controller:v1.20.0
+          - name: reconciler
+            image: gcr.io/config-management-release/reconciler:v1.20.0
+          - name: git-sync
+            image: gcr.io/config-management-release/git-sync:v4.2.3-gke.5__linux_amd64
+          - name: otel-agent
+            image: gcr.io/config-management-release/otelcontribcol:v0.103.0-gke.7
+  otel-agent-config.yaml: |
+    receivers:
+      opencensus:
+  invalid: "{"
+`
+
+func TestListImages(t *testing.T) {
+	images, err := listImages([]byte(testManifest))
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"gcr.io/config-management-release/git-sync:v4.2.3-gke.5__linux_amd64",
+		"gcr.io/config-management-release/hydration-controller-with-shell:v1.20.0",
+		"gcr.io/config-management-release/hydration-controller:v1.20.0",
+		"gcr.io/config-management-release/otelcontribcol:v0.103.0-gke.7",
+		"gcr.io/config-management-release/reconciler-manager:v1.20.0",
+		"gcr.io/config-management-release/reconciler:v1.20.0",
+	}, images)
+}
+
+func TestReadManifest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/config-sync-manifest.yaml" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(testManifest))
+	}))
+	defer server.Close()
+
+	data, err := readManifest(context.Background(), server.URL+"/config-sync-manifest.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, testManifest, string(data))
+
+	_, err = readManifest(context.Background(), server.URL+"/missing.yaml")
+	assert.ErrorContains(t, err, "404")
+}
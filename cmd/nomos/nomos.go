@@ -24,9 +24,12 @@ import (
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 	"k8s.io/klog/v2"
 	"kpt.dev/configsync/cmd/nomos/bugreport"
+	"kpt.dev/configsync/cmd/nomos/explain"
 	"kpt.dev/configsync/cmd/nomos/hydrate"
+	"kpt.dev/configsync/cmd/nomos/image"
 	"kpt.dev/configsync/cmd/nomos/initialize"
 	"kpt.dev/configsync/cmd/nomos/migrate"
+	"kpt.dev/configsync/cmd/nomos/pack"
 	"kpt.dev/configsync/cmd/nomos/status"
 	"kpt.dev/configsync/cmd/nomos/version"
 	"kpt.dev/configsync/cmd/nomos/vet"
@@ -62,6 +65,9 @@ func init() {
 	rootCmd.AddCommand(status.Cmd)
 	rootCmd.AddCommand(bugreport.Cmd)
 	rootCmd.AddCommand(migrate.Cmd)
+	rootCmd.AddCommand(explain.Cmd)
+	rootCmd.AddCommand(image.Cmd)
+	rootCmd.AddCommand(pack.Cmd)
 }
 
 func main() {
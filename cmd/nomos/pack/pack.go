@@ -0,0 +1,200 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package pack implements the nomos package command.
+package pack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	"github.com/spf13/cobra"
+	"kpt.dev/configsync/cmd/nomos/flags"
+	nomosparse "kpt.dev/configsync/cmd/nomos/parse"
+	"kpt.dev/configsync/cmd/nomos/util"
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/declared"
+	"kpt.dev/configsync/pkg/hydrate"
+	"kpt.dev/configsync/pkg/importer/analyzer/ast"
+	"kpt.dev/configsync/pkg/importer/filesystem"
+	"kpt.dev/configsync/pkg/importer/filesystem/cmpath"
+	"kpt.dev/configsync/pkg/importer/reader"
+	"kpt.dev/configsync/pkg/oci"
+	"kpt.dev/configsync/pkg/status"
+)
+
+var (
+	imageName string
+	outPath   string
+)
+
+func init() {
+	flags.AddClusterLabels(Cmd)
+	flags.AddVars(Cmd)
+	flags.AddPath(Cmd)
+	flags.AddSkipAPIServerCheck(Cmd)
+	flags.AddSourceFormat(Cmd)
+	flags.AddRenderingEngine(Cmd)
+	flags.AddAPIServerTimeout(Cmd)
+	Cmd.Flags().StringVar(&imageName, "image", "",
+		`Name of the image, for example us-docker.pkg.dev/my-project/config/acme:v1. Required.`)
+	Cmd.Flags().StringVar(&outPath, "output", "",
+		`If set, writes the image to a tarball at this path, which can be pushed later with "crane push", instead of pushing it to the registry.`)
+}
+
+// Cmd is the Cobra object representing the nomos package command.
+var Cmd = &cobra.Command{
+	Use:   "package",
+	Short: "Packages an Anthos Configuration Management directory into an OCI image",
+	Long: `Packages an Anthos Configuration Management directory into an OCI image which can be
+synced by a RootSync or RepoSync with the oci source type.
+
+The directory is validated as with nomos vet. In the hierarchy source format, only the
+system/, cluster/, clusterregistry/ and namespaces/ directories are packaged. The files are
+packaged in a single layer at their paths relative to the directory, so the image is synced with
+spec.oci.dir set to ".", and the source format is recorded in the image manifest annotation
+` + oci.SourceFormatAnnotationKey + `.
+
+The image is pushed with the credentials of the Docker config, for example configured with
+"gcloud auth configure-docker".`,
+	Example: `  nomos package --path ./acme --image us-docker.pkg.dev/my-project/config/acme:v1
+  nomos hydrate --path ./acme --output ./compiled && \
+    nomos package --path ./compiled --source-format unstructured --image us-docker.pkg.dev/my-project/config/acme:v1`,
+	Args: cobra.ExactArgs(0),
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		if imageName == "" {
+			return fmt.Errorf("--image is required")
+		}
+		ref, err := name.ParseReference(imageName)
+		if err != nil {
+			return fmt.Errorf("invalid --image %q: %w", imageName, err)
+		}
+		// Don't show usage on error, as argument validation passed.
+		cmd.SilenceUsage = true
+
+		sourceFormat := configsync.SourceFormat(flags.SourceFormat)
+		if sourceFormat == "" {
+			sourceFormat = configsync.SourceFormatHierarchy
+		}
+		rootDir, files, err := validateFiles(cmd.Context(), sourceFormat)
+		if err != nil {
+			return err
+		}
+
+		paths := make([]string, len(files))
+		for i, file := range files {
+			paths[i] = file.OSPath()
+		}
+		image, err := oci.Package(rootDir.OSPath(), paths, map[string]string{
+			oci.SourceFormatAnnotationKey: string(sourceFormat),
+		})
+		if err != nil {
+			return err
+		}
+		digest, err := image.Digest()
+		if err != nil {
+			return fmt.Errorf("failed to calculate the image digest: %w", err)
+		}
+
+		if outPath != "" {
+			if err := tarball.WriteToFile(outPath, ref, image); err != nil {
+				return fmt.Errorf("failed to write the image to %q: %w", outPath, err)
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Wrote %s@%s to %s\n", ref.Context(), digest, outPath)
+			return nil
+		}
+		if err := remote.Write(ref, image, remote.WithContext(cmd.Context()),
+			remote.WithAuthFromKeychain(authn.DefaultKeychain)); err != nil {
+			return fmt.Errorf("failed to push the image %s: %w", ref, err)
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Pushed %s@%s\n", ref.Context(), digest)
+		return nil
+	},
+}
+
+// validateFiles validates the source directory for every Cluster, and returns
+// the absolute path of the directory and the files to package.
+func validateFiles(ctx context.Context, sourceFormat configsync.SourceFormat) (cmpath.Absolute, []cmpath.Absolute, error) {
+	rootDir, renderer, err := hydrate.ValidateHydrateFlags(sourceFormat)
+	if err != nil {
+		return "", nil, err
+	}
+	files, err := nomosparse.FindFiles(rootDir)
+	if err != nil {
+		return "", nil, err
+	}
+	if sourceFormat == configsync.SourceFormatHierarchy {
+		files = filesystem.FilterHierarchyFiles(rootDir, files)
+	}
+
+	// The source files are packaged as is, and rendered by the reconciler.
+	// Only the rendered output is validated.
+	validateDir := rootDir
+	renderedFiles := files
+	if renderer != nil {
+		if validateDir, err = hydrate.ValidateAndRender(renderer, rootDir.OSPath()); err != nil {
+			return "", nil, err
+		}
+		defer func() {
+			_ = os.RemoveAll(validateDir.OSPath())
+		}()
+		if renderedFiles, err = nomosparse.FindFiles(validateDir); err != nil {
+			return "", nil, err
+		}
+	}
+
+	validateOpts, err := hydrate.ValidateOptions(ctx, validateDir, flags.APIServerTimeout)
+	if err != nil {
+		return "", nil, err
+	}
+	validateOpts.FieldManager = util.FieldManager
+	// Objects rendered from the source are located relative to the source
+	// directory rather than the hydrated output.
+	validateOpts.PolicyDir = cmpath.RelativeOS(rootDir.OSPath())
+	if sourceFormat != configsync.SourceFormatHierarchy {
+		validateOpts.Scope = declared.RootScope
+	}
+
+	parseOpts := hydrate.ParseOptions{
+		Parser:       filesystem.NewParser(&reader.File{}),
+		SourceFormat: sourceFormat,
+		FilePaths: reader.FilePaths{
+			RootDir:   validateDir,
+			PolicyDir: validateOpts.PolicyDir,
+			Files:     renderedFiles,
+		},
+	}
+
+	var clusterErrs []string
+	hydrate.ForEachCluster(ctx, parseOpts, validateOpts, func(clusterName string, _ []ast.FileObject, err status.MultiError) {
+		if err == nil {
+			return
+		}
+		if clusterName == "" {
+			clusterName = nomosparse.UnregisteredCluster
+		}
+		clusterErrs = append(clusterErrs, fmt.Sprintf("errors for Cluster %q: %v", clusterName, err))
+	})
+	if len(clusterErrs) > 0 {
+		return "", nil, errors.New(strings.Join(clusterErrs, "\n\n"))
+	}
+	return rootDir, files, nil
+}
@@ -125,6 +125,12 @@ func (r *RepoState) printRows(writer io.Writer) {
 			} else {
 				util.MustFprintf(writer, "%s\t%s\t%s\t%s\t%s\n", util.Indent, r.Namespace, r.String(), r.Status, r.SourceHash)
 			}
+			if r.SourceLocation != "" {
+				util.MustFprintf(writer, "%s%s%s%ssource: %s\n", util.Indent, util.Indent, util.Indent, util.Indent, r.SourceLocation)
+			}
+			if r.SourceChain != "" {
+				util.MustFprintf(writer, "%s%s%s%srendered from: %s\n", util.Indent, util.Indent, util.Indent, util.Indent, r.SourceChain)
+			}
 			if len(r.Conditions) > 0 {
 				for _, condition := range r.Conditions {
 					util.MustFprintf(writer, "%s%s%s%s%s\n", util.Indent, util.Indent, util.Indent, util.Indent, condition.Message)
@@ -90,6 +90,28 @@ func TestRepoState_PrintRows(t *testing.T) {
 			},
 			"  <root>:root-sync\thttps://github.com/tester/sample@master\t\n  SYNCED @ 0001-01-01 00:00:00 +0000 UTC\tabc123\t\n  Managed resources:\n  \tNAMESPACE\tNAME\tSTATUS\tSOURCEHASH\n  \tbookstore\tdeployment.apps/test\tCurrent\tabc123\n  \tbookstore\tservice/test\tFailed\tabc123\n        A detailed message explaining the current condition.\n  \tbookstore\tservice/test2\tConflict\tabc123\n        A detailed message explaining why it is in the status ownership overlap.\n",
 		},
+		{
+			"resources with source location",
+			&RepoState{
+				scope:    "<root>",
+				syncName: "root-sync",
+				git: &v1beta1.Git{
+					Repo: "https://github.com/tester/sample/",
+				},
+				status: "SYNCED",
+				commit: "abc123",
+				resources: []resourceState{{
+					Kind:           "ConfigMap",
+					Namespace:      "bookstore",
+					Name:           "test",
+					Status:         "Current",
+					SourceHash:     "abc123",
+					SourceLocation: "base/configmap.yaml:3:1",
+					SourceChain:    "base/configmap.yaml -> overlay/kustomization.yaml (NamespaceTransformer namespace)",
+				}},
+			},
+			"  <root>:root-sync\thttps://github.com/tester/sample@master\t\n  SYNCED @ 0001-01-01 00:00:00 +0000 UTC\tabc123\t\n  Managed resources:\n  \tNAMESPACE\tNAME\tSTATUS\tSOURCEHASH\n  \tbookstore\tconfigmap/test\tCurrent\tabc123\n        source: base/configmap.yaml:3:1\n        rendered from: base/configmap.yaml -> overlay/kustomization.yaml (NamespaceTransformer namespace)\n",
+		},
 		{
 			"optional git subdirectory specified",
 			&RepoState{
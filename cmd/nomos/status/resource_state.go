@@ -24,13 +24,17 @@ import (
 )
 
 type resourceState struct {
-	Namespace  string      `json:"namespace"`
-	Name       string      `json:"name"`
-	Group      string      `json:"group,omitempty"`
-	Kind       string      `json:"kind"`
-	Status     string      `json:"status"`
-	SourceHash string      `json:"sourceHash,omitempty"`
-	Conditions []Condition `json:"conditions,omitempty"`
+	Namespace  string `json:"namespace"`
+	Name       string `json:"name"`
+	Group      string `json:"group,omitempty"`
+	Kind       string `json:"kind"`
+	Status     string `json:"status"`
+	SourceHash string `json:"sourceHash,omitempty"`
+	// SourceLocation is the file, line and column which declare the resource.
+	SourceLocation string `json:"sourceLocation,omitempty"`
+	// SourceChain lists the files the resource was rendered from.
+	SourceChain string      `json:"sourceChain,omitempty"`
+	Conditions  []Condition `json:"conditions,omitempty"`
 }
 
 // Condition is the for the resource status condition
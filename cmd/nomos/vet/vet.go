@@ -22,6 +22,7 @@ import (
 	"kpt.dev/configsync/cmd/nomos/flags"
 	"kpt.dev/configsync/pkg/api/configsync"
 	"kpt.dev/configsync/pkg/importer/analyzer/validation/system"
+	"kpt.dev/configsync/pkg/validate/policy"
 )
 
 var (
@@ -29,13 +30,18 @@ var (
 	keepOutput     bool
 	threshold      int
 	outPath        string
+	policyFiles    []string
+	schemaBundle   string
 )
 
 func init() {
 	flags.AddClusters(Cmd)
+	flags.AddClusterLabels(Cmd)
+	flags.AddVars(Cmd)
 	flags.AddPath(Cmd)
 	flags.AddSkipAPIServerCheck(Cmd)
 	flags.AddSourceFormat(Cmd)
+	flags.AddRenderingEngine(Cmd)
 	flags.AddOutputFormat(Cmd)
 	flags.AddAPIServerTimeout(Cmd)
 	Cmd.Flags().StringVar(&namespaceValue, "namespace", "",
@@ -60,6 +66,15 @@ func init() {
 
 	Cmd.Flags().StringVar(&outPath, "output", flags.DefaultHydrationOutput,
 		`Location of the hydrated output`)
+
+	Cmd.Flags().StringSliceVar(&policyFiles, "validation-policy", nil,
+		fmt.Sprintf(`Paths to files declaring ValidationPolicies, or the ConfigMap %s/%s exported from a cluster. `, configsync.ControllerNamespace, policy.ConfigMapName)+
+			`They are enforced in addition to the ValidationPolicies declared in the repository.`)
+
+	Cmd.Flags().StringVar(&schemaBundle, "schema-bundle", "",
+		`Path to an OpenAPI v2 document in JSON, YAML or protobuf, as served by the /openapi/v2 endpoint of the API server. `+
+			`If set, the objects are validated against its schemas rather than the schemas served by the cluster, `+
+			`which allows validating them offline with --no-api-server-check.`)
 }
 
 // Cmd is the Cobra object representing the nomos vet command.
@@ -84,6 +99,8 @@ returns a non-zero error code if any issues are found.
 			SourceFormat:     configsync.SourceFormat(flags.SourceFormat),
 			APIServerTimeout: flags.APIServerTimeout,
 			MaxObjectCount:   threshold,
+			PolicyFiles:      policyFiles,
+			SchemaBundle:     schemaBundle,
 		})
 	},
 }
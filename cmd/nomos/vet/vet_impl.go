@@ -36,6 +36,8 @@ import (
 	"kpt.dev/configsync/pkg/parse"
 	"kpt.dev/configsync/pkg/reconcilermanager"
 	"kpt.dev/configsync/pkg/status"
+	"kpt.dev/configsync/pkg/validate/openapi"
+	"kpt.dev/configsync/pkg/validate/policy"
 )
 
 type vetOptions struct {
@@ -43,6 +45,8 @@ type vetOptions struct {
 	SourceFormat     configsync.SourceFormat
 	APIServerTimeout time.Duration
 	MaxObjectCount   int
+	PolicyFiles      []string
+	SchemaBundle     string
 }
 
 // vet runs nomos vet with the specified options.
@@ -73,14 +77,17 @@ func runVet(ctx context.Context, out io.Writer, opts vetOptions) error {
 		}
 	}
 
-	rootDir, needsHydrate, err := hydrate.ValidateHydrateFlags(sourceFormat)
+	rootDir, renderer, err := hydrate.ValidateHydrateFlags(sourceFormat)
 	if err != nil {
 		return err
 	}
 
-	if needsHydrate {
+	// Objects rendered from the source are located relative to the source
+	// directory rather than the hydrated output.
+	policyDir := cmpath.RelativeOS(rootDir.OSPath())
+	if renderer != nil {
 		// update rootDir to point to the hydrated output for further processing.
-		if rootDir, err = hydrate.ValidateAndRunKustomize(rootDir.OSPath()); err != nil {
+		if rootDir, err = hydrate.ValidateAndRender(renderer, rootDir.OSPath()); err != nil {
 			return err
 		}
 		// delete the hydrated output directory in the end.
@@ -101,7 +108,18 @@ func runVet(ctx context.Context, out io.Writer, opts vetOptions) error {
 		return err
 	}
 	validateOpts.FieldManager = util.FieldManager
+	validateOpts.PolicyDir = policyDir
 	validateOpts.MaxObjectCount = opts.MaxObjectCount
+	validateOpts.ValidationPolicies, err = readPolicyFiles(opts.PolicyFiles)
+	if err != nil {
+		return err
+	}
+	if opts.SchemaBundle != "" {
+		validateOpts.Schemas, err = readSchemaBundle(opts.SchemaBundle)
+		if err != nil {
+			return err
+		}
+	}
 
 	switch sourceFormat {
 	case configsync.SourceFormatHierarchy:
@@ -125,7 +143,7 @@ func runVet(ctx context.Context, out io.Writer, opts vetOptions) error {
 
 	filePaths := reader.FilePaths{
 		RootDir:   rootDir,
-		PolicyDir: cmpath.RelativeOS(rootDir.OSPath()),
+		PolicyDir: policyDir,
 		Files:     files,
 	}
 
@@ -193,3 +211,30 @@ func (e clusterErrors) Error() string {
 	}
 	return fmt.Sprintf("errors for cluster %q:\n%v\n", e.name, e.MultiError.Error())
 }
+
+// readPolicyFiles reads the ValidationPolicies declared in the files.
+func readPolicyFiles(paths []string) ([]*policy.ValidationPolicy, error) {
+	var policies []*policy.ValidationPolicy
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read the ValidationPolicy file %s: %w", path, err)
+		}
+		ps, errs := policy.FromFile(data, path)
+		if errs != nil {
+			return nil, errs
+		}
+		policies = append(policies, ps...)
+	}
+	return policies, nil
+}
+
+// readSchemaBundle reads the OpenAPI schemas declared in the offline schema
+// bundle.
+func readSchemaBundle(path string) (openapi.Resources, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the schema bundle %s: %w", path, err)
+	}
+	return openapi.FromBundle(data, path)
+}
@@ -125,10 +125,14 @@ func TestVet_MultiCluster(t *testing.T) {
 					kinds.ClusterRole().GroupKind(), "clusterrole",
 					k8sobjects.ClusterRoleObject(core.Name("clusterrole"),
 						core.Annotation(metadata.SourcePathAnnotationKey,
-							filepath.Join(absRepoPath, "cluster/default_clusterrole.yaml"))),
+							filepath.Join(absRepoPath, "cluster/default_clusterrole.yaml")),
+						core.Annotation(metadata.SourceLocationAnnotationKey,
+							filepath.Join(absRepoPath, "cluster/default_clusterrole.yaml")+":15:1")),
 					k8sobjects.ClusterRoleObject(core.Name("clusterrole"),
 						core.Annotation(metadata.SourcePathAnnotationKey,
-							filepath.Join(absRepoPath, "cluster/prod_clusterrole.yaml"))),
+							filepath.Join(absRepoPath, "cluster/prod_clusterrole.yaml")),
+						core.Annotation(metadata.SourceLocationAnnotationKey,
+							filepath.Join(absRepoPath, "cluster/prod_clusterrole.yaml")+":15:1")),
 				),
 			},
 		},
@@ -141,10 +145,14 @@ func TestVet_MultiCluster(t *testing.T) {
 					kinds.ClusterRole().GroupKind(), "clusterrole",
 					k8sobjects.ClusterRoleObject(core.Name("clusterrole"),
 						core.Annotation(metadata.SourcePathAnnotationKey,
-							filepath.Join(absRepoPath, "cluster/default_clusterrole.yaml"))),
+							filepath.Join(absRepoPath, "cluster/default_clusterrole.yaml")),
+						core.Annotation(metadata.SourceLocationAnnotationKey,
+							filepath.Join(absRepoPath, "cluster/default_clusterrole.yaml")+":15:1")),
 					k8sobjects.ClusterRoleObject(core.Name("clusterrole"),
 						core.Annotation(metadata.SourcePathAnnotationKey,
-							filepath.Join(absRepoPath, "cluster/prod_clusterrole.yaml"))),
+							filepath.Join(absRepoPath, "cluster/prod_clusterrole.yaml")),
+						core.Annotation(metadata.SourceLocationAnnotationKey,
+							filepath.Join(absRepoPath, "cluster/prod_clusterrole.yaml")+":15:1")),
 				),
 			},
 		},
@@ -44,8 +44,10 @@ import (
 	"kpt.dev/configsync/pkg/status"
 	"kpt.dev/configsync/pkg/syncer/client"
 	"kpt.dev/configsync/pkg/util/clusterconfig"
+	"kpt.dev/configsync/pkg/validate/policy"
 	"kpt.dev/configsync/pkg/validate/raw/validate"
 	rsyncvalidate "kpt.dev/configsync/pkg/validate/rsync/validate"
+	"kpt.dev/configsync/pkg/validate/variables"
 	"kpt.dev/configsync/pkg/vet"
 	"kpt.dev/configsync/pkg/webhook/configuration"
 )
@@ -325,6 +327,28 @@ func Generate() AllExamples {
 	// 1070
 	result.add(system.MaxObjectCountError(system.DefaultMaxObjectCount, system.DefaultMaxObjectCount+1))
 
+	// 1071
+	guardrails := &policy.ValidationPolicy{Name: "guardrails", Source: "system/guardrails.yaml"}
+	noLoadBalancer := policy.Rule{Name: "no-load-balancer", Expression: "object.spec.type != 'LoadBalancer'"}
+	result.add(policy.ViolationError(k8sobjects.ServiceObject(core.Name("my-service"), core.Namespace("my-namespace")),
+		guardrails, noLoadBalancer, "Services of type LoadBalancer are not allowed"))
+
+	// 1072
+	result.add(policy.InvalidPolicyError("system/guardrails.yaml", errors.New("spec.rules: expected list")))
+	result.add(policy.InvalidRuleError(guardrails, noLoadBalancer, errors.New("undeclared reference to 'objects'")))
+
+	// 1073
+	result.add(selectors.InvalidClusterLabelsError("ConfigMap config-management-system/config-sync-cluster-labels",
+		errors.New(`invalid label key "not/a/key"`)))
+
+	// 1074
+	result.add(variables.UndefinedVariablesError(k8sobjects.ConfigMapObject(core.Name("my-config"), core.Namespace("my-namespace")),
+		[]string{"project-id", "region"}))
+
+	// 1075
+	result.add(variables.InvalidVariablesError("ConfigMap config-management-system/config-sync-cluster-vars",
+		errors.New(`the built-in variable "cluster-name" can't be declared`)))
+
 	// 2001
 	result.add(status.PathWrapError(errors.New("error creating directory"), "namespaces/foo"))
 
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"time"
 
 	traceapi "cloud.google.com/go/trace/apiv2"
 	"github.com/go-logr/logr"
@@ -39,6 +40,7 @@ import (
 	"kpt.dev/configsync/pkg/reconcilermanager"
 	"kpt.dev/configsync/pkg/reconcilermanager/controllers"
 	"kpt.dev/configsync/pkg/util/customresource"
+	"kpt.dev/configsync/pkg/util/imagemirror"
 	"kpt.dev/configsync/pkg/util/log"
 	utilwatch "kpt.dev/configsync/pkg/util/watch"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -57,6 +59,33 @@ var (
 	hydrationPollingPeriod = flag.Duration("hydration-polling-period",
 		controllers.PollingPeriod(reconcilermanager.HydrationPollingPeriod, configsync.DefaultHydrationPollingPeriod),
 		"Period of time between checking the filesystem for source updates to render.")
+
+	repoSyncOCIPoolSize = flag.Int("reposync-unauthenticated-oci-pool-size", 0,
+		"Maximum number of shared reconcilers for RepoSyncs which pull an unauthenticated OCI image. "+
+			"If positive, RepoSyncs with an OCI source, auth none, no CA certificate, and which don't require rendering or Pod overrides, "+
+			"are synced by one of the shared reconcilers instead of a dedicated reconciler. "+
+			"Git, Helm and authenticated OCI RepoSyncs always use a dedicated reconciler. Default: 0 (disabled).")
+
+	imageRegistryMirror = flag.String("image-registry-mirror", os.Getenv(reconcilermanager.ImageRegistryMirrorKey),
+		"Registry prefix which replaces the registry of the reconciler container images, "+
+			"for example registry.example.com/config-sync. Default: empty (disabled).")
+
+	repoSyncMaxClientQPS = flag.Int("reposync-max-client-qps", 0,
+		"Maximum client-side throttling QPS of the namespace reconcilers. "+
+			"RepoSyncs which don't override the QPS, or override it higher, are limited to this value. Default: 0 (no cap).")
+
+	repoSyncMaxClientBurst = flag.Int("reposync-max-client-burst", 0,
+		"Maximum client-side throttling burst of the namespace reconcilers. Default: 0 (no cap).")
+
+	repoSyncMaxApplierInflightRequests = flag.Int("reposync-max-applier-inflight-requests", 0,
+		"Maximum number of concurrent requests from the applier of the namespace reconcilers. Default: 0 (no cap).")
+
+	orphanSweepPeriod = flag.Duration("orphan-sweep-period", 10*time.Minute,
+		"Period of time between searching for reconciler-manager managed objects whose RootSync or RepoSync no longer exists. "+
+			"Set to 0 to disable the orphan sweep.")
+
+	orphanGracePeriod = flag.Duration("orphan-grace-period", time.Hour,
+		"Period of time an object must be orphaned before the orphan sweep deletes it.")
 )
 
 func main() {
@@ -74,8 +103,17 @@ func main() {
 	profiler.Service()
 	ctrl.SetLogger(logger)
 
-	setupLog.Info(fmt.Sprintf("running with flags --cluster-name=%s; --reconciler-polling-period=%s; --hydration-polling-period=%s",
-		*clusterName, *reconcilerPollingPeriod, *hydrationPollingPeriod))
+	setupLog.Info(fmt.Sprintf("running with flags --cluster-name=%s; --reconciler-polling-period=%s; --hydration-polling-period=%s; --reposync-unauthenticated-oci-pool-size=%d; --image-registry-mirror=%s; --reposync-max-client-qps=%d; --reposync-max-client-burst=%d; --reposync-max-applier-inflight-requests=%d; --orphan-sweep-period=%s; --orphan-grace-period=%s",
+		*clusterName, *reconcilerPollingPeriod, *hydrationPollingPeriod, *repoSyncOCIPoolSize, *imageRegistryMirror,
+		*repoSyncMaxClientQPS, *repoSyncMaxClientBurst, *repoSyncMaxApplierInflightRequests,
+		*orphanSweepPeriod, *orphanGracePeriod))
+
+	if *imageRegistryMirror != "" {
+		if err := imagemirror.Validate(*imageRegistryMirror); err != nil {
+			setupLog.Error(err, "invalid flag", "flag", "image-registry-mirror")
+			os.Exit(1)
+		}
+	}
 
 	cfg := ctrl.GetConfigOrDie()
 
@@ -130,6 +168,13 @@ func main() {
 		mgr.GetClient(), watcher, dynamicClient,
 		logger.WithName("controllers").WithName(configsync.RepoSyncKind),
 		mgr.GetScheme())
+	repoSyncController.SetReconcilerPoolSize(*repoSyncOCIPoolSize)
+	repoSyncController.SetImageRegistryMirror(*imageRegistryMirror)
+	repoSyncController.SetAPIClientCaps(controllers.APIClientLimits{
+		QPS:                        *repoSyncMaxClientQPS,
+		Burst:                      *repoSyncMaxClientBurst,
+		ApplierMaxInflightRequests: *repoSyncMaxApplierInflightRequests,
+	})
 	crdController.SetReconciler(kinds.RepoSyncV1Beta1().GroupKind(), func(_ context.Context, crd *apiextensionsv1.CustomResourceDefinition) error {
 		if customresource.IsEstablished(crd) {
 			if err := repoSyncController.Register(mgr, watchFleetMembership); err != nil {
@@ -148,6 +193,7 @@ func main() {
 		mgr.GetClient(), watcher, dynamicClient,
 		logger.WithName("controllers").WithName(configsync.RootSyncKind),
 		mgr.GetScheme())
+	rootSyncController.SetImageRegistryMirror(*imageRegistryMirror)
 	crdController.SetReconciler(kinds.RootSyncV1Beta1().GroupKind(), func(_ context.Context, crd *apiextensionsv1.CustomResourceDefinition) error {
 		if customresource.IsEstablished(crd) {
 			if err := rootSyncController.Register(mgr, watchFleetMembership); err != nil {
@@ -161,6 +207,31 @@ func main() {
 	})
 	setupLog.Info("RootSync controller registration scheduled")
 
+	installationController := controllers.NewInstallationReconciler(mgr.GetClient(),
+		logger.WithName("controllers").WithName(configsync.ConfigSyncInstallationKind))
+	crdController.SetReconciler(kinds.ConfigSyncInstallationV1Beta1().GroupKind(), func(_ context.Context, crd *apiextensionsv1.CustomResourceDefinition) error {
+		if customresource.IsEstablished(crd) {
+			if err := installationController.Register(mgr); err != nil {
+				return fmt.Errorf("registering %s controller: %w", configsync.ConfigSyncInstallationKind, err)
+			}
+			setupLog.Info("ConfigSyncInstallation controller registration successful")
+		}
+		return nil
+	})
+	setupLog.Info("ConfigSyncInstallation controller registration scheduled")
+
+	if *orphanSweepPeriod > 0 {
+		orphanSweeper := controllers.NewOrphanSweeper(mgr.GetClient(), watcher,
+			mgr.GetEventRecorderFor(reconcilermanager.ManagerName),
+			logger.WithName("controllers").WithName("OrphanSweeper"),
+			*orphanSweepPeriod, *orphanGracePeriod)
+		if err := mgr.Add(orphanSweeper); err != nil {
+			setupLog.Error(err, "failed to register orphan sweeper")
+			os.Exit(1)
+		}
+		setupLog.Info("Orphan sweeper registration successful")
+	}
+
 	otelCredentialProvider := &auth.CachingCredentialProvider{
 		Scopes: traceapi.DefaultAuthScopes(),
 	}
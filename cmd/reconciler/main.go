@@ -20,9 +20,12 @@ import (
 	"os"
 	"strings"
 
+	"github.com/go-logr/logr"
 	"k8s.io/klog/v2"
 	"k8s.io/klog/v2/textlogger"
 	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/client/restconfig"
+	"kpt.dev/configsync/pkg/core"
 	"kpt.dev/configsync/pkg/declared"
 	"kpt.dev/configsync/pkg/importer/filesystem"
 	"kpt.dev/configsync/pkg/importer/filesystem/cmpath"
@@ -33,9 +36,12 @@ import (
 	"kpt.dev/configsync/pkg/reconcilermanager"
 	"kpt.dev/configsync/pkg/reconcilermanager/controllers"
 	"kpt.dev/configsync/pkg/status"
+	"kpt.dev/configsync/pkg/syncer/reconcile/fight"
 	"kpt.dev/configsync/pkg/util"
 	"kpt.dev/configsync/pkg/util/log"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager/signals"
 )
 
 var (
@@ -47,6 +53,9 @@ var (
 		"Name of the RootSync or RepoSync object.")
 	reconcilerName = flag.String("reconciler-name", os.Getenv(reconcilermanager.ReconcilerNameKey),
 		"Name of the reconciler Deployment.")
+	reconcilerPool = flag.String("reconciler-pool", os.Getenv(reconcilermanager.ReconcilerPool),
+		"Name of the reconciler pool. If set, the reconciler runs the pipelines of the RepoSyncs assigned to the pool, "+
+			"and the source, scope and sync flags are ignored.")
 
 	// source configuration flags. These values originate in the ConfigManagement and
 	// configure git-sync/oci-sync to clone the desired repository/reference we want.
@@ -75,8 +84,21 @@ var (
 		"The rate of updates per minute to an API Resource at which the Syncer logs warnings about too many updates to the resource.")
 	fullSyncPeriod = flag.Duration("full-sync-period", configsync.DefaultReconcilerFullSyncPeriod,
 		"Period of time between forced re-syncs from source (even without a new commit).")
-	workers = flag.Int("workers", 1,
-		"Number of concurrent remediator workers to run at once.")
+	workers = flag.Int("workers", 0,
+		"Deprecated: use --min-workers and --max-workers. If set, sets both the minimum and maximum number of concurrent remediator workers.")
+	minWorkers = flag.Int("min-workers",
+		util.EnvInt(reconcilermanager.RemediatorMinWorkers, configsync.DefaultRemediatorMinWorkers),
+		"Minimum number of concurrent remediator workers to run at once.")
+	maxWorkers = flag.Int("max-workers",
+		util.EnvInt(reconcilermanager.RemediatorMaxWorkers, configsync.DefaultRemediatorMaxWorkers),
+		"Maximum number of concurrent remediator workers to run at once. "+
+			"Workers are added while objects are waiting to be remediated, and removed when idle or throttled by the API server.")
+	clientQPS = flag.Int("client-qps", util.EnvInt(reconcilermanager.APIClientQPS, 0),
+		"Maximum sustained QPS of the requests to the API server. Zero keeps the default, which is unlimited if API Priority and Fairness is enabled.")
+	clientBurst = flag.Int("client-burst", util.EnvInt(reconcilermanager.APIClientBurst, 0),
+		"Maximum burst of the requests to the API server. Zero defaults to twice --client-qps. Ignored unless --client-qps is set.")
+	applierMaxInflightRequests = flag.Int("applier-max-inflight-requests", util.EnvInt(reconcilermanager.ApplierMaxInflightRequests, 0),
+		"Maximum number of concurrent requests from the applier to the API server, excluding watches. Zero is unlimited.")
 	pollingPeriod = flag.Duration("filesystem-polling-period",
 		controllers.PollingPeriod(reconcilermanager.ReconcilerPollingPeriod, configsync.DefaultReconcilerPollingPeriod),
 		"Period of time between checking the filesystem for source updates to sync.")
@@ -96,14 +118,22 @@ var (
 		"Enable debug mode, panicking in many scenarios where normally an InternalError would be logged. "+
 			"Do not use in production.")
 
-	renderingEnabled  = flag.Bool("rendering-enabled", util.EnvBool(reconcilermanager.RenderingEnabled, false), "")
+	renderingEnabled = flag.Bool("rendering-enabled", util.EnvBool(reconcilermanager.RenderingEnabled, false), "")
+	renderingEngine  = flag.String("rendering-engine", os.Getenv(reconcilermanager.RenderingEngine),
+		"The engine used to render the source configs. If unset, the engine is detected from the source files.")
 	namespaceStrategy = flag.String(flags.namespaceStrategy, util.EnvString(reconcilermanager.NamespaceStrategy, ""),
 		fmt.Sprintf("Set the namespace strategy for the reconciler. Must be %s or %s. Default: %s.",
 			configsync.NamespaceStrategyImplicit, configsync.NamespaceStrategyExplicit, configsync.NamespaceStrategyImplicit))
 
 	dynamicNSSelectorEnabled = flag.Bool("dynamic-ns-selector-enabled", util.EnvBool(reconcilermanager.DynamicNSSelectorEnabled, false), "")
 
-	webhookEnabled       = flag.Bool("webhook-enabled", util.EnvBool(reconcilermanager.WebhookEnabled, false), "")
+	webhookEnabled = flag.Bool("webhook-enabled", util.EnvBool(reconcilermanager.WebhookEnabled, false), "")
+	leaderElection = flag.Bool("leader-election", util.EnvBool(reconcilermanager.LeaderElectionEnabled, false),
+		"Only sync while holding the leader election Lease of the reconciler. Required when the reconciler Deployment has more than one replica.")
+	resourceUsageReporting = flag.Bool("resource-usage-reporting", util.EnvBool(reconcilermanager.ResourceUsageReportingEnabled, false),
+		"Report the declared resource count and memory high-water mark of the reconciler in the RSync status.")
+	minimalRBAC = flag.Bool("minimal-rbac", util.EnvBool(reconcilermanager.MinimalRBACEnabled, false),
+		"Report the declared kinds in the RootSync status, and wait for the reconciler-manager to grant access to them before applying each commit.")
 	reconcilerSignalsDir = flag.String(flags.reconcilerSignalDir, "/reconciler-signals",
 		"The absolute path in the container that contains reconciler signals that unblock the rendering phase, for example, the latest image digest that is ready to render.")
 )
@@ -140,6 +170,16 @@ func main() {
 		status.EnablePanicOnMisuse()
 	}
 
+	if *workers > 0 {
+		klog.Warning("--workers is deprecated, use --min-workers and --max-workers instead")
+		*minWorkers = *workers
+		*maxWorkers = *workers
+	}
+	if *minWorkers < 1 || *maxWorkers < *minWorkers {
+		klog.Fatalf("--min-workers (%d) must be at least 1 and no greater than --max-workers (%d)",
+			*minWorkers, *maxWorkers)
+	}
+
 	// Register the OpenCensus views
 	if err := ocmetrics.RegisterReconcilerMetricsViews(); err != nil {
 		klog.Fatalf("Failed to register OpenCensus views: %v", err)
@@ -162,6 +202,11 @@ func main() {
 		klog.Fatalf("%s must be an absolute path: %v", flags.repoRootDir, err)
 	}
 
+	if *reconcilerPool != "" {
+		runPool(logger, absRepoRoot)
+		return
+	}
+
 	absReconcilerSignalDir, err := cmpath.AbsoluteOS(*reconcilerSignalsDir)
 	if err != nil {
 		klog.Fatalf("%s must be an absolute path: %v", flags.reconcilerSignalDir, err)
@@ -189,33 +234,41 @@ func main() {
 	}
 
 	opts := reconciler.Options{
-		Logger:                   logger,
-		ClusterName:              *clusterName,
-		FightDetectionThreshold:  *fightDetectionThreshold,
-		NumWorkers:               *workers,
-		ReconcilerScope:          scope,
-		FullSyncPeriod:           *fullSyncPeriod,
-		PollingPeriod:            *pollingPeriod,
-		RetryPeriod:              configsync.DefaultReconcilerRetryPeriod,
-		StatusUpdatePeriod:       configsync.DefaultReconcilerSyncStatusUpdatePeriod,
-		SourceRoot:               absSourceDir,
-		RepoRoot:                 absRepoRoot,
-		HydratedRoot:             *hydratedRootDir,
-		HydratedLink:             *hydratedLinkDir,
-		SourceRev:                *sourceRev,
-		SourceBranch:             *sourceBranch,
-		SourceType:               configsync.SourceType(*sourceType),
-		SourceRepo:               *sourceRepo,
-		SyncDir:                  relSyncDir,
-		SyncName:                 *syncName,
-		ReconcilerName:           *reconcilerName,
-		StatusMode:               metadata.StatusMode(*statusMode),
-		ReconcileTimeout:         *reconcileTimeout,
-		APIServerTimeout:         *apiServerTimeout,
-		RenderingEnabled:         *renderingEnabled,
-		DynamicNSSelectorEnabled: *dynamicNSSelectorEnabled,
-		WebhookEnabled:           *webhookEnabled,
-		ReconcilerSignalsDir:     absReconcilerSignalDir,
+		Logger:                     logger,
+		ClusterName:                *clusterName,
+		FightDetectionThreshold:    *fightDetectionThreshold,
+		MinWorkers:                 *minWorkers,
+		MaxWorkers:                 *maxWorkers,
+		ReconcilerScope:            scope,
+		FullSyncPeriod:             *fullSyncPeriod,
+		PollingPeriod:              *pollingPeriod,
+		RetryPeriod:                configsync.DefaultReconcilerRetryPeriod,
+		StatusUpdatePeriod:         configsync.DefaultReconcilerSyncStatusUpdatePeriod,
+		SourceRoot:                 absSourceDir,
+		RepoRoot:                   absRepoRoot,
+		HydratedRoot:               *hydratedRootDir,
+		HydratedLink:               *hydratedLinkDir,
+		SourceRev:                  *sourceRev,
+		SourceBranch:               *sourceBranch,
+		SourceType:                 configsync.SourceType(*sourceType),
+		SourceRepo:                 *sourceRepo,
+		SyncDir:                    relSyncDir,
+		SyncName:                   *syncName,
+		ReconcilerName:             *reconcilerName,
+		StatusMode:                 metadata.StatusMode(*statusMode),
+		ReconcileTimeout:           *reconcileTimeout,
+		APIServerTimeout:           *apiServerTimeout,
+		ClientQPS:                  *clientQPS,
+		ClientBurst:                *clientBurst,
+		ApplierMaxInflightRequests: *applierMaxInflightRequests,
+		RenderingEnabled:           *renderingEnabled,
+		RenderingEngine:            configsync.RenderingEngine(*renderingEngine),
+		DynamicNSSelectorEnabled:   *dynamicNSSelectorEnabled,
+		WebhookEnabled:             *webhookEnabled,
+		ReconcilerSignalsDir:       absReconcilerSignalDir,
+		LeaderElection:             *leaderElection,
+		ResourceUsageReporting:     *resourceUsageReporting,
+		MinimalRBAC:                *minimalRBAC,
 	}
 
 	if scope == declared.RootScope {
@@ -250,6 +303,42 @@ func main() {
 	reconciler.Run(opts)
 }
 
+// runPool runs the pipelines of the RepoSyncs assigned to the reconciler pool,
+// until a term or kill signal is received.
+func runPool(logger logr.Logger, repoRoot cmpath.Absolute) {
+	klog.Infof("Starting reconciler pool: %s", *reconcilerPool)
+	signalCtx := signals.SetupSignalHandler()
+	fight.SetFightThreshold(*fightDetectionThreshold)
+
+	// The pool reads its ConfigMap with its own ServiceAccount.
+	// Each pipeline impersonates the reconciler ServiceAccount of its RepoSync.
+	cfg, err := restconfig.NewRestConfig(restconfig.DefaultTimeout)
+	if err != nil {
+		klog.Fatalf("Error creating rest config: %v", err)
+	}
+	cl, err := client.New(cfg, client.Options{Scheme: core.Scheme})
+	if err != nil {
+		klog.Fatalf("Error creating client: %v", err)
+	}
+
+	reconciler.RunPool(signalCtx, cl, reconciler.PoolOptions{
+		Name: *reconcilerPool,
+		Pipeline: reconciler.Options{
+			Logger:                  logger,
+			ClusterName:             *clusterName,
+			FightDetectionThreshold: *fightDetectionThreshold,
+			MinWorkers:              *minWorkers,
+			MaxWorkers:              *maxWorkers,
+			FullSyncPeriod:          *fullSyncPeriod,
+			PollingPeriod:           *pollingPeriod,
+			RetryPeriod:             configsync.DefaultReconcilerRetryPeriod,
+			StatusUpdatePeriod:      configsync.DefaultReconcilerSyncStatusUpdatePeriod,
+		},
+		RepoRoot:     repoRoot.Join(cmpath.RelativeSlash("pool")),
+		ResyncPeriod: *pollingPeriod,
+	})
+}
+
 // validateStatusMode validates the --status-mode flag option value.
 func validateStatusMode(statusMode string) error {
 	switch statusMode {
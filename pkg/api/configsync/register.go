@@ -47,6 +47,13 @@ const (
 	RepoSyncCRDName = "reposyncs.configsync.gke.io"
 	// ResourceGroupCRDName is the name of the ResourceGroup CRD
 	ResourceGroupCRDName = "resourcegroups.kpt.dev"
+	// ConfigSyncInstallationName is the name of the ConfigSyncInstallation
+	// maintained by the reconciler-manager.
+	ConfigSyncInstallationName = "config-sync"
+	// ConfigSyncInstallationKind is the kind of the ConfigSyncInstallation resource.
+	ConfigSyncInstallationKind = "ConfigSyncInstallation"
+	// ConfigSyncInstallationCRDName is the name of the ConfigSyncInstallation CRD
+	ConfigSyncInstallationCRDName = "configsyncinstallations.configsync.gke.io"
 )
 
 const (
@@ -88,6 +95,15 @@ const (
 	// For Delete, it waits for NotFound status.
 	DefaultReconcileTimeout = 5 * time.Minute
 
+	// DefaultRemediatorMinWorkers is the default minimum number of concurrent
+	// remediator workers.
+	DefaultRemediatorMinWorkers = 1
+
+	// DefaultRemediatorMaxWorkers is the default maximum number of concurrent
+	// remediator workers. It matches the minimum, so that the remediator only
+	// scales its workers when a maximum is set.
+	DefaultRemediatorMaxWorkers = DefaultRemediatorMinWorkers
+
 	// DefaultHelmReleaseNamespace is the default namespace for a Helm Release which does not have a namespace specified
 	DefaultHelmReleaseNamespace = "default"
 )
@@ -156,3 +172,35 @@ const (
 	// declared to be created by the reconciler.
 	NamespaceStrategyExplicit NamespaceStrategy = "explicit"
 )
+
+// RBACMode specifies how the permissions of a RootSync reconciler are managed.
+type RBACMode string
+
+const (
+	// RBACModeRoleRefs indicates that the reconciler is bound to the roleRefs,
+	// or cluster-admin if no roleRefs are specified. Default
+	RBACModeRoleRefs RBACMode = "roleRefs"
+	// RBACModeMinimal indicates that the reconciler is bound to generated roles
+	// which only grant access to the declared kinds and namespaces, in addition
+	// to the roleRefs.
+	RBACModeMinimal RBACMode = "minimal"
+)
+
+// RenderingEngine specifies the tool used to render the source configs.
+type RenderingEngine string
+
+const (
+	// RenderingEngineKustomize renders the configs with `kustomize build`.
+	// Detected by a kustomization.yaml file in the sync directory.
+	RenderingEngineKustomize RenderingEngine = "kustomize"
+	// RenderingEngineKpt renders the configs with the functions of the Kptfile
+	// pipelines. Never detected, so it must be set explicitly.
+	RenderingEngineKpt RenderingEngine = "kpt"
+	// RenderingEngineJsonnet renders the configs by evaluating Jsonnet.
+	// Detected by main.jsonnet and jsonnetfile.json files in the sync
+	// directory.
+	RenderingEngineJsonnet RenderingEngine = "jsonnet"
+	// RenderingEngineCUE renders the configs by evaluating a CUE package.
+	// Detected by a cue.mod directory in the sync directory.
+	RenderingEngineCUE RenderingEngine = "cue"
+)
@@ -76,6 +76,11 @@ type RepoSyncSpec struct {
 	// +optional
 	Helm *HelmRepoSync `json:"helm,omitempty"`
 
+	// rendering configures how the source configs are rendered by the
+	// hydration-controller.
+	// +optional
+	Rendering *RenderingSpec `json:"rendering,omitempty"`
+
 	// override allows to override the settings for a reconciler.
 	// +nullable
 	// +optional
@@ -76,6 +76,11 @@ type RootSyncSpec struct {
 	// +optional
 	Helm *HelmRootSync `json:"helm,omitempty"`
 
+	// rendering configures how the source configs are rendered by the
+	// hydration-controller.
+	// +optional
+	Rendering *RenderingSpec `json:"rendering,omitempty"`
+
 	// override allows to override the settings for a reconciler.
 	// +nullable
 	// +optional
@@ -86,12 +91,37 @@ type RootSyncSpec struct {
 type RootSyncStatus struct {
 	Status `json:",inline"`
 
+	// rbac describes the permissions generated for the reconciler, when
+	// spec.override.rbacMode is "minimal".
+	// +optional
+	RBAC *RBACStatus `json:"rbac,omitempty"`
+
 	// conditions represents the latest available observations of the RootSync's
 	// current state.
 	// +optional
 	Conditions []RootSyncCondition `json:"conditions,omitempty"`
 }
 
+// RBACStatus describes the permissions generated for a RootSync reconciler.
+type RBACStatus struct {
+	// commit is the most recent commit whose declared kinds the reconciler
+	// has been granted access to. The reconciler waits to apply a commit
+	// until it matches.
+	// +optional
+	Commit string `json:"commit,omitempty"`
+
+	// pendingPermissions lists the declared kinds and namespaces of the
+	// latest parsed commit, which the reconciler was newly granted access
+	// to, until the commit is synced.
+	// +optional
+	PendingPermissions []DeclaredKind `json:"pendingPermissions,omitempty"`
+
+	// lastUpdate is the timestamp of when the generated permissions last
+	// changed.
+	// +optional
+	LastUpdate metav1.Time `json:"lastUpdate,omitempty"`
+}
+
 // RootSyncConditionType is an enum of types of conditions for RootSyncs.
 type RootSyncConditionType string
 
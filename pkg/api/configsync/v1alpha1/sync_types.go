@@ -15,9 +15,22 @@
 package v1alpha1
 
 import (
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"kpt.dev/configsync/pkg/api/configsync"
 )
 
+// RenderingSpec configures how the source configs are rendered.
+type RenderingSpec struct {
+	// engine is the tool used to render the source configs.
+	// Must be one of kustomize, kpt, jsonnet, cue. Optional. If unset, the
+	// engine is detected from the files in the sync directory. The kpt
+	// engine is never detected, and must be set explicitly.
+	// +kubebuilder:validation:Enum=kustomize;kpt;jsonnet;cue
+	// +optional
+	Engine configsync.RenderingEngine `json:"engine,omitempty"`
+}
+
 // Status provides a common type that is embedded in RepoSyncStatus and RootSyncStatus.
 type Status struct {
 	// observedGeneration is the most recent generation observed for the sync resource.
@@ -31,6 +44,16 @@ type Status struct {
 	// +optional
 	Reconciler string `json:"reconciler,omitempty"`
 
+	// leaseHolder is the name of the reconciler pod which holds the leader
+	// election Lease, when the reconciler runs with more than one replica.
+	// +optional
+	LeaseHolder string `json:"leaseHolder,omitempty"`
+
+	// resourceRecommendation is the recommended resources of the reconciler
+	// container, when enabled with spec.override.resourceRecommendations.
+	// +optional
+	ResourceRecommendation *ResourceRecommendation `json:"resourceRecommendation,omitempty"`
+
 	// lastSyncedCommit describes the most recent hash that is successfully synced.
 	// It can be a git commit hash, or an OCI image digest.
 	// +optional
@@ -84,6 +107,37 @@ type SourceStatus struct {
 	// errorSummary summarizes the errors encountered during the process of reading from the source of truth.
 	// +optional
 	ErrorSummary *ErrorSummary `json:"errorSummary,omitempty"`
+
+	// declaredKinds lists the kinds declared in the last successfully parsed
+	// commit. Only reported by RootSync reconcilers when
+	// spec.override.rbacMode is "minimal".
+	// +optional
+	DeclaredKinds *DeclaredKinds `json:"declaredKinds,omitempty"`
+}
+
+// DeclaredKinds lists the kinds of the objects declared in a commit.
+type DeclaredKinds struct {
+	// commit is the hash of the source of truth the kinds were parsed from.
+	Commit string `json:"commit"`
+
+	// kinds lists the declared kinds and the namespaces they are declared in.
+	// +optional
+	Kinds []DeclaredKind `json:"kinds,omitempty"`
+}
+
+// DeclaredKind is a kind of the objects declared in a commit.
+type DeclaredKind struct {
+	// group is the API group of the kind. Empty for the core group.
+	// +optional
+	Group string `json:"group,omitempty"`
+
+	// kind is the name of the kind.
+	Kind string `json:"kind"`
+
+	// namespaces lists the namespaces of the declared objects.
+	// Empty for cluster-scoped objects.
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
 }
 
 // RenderingStatus describes the status of rendering the source DRY configs to the WET format.
@@ -156,6 +210,69 @@ type SyncStatus struct {
 	// errorSummary summarizes the errors encountered during the process of syncing the resources.
 	// +optional
 	ErrorSummary *ErrorSummary `json:"errorSummary,omitempty"`
+
+	// ownershipTransfers lists the resources declared in the source of truth
+	// which were claimed by a reconciler with higher precedence, using the
+	// `configsync.gke.io/ownership-transfer: claim` annotation.
+	// These resources have been removed from this reconciler's inventory
+	// without being pruned.
+	// +optional
+	OwnershipTransfers []OwnershipTransfer `json:"ownershipTransfers,omitempty"`
+
+	// resourceUsage describes the resource usage of the reconciler, observed
+	// when this status was last updated by the reconciler.
+	// +optional
+	ResourceUsage *ReconcilerResourceUsage `json:"resourceUsage,omitempty"`
+}
+
+// ReconcilerResourceUsage describes the resource usage of a reconciler.
+type ReconcilerResourceUsage struct {
+	// declaredResources is the number of resources declared in the source of
+	// truth.
+	DeclaredResources int `json:"declaredResources"`
+
+	// memoryHighWaterMark is the highest memory usage of the reconciler
+	// container since it started.
+	MemoryHighWaterMark resource.Quantity `json:"memoryHighWaterMark"`
+}
+
+// ResourceRecommendation describes the recommended resources of the reconciler
+// container and their rationale.
+type ResourceRecommendation struct {
+	// cpuRequest is the recommended CPU request.
+	CPURequest resource.Quantity `json:"cpuRequest"`
+
+	// memoryRequest is the recommended memory request.
+	MemoryRequest resource.Quantity `json:"memoryRequest"`
+
+	// applied indicates whether the recommended resources are applied to the
+	// reconciler container. On GKE Autopilot clusters, the limits are also set
+	// to the recommended requests.
+	Applied bool `json:"applied"`
+
+	// reason is a human-readable rationale for the recommendation.
+	Reason string `json:"reason"`
+
+	// inventoryObjects is the number of objects in the inventory of the
+	// reconciler, when the recommendation was computed.
+	InventoryObjects int `json:"inventoryObjects"`
+
+	// declaredResources is the number of resources declared in the source of
+	// truth, when the recommendation was computed.
+	DeclaredResources int `json:"declaredResources"`
+
+	// memoryHighWaterMark is the highest memory usage reported by the current
+	// reconciler pod, or by previous reconciler pods in the last 7 days.
+	MemoryHighWaterMark resource.Quantity `json:"memoryHighWaterMark"`
+
+	// memoryHighWaterMarkTime is the timestamp of when the memoryHighWaterMark
+	// was reported.
+	// +optional
+	MemoryHighWaterMarkTime metav1.Time `json:"memoryHighWaterMarkTime,omitempty"`
+
+	// lastUpdate is the timestamp of when the recommendation last changed.
+	// +optional
+	LastUpdate metav1.Time `json:"lastUpdate,omitempty"`
 }
 
 // GitStatus describes the status of a Git source of truth.
@@ -225,6 +342,18 @@ type ErrorSummary struct {
 	ErrorCountAfterTruncation int `json:"errorCountAfterTruncation,omitempty"`
 }
 
+// OwnershipTransfer describes a resource whose ownership was transferred from
+// this reconciler to another reconciler.
+type OwnershipTransfer struct {
+	// resource identifies the transferred resource.
+	Resource ResourceRef `json:"resource"`
+
+	// manager identifies the reconciler which claimed the resource.
+	// It matches the value of the `configsync.gke.io/manager` annotation on
+	// the resource.
+	Manager string `json:"manager"`
+}
+
 // ResourceRef contains the identification bits of a single managed resource.
 type ResourceRef struct {
 	// sourcePath is the repo-relative slash path to where the config is defined.
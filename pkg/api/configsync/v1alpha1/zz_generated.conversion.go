@@ -8,6 +8,7 @@ package v1alpha1
 import (
 	unsafe "unsafe"
 
+	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	conversion "k8s.io/apimachinery/pkg/conversion"
@@ -23,6 +24,16 @@ func init() {
 // RegisterConversions adds conversion functions to the given scheme.
 // Public to allow building arbitrary schemes.
 func RegisterConversions(s *runtime.Scheme) error {
+	if err := s.AddGeneratedConversionFunc((*APIClientOverride)(nil), (*v1beta1.APIClientOverride)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_APIClientOverride_To_v1beta1_APIClientOverride(a.(*APIClientOverride), b.(*v1beta1.APIClientOverride), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.APIClientOverride)(nil), (*APIClientOverride)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_APIClientOverride_To_v1alpha1_APIClientOverride(a.(*v1beta1.APIClientOverride), b.(*APIClientOverride), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ConfigSyncError)(nil), (*v1beta1.ConfigSyncError)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_ConfigSyncError_To_v1beta1_ConfigSyncError(a.(*ConfigSyncError), b.(*v1beta1.ConfigSyncError), scope)
 	}); err != nil {
@@ -53,6 +64,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*DeclaredKind)(nil), (*v1beta1.DeclaredKind)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_DeclaredKind_To_v1beta1_DeclaredKind(a.(*DeclaredKind), b.(*v1beta1.DeclaredKind), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.DeclaredKind)(nil), (*DeclaredKind)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_DeclaredKind_To_v1alpha1_DeclaredKind(a.(*v1beta1.DeclaredKind), b.(*DeclaredKind), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*DeclaredKinds)(nil), (*v1beta1.DeclaredKinds)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_DeclaredKinds_To_v1beta1_DeclaredKinds(a.(*DeclaredKinds), b.(*v1beta1.DeclaredKinds), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.DeclaredKinds)(nil), (*DeclaredKinds)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_DeclaredKinds_To_v1alpha1_DeclaredKinds(a.(*v1beta1.DeclaredKinds), b.(*DeclaredKinds), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ErrorSummary)(nil), (*v1beta1.ErrorSummary)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_ErrorSummary_To_v1beta1_ErrorSummary(a.(*ErrorSummary), b.(*v1beta1.ErrorSummary), scope)
 	}); err != nil {
@@ -143,6 +174,56 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*OwnershipTransfer)(nil), (*v1beta1.OwnershipTransfer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_OwnershipTransfer_To_v1beta1_OwnershipTransfer(a.(*OwnershipTransfer), b.(*v1beta1.OwnershipTransfer), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.OwnershipTransfer)(nil), (*OwnershipTransfer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_OwnershipTransfer_To_v1alpha1_OwnershipTransfer(a.(*v1beta1.OwnershipTransfer), b.(*OwnershipTransfer), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*RBACStatus)(nil), (*v1beta1.RBACStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_RBACStatus_To_v1beta1_RBACStatus(a.(*RBACStatus), b.(*v1beta1.RBACStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.RBACStatus)(nil), (*RBACStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_RBACStatus_To_v1alpha1_RBACStatus(a.(*v1beta1.RBACStatus), b.(*RBACStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ReconcilerResourceUsage)(nil), (*v1beta1.ReconcilerResourceUsage)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ReconcilerResourceUsage_To_v1beta1_ReconcilerResourceUsage(a.(*ReconcilerResourceUsage), b.(*v1beta1.ReconcilerResourceUsage), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.ReconcilerResourceUsage)(nil), (*ReconcilerResourceUsage)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ReconcilerResourceUsage_To_v1alpha1_ReconcilerResourceUsage(a.(*v1beta1.ReconcilerResourceUsage), b.(*ReconcilerResourceUsage), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*RemediatorWorkersOverride)(nil), (*v1beta1.RemediatorWorkersOverride)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_RemediatorWorkersOverride_To_v1beta1_RemediatorWorkersOverride(a.(*RemediatorWorkersOverride), b.(*v1beta1.RemediatorWorkersOverride), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.RemediatorWorkersOverride)(nil), (*RemediatorWorkersOverride)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_RemediatorWorkersOverride_To_v1alpha1_RemediatorWorkersOverride(a.(*v1beta1.RemediatorWorkersOverride), b.(*RemediatorWorkersOverride), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*RenderingSpec)(nil), (*v1beta1.RenderingSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_RenderingSpec_To_v1beta1_RenderingSpec(a.(*RenderingSpec), b.(*v1beta1.RenderingSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.RenderingSpec)(nil), (*RenderingSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_RenderingSpec_To_v1alpha1_RenderingSpec(a.(*v1beta1.RenderingSpec), b.(*RenderingSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*RenderingStatus)(nil), (*v1beta1.RenderingStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_RenderingStatus_To_v1beta1_RenderingStatus(a.(*RenderingStatus), b.(*v1beta1.RenderingStatus), scope)
 	}); err != nil {
@@ -213,6 +294,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ResourceRecommendation)(nil), (*v1beta1.ResourceRecommendation)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ResourceRecommendation_To_v1beta1_ResourceRecommendation(a.(*ResourceRecommendation), b.(*v1beta1.ResourceRecommendation), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.ResourceRecommendation)(nil), (*ResourceRecommendation)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ResourceRecommendation_To_v1alpha1_ResourceRecommendation(a.(*v1beta1.ResourceRecommendation), b.(*ResourceRecommendation), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ResourceRecommendationsSpec)(nil), (*v1beta1.ResourceRecommendationsSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ResourceRecommendationsSpec_To_v1beta1_ResourceRecommendationsSpec(a.(*ResourceRecommendationsSpec), b.(*v1beta1.ResourceRecommendationsSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.ResourceRecommendationsSpec)(nil), (*ResourceRecommendationsSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ResourceRecommendationsSpec_To_v1alpha1_ResourceRecommendationsSpec(a.(*v1beta1.ResourceRecommendationsSpec), b.(*ResourceRecommendationsSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ResourceRef)(nil), (*v1beta1.ResourceRef)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_ResourceRef_To_v1beta1_ResourceRef(a.(*ResourceRef), b.(*v1beta1.ResourceRef), scope)
 	}); err != nil {
@@ -356,6 +457,30 @@ func RegisterConversions(s *runtime.Scheme) error {
 	return nil
 }
 
+func autoConvert_v1alpha1_APIClientOverride_To_v1beta1_APIClientOverride(in *APIClientOverride, out *v1beta1.APIClientOverride, s conversion.Scope) error {
+	out.QPS = (*int32)(unsafe.Pointer(in.QPS))
+	out.Burst = (*int32)(unsafe.Pointer(in.Burst))
+	out.ApplierMaxInflightRequests = (*int32)(unsafe.Pointer(in.ApplierMaxInflightRequests))
+	return nil
+}
+
+// Convert_v1alpha1_APIClientOverride_To_v1beta1_APIClientOverride is an autogenerated conversion function.
+func Convert_v1alpha1_APIClientOverride_To_v1beta1_APIClientOverride(in *APIClientOverride, out *v1beta1.APIClientOverride, s conversion.Scope) error {
+	return autoConvert_v1alpha1_APIClientOverride_To_v1beta1_APIClientOverride(in, out, s)
+}
+
+func autoConvert_v1beta1_APIClientOverride_To_v1alpha1_APIClientOverride(in *v1beta1.APIClientOverride, out *APIClientOverride, s conversion.Scope) error {
+	out.QPS = (*int32)(unsafe.Pointer(in.QPS))
+	out.Burst = (*int32)(unsafe.Pointer(in.Burst))
+	out.ApplierMaxInflightRequests = (*int32)(unsafe.Pointer(in.ApplierMaxInflightRequests))
+	return nil
+}
+
+// Convert_v1beta1_APIClientOverride_To_v1alpha1_APIClientOverride is an autogenerated conversion function.
+func Convert_v1beta1_APIClientOverride_To_v1alpha1_APIClientOverride(in *v1beta1.APIClientOverride, out *APIClientOverride, s conversion.Scope) error {
+	return autoConvert_v1beta1_APIClientOverride_To_v1alpha1_APIClientOverride(in, out, s)
+}
+
 func autoConvert_v1alpha1_ConfigSyncError_To_v1beta1_ConfigSyncError(in *ConfigSyncError, out *v1beta1.ConfigSyncError, s conversion.Scope) error {
 	out.Code = in.Code
 	out.ErrorMessage = in.ErrorMessage
@@ -430,6 +555,52 @@ func Convert_v1beta1_ContainerResourcesSpec_To_v1alpha1_ContainerResourcesSpec(i
 	return autoConvert_v1beta1_ContainerResourcesSpec_To_v1alpha1_ContainerResourcesSpec(in, out, s)
 }
 
+func autoConvert_v1alpha1_DeclaredKind_To_v1beta1_DeclaredKind(in *DeclaredKind, out *v1beta1.DeclaredKind, s conversion.Scope) error {
+	out.Group = in.Group
+	out.Kind = in.Kind
+	out.Namespaces = *(*[]string)(unsafe.Pointer(&in.Namespaces))
+	return nil
+}
+
+// Convert_v1alpha1_DeclaredKind_To_v1beta1_DeclaredKind is an autogenerated conversion function.
+func Convert_v1alpha1_DeclaredKind_To_v1beta1_DeclaredKind(in *DeclaredKind, out *v1beta1.DeclaredKind, s conversion.Scope) error {
+	return autoConvert_v1alpha1_DeclaredKind_To_v1beta1_DeclaredKind(in, out, s)
+}
+
+func autoConvert_v1beta1_DeclaredKind_To_v1alpha1_DeclaredKind(in *v1beta1.DeclaredKind, out *DeclaredKind, s conversion.Scope) error {
+	out.Group = in.Group
+	out.Kind = in.Kind
+	out.Namespaces = *(*[]string)(unsafe.Pointer(&in.Namespaces))
+	return nil
+}
+
+// Convert_v1beta1_DeclaredKind_To_v1alpha1_DeclaredKind is an autogenerated conversion function.
+func Convert_v1beta1_DeclaredKind_To_v1alpha1_DeclaredKind(in *v1beta1.DeclaredKind, out *DeclaredKind, s conversion.Scope) error {
+	return autoConvert_v1beta1_DeclaredKind_To_v1alpha1_DeclaredKind(in, out, s)
+}
+
+func autoConvert_v1alpha1_DeclaredKinds_To_v1beta1_DeclaredKinds(in *DeclaredKinds, out *v1beta1.DeclaredKinds, s conversion.Scope) error {
+	out.Commit = in.Commit
+	out.Kinds = *(*[]v1beta1.DeclaredKind)(unsafe.Pointer(&in.Kinds))
+	return nil
+}
+
+// Convert_v1alpha1_DeclaredKinds_To_v1beta1_DeclaredKinds is an autogenerated conversion function.
+func Convert_v1alpha1_DeclaredKinds_To_v1beta1_DeclaredKinds(in *DeclaredKinds, out *v1beta1.DeclaredKinds, s conversion.Scope) error {
+	return autoConvert_v1alpha1_DeclaredKinds_To_v1beta1_DeclaredKinds(in, out, s)
+}
+
+func autoConvert_v1beta1_DeclaredKinds_To_v1alpha1_DeclaredKinds(in *v1beta1.DeclaredKinds, out *DeclaredKinds, s conversion.Scope) error {
+	out.Commit = in.Commit
+	out.Kinds = *(*[]DeclaredKind)(unsafe.Pointer(&in.Kinds))
+	return nil
+}
+
+// Convert_v1beta1_DeclaredKinds_To_v1alpha1_DeclaredKinds is an autogenerated conversion function.
+func Convert_v1beta1_DeclaredKinds_To_v1alpha1_DeclaredKinds(in *v1beta1.DeclaredKinds, out *DeclaredKinds, s conversion.Scope) error {
+	return autoConvert_v1beta1_DeclaredKinds_To_v1alpha1_DeclaredKinds(in, out, s)
+}
+
 func autoConvert_v1alpha1_ErrorSummary_To_v1beta1_ErrorSummary(in *ErrorSummary, out *v1beta1.ErrorSummary, s conversion.Scope) error {
 	out.TotalCount = in.TotalCount
 	out.Truncated = in.Truncated
@@ -688,6 +859,17 @@ func autoConvert_v1alpha1_OverrideSpec_To_v1beta1_OverrideSpec(in *OverrideSpec,
 	out.APIServerTimeout = (*metav1.Duration)(unsafe.Pointer(in.APIServerTimeout))
 	out.EnableShellInRendering = (*bool)(unsafe.Pointer(in.EnableShellInRendering))
 	out.LogLevels = *(*[]v1beta1.ContainerLogLevelOverride)(unsafe.Pointer(&in.LogLevels))
+	out.RemediatorWorkers = (*v1beta1.RemediatorWorkersOverride)(unsafe.Pointer(in.RemediatorWorkers))
+	out.APIClient = (*v1beta1.APIClientOverride)(unsafe.Pointer(in.APIClient))
+	out.NodeSelector = *(*map[string]string)(unsafe.Pointer(&in.NodeSelector))
+	out.Tolerations = *(*[]corev1.Toleration)(unsafe.Pointer(&in.Tolerations))
+	out.Affinity = (*corev1.Affinity)(unsafe.Pointer(in.Affinity))
+	out.TopologySpreadConstraints = *(*[]corev1.TopologySpreadConstraint)(unsafe.Pointer(&in.TopologySpreadConstraints))
+	out.PriorityClassName = in.PriorityClassName
+	out.PodLabels = *(*map[string]string)(unsafe.Pointer(&in.PodLabels))
+	out.PodAnnotations = *(*map[string]string)(unsafe.Pointer(&in.PodAnnotations))
+	out.Replicas = (*int32)(unsafe.Pointer(in.Replicas))
+	out.ResourceRecommendations = (*v1beta1.ResourceRecommendationsSpec)(unsafe.Pointer(in.ResourceRecommendations))
 	return nil
 }
 
@@ -704,6 +886,17 @@ func autoConvert_v1beta1_OverrideSpec_To_v1alpha1_OverrideSpec(in *v1beta1.Overr
 	out.APIServerTimeout = (*metav1.Duration)(unsafe.Pointer(in.APIServerTimeout))
 	out.EnableShellInRendering = (*bool)(unsafe.Pointer(in.EnableShellInRendering))
 	out.LogLevels = *(*[]ContainerLogLevelOverride)(unsafe.Pointer(&in.LogLevels))
+	out.RemediatorWorkers = (*RemediatorWorkersOverride)(unsafe.Pointer(in.RemediatorWorkers))
+	out.APIClient = (*APIClientOverride)(unsafe.Pointer(in.APIClient))
+	out.NodeSelector = *(*map[string]string)(unsafe.Pointer(&in.NodeSelector))
+	out.Tolerations = *(*[]corev1.Toleration)(unsafe.Pointer(&in.Tolerations))
+	out.Affinity = (*corev1.Affinity)(unsafe.Pointer(in.Affinity))
+	out.TopologySpreadConstraints = *(*[]corev1.TopologySpreadConstraint)(unsafe.Pointer(&in.TopologySpreadConstraints))
+	out.PriorityClassName = in.PriorityClassName
+	out.PodLabels = *(*map[string]string)(unsafe.Pointer(&in.PodLabels))
+	out.PodAnnotations = *(*map[string]string)(unsafe.Pointer(&in.PodAnnotations))
+	out.Replicas = (*int32)(unsafe.Pointer(in.Replicas))
+	out.ResourceRecommendations = (*ResourceRecommendationsSpec)(unsafe.Pointer(in.ResourceRecommendations))
 	return nil
 }
 
@@ -712,6 +905,120 @@ func Convert_v1beta1_OverrideSpec_To_v1alpha1_OverrideSpec(in *v1beta1.OverrideS
 	return autoConvert_v1beta1_OverrideSpec_To_v1alpha1_OverrideSpec(in, out, s)
 }
 
+func autoConvert_v1alpha1_OwnershipTransfer_To_v1beta1_OwnershipTransfer(in *OwnershipTransfer, out *v1beta1.OwnershipTransfer, s conversion.Scope) error {
+	if err := Convert_v1alpha1_ResourceRef_To_v1beta1_ResourceRef(&in.Resource, &out.Resource, s); err != nil {
+		return err
+	}
+	out.Manager = in.Manager
+	return nil
+}
+
+// Convert_v1alpha1_OwnershipTransfer_To_v1beta1_OwnershipTransfer is an autogenerated conversion function.
+func Convert_v1alpha1_OwnershipTransfer_To_v1beta1_OwnershipTransfer(in *OwnershipTransfer, out *v1beta1.OwnershipTransfer, s conversion.Scope) error {
+	return autoConvert_v1alpha1_OwnershipTransfer_To_v1beta1_OwnershipTransfer(in, out, s)
+}
+
+func autoConvert_v1beta1_OwnershipTransfer_To_v1alpha1_OwnershipTransfer(in *v1beta1.OwnershipTransfer, out *OwnershipTransfer, s conversion.Scope) error {
+	if err := Convert_v1beta1_ResourceRef_To_v1alpha1_ResourceRef(&in.Resource, &out.Resource, s); err != nil {
+		return err
+	}
+	out.Manager = in.Manager
+	return nil
+}
+
+// Convert_v1beta1_OwnershipTransfer_To_v1alpha1_OwnershipTransfer is an autogenerated conversion function.
+func Convert_v1beta1_OwnershipTransfer_To_v1alpha1_OwnershipTransfer(in *v1beta1.OwnershipTransfer, out *OwnershipTransfer, s conversion.Scope) error {
+	return autoConvert_v1beta1_OwnershipTransfer_To_v1alpha1_OwnershipTransfer(in, out, s)
+}
+
+func autoConvert_v1alpha1_RBACStatus_To_v1beta1_RBACStatus(in *RBACStatus, out *v1beta1.RBACStatus, s conversion.Scope) error {
+	out.Commit = in.Commit
+	out.PendingPermissions = *(*[]v1beta1.DeclaredKind)(unsafe.Pointer(&in.PendingPermissions))
+	out.LastUpdate = in.LastUpdate
+	return nil
+}
+
+// Convert_v1alpha1_RBACStatus_To_v1beta1_RBACStatus is an autogenerated conversion function.
+func Convert_v1alpha1_RBACStatus_To_v1beta1_RBACStatus(in *RBACStatus, out *v1beta1.RBACStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha1_RBACStatus_To_v1beta1_RBACStatus(in, out, s)
+}
+
+func autoConvert_v1beta1_RBACStatus_To_v1alpha1_RBACStatus(in *v1beta1.RBACStatus, out *RBACStatus, s conversion.Scope) error {
+	out.Commit = in.Commit
+	out.PendingPermissions = *(*[]DeclaredKind)(unsafe.Pointer(&in.PendingPermissions))
+	out.LastUpdate = in.LastUpdate
+	return nil
+}
+
+// Convert_v1beta1_RBACStatus_To_v1alpha1_RBACStatus is an autogenerated conversion function.
+func Convert_v1beta1_RBACStatus_To_v1alpha1_RBACStatus(in *v1beta1.RBACStatus, out *RBACStatus, s conversion.Scope) error {
+	return autoConvert_v1beta1_RBACStatus_To_v1alpha1_RBACStatus(in, out, s)
+}
+
+func autoConvert_v1alpha1_ReconcilerResourceUsage_To_v1beta1_ReconcilerResourceUsage(in *ReconcilerResourceUsage, out *v1beta1.ReconcilerResourceUsage, s conversion.Scope) error {
+	out.DeclaredResources = in.DeclaredResources
+	out.MemoryHighWaterMark = in.MemoryHighWaterMark
+	return nil
+}
+
+// Convert_v1alpha1_ReconcilerResourceUsage_To_v1beta1_ReconcilerResourceUsage is an autogenerated conversion function.
+func Convert_v1alpha1_ReconcilerResourceUsage_To_v1beta1_ReconcilerResourceUsage(in *ReconcilerResourceUsage, out *v1beta1.ReconcilerResourceUsage, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ReconcilerResourceUsage_To_v1beta1_ReconcilerResourceUsage(in, out, s)
+}
+
+func autoConvert_v1beta1_ReconcilerResourceUsage_To_v1alpha1_ReconcilerResourceUsage(in *v1beta1.ReconcilerResourceUsage, out *ReconcilerResourceUsage, s conversion.Scope) error {
+	out.DeclaredResources = in.DeclaredResources
+	out.MemoryHighWaterMark = in.MemoryHighWaterMark
+	return nil
+}
+
+// Convert_v1beta1_ReconcilerResourceUsage_To_v1alpha1_ReconcilerResourceUsage is an autogenerated conversion function.
+func Convert_v1beta1_ReconcilerResourceUsage_To_v1alpha1_ReconcilerResourceUsage(in *v1beta1.ReconcilerResourceUsage, out *ReconcilerResourceUsage, s conversion.Scope) error {
+	return autoConvert_v1beta1_ReconcilerResourceUsage_To_v1alpha1_ReconcilerResourceUsage(in, out, s)
+}
+
+func autoConvert_v1alpha1_RemediatorWorkersOverride_To_v1beta1_RemediatorWorkersOverride(in *RemediatorWorkersOverride, out *v1beta1.RemediatorWorkersOverride, s conversion.Scope) error {
+	out.Min = (*int64)(unsafe.Pointer(in.Min))
+	out.Max = (*int64)(unsafe.Pointer(in.Max))
+	return nil
+}
+
+// Convert_v1alpha1_RemediatorWorkersOverride_To_v1beta1_RemediatorWorkersOverride is an autogenerated conversion function.
+func Convert_v1alpha1_RemediatorWorkersOverride_To_v1beta1_RemediatorWorkersOverride(in *RemediatorWorkersOverride, out *v1beta1.RemediatorWorkersOverride, s conversion.Scope) error {
+	return autoConvert_v1alpha1_RemediatorWorkersOverride_To_v1beta1_RemediatorWorkersOverride(in, out, s)
+}
+
+func autoConvert_v1beta1_RemediatorWorkersOverride_To_v1alpha1_RemediatorWorkersOverride(in *v1beta1.RemediatorWorkersOverride, out *RemediatorWorkersOverride, s conversion.Scope) error {
+	out.Min = (*int64)(unsafe.Pointer(in.Min))
+	out.Max = (*int64)(unsafe.Pointer(in.Max))
+	return nil
+}
+
+// Convert_v1beta1_RemediatorWorkersOverride_To_v1alpha1_RemediatorWorkersOverride is an autogenerated conversion function.
+func Convert_v1beta1_RemediatorWorkersOverride_To_v1alpha1_RemediatorWorkersOverride(in *v1beta1.RemediatorWorkersOverride, out *RemediatorWorkersOverride, s conversion.Scope) error {
+	return autoConvert_v1beta1_RemediatorWorkersOverride_To_v1alpha1_RemediatorWorkersOverride(in, out, s)
+}
+
+func autoConvert_v1alpha1_RenderingSpec_To_v1beta1_RenderingSpec(in *RenderingSpec, out *v1beta1.RenderingSpec, s conversion.Scope) error {
+	out.Engine = configsync.RenderingEngine(in.Engine)
+	return nil
+}
+
+// Convert_v1alpha1_RenderingSpec_To_v1beta1_RenderingSpec is an autogenerated conversion function.
+func Convert_v1alpha1_RenderingSpec_To_v1beta1_RenderingSpec(in *RenderingSpec, out *v1beta1.RenderingSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha1_RenderingSpec_To_v1beta1_RenderingSpec(in, out, s)
+}
+
+func autoConvert_v1beta1_RenderingSpec_To_v1alpha1_RenderingSpec(in *v1beta1.RenderingSpec, out *RenderingSpec, s conversion.Scope) error {
+	out.Engine = configsync.RenderingEngine(in.Engine)
+	return nil
+}
+
+// Convert_v1beta1_RenderingSpec_To_v1alpha1_RenderingSpec is an autogenerated conversion function.
+func Convert_v1beta1_RenderingSpec_To_v1alpha1_RenderingSpec(in *v1beta1.RenderingSpec, out *RenderingSpec, s conversion.Scope) error {
+	return autoConvert_v1beta1_RenderingSpec_To_v1alpha1_RenderingSpec(in, out, s)
+}
+
 func autoConvert_v1alpha1_RenderingStatus_To_v1beta1_RenderingStatus(in *RenderingStatus, out *v1beta1.RenderingStatus, s conversion.Scope) error {
 	out.Git = (*v1beta1.GitStatus)(unsafe.Pointer(in.Git))
 	out.Oci = (*v1beta1.OciStatus)(unsafe.Pointer(in.Oci))
@@ -896,6 +1203,7 @@ func autoConvert_v1alpha1_RepoSyncSpec_To_v1beta1_RepoSyncSpec(in *RepoSyncSpec,
 	} else {
 		out.Helm = nil
 	}
+	out.Rendering = (*v1beta1.RenderingSpec)(unsafe.Pointer(in.Rendering))
 	out.Override = (*v1beta1.RepoSyncOverrideSpec)(unsafe.Pointer(in.Override))
 	return nil
 }
@@ -919,6 +1227,7 @@ func autoConvert_v1beta1_RepoSyncSpec_To_v1alpha1_RepoSyncSpec(in *v1beta1.RepoS
 	} else {
 		out.Helm = nil
 	}
+	out.Rendering = (*RenderingSpec)(unsafe.Pointer(in.Rendering))
 	out.Override = (*RepoSyncOverrideSpec)(unsafe.Pointer(in.Override))
 	return nil
 }
@@ -954,6 +1263,70 @@ func Convert_v1beta1_RepoSyncStatus_To_v1alpha1_RepoSyncStatus(in *v1beta1.RepoS
 	return autoConvert_v1beta1_RepoSyncStatus_To_v1alpha1_RepoSyncStatus(in, out, s)
 }
 
+func autoConvert_v1alpha1_ResourceRecommendation_To_v1beta1_ResourceRecommendation(in *ResourceRecommendation, out *v1beta1.ResourceRecommendation, s conversion.Scope) error {
+	out.CPURequest = in.CPURequest
+	out.MemoryRequest = in.MemoryRequest
+	out.Applied = in.Applied
+	out.Reason = in.Reason
+	out.InventoryObjects = in.InventoryObjects
+	out.DeclaredResources = in.DeclaredResources
+	out.MemoryHighWaterMark = in.MemoryHighWaterMark
+	out.MemoryHighWaterMarkTime = in.MemoryHighWaterMarkTime
+	out.LastUpdate = in.LastUpdate
+	return nil
+}
+
+// Convert_v1alpha1_ResourceRecommendation_To_v1beta1_ResourceRecommendation is an autogenerated conversion function.
+func Convert_v1alpha1_ResourceRecommendation_To_v1beta1_ResourceRecommendation(in *ResourceRecommendation, out *v1beta1.ResourceRecommendation, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ResourceRecommendation_To_v1beta1_ResourceRecommendation(in, out, s)
+}
+
+func autoConvert_v1beta1_ResourceRecommendation_To_v1alpha1_ResourceRecommendation(in *v1beta1.ResourceRecommendation, out *ResourceRecommendation, s conversion.Scope) error {
+	out.CPURequest = in.CPURequest
+	out.MemoryRequest = in.MemoryRequest
+	out.Applied = in.Applied
+	out.Reason = in.Reason
+	out.InventoryObjects = in.InventoryObjects
+	out.DeclaredResources = in.DeclaredResources
+	out.MemoryHighWaterMark = in.MemoryHighWaterMark
+	out.MemoryHighWaterMarkTime = in.MemoryHighWaterMarkTime
+	out.LastUpdate = in.LastUpdate
+	return nil
+}
+
+// Convert_v1beta1_ResourceRecommendation_To_v1alpha1_ResourceRecommendation is an autogenerated conversion function.
+func Convert_v1beta1_ResourceRecommendation_To_v1alpha1_ResourceRecommendation(in *v1beta1.ResourceRecommendation, out *ResourceRecommendation, s conversion.Scope) error {
+	return autoConvert_v1beta1_ResourceRecommendation_To_v1alpha1_ResourceRecommendation(in, out, s)
+}
+
+func autoConvert_v1alpha1_ResourceRecommendationsSpec_To_v1beta1_ResourceRecommendationsSpec(in *ResourceRecommendationsSpec, out *v1beta1.ResourceRecommendationsSpec, s conversion.Scope) error {
+	out.AutoApply = (*bool)(unsafe.Pointer(in.AutoApply))
+	out.MinCPU = in.MinCPU
+	out.MaxCPU = in.MaxCPU
+	out.MinMemory = in.MinMemory
+	out.MaxMemory = in.MaxMemory
+	return nil
+}
+
+// Convert_v1alpha1_ResourceRecommendationsSpec_To_v1beta1_ResourceRecommendationsSpec is an autogenerated conversion function.
+func Convert_v1alpha1_ResourceRecommendationsSpec_To_v1beta1_ResourceRecommendationsSpec(in *ResourceRecommendationsSpec, out *v1beta1.ResourceRecommendationsSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ResourceRecommendationsSpec_To_v1beta1_ResourceRecommendationsSpec(in, out, s)
+}
+
+func autoConvert_v1beta1_ResourceRecommendationsSpec_To_v1alpha1_ResourceRecommendationsSpec(in *v1beta1.ResourceRecommendationsSpec, out *ResourceRecommendationsSpec, s conversion.Scope) error {
+	out.AutoApply = (*bool)(unsafe.Pointer(in.AutoApply))
+	out.MinCPU = in.MinCPU
+	out.MaxCPU = in.MaxCPU
+	out.MinMemory = in.MinMemory
+	out.MaxMemory = in.MaxMemory
+	return nil
+}
+
+// Convert_v1beta1_ResourceRecommendationsSpec_To_v1alpha1_ResourceRecommendationsSpec is an autogenerated conversion function.
+func Convert_v1beta1_ResourceRecommendationsSpec_To_v1alpha1_ResourceRecommendationsSpec(in *v1beta1.ResourceRecommendationsSpec, out *ResourceRecommendationsSpec, s conversion.Scope) error {
+	return autoConvert_v1beta1_ResourceRecommendationsSpec_To_v1alpha1_ResourceRecommendationsSpec(in, out, s)
+}
+
 func autoConvert_v1alpha1_ResourceRef_To_v1beta1_ResourceRef(in *ResourceRef, out *v1beta1.ResourceRef, s conversion.Scope) error {
 	out.SourcePath = in.SourcePath
 	out.Name = in.Name
@@ -1098,6 +1471,7 @@ func autoConvert_v1alpha1_RootSyncOverrideSpec_To_v1beta1_RootSyncOverrideSpec(i
 	}
 	out.NamespaceStrategy = configsync.NamespaceStrategy(in.NamespaceStrategy)
 	out.RoleRefs = *(*[]v1beta1.RootSyncRoleRef)(unsafe.Pointer(&in.RoleRefs))
+	out.RBACMode = configsync.RBACMode(in.RBACMode)
 	return nil
 }
 
@@ -1112,6 +1486,7 @@ func autoConvert_v1beta1_RootSyncOverrideSpec_To_v1alpha1_RootSyncOverrideSpec(i
 	}
 	out.NamespaceStrategy = configsync.NamespaceStrategy(in.NamespaceStrategy)
 	out.RoleRefs = *(*[]RootSyncRoleRef)(unsafe.Pointer(&in.RoleRefs))
+	out.RBACMode = configsync.RBACMode(in.RBACMode)
 	return nil
 }
 
@@ -1158,6 +1533,7 @@ func autoConvert_v1alpha1_RootSyncSpec_To_v1beta1_RootSyncSpec(in *RootSyncSpec,
 	} else {
 		out.Helm = nil
 	}
+	out.Rendering = (*v1beta1.RenderingSpec)(unsafe.Pointer(in.Rendering))
 	out.Override = (*v1beta1.RootSyncOverrideSpec)(unsafe.Pointer(in.Override))
 	return nil
 }
@@ -1181,6 +1557,7 @@ func autoConvert_v1beta1_RootSyncSpec_To_v1alpha1_RootSyncSpec(in *v1beta1.RootS
 	} else {
 		out.Helm = nil
 	}
+	out.Rendering = (*RenderingSpec)(unsafe.Pointer(in.Rendering))
 	out.Override = (*RootSyncOverrideSpec)(unsafe.Pointer(in.Override))
 	return nil
 }
@@ -1194,6 +1571,7 @@ func autoConvert_v1alpha1_RootSyncStatus_To_v1beta1_RootSyncStatus(in *RootSyncS
 	if err := Convert_v1alpha1_Status_To_v1beta1_Status(&in.Status, &out.Status, s); err != nil {
 		return err
 	}
+	out.RBAC = (*v1beta1.RBACStatus)(unsafe.Pointer(in.RBAC))
 	out.Conditions = *(*[]v1beta1.RootSyncCondition)(unsafe.Pointer(&in.Conditions))
 	return nil
 }
@@ -1207,6 +1585,7 @@ func autoConvert_v1beta1_RootSyncStatus_To_v1alpha1_RootSyncStatus(in *v1beta1.R
 	if err := Convert_v1beta1_Status_To_v1alpha1_Status(&in.Status, &out.Status, s); err != nil {
 		return err
 	}
+	out.RBAC = (*RBACStatus)(unsafe.Pointer(in.RBAC))
 	out.Conditions = *(*[]RootSyncCondition)(unsafe.Pointer(&in.Conditions))
 	return nil
 }
@@ -1244,6 +1623,7 @@ func autoConvert_v1alpha1_SourceStatus_To_v1beta1_SourceStatus(in *SourceStatus,
 	out.LastUpdate = in.LastUpdate
 	out.Errors = *(*[]v1beta1.ConfigSyncError)(unsafe.Pointer(&in.Errors))
 	out.ErrorSummary = (*v1beta1.ErrorSummary)(unsafe.Pointer(in.ErrorSummary))
+	out.DeclaredKinds = (*v1beta1.DeclaredKinds)(unsafe.Pointer(in.DeclaredKinds))
 	return nil
 }
 
@@ -1260,6 +1640,7 @@ func autoConvert_v1beta1_SourceStatus_To_v1alpha1_SourceStatus(in *v1beta1.Sourc
 	out.LastUpdate = in.LastUpdate
 	out.Errors = *(*[]ConfigSyncError)(unsafe.Pointer(&in.Errors))
 	out.ErrorSummary = (*ErrorSummary)(unsafe.Pointer(in.ErrorSummary))
+	out.DeclaredKinds = (*DeclaredKinds)(unsafe.Pointer(in.DeclaredKinds))
 	return nil
 }
 
@@ -1271,6 +1652,8 @@ func Convert_v1beta1_SourceStatus_To_v1alpha1_SourceStatus(in *v1beta1.SourceSta
 func autoConvert_v1alpha1_Status_To_v1beta1_Status(in *Status, out *v1beta1.Status, s conversion.Scope) error {
 	out.ObservedGeneration = in.ObservedGeneration
 	out.Reconciler = in.Reconciler
+	out.LeaseHolder = in.LeaseHolder
+	out.ResourceRecommendation = (*v1beta1.ResourceRecommendation)(unsafe.Pointer(in.ResourceRecommendation))
 	out.LastSyncedCommit = in.LastSyncedCommit
 	if err := Convert_v1alpha1_SourceStatus_To_v1beta1_SourceStatus(&in.Source, &out.Source, s); err != nil {
 		return err
@@ -1292,6 +1675,8 @@ func Convert_v1alpha1_Status_To_v1beta1_Status(in *Status, out *v1beta1.Status,
 func autoConvert_v1beta1_Status_To_v1alpha1_Status(in *v1beta1.Status, out *Status, s conversion.Scope) error {
 	out.ObservedGeneration = in.ObservedGeneration
 	out.Reconciler = in.Reconciler
+	out.LeaseHolder = in.LeaseHolder
+	out.ResourceRecommendation = (*ResourceRecommendation)(unsafe.Pointer(in.ResourceRecommendation))
 	out.LastSyncedCommit = in.LastSyncedCommit
 	if err := Convert_v1beta1_SourceStatus_To_v1alpha1_SourceStatus(&in.Source, &out.Source, s); err != nil {
 		return err
@@ -1318,6 +1703,8 @@ func autoConvert_v1alpha1_SyncStatus_To_v1beta1_SyncStatus(in *SyncStatus, out *
 	out.LastUpdate = in.LastUpdate
 	out.Errors = *(*[]v1beta1.ConfigSyncError)(unsafe.Pointer(&in.Errors))
 	out.ErrorSummary = (*v1beta1.ErrorSummary)(unsafe.Pointer(in.ErrorSummary))
+	out.OwnershipTransfers = *(*[]v1beta1.OwnershipTransfer)(unsafe.Pointer(&in.OwnershipTransfers))
+	out.ResourceUsage = (*v1beta1.ReconcilerResourceUsage)(unsafe.Pointer(in.ResourceUsage))
 	return nil
 }
 
@@ -1334,6 +1721,8 @@ func autoConvert_v1beta1_SyncStatus_To_v1alpha1_SyncStatus(in *v1beta1.SyncStatu
 	out.LastUpdate = in.LastUpdate
 	out.Errors = *(*[]ConfigSyncError)(unsafe.Pointer(&in.Errors))
 	out.ErrorSummary = (*ErrorSummary)(unsafe.Pointer(in.ErrorSummary))
+	out.OwnershipTransfers = *(*[]OwnershipTransfer)(unsafe.Pointer(&in.OwnershipTransfers))
+	out.ResourceUsage = (*ReconcilerResourceUsage)(unsafe.Pointer(in.ResourceUsage))
 	return nil
 }
 
@@ -6,11 +6,43 @@
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIClientOverride) DeepCopyInto(out *APIClientOverride) {
+	*out = *in
+	if in.QPS != nil {
+		in, out := &in.QPS, &out.QPS
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Burst != nil {
+		in, out := &in.Burst, &out.Burst
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ApplierMaxInflightRequests != nil {
+		in, out := &in.ApplierMaxInflightRequests, &out.ApplierMaxInflightRequests
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIClientOverride.
+func (in *APIClientOverride) DeepCopy() *APIClientOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(APIClientOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConfigSyncError) DeepCopyInto(out *ConfigSyncError) {
 	*out = *in
@@ -68,6 +100,50 @@ func (in *ContainerResourcesSpec) DeepCopy() *ContainerResourcesSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeclaredKind) DeepCopyInto(out *DeclaredKind) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeclaredKind.
+func (in *DeclaredKind) DeepCopy() *DeclaredKind {
+	if in == nil {
+		return nil
+	}
+	out := new(DeclaredKind)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeclaredKinds) DeepCopyInto(out *DeclaredKinds) {
+	*out = *in
+	if in.Kinds != nil {
+		in, out := &in.Kinds, &out.Kinds
+		*out = make([]DeclaredKind, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeclaredKinds.
+func (in *DeclaredKinds) DeepCopy() *DeclaredKinds {
+	if in == nil {
+		return nil
+	}
+	out := new(DeclaredKinds)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ErrorSummary) DeepCopyInto(out *ErrorSummary) {
 	*out = *in
@@ -287,6 +363,66 @@ func (in *OverrideSpec) DeepCopyInto(out *OverrideSpec) {
 		*out = make([]ContainerLogLevelOverride, len(*in))
 		copy(*out, *in)
 	}
+	if in.RemediatorWorkers != nil {
+		in, out := &in.RemediatorWorkers, &out.RemediatorWorkers
+		*out = new(RemediatorWorkersOverride)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.APIClient != nil {
+		in, out := &in.APIClient, &out.APIClient
+		*out = new(APIClientOverride)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(corev1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make([]corev1.TopologySpreadConstraint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PodLabels != nil {
+		in, out := &in.PodLabels, &out.PodLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.PodAnnotations != nil {
+		in, out := &in.PodAnnotations, &out.PodAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ResourceRecommendations != nil {
+		in, out := &in.ResourceRecommendations, &out.ResourceRecommendations
+		*out = new(ResourceRecommendationsSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -300,6 +436,105 @@ func (in *OverrideSpec) DeepCopy() *OverrideSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OwnershipTransfer) DeepCopyInto(out *OwnershipTransfer) {
+	*out = *in
+	out.Resource = in.Resource
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OwnershipTransfer.
+func (in *OwnershipTransfer) DeepCopy() *OwnershipTransfer {
+	if in == nil {
+		return nil
+	}
+	out := new(OwnershipTransfer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RBACStatus) DeepCopyInto(out *RBACStatus) {
+	*out = *in
+	if in.PendingPermissions != nil {
+		in, out := &in.PendingPermissions, &out.PendingPermissions
+		*out = make([]DeclaredKind, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RBACStatus.
+func (in *RBACStatus) DeepCopy() *RBACStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RBACStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReconcilerResourceUsage) DeepCopyInto(out *ReconcilerResourceUsage) {
+	*out = *in
+	out.MemoryHighWaterMark = in.MemoryHighWaterMark.DeepCopy()
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReconcilerResourceUsage.
+func (in *ReconcilerResourceUsage) DeepCopy() *ReconcilerResourceUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(ReconcilerResourceUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemediatorWorkersOverride) DeepCopyInto(out *RemediatorWorkersOverride) {
+	*out = *in
+	if in.Min != nil {
+		in, out := &in.Min, &out.Min
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Max != nil {
+		in, out := &in.Max, &out.Max
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemediatorWorkersOverride.
+func (in *RemediatorWorkersOverride) DeepCopy() *RemediatorWorkersOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(RemediatorWorkersOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RenderingSpec) DeepCopyInto(out *RenderingSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RenderingSpec.
+func (in *RenderingSpec) DeepCopy() *RenderingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RenderingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RenderingStatus) DeepCopyInto(out *RenderingStatus) {
 	*out = *in
@@ -475,6 +710,11 @@ func (in *RepoSyncSpec) DeepCopyInto(out *RepoSyncSpec) {
 		*out = new(HelmRepoSync)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Rendering != nil {
+		in, out := &in.Rendering, &out.Rendering
+		*out = new(RenderingSpec)
+		**out = **in
+	}
 	if in.Override != nil {
 		in, out := &in.Override, &out.Override
 		*out = new(RepoSyncOverrideSpec)
@@ -517,6 +757,52 @@ func (in *RepoSyncStatus) DeepCopy() *RepoSyncStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceRecommendation) DeepCopyInto(out *ResourceRecommendation) {
+	*out = *in
+	out.CPURequest = in.CPURequest.DeepCopy()
+	out.MemoryRequest = in.MemoryRequest.DeepCopy()
+	out.MemoryHighWaterMark = in.MemoryHighWaterMark.DeepCopy()
+	in.MemoryHighWaterMarkTime.DeepCopyInto(&out.MemoryHighWaterMarkTime)
+	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceRecommendation.
+func (in *ResourceRecommendation) DeepCopy() *ResourceRecommendation {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceRecommendation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceRecommendationsSpec) DeepCopyInto(out *ResourceRecommendationsSpec) {
+	*out = *in
+	if in.AutoApply != nil {
+		in, out := &in.AutoApply, &out.AutoApply
+		*out = new(bool)
+		**out = **in
+	}
+	out.MinCPU = in.MinCPU.DeepCopy()
+	out.MaxCPU = in.MaxCPU.DeepCopy()
+	out.MinMemory = in.MinMemory.DeepCopy()
+	out.MaxMemory = in.MaxMemory.DeepCopy()
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceRecommendationsSpec.
+func (in *ResourceRecommendationsSpec) DeepCopy() *ResourceRecommendationsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceRecommendationsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourceRef) DeepCopyInto(out *ResourceRef) {
 	*out = *in
@@ -686,6 +972,11 @@ func (in *RootSyncSpec) DeepCopyInto(out *RootSyncSpec) {
 		*out = new(HelmRootSync)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Rendering != nil {
+		in, out := &in.Rendering, &out.Rendering
+		*out = new(RenderingSpec)
+		**out = **in
+	}
 	if in.Override != nil {
 		in, out := &in.Override, &out.Override
 		*out = new(RootSyncOverrideSpec)
@@ -708,6 +999,11 @@ func (in *RootSyncSpec) DeepCopy() *RootSyncSpec {
 func (in *RootSyncStatus) DeepCopyInto(out *RootSyncStatus) {
 	*out = *in
 	in.Status.DeepCopyInto(&out.Status)
+	if in.RBAC != nil {
+		in, out := &in.RBAC, &out.RBAC
+		*out = new(RBACStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]RootSyncCondition, len(*in))
@@ -775,6 +1071,11 @@ func (in *SourceStatus) DeepCopyInto(out *SourceStatus) {
 		*out = new(ErrorSummary)
 		**out = **in
 	}
+	if in.DeclaredKinds != nil {
+		in, out := &in.DeclaredKinds, &out.DeclaredKinds
+		*out = new(DeclaredKinds)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -791,6 +1092,11 @@ func (in *SourceStatus) DeepCopy() *SourceStatus {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Status) DeepCopyInto(out *Status) {
 	*out = *in
+	if in.ResourceRecommendation != nil {
+		in, out := &in.ResourceRecommendation, &out.ResourceRecommendation
+		*out = new(ResourceRecommendation)
+		(*in).DeepCopyInto(*out)
+	}
 	in.Source.DeepCopyInto(&out.Source)
 	in.Rendering.DeepCopyInto(&out.Rendering)
 	in.Sync.DeepCopyInto(&out.Sync)
@@ -838,6 +1144,16 @@ func (in *SyncStatus) DeepCopyInto(out *SyncStatus) {
 		*out = new(ErrorSummary)
 		**out = **in
 	}
+	if in.OwnershipTransfers != nil {
+		in, out := &in.OwnershipTransfers, &out.OwnershipTransfers
+		*out = make([]OwnershipTransfer, len(*in))
+		copy(*out, *in)
+	}
+	if in.ResourceUsage != nil {
+		in, out := &in.ResourceUsage, &out.ResourceUsage
+		*out = new(ReconcilerResourceUsage)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -0,0 +1,173 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Healthy",type="string",JSONPath=".status.conditions[?(@.type=='Healthy')].status"
+// +kubebuilder:printcolumn:name="Reason",type="string",JSONPath=".status.conditions[?(@.type=='Healthy')].reason"
+// +kubebuilder:printcolumn:name="RootSyncs",type="integer",JSONPath=".status.rootSyncs.total"
+// +kubebuilder:printcolumn:name="RootSyncsStalled",type="integer",JSONPath=".status.rootSyncs.stalled"
+// +kubebuilder:printcolumn:name="RepoSyncs",type="integer",JSONPath=".status.repoSyncs.total"
+// +kubebuilder:printcolumn:name="RepoSyncsStalled",type="integer",JSONPath=".status.repoSyncs.stalled"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ConfigSyncInstallation reports the health of the Config Sync installation.
+// The reconciler-manager maintains a single ConfigSyncInstallation, named
+// config-sync, which aggregates the status of all RootSyncs and RepoSyncs,
+// their reconcilers, and the Config Sync components.
+type ConfigSyncInstallation struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +optional
+	Status ConfigSyncInstallationStatus `json:"status,omitempty"`
+}
+
+// ConfigSyncInstallationStatus defines the observed state of the Config Sync
+// installation.
+type ConfigSyncInstallationStatus struct {
+	// rootSyncs summarizes the status of the RootSyncs.
+	// +optional
+	RootSyncs SyncSummary `json:"rootSyncs,omitempty"`
+
+	// repoSyncs summarizes the status of the RepoSyncs.
+	// +optional
+	RepoSyncs SyncSummary `json:"repoSyncs,omitempty"`
+
+	// stalledSyncs lists the RootSyncs and RepoSyncs which are stalled, up to
+	// a maximum of 20.
+	// +optional
+	StalledSyncs []SyncReference `json:"stalledSyncs,omitempty"`
+
+	// reconcilersNotReady lists the names of the reconciler Deployments which
+	// are not ready, up to a maximum of 20.
+	// +optional
+	ReconcilersNotReady []string `json:"reconcilersNotReady,omitempty"`
+
+	// components describes the status of the Config Sync components.
+	// +optional
+	Components []ComponentStatus `json:"components,omitempty"`
+
+	// conditions represents the latest available observations of the
+	// installation's current state. The Healthy condition is True when no
+	// sync is stalled, all reconcilers are ready, and all installed components
+	// are ready.
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// lastUpdate is the timestamp of when the status was last computed.
+	// +optional
+	LastUpdate metav1.Time `json:"lastUpdate,omitempty"`
+}
+
+// SyncSummary counts the RootSyncs or RepoSyncs by condition.
+type SyncSummary struct {
+	// total is the number of syncs.
+	// +optional
+	Total int `json:"total,omitempty"`
+
+	// reconciling is the number of syncs with a True Reconciling condition.
+	// +optional
+	Reconciling int `json:"reconciling,omitempty"`
+
+	// stalled is the number of syncs with a True Stalled condition.
+	// +optional
+	Stalled int `json:"stalled,omitempty"`
+
+	// syncing is the number of syncs with a True Syncing condition.
+	// +optional
+	Syncing int `json:"syncing,omitempty"`
+
+	// withErrors is the number of syncs with source, rendering or sync errors.
+	// +optional
+	WithErrors int `json:"withErrors,omitempty"`
+
+	// reconcilersNotReady is the number of syncs whose reconciler Deployment
+	// is not ready.
+	// +optional
+	ReconcilersNotReady int `json:"reconcilersNotReady,omitempty"`
+}
+
+// SyncReference identifies a RootSync or RepoSync.
+type SyncReference struct {
+	// kind is the kind of the sync, RootSync or RepoSync.
+	Kind string `json:"kind"`
+
+	// namespace is the namespace of the sync.
+	Namespace string `json:"namespace"`
+
+	// name is the name of the sync.
+	Name string `json:"name"`
+
+	// reason is the reason of the sync condition.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// message is the message of the sync condition.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// ComponentState is the state of a Config Sync component.
+type ComponentState string
+
+const (
+	// ComponentReady means the component Deployment is available and up to date.
+	ComponentReady ComponentState = "Ready"
+	// ComponentNotReady means the component Deployment is not available or
+	// not up to date.
+	ComponentNotReady ComponentState = "NotReady"
+	// ComponentNotInstalled means the component Deployment does not exist.
+	ComponentNotInstalled ComponentState = "NotInstalled"
+)
+
+// ComponentStatus describes the status of a Config Sync component.
+type ComponentStatus struct {
+	// name is the name of the component Deployment.
+	Name string `json:"name"`
+
+	// namespace is the namespace of the component Deployment.
+	Namespace string `json:"namespace"`
+
+	// version is the image tag of the main container of the component.
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// state is the state of the component: Ready, NotReady or NotInstalled.
+	State ComponentState `json:"state"`
+
+	// message describes why the component is not ready.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ConfigSyncInstallationList contains a list of ConfigSyncInstallation
+type ConfigSyncInstallationList struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ConfigSyncInstallation `json:"items"`
+}
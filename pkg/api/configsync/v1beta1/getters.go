@@ -39,6 +39,15 @@ func GetSecretName(secretRef *SecretReference) string {
 	return ""
 }
 
+// GetRenderingEngine will return an empty string if the rendering doesn't
+// exist, in which case the engine is detected from the source files
+func GetRenderingEngine(rendering *RenderingSpec) configsync.RenderingEngine {
+	if rendering != nil {
+		return rendering.Engine
+	}
+	return ""
+}
+
 // SafeOverride creates an override or returns an existing one
 // use it if you need to ensure that you are assigning
 // to an object, but not to test for nil (current existence)
@@ -74,3 +83,28 @@ func GetAPIServerTimeout(d *metav1.Duration) string {
 	}
 	return d.Duration.String()
 }
+
+// GetRemediatorWorkers returns the minimum and maximum number of remediator
+// workers, defaulting to 1 if empty. The maximum defaults to the minimum, so
+// that the workers are only scaled if the maximum is specified, and is never
+// less than the minimum.
+func GetRemediatorWorkers(o *RemediatorWorkersOverride) (int, int) {
+	minWorkers := configsync.DefaultRemediatorMinWorkers
+	if o != nil && o.Min != nil && *o.Min > 0 {
+		minWorkers = int(*o.Min)
+	}
+	maxWorkers := minWorkers
+	if o != nil && o.Max != nil && int(*o.Max) > minWorkers {
+		maxWorkers = int(*o.Max)
+	}
+	return minWorkers, maxWorkers
+}
+
+// GetReplicas returns the number of reconciler replicas, defaulting to 1 if
+// empty.
+func GetReplicas(r *int32) int32 {
+	if r == nil || *r < 1 {
+		return 1
+	}
+	return *r
+}
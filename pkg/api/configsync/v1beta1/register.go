@@ -38,6 +38,8 @@ var (
 
 func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(SchemeGroupVersion,
+		&ConfigSyncInstallation{},
+		&ConfigSyncInstallationList{},
 		&RepoSync{},
 		&RepoSyncList{},
 		&RootSync{},
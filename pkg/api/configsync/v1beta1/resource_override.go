@@ -15,6 +15,7 @@
 package v1beta1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"kpt.dev/configsync/pkg/api/configsync"
@@ -74,6 +75,96 @@ type OverrideSpec struct {
 	// +listMapKey=containerName
 	// +optional
 	LogLevels []ContainerLogLevelOverride `json:"logLevels,omitempty"`
+
+	// remediatorWorkers allows one to override the bounds of the remediator
+	// worker pool. The number of workers scales up when objects are waiting to
+	// be remediated, and scales down when the queue is empty or the API server
+	// throttles requests.
+	// +optional
+	RemediatorWorkers *RemediatorWorkersOverride `json:"remediatorWorkers,omitempty"`
+
+	// apiClient allows one to override the client-side rate limits of the
+	// reconciler's requests to the API server. The reconciler-manager may cap
+	// these limits for RepoSyncs.
+	// +optional
+	APIClient *APIClientOverride `json:"apiClient,omitempty"`
+
+	// nodeSelector allows one to constrain the reconciler pod to nodes with
+	// matching labels.
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// tolerations allow the reconciler pod to be scheduled onto nodes with
+	// matching taints.
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// affinity allows one to override the scheduling constraints of the
+	// reconciler pod.
+	// +optional
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+
+	// topologySpreadConstraints describe how the reconciler pods should be
+	// spread across topology domains.
+	// +optional
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+
+	// priorityClassName allows one to set the PriorityClass of the reconciler
+	// pod.
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// podLabels are added to the labels of the reconciler pod.
+	// Labels set by Config Sync take precedence and must not be specified.
+	// +optional
+	PodLabels map[string]string `json:"podLabels,omitempty"`
+
+	// podAnnotations are added to the annotations of the reconciler pod.
+	// Annotations set by Config Sync take precedence and must not be specified.
+	// +optional
+	PodAnnotations map[string]string `json:"podAnnotations,omitempty"`
+
+	// replicas allows one to override the number of reconciler pods.
+	// Default: 1.
+	// With 2 or more replicas, the reconciler pods use a Lease to elect a
+	// leader, which syncs and remediates, while the standby pods keep pulling
+	// the source, ready to take over if the leader is evicted. A
+	// PodDisruptionBudget is also created to keep one reconciler pod available.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=5
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// resourceRecommendations enables recommending the resources of the
+	// reconciler container, based on the resource usage observed by the
+	// reconciler. The recommendation and its rationale are recorded in
+	// status.resourceRecommendation.
+	// +optional
+	ResourceRecommendations *ResourceRecommendationsSpec `json:"resourceRecommendations,omitempty"`
+}
+
+// ResourceRecommendationsSpec configures the resource recommendations for the
+// reconciler container.
+type ResourceRecommendationsSpec struct {
+	// autoApply specifies whether to apply the recommended resources to the
+	// reconciler container. Resources specified in the resources override for
+	// the reconciler container take precedence.
+	// Default: false.
+	// +optional
+	AutoApply *bool `json:"autoApply,omitempty"`
+	// minCPU is the lower bound of the recommended CPU request.
+	// +optional
+	MinCPU resource.Quantity `json:"minCPU,omitempty"`
+	// maxCPU is the upper bound of the recommended CPU request.
+	// +optional
+	MaxCPU resource.Quantity `json:"maxCPU,omitempty"`
+	// minMemory is the lower bound of the recommended memory request.
+	// +optional
+	MinMemory resource.Quantity `json:"minMemory,omitempty"`
+	// maxMemory is the upper bound of the recommended memory request.
+	// +optional
+	MaxMemory resource.Quantity `json:"maxMemory,omitempty"`
 }
 
 // RootSyncOverrideSpec allows to override the settings for a RootSync reconciler pod
@@ -94,10 +185,25 @@ type RootSyncOverrideSpec struct {
 	NamespaceStrategy configsync.NamespaceStrategy `json:"namespaceStrategy,omitempty"`
 
 	// roleRefs is a list of Roles or ClusterRoles to create bindings.
-	// If unset, a binding to cluster-admin will be created.
+	// If unset, a binding to cluster-admin will be created, unless rbacMode is
+	// "minimal".
 	//
 	// +optional
 	RoleRefs []RootSyncRoleRef `json:"roleRefs,omitempty"`
+
+	// rbacMode controls how the permissions of the reconciler are managed.
+	// Must be "roleRefs" or "minimal". Default: "roleRefs".
+	// "roleRefs" means that the reconciler is bound to the roleRefs, or to
+	// cluster-admin if roleRefs is unset.
+	// "minimal" means that the reconciler-manager generates and binds a
+	// ClusterRole and Roles which only grant access to the kinds and
+	// namespaces declared in the last successfully parsed commit, in addition
+	// to the roleRefs. The reconciler waits to apply each commit until the
+	// permissions it needs are granted.
+	//
+	// +kubebuilder:validation:Enum=roleRefs;minimal
+	// +optional
+	RBACMode configsync.RBACMode `json:"rbacMode,omitempty"`
 }
 
 // each item references a Role or ClusterRole to create
@@ -170,3 +276,46 @@ type ContainerLogLevelOverride struct {
 	// +kubebuilder:validation:Required
 	LogLevel int `json:"logLevel"`
 }
+
+// APIClientOverride specifies the client-side rate limits of the reconciler's
+// requests to the API server
+type APIClientOverride struct {
+	// qps is the maximum sustained number of requests per second from the
+	// reconciler to the API server.
+	// Default: unlimited if the API server has API Priority and Fairness
+	// enabled, otherwise 30.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	QPS *int32 `json:"qps,omitempty"`
+
+	// burst is the maximum number of requests from the reconciler to the API
+	// server in a burst. Only applies when qps is specified.
+	// Default: twice qps.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Burst *int32 `json:"burst,omitempty"`
+
+	// applierMaxInflightRequests is the maximum number of concurrent requests
+	// from the applier to the API server, excluding watches.
+	// Default: unlimited.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ApplierMaxInflightRequests *int32 `json:"applierMaxInflightRequests,omitempty"`
+}
+
+// RemediatorWorkersOverride specifies the bounds of the remediator worker pool
+type RemediatorWorkersOverride struct {
+	// min is the minimum number of concurrent remediator workers.
+	// Default: 1.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Min *int64 `json:"min,omitempty"`
+
+	// max is the maximum number of concurrent remediator workers.
+	// Must be no less than min.
+	// Default: min, which disables scaling.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=64
+	// +optional
+	Max *int64 `json:"max,omitempty"`
+}
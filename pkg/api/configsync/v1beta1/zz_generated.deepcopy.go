@@ -6,11 +6,59 @@
 package v1beta1
 
 import (
-	v1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIClientOverride) DeepCopyInto(out *APIClientOverride) {
+	*out = *in
+	if in.QPS != nil {
+		in, out := &in.QPS, &out.QPS
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Burst != nil {
+		in, out := &in.Burst, &out.Burst
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ApplierMaxInflightRequests != nil {
+		in, out := &in.ApplierMaxInflightRequests, &out.ApplierMaxInflightRequests
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIClientOverride.
+func (in *APIClientOverride) DeepCopy() *APIClientOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(APIClientOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentStatus) DeepCopyInto(out *ComponentStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentStatus.
+func (in *ComponentStatus) DeepCopy() *ComponentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConfigSyncError) DeepCopyInto(out *ConfigSyncError) {
 	*out = *in
@@ -32,6 +80,107 @@ func (in *ConfigSyncError) DeepCopy() *ConfigSyncError {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigSyncInstallation) DeepCopyInto(out *ConfigSyncInstallation) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigSyncInstallation.
+func (in *ConfigSyncInstallation) DeepCopy() *ConfigSyncInstallation {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigSyncInstallation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ConfigSyncInstallation) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigSyncInstallationList) DeepCopyInto(out *ConfigSyncInstallationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ConfigSyncInstallation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigSyncInstallationList.
+func (in *ConfigSyncInstallationList) DeepCopy() *ConfigSyncInstallationList {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigSyncInstallationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ConfigSyncInstallationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigSyncInstallationStatus) DeepCopyInto(out *ConfigSyncInstallationStatus) {
+	*out = *in
+	out.RootSyncs = in.RootSyncs
+	out.RepoSyncs = in.RepoSyncs
+	if in.StalledSyncs != nil {
+		in, out := &in.StalledSyncs, &out.StalledSyncs
+		*out = make([]SyncReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.ReconcilersNotReady != nil {
+		in, out := &in.ReconcilersNotReady, &out.ReconcilersNotReady
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Components != nil {
+		in, out := &in.Components, &out.Components
+		*out = make([]ComponentStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigSyncInstallationStatus.
+func (in *ConfigSyncInstallationStatus) DeepCopy() *ConfigSyncInstallationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigSyncInstallationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ContainerLogLevelOverride) DeepCopyInto(out *ContainerLogLevelOverride) {
 	*out = *in
@@ -68,6 +217,50 @@ func (in *ContainerResourcesSpec) DeepCopy() *ContainerResourcesSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeclaredKind) DeepCopyInto(out *DeclaredKind) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeclaredKind.
+func (in *DeclaredKind) DeepCopy() *DeclaredKind {
+	if in == nil {
+		return nil
+	}
+	out := new(DeclaredKind)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeclaredKinds) DeepCopyInto(out *DeclaredKinds) {
+	*out = *in
+	if in.Kinds != nil {
+		in, out := &in.Kinds, &out.Kinds
+		*out = make([]DeclaredKind, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeclaredKinds.
+func (in *DeclaredKinds) DeepCopy() *DeclaredKinds {
+	if in == nil {
+		return nil
+	}
+	out := new(DeclaredKinds)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ErrorSummary) DeepCopyInto(out *ErrorSummary) {
 	*out = *in
@@ -132,7 +325,7 @@ func (in *HelmBase) DeepCopyInto(out *HelmBase) {
 	*out = *in
 	if in.Values != nil {
 		in, out := &in.Values, &out.Values
-		*out = new(v1.JSON)
+		*out = new(apiextensionsv1.JSON)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.ValuesFileRefs != nil {
@@ -269,12 +462,12 @@ func (in *OverrideSpec) DeepCopyInto(out *OverrideSpec) {
 	}
 	if in.ReconcileTimeout != nil {
 		in, out := &in.ReconcileTimeout, &out.ReconcileTimeout
-		*out = new(metav1.Duration)
+		*out = new(v1.Duration)
 		**out = **in
 	}
 	if in.APIServerTimeout != nil {
 		in, out := &in.APIServerTimeout, &out.APIServerTimeout
-		*out = new(metav1.Duration)
+		*out = new(v1.Duration)
 		**out = **in
 	}
 	if in.EnableShellInRendering != nil {
@@ -287,6 +480,66 @@ func (in *OverrideSpec) DeepCopyInto(out *OverrideSpec) {
 		*out = make([]ContainerLogLevelOverride, len(*in))
 		copy(*out, *in)
 	}
+	if in.RemediatorWorkers != nil {
+		in, out := &in.RemediatorWorkers, &out.RemediatorWorkers
+		*out = new(RemediatorWorkersOverride)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.APIClient != nil {
+		in, out := &in.APIClient, &out.APIClient
+		*out = new(APIClientOverride)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(corev1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make([]corev1.TopologySpreadConstraint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PodLabels != nil {
+		in, out := &in.PodLabels, &out.PodLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.PodAnnotations != nil {
+		in, out := &in.PodAnnotations, &out.PodAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ResourceRecommendations != nil {
+		in, out := &in.ResourceRecommendations, &out.ResourceRecommendations
+		*out = new(ResourceRecommendationsSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -300,6 +553,105 @@ func (in *OverrideSpec) DeepCopy() *OverrideSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OwnershipTransfer) DeepCopyInto(out *OwnershipTransfer) {
+	*out = *in
+	out.Resource = in.Resource
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OwnershipTransfer.
+func (in *OwnershipTransfer) DeepCopy() *OwnershipTransfer {
+	if in == nil {
+		return nil
+	}
+	out := new(OwnershipTransfer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RBACStatus) DeepCopyInto(out *RBACStatus) {
+	*out = *in
+	if in.PendingPermissions != nil {
+		in, out := &in.PendingPermissions, &out.PendingPermissions
+		*out = make([]DeclaredKind, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RBACStatus.
+func (in *RBACStatus) DeepCopy() *RBACStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RBACStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReconcilerResourceUsage) DeepCopyInto(out *ReconcilerResourceUsage) {
+	*out = *in
+	out.MemoryHighWaterMark = in.MemoryHighWaterMark.DeepCopy()
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReconcilerResourceUsage.
+func (in *ReconcilerResourceUsage) DeepCopy() *ReconcilerResourceUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(ReconcilerResourceUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemediatorWorkersOverride) DeepCopyInto(out *RemediatorWorkersOverride) {
+	*out = *in
+	if in.Min != nil {
+		in, out := &in.Min, &out.Min
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Max != nil {
+		in, out := &in.Max, &out.Max
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemediatorWorkersOverride.
+func (in *RemediatorWorkersOverride) DeepCopy() *RemediatorWorkersOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(RemediatorWorkersOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RenderingSpec) DeepCopyInto(out *RenderingSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RenderingSpec.
+func (in *RenderingSpec) DeepCopy() *RenderingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RenderingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RenderingStatus) DeepCopyInto(out *RenderingStatus) {
 	*out = *in
@@ -475,6 +827,11 @@ func (in *RepoSyncSpec) DeepCopyInto(out *RepoSyncSpec) {
 		*out = new(HelmRepoSync)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Rendering != nil {
+		in, out := &in.Rendering, &out.Rendering
+		*out = new(RenderingSpec)
+		**out = **in
+	}
 	if in.Override != nil {
 		in, out := &in.Override, &out.Override
 		*out = new(RepoSyncOverrideSpec)
@@ -517,6 +874,52 @@ func (in *RepoSyncStatus) DeepCopy() *RepoSyncStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceRecommendation) DeepCopyInto(out *ResourceRecommendation) {
+	*out = *in
+	out.CPURequest = in.CPURequest.DeepCopy()
+	out.MemoryRequest = in.MemoryRequest.DeepCopy()
+	out.MemoryHighWaterMark = in.MemoryHighWaterMark.DeepCopy()
+	in.MemoryHighWaterMarkTime.DeepCopyInto(&out.MemoryHighWaterMarkTime)
+	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceRecommendation.
+func (in *ResourceRecommendation) DeepCopy() *ResourceRecommendation {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceRecommendation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceRecommendationsSpec) DeepCopyInto(out *ResourceRecommendationsSpec) {
+	*out = *in
+	if in.AutoApply != nil {
+		in, out := &in.AutoApply, &out.AutoApply
+		*out = new(bool)
+		**out = **in
+	}
+	out.MinCPU = in.MinCPU.DeepCopy()
+	out.MaxCPU = in.MaxCPU.DeepCopy()
+	out.MinMemory = in.MinMemory.DeepCopy()
+	out.MaxMemory = in.MaxMemory.DeepCopy()
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceRecommendationsSpec.
+func (in *ResourceRecommendationsSpec) DeepCopy() *ResourceRecommendationsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceRecommendationsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourceRef) DeepCopyInto(out *ResourceRef) {
 	*out = *in
@@ -686,6 +1089,11 @@ func (in *RootSyncSpec) DeepCopyInto(out *RootSyncSpec) {
 		*out = new(HelmRootSync)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Rendering != nil {
+		in, out := &in.Rendering, &out.Rendering
+		*out = new(RenderingSpec)
+		**out = **in
+	}
 	if in.Override != nil {
 		in, out := &in.Override, &out.Override
 		*out = new(RootSyncOverrideSpec)
@@ -708,6 +1116,11 @@ func (in *RootSyncSpec) DeepCopy() *RootSyncSpec {
 func (in *RootSyncStatus) DeepCopyInto(out *RootSyncStatus) {
 	*out = *in
 	in.Status.DeepCopyInto(&out.Status)
+	if in.RBAC != nil {
+		in, out := &in.RBAC, &out.RBAC
+		*out = new(RBACStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]RootSyncCondition, len(*in))
@@ -775,6 +1188,11 @@ func (in *SourceStatus) DeepCopyInto(out *SourceStatus) {
 		*out = new(ErrorSummary)
 		**out = **in
 	}
+	if in.DeclaredKinds != nil {
+		in, out := &in.DeclaredKinds, &out.DeclaredKinds
+		*out = new(DeclaredKinds)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -791,6 +1209,11 @@ func (in *SourceStatus) DeepCopy() *SourceStatus {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Status) DeepCopyInto(out *Status) {
 	*out = *in
+	if in.ResourceRecommendation != nil {
+		in, out := &in.ResourceRecommendation, &out.ResourceRecommendation
+		*out = new(ResourceRecommendation)
+		(*in).DeepCopyInto(*out)
+	}
 	in.Source.DeepCopyInto(&out.Source)
 	in.Rendering.DeepCopyInto(&out.Rendering)
 	in.Sync.DeepCopyInto(&out.Sync)
@@ -807,6 +1230,22 @@ func (in *Status) DeepCopy() *Status {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SyncReference) DeepCopyInto(out *SyncReference) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyncReference.
+func (in *SyncReference) DeepCopy() *SyncReference {
+	if in == nil {
+		return nil
+	}
+	out := new(SyncReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SyncStatus) DeepCopyInto(out *SyncStatus) {
 	*out = *in
@@ -838,6 +1277,16 @@ func (in *SyncStatus) DeepCopyInto(out *SyncStatus) {
 		*out = new(ErrorSummary)
 		**out = **in
 	}
+	if in.OwnershipTransfers != nil {
+		in, out := &in.OwnershipTransfers, &out.OwnershipTransfers
+		*out = make([]OwnershipTransfer, len(*in))
+		copy(*out, *in)
+	}
+	if in.ResourceUsage != nil {
+		in, out := &in.ResourceUsage, &out.ResourceUsage
+		*out = new(ReconcilerResourceUsage)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -851,6 +1300,22 @@ func (in *SyncStatus) DeepCopy() *SyncStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SyncSummary) DeepCopyInto(out *SyncSummary) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyncSummary.
+func (in *SyncSummary) DeepCopy() *SyncSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(SyncSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ValuesFileRef) DeepCopyInto(out *ValuesFileRef) {
 	*out = *in
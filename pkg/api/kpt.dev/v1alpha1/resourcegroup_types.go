@@ -190,8 +190,16 @@ const (
 // its group, kind, name and namespace.
 type ResourceStatus struct {
 	ObjMetadata `json:",inline"`
-	Status      Status      `json:"status"`
-	SourceHash  string      `json:"sourceHash,omitempty"`
+	Status      Status `json:"status"`
+	SourceHash  string `json:"sourceHash,omitempty"`
+	// SourceLocation is the file, line and column in the source which
+	// declare the resource.
+	SourceLocation string `json:"sourceLocation,omitempty"`
+	// SourceChain lists the files the resource was rendered from, from the
+	// file which declares it to the last transformation applied to it.
+	// The transformations are only listed if the kustomization opts in with
+	// `buildMetadata: [transformerAnnotations]`.
+	SourceChain string      `json:"sourceChain,omitempty"`
 	Conditions  []Condition `json:"conditions,omitempty"`
 	Strategy    Strategy    `json:"strategy,omitempty"`
 	Actuation   Actuation   `json:"actuation,omitempty"`
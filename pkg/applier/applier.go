@@ -18,6 +18,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 
@@ -32,6 +33,7 @@ import (
 	"kpt.dev/configsync/pkg/applier/stats"
 	"kpt.dev/configsync/pkg/core"
 	"kpt.dev/configsync/pkg/declared"
+	"kpt.dev/configsync/pkg/diff"
 	"kpt.dev/configsync/pkg/kinds"
 	"kpt.dev/configsync/pkg/metadata"
 	m "kpt.dev/configsync/pkg/metrics"
@@ -91,6 +93,8 @@ type EventType string
 const (
 	// ErrorEventType is the type of the ErrorEvent
 	ErrorEventType EventType = "ErrorEvent"
+	// OwnershipTransferEventType is the type of the OwnershipTransferEvent
+	OwnershipTransferEventType EventType = "OwnershipTransferEvent"
 )
 
 // Event is sent to the eventHandler by the supervisor.
@@ -110,6 +114,20 @@ func (e ErrorEvent) Type() EventType {
 	return ErrorEventType
 }
 
+// OwnershipTransferEvent is sent after a declared object, which was claimed by
+// a reconciler with higher precedence, has been removed from the inventory.
+type OwnershipTransferEvent struct {
+	// ID of the transferred object.
+	ID core.ID
+	// Manager of the object, after the transfer.
+	Manager string
+}
+
+// Type returns the type of the event.
+func (e OwnershipTransferEvent) Type() EventType {
+	return OwnershipTransferEventType
+}
+
 // Supervisor is a bulk client for applying and deleting a mutable set of
 // resource objects. Managed objects are tracked in a ResourceGroup inventory
 // object.
@@ -127,6 +145,8 @@ type Supervisor interface {
 
 // supervisor is the default implementation of the Supervisor interface.
 type supervisor struct {
+	// scope is the scope of the reconciler: root or a namespace
+	scope declared.Scope
 	// inventory policy for configuring the inventory status
 	policy inventory.Policy
 	// invInfo is the metadata for the ResourceGroup used to track managed objects
@@ -167,6 +187,7 @@ func NewSupervisor(cs *ClientSet, scope declared.Scope, syncName string, reconci
 		policy = inventory.PolicyAdoptAll
 	}
 	a := &supervisor{
+		scope:            scope,
 		invInfo:          invInfo,
 		clientSet:        cs,
 		policy:           policy,
@@ -207,7 +228,7 @@ func (s *supervisor) UpdateStatusMode(ctx context.Context) error {
 	})
 }
 
-func (s *supervisor) processApplyEvent(ctx context.Context, e event.ApplyEvent, syncStats *stats.ApplyEventStats, objectStatusMap ObjectStatusMap, unknownTypeResources map[core.ID]struct{}, resourceMap map[core.ID]client.Object) status.Error {
+func (s *supervisor) processApplyEvent(ctx context.Context, e event.ApplyEvent, syncStats *stats.ApplyEventStats, objectStatusMap ObjectStatusMap, unknownTypeResources map[core.ID]struct{}, resourceMap map[core.ID]client.Object, transfers map[core.ID]string) status.Error {
 	id := idFrom(e.Identifier)
 	syncStats.Add(e.Status)
 
@@ -245,7 +266,7 @@ func (s *supervisor) processApplyEvent(ctx context.Context, e event.ApplyEvent,
 	case event.ApplySkipped:
 		objectStatus.Actuation = actuation.ActuationSkipped
 		// Skip event always includes an error with the reason
-		return s.handleApplySkippedEvent(e.Resource, id, e.Error)
+		return s.handleApplySkippedEvent(ctx, e.Resource, id, e.Error, transfers)
 
 	default:
 		return ErrorForResource(fmt.Errorf("unexpected prune event status: %v", e.Status), id)
@@ -288,7 +309,9 @@ func (s *supervisor) processWaitEvent(e event.WaitEvent, syncStats *stats.WaitEv
 }
 
 // handleApplySkippedEvent translates from apply skipped event into resource error.
-func (s *supervisor) handleApplySkippedEvent(obj *unstructured.Unstructured, id core.ID, err error) status.Error {
+// Objects claimed by a reconciler with higher precedence are added to the
+// transfers map, instead of being reported as a management conflict.
+func (s *supervisor) handleApplySkippedEvent(ctx context.Context, obj *unstructured.Unstructured, id core.ID, err error, transfers map[core.ID]string) status.Error {
 	var depErr *filter.DependencyPreventedActuationError
 	if errors.As(err, &depErr) {
 		return SkipErrorForResource(err, id, depErr.Strategy)
@@ -301,6 +324,15 @@ func (s *supervisor) handleApplySkippedEvent(obj *unstructured.Unstructured, id
 
 	var policyErr *inventory.PolicyPreventedActuationError
 	if errors.As(err, &policyErr) {
+		manager, transferred, getErr := s.ownershipTransferred(ctx, obj)
+		if getErr != nil {
+			return ErrorForResource(getErr, id)
+		}
+		if transferred {
+			klog.Infof("Resource object claimed by reconciler %q: %v", manager, id)
+			transfers[id] = manager
+			return nil
+		}
 		// TODO: return ManagementConflictError with the conflicting manager if
 		// cli-utils supports reporting the conflicting manager in
 		// PolicyPreventedActuationError.
@@ -536,6 +568,7 @@ func (s *supervisor) applyInner(ctx context.Context, eventHandler func(Event), d
 	}
 
 	unknownTypeResources := make(map[core.ID]struct{})
+	transfers := make(map[core.ID]string)
 	options := apply.ApplierOptions{
 		ServerSideOptions: common.ServerSideOptions{
 			ServerSideApply: true,
@@ -604,7 +637,7 @@ func (s *supervisor) applyInner(ctx context.Context, eventHandler func(Event), d
 			} else {
 				klog.V(1).Info(e.ApplyEvent)
 			}
-			if err := s.processApplyEvent(ctx, e.ApplyEvent, syncStats.ApplyEvent, objStatusMap, unknownTypeResources, resourceMap); err != nil {
+			if err := s.processApplyEvent(ctx, e.ApplyEvent, syncStats.ApplyEvent, objStatusMap, unknownTypeResources, resourceMap, transfers); err != nil {
 				sendErrorEvent(err, eventHandler)
 			}
 		case event.PruneType:
@@ -621,6 +654,10 @@ func (s *supervisor) applyInner(ctx context.Context, eventHandler func(Event), d
 		}
 	}
 
+	if len(transfers) > 0 {
+		s.handleOwnershipTransfers(ctx, transfers, eventHandler)
+	}
+
 	return objStatusMap, syncStats
 }
 
@@ -770,6 +807,62 @@ func (s *supervisor) handleDisabledObjects(ctx context.Context, objs []client.Ob
 	return disabledCount, errs
 }
 
+// ownershipTransferred looks up the current state of the specified object and
+// returns its manager and true, if this reconciler has handed off management
+// of the object to a reconciler with higher precedence.
+func (s *supervisor) ownershipTransferred(ctx context.Context, obj *unstructured.Unstructured) (string, bool, error) {
+	if s.scope == declared.RootScope {
+		// Only namespace reconcilers hand off management.
+		return "", false, nil
+	}
+	uObj := &unstructured.Unstructured{}
+	uObj.SetGroupVersionKind(obj.GroupVersionKind())
+	err := s.clientSet.Client.Get(ctx, client.ObjectKeyFromObject(obj), uObj)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", false, nil
+		}
+		return "", false, err
+	}
+	if !diff.IsOwnershipTransferred(s.scope, s.syncName, uObj) {
+		return "", false, nil
+	}
+	return core.GetAnnotation(uObj, metadata.ResourceManagerKey), true, nil
+}
+
+// handleOwnershipTransfers removes the objects claimed by a reconciler with
+// higher precedence from the inventory, without pruning them, and sends an
+// OwnershipTransferEvent for each of them.
+func (s *supervisor) handleOwnershipTransfers(ctx context.Context, transfers map[core.ID]string, eventHandler func(Event)) {
+	ids := make([]core.ID, 0, len(transfers))
+	for id := range transfers {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i].String() < ids[j].String()
+	})
+	objs := make([]client.Object, 0, len(ids))
+	for _, id := range ids {
+		u := &unstructured.Unstructured{}
+		u.SetGroupVersionKind(id.WithVersion(""))
+		u.SetNamespace(id.Namespace)
+		u.SetName(id.Name)
+		objs = append(objs, u)
+	}
+	klog.Infof("%v objects claimed by another reconciler to be removed from the inventory: %v", len(objs), core.GKNNs(objs))
+	if err := s.removeFromInventory(ctx, objs); err != nil {
+		if nomosutil.IsRequestTooLargeError(err) {
+			sendErrorEvent(largeResourceGroupError(err, coreIDFromInventoryInfo(s.invInfo)), eventHandler)
+		} else {
+			sendErrorEvent(Error(err), eventHandler)
+		}
+		return
+	}
+	for _, id := range ids {
+		eventHandler(OwnershipTransferEvent{ID: id, Manager: transfers[id]})
+	}
+}
+
 // removeFromInventory removes the specified objects from the inventory, if it
 // exists.
 func (s *supervisor) removeFromInventory(ctx context.Context, objs []client.Object) error {
@@ -118,6 +118,18 @@ func TestApply(t *testing.T) {
 	})
 	abandonObjID := core.IDOf(abandonObj)
 
+	claimedObj := deploymentObj.DeepCopy()
+	claimedObj.SetName("claim-me")
+	claimedObj.SetAnnotations(map[string]string{
+		metadata.ManagementModeAnnotationKey:    metadata.ManagementEnabled.String(),
+		metadata.ResourceIDKey:                  core.GKNN(claimedObj),
+		metadata.ResourceManagerKey:             declared.ResourceManager(declared.RootScope, "root-sync"),
+		metadata.OwningInventoryKey:             "config-management-system_root-sync",
+		metadata.OwnershipTransferAnnotationKey: metadata.OwnershipTransferClaim.String(),
+	})
+	claimedObjMeta := object.UnstructuredToObjMetadata(claimedObj)
+	claimedObjID := core.IDOf(claimedObj)
+
 	testObj2 := newTestObj("test-2")
 	testObj2ID := core.IDOf(testObj2)
 	testObj3 := newTestObj("test-3")
@@ -150,6 +162,9 @@ func TestApply(t *testing.T) {
 		expectedObjectStatusMap ObjectStatusMap
 		expectedSyncStats       *stats.SyncStats
 		expectedServerObjs      []client.Object
+		inventory               object.ObjMetadataSet
+		expectedInventory       object.ObjMetadataSet
+		expectedTransfers       []OwnershipTransferEvent
 	}{
 		{
 			name: "unknown type for some resource",
@@ -185,6 +200,41 @@ func TestApply(t *testing.T) {
 				WithApplyEvents(event.ApplySkipped, 1).
 				WithApplyEvents(event.ApplyPending, 1),
 		},
+		{
+			name: "ownership claimed by root reconciler",
+			serverObjs: []client.Object{
+				claimedObj,
+			},
+			events: []event.Event{
+				formApplySkipEvent(claimedObjMeta, claimedObj.DeepCopy(), &inventory.PolicyPreventedActuationError{
+					Strategy: actuation.ActuationStrategyApply,
+					Policy:   inventory.PolicyAdoptIfNoInventory,
+					Status:   inventory.NoMatch,
+				}),
+				formApplyEvent(event.ApplySuccessful, testObj1, nil),
+			},
+			inventory:         object.ObjMetadataSet{claimedObjMeta, testObj1Meta},
+			expectedInventory: object.ObjMetadataSet{testObj1Meta},
+			expectedTransfers: []OwnershipTransferEvent{
+				{ID: claimedObjID, Manager: declared.ResourceManager(declared.RootScope, "root-sync")},
+			},
+			expectedObjectStatusMap: ObjectStatusMap{
+				claimedObjID: &ObjectStatus{Strategy: actuation.ActuationStrategyApply, Actuation: actuation.ActuationSkipped},
+				testObj1ID:   &ObjectStatus{Strategy: actuation.ActuationStrategyApply, Actuation: actuation.ActuationSucceeded},
+			},
+			expectedSyncStats: stats.NewSyncStats().
+				WithApplyEvents(event.ApplySkipped, 1).
+				WithApplyEvents(event.ApplySuccessful, 1),
+			expectedServerObjs: []client.Object{
+				func() client.Object {
+					obj := claimedObj.DeepCopy()
+					obj.SetUID("1")
+					obj.SetResourceVersion("1")
+					obj.SetGeneration(1)
+					return obj
+				}(),
+			},
+		},
 		{
 			name: "inventory object is too large",
 			events: []event.Event{
@@ -371,8 +421,10 @@ func TestApply(t *testing.T) {
 			tc.expectedServerObjs = append(tc.expectedServerObjs, expectedRSObj)
 
 			fakeClient := testingfake.NewClient(t, core.Scheme, tc.serverObjs...)
+			fakeInvClient := inventory.NewFakeClient(tc.inventory)
 			cs := &ClientSet{
 				KptApplier: newFakeKptApplier(tc.events),
+				InvClient:  fakeInvClient,
 				Client:     fakeClient,
 				Mapper:     fakeClient.RESTMapper(),
 				// TODO: Add tests to cover status mode
@@ -380,13 +432,17 @@ func TestApply(t *testing.T) {
 			applier := NewSupervisor(cs, syncScope, syncName, 5*time.Minute)
 
 			var errs status.MultiError
+			var transfers []OwnershipTransferEvent
 			eventHandler := func(event Event) {
-				if errEvent, ok := event.(ErrorEvent); ok {
+				switch e := event.(type) {
+				case ErrorEvent:
 					if errs == nil {
-						errs = errEvent.Error
+						errs = e.Error
 					} else {
-						errs = status.Append(errs, errEvent.Error)
+						errs = status.Append(errs, e.Error)
 					}
+				case OwnershipTransferEvent:
+					transfers = append(transfers, e)
 				}
 			}
 
@@ -400,6 +456,10 @@ func TestApply(t *testing.T) {
 			testutil.AssertEqual(t, tc.expectedError, errs)
 			testutil.AssertEqual(t, tc.expectedObjectStatusMap, objectStatusMap)
 			testutil.AssertEqual(t, tc.expectedSyncStats, syncStats)
+			testutil.AssertEqual(t, tc.expectedTransfers, transfers)
+			if tc.expectedInventory != nil {
+				testutil.AssertEqual(t, tc.expectedInventory, fakeInvClient.Inv.GetObjectRefs())
+			}
 
 			fakeClient.Check(t, tc.expectedServerObjs...)
 		})
@@ -921,12 +981,13 @@ func TestProcessApplyEvent(t *testing.T) {
 	syncStats := stats.NewSyncStats()
 	objStatusMap := make(ObjectStatusMap)
 	unknownTypeResources := make(map[core.ID]struct{})
+	transfers := make(map[core.ID]string)
 	s := supervisor{}
 
 	resourceMap := make(map[core.ID]client.Object)
 	resourceMap[deploymentObjID] = deploymentObj
 
-	err := s.processApplyEvent(ctx, formApplyEvent(event.ApplyFailed, deploymentObj, fmt.Errorf("test error")).ApplyEvent, syncStats.ApplyEvent, objStatusMap, unknownTypeResources, resourceMap)
+	err := s.processApplyEvent(ctx, formApplyEvent(event.ApplyFailed, deploymentObj, fmt.Errorf("test error")).ApplyEvent, syncStats.ApplyEvent, objStatusMap, unknownTypeResources, resourceMap, transfers)
 	expectedError := ErrorForResourceWithResource(fmt.Errorf("test error"), deploymentObjID, deploymentObj)
 	testutil.AssertEqual(t, expectedError, err, "expected processPruneEvent to error on apply %s", event.ApplyFailed)
 
@@ -946,7 +1007,7 @@ func TestProcessApplyEvent(t *testing.T) {
 	}
 	testutil.AssertEqual(t, expectedCSE, err.ToCSE(), "expected CSEs to match")
 
-	err = s.processApplyEvent(ctx, formApplyEvent(event.ApplySuccessful, testObj1, nil).ApplyEvent, syncStats.ApplyEvent, objStatusMap, unknownTypeResources, resourceMap)
+	err = s.processApplyEvent(ctx, formApplyEvent(event.ApplySuccessful, testObj1, nil).ApplyEvent, syncStats.ApplyEvent, objStatusMap, unknownTypeResources, resourceMap, transfers)
 	assert.Nil(t, err, "expected processApplyEvent NOT to error on apply %s", event.ApplySuccessful)
 
 	expectedApplyStatus := stats.NewSyncStats()
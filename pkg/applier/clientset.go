@@ -57,11 +57,11 @@ type ClientSet struct {
 }
 
 // NewClientSet constructs a new ClientSet.
-func NewClientSet(c client.Client, configFlags *genericclioptions.ConfigFlags, scope declared.Scope, syncName string, statusMode metadata.StatusMode, applySetID string) (*ClientSet, error) {
+func NewClientSet(c client.Client, configFlags *genericclioptions.ConfigFlags, scope declared.Scope, syncName string, statusMode metadata.StatusMode, applySetID string, resources *declared.Resources) (*ClientSet, error) {
 	matchVersionKubeConfigFlags := util.NewMatchVersionFlags(configFlags)
 	f := util.NewFactory(matchVersionKubeConfigFlags)
 
-	ic := csinventory.NewInventoryConverter(scope, syncName, statusMode)
+	ic := csinventory.NewInventoryConverter(scope, syncName, statusMode, resources)
 	invClient, err := ic.UnstructuredClientFromFactory(f)
 	if err != nil {
 		return nil, err
@@ -29,6 +29,7 @@ import (
 	"sigs.k8s.io/cli-utils/pkg/apis/actuation"
 	"sigs.k8s.io/cli-utils/pkg/inventory"
 	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 // ResourceGroupInventoryConverter is used for converting ResourceGroup objects and persisting
@@ -40,16 +41,20 @@ type ResourceGroupInventoryConverter struct {
 	syncName      string
 	syncNamespace string
 	statusMode    metadata.StatusMode
+	// resources are the declared resources, whose sources are reported in the
+	// status. Nil if the sources are not reported.
+	resources *declared.Resources
 	// TODO: add source/commit hash and interface method once it's to be persisted on ResourceGroup
 }
 
 // NewInventoryConverter constructs a new ResourceGroupInventoryConverter
-func NewInventoryConverter(scope declared.Scope, syncName string, statusMode metadata.StatusMode) *ResourceGroupInventoryConverter {
+func NewInventoryConverter(scope declared.Scope, syncName string, statusMode metadata.StatusMode, resources *declared.Resources) *ResourceGroupInventoryConverter {
 	return &ResourceGroupInventoryConverter{
 		syncKind:      scope.SyncKind(),
 		syncName:      syncName,
 		syncNamespace: scope.SyncNamespace(),
 		statusMode:    statusMode,
+		resources:     resources,
 	}
 }
 
@@ -200,7 +205,7 @@ func (ic *ResourceGroupInventoryConverter) InventoryToUnstructuredStatus(fromObj
 		status, found := objStatusMap[objMeta]
 		if found {
 			klog.V(4).Infof("converting to object status: %s", objMeta)
-			objStatus = append(objStatus, map[string]interface{}{
+			resStatus := map[string]interface{}{
 				"group":     objMeta.GroupKind.Group,
 				"kind":      objMeta.GroupKind.Kind,
 				"namespace": objMeta.Namespace,
@@ -209,7 +214,9 @@ func (ic *ResourceGroupInventoryConverter) InventoryToUnstructuredStatus(fromObj
 				"strategy":  status.Strategy.String(),
 				"actuation": status.Actuation.String(),
 				"reconcile": status.Reconcile.String(),
-			})
+			}
+			ic.setSource(resStatus, objMeta)
+			objStatus = append(objStatus, resStatus)
 		}
 	}
 
@@ -249,6 +256,28 @@ func (ic *ResourceGroupInventoryConverter) InventoryToUnstructuredStatus(fromObj
 	return toObj, nil
 }
 
+// setSource sets the location and rendering chain of the declared object on
+// its resource status. They are not set on the applied object, so the status
+// is the only place where they are persisted.
+func (ic *ResourceGroupInventoryConverter) setSource(resStatus map[string]interface{}, objMeta object.ObjMetadata) {
+	if ic.resources == nil {
+		return
+	}
+	source, found := ic.resources.GetSource(core.ID{
+		GroupKind: objMeta.GroupKind,
+		ObjectKey: client.ObjectKey{Namespace: objMeta.Namespace, Name: objMeta.Name},
+	})
+	if !found {
+		return
+	}
+	if source.Location != "" {
+		resStatus["sourceLocation"] = source.Location
+	}
+	if source.Chain != "" {
+		resStatus["sourceChain"] = source.Chain
+	}
+}
+
 // setMeta set Config Sync specific metadata on the inventory object
 func (ic *ResourceGroupInventoryConverter) setMeta(toObj *unstructured.Unstructured) {
 	core.SetLabel(toObj, metadata.ManagedByKey, metadata.ManagedByValue)
@@ -15,6 +15,7 @@
 package inventory
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 	"testing"
@@ -25,6 +26,7 @@ import (
 	"kpt.dev/configsync/pkg/api/configsync"
 	"kpt.dev/configsync/pkg/api/kpt.dev/v1alpha1"
 	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/core/k8sobjects"
 	"kpt.dev/configsync/pkg/declared"
 	"kpt.dev/configsync/pkg/kinds"
 	"kpt.dev/configsync/pkg/metadata"
@@ -33,6 +35,7 @@ import (
 	"sigs.k8s.io/cli-utils/pkg/inventory"
 	"sigs.k8s.io/cli-utils/pkg/object"
 	"sigs.k8s.io/cli-utils/pkg/testutil"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 const (
@@ -227,7 +230,7 @@ func TestInventoryFromUnstructured(t *testing.T) {
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			ic := NewInventoryConverter(declared.RootScope, "test-sync", metadata.StatusEnabled)
+			ic := NewInventoryConverter(declared.RootScope, "test-sync", metadata.StatusEnabled, nil)
 			inv, err := ic.InventoryFromUnstructured(tc.fromObj)
 			if tc.wantErr != nil {
 				require.Equal(t, tc.wantErr, err)
@@ -669,7 +672,7 @@ func TestInventoryToUnstructured(t *testing.T) {
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			ic := NewInventoryConverter(declared.RootScope, invName, tc.statusMode)
+			ic := NewInventoryConverter(declared.RootScope, invName, tc.statusMode, nil)
 			rg, err := ic.InventoryToUnstructured(tc.fromObj, tc.toInv)
 			if tc.wantErr != nil {
 				require.Equal(t, tc.wantErr, err)
@@ -703,6 +706,7 @@ func TestInventoryToUnstructuredStatus(t *testing.T) {
 		fromObj    *unstructured.Unstructured
 		toInv      *inventory.SingleObjectInventory
 		statusMode metadata.StatusMode
+		resources  []client.Object
 		wantErr    error
 		wantObj    *unstructured.Unstructured
 	}{
@@ -823,6 +827,58 @@ func TestInventoryToUnstructuredStatus(t *testing.T) {
 				},
 			}.buildStatus(t),
 		},
+		{
+			name:       "empty ResourceGroup object and inventory with declared sources",
+			statusMode: metadata.StatusEnabled,
+			fromObj:    resourceGroupFactory{}.build(t),
+			resources: []client.Object{
+				k8sobjects.ConfigMapObject(core.Name("cm"), core.Namespace("obj-ns"),
+					core.Annotation(metadata.SourcePathAnnotationKey, "acme/v1_configmap_cm.yaml"),
+					core.Annotation(metadata.SourceLocationAnnotationKey, "acme/base/cm.yaml:3:1"),
+					core.Annotation(metadata.SourceChainAnnotationKey, "acme/base/cm.yaml -> acme/kustomization.yaml")),
+			},
+			toInv: inventoryFactory{
+				objRefs: object.ObjMetadataSet{
+					{
+						Name:      "cm",
+						Namespace: "obj-ns",
+						GroupKind: kinds.ConfigMap().GroupKind(),
+					},
+				},
+				objStatuses: object.ObjectStatusSet{
+					{
+						ObjectReference: actuation.ObjectReference{
+							Name:      "cm",
+							Namespace: "obj-ns",
+							Kind:      "ConfigMap",
+						},
+						Strategy:  actuation.ActuationStrategyApply,
+						Actuation: actuation.ActuationSucceeded,
+						Reconcile: actuation.ReconcileSucceeded,
+					},
+				},
+			}.build(t),
+			wantObj: resourceGroupFactory{
+				status: v1alpha1.ResourceGroupStatus{
+					ObservedGeneration: invGeneration,
+					ResourceStatuses: []v1alpha1.ResourceStatus{
+						{
+							ObjMetadata: v1alpha1.ObjMetadata{
+								Name:      "cm",
+								Namespace: "obj-ns",
+								GroupKind: v1alpha1.GroupKind{Kind: "ConfigMap"},
+							},
+							Status:         v1alpha1.Unknown,
+							SourceLocation: "acme/base/cm.yaml:3:1",
+							SourceChain:    "acme/base/cm.yaml -> acme/kustomization.yaml",
+							Strategy:       v1alpha1.Apply,
+							Actuation:      v1alpha1.ActuationSucceeded,
+							Reconcile:      v1alpha1.ReconcileSucceeded,
+						},
+					},
+				},
+			}.buildStatus(t),
+		},
 		{
 			name:       "non-empty ResourceGroup object and empty inventory",
 			statusMode: metadata.StatusEnabled,
@@ -1129,7 +1185,10 @@ func TestInventoryToUnstructuredStatus(t *testing.T) {
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			ic := NewInventoryConverter(declared.RootScope, invName, tc.statusMode)
+			resources := &declared.Resources{}
+			_, declErr := resources.UpdateDeclared(context.Background(), tc.resources, "abc123")
+			require.NoError(t, declErr)
+			ic := NewInventoryConverter(declared.RootScope, invName, tc.statusMode, resources)
 			rg, err := ic.InventoryToUnstructuredStatus(tc.fromObj, tc.toInv)
 			if tc.wantErr != nil {
 				require.Equal(t, tc.wantErr, err)
@@ -1175,7 +1234,7 @@ func TestNewInventoryConverter(t *testing.T) {
 	}
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
-			ic := NewInventoryConverter(tc.scope, invName, metadata.StatusEnabled)
+			ic := NewInventoryConverter(tc.scope, invName, metadata.StatusEnabled, nil)
 			require.Equal(t, tc.wantSyncKind, ic.syncKind)
 			require.Equal(t, tc.wantSyncNamespace, ic.syncNamespace)
 		})
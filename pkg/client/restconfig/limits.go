@@ -0,0 +1,72 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package restconfig
+
+import (
+	"net/http"
+
+	"k8s.io/client-go/rest"
+	"k8s.io/klog/v2"
+)
+
+// SetRateLimits overrides the client-side throttling QPS and Burst set by
+// UpdateQPS. If qps is not positive, the config is unchanged. If burst is not
+// positive, it defaults to twice qps.
+func SetRateLimits(config *rest.Config, qps, burst int) {
+	if qps <= 0 {
+		return
+	}
+	if burst <= 0 {
+		burst = 2 * qps
+	}
+	config.QPS = float32(qps)
+	config.Burst = burst
+	klog.V(1).Infof("Client-side throttling QPS overridden to %.0f (burst: %d)", config.QPS, config.Burst)
+}
+
+// LimitInflightRequests wraps the transport of the config to limit the number
+// of concurrent requests. Watch requests are long-running, so they're not
+// limited. If maxInflight is not positive, the config is unchanged.
+func LimitInflightRequests(config *rest.Config, maxInflight int) {
+	if maxInflight <= 0 {
+		return
+	}
+	sem := make(chan struct{}, maxInflight)
+	config.Wrap(func(rt http.RoundTripper) http.RoundTripper {
+		return &inflightLimiter{delegate: rt, sem: sem}
+	})
+}
+
+// inflightLimiter is a RoundTripper which blocks while the maximum number of
+// requests are in flight.
+type inflightLimiter struct {
+	delegate http.RoundTripper
+	sem      chan struct{}
+}
+
+// RoundTrip implements http.RoundTripper. The request is counted as in flight
+// until the response headers are received.
+func (l *inflightLimiter) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.URL.Query().Get("watch") == "true" {
+		return l.delegate.RoundTrip(req)
+	}
+	select {
+	case l.sem <- struct{}{}:
+	case <-req.Context().Done():
+		return nil, req.Context().Err()
+	}
+	defer func() { <-l.sem }()
+	return l.delegate.RoundTrip(req)
+}
@@ -0,0 +1,103 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package restconfig
+
+import (
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/rest"
+)
+
+func TestSetRateLimits(t *testing.T) {
+	testCases := []struct {
+		name      string
+		qps       int
+		burst     int
+		wantQPS   float32
+		wantBurst int
+	}{
+		{name: "unset", wantQPS: -1, wantBurst: -1},
+		{name: "qps only", qps: 10, wantQPS: 10, wantBurst: 20},
+		{name: "qps and burst", qps: 10, burst: 15, wantQPS: 10, wantBurst: 15},
+		{name: "burst only", burst: 15, wantQPS: -1, wantBurst: -1},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := &rest.Config{QPS: -1, Burst: -1}
+			SetRateLimits(cfg, tc.qps, tc.burst)
+			assert.Equal(t, tc.wantQPS, cfg.QPS)
+			assert.Equal(t, tc.wantBurst, cfg.Burst)
+		})
+	}
+}
+
+type blockingRoundTripper struct {
+	inflight    atomic.Int32
+	maxInflight atomic.Int32
+	release     chan struct{}
+}
+
+func (rt *blockingRoundTripper) RoundTrip(_ *http.Request) (*http.Response, error) {
+	n := rt.inflight.Add(1)
+	defer rt.inflight.Add(-1)
+	for {
+		cur := rt.maxInflight.Load()
+		if n <= cur || rt.maxInflight.CompareAndSwap(cur, n) {
+			break
+		}
+	}
+	<-rt.release
+	return &http.Response{StatusCode: http.StatusOK}, nil
+}
+
+func TestLimitInflightRequests(t *testing.T) {
+	delegate := &blockingRoundTripper{release: make(chan struct{})}
+	cfg := &rest.Config{}
+	LimitInflightRequests(cfg, 2)
+	require.NotNil(t, cfg.WrapTransport)
+	rt := cfg.WrapTransport(delegate)
+
+	var wg sync.WaitGroup
+	send := func(url string) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req, err := http.NewRequest(http.MethodGet, url, nil)
+			if !assert.NoError(t, err) {
+				return
+			}
+			_, err = rt.RoundTrip(req)
+			assert.NoError(t, err)
+		}()
+	}
+	for i := 0; i < 5; i++ {
+		send("https://example.com/api/v1/configmaps")
+	}
+	// Watches are not limited.
+	send("https://example.com/api/v1/configmaps?watch=true")
+
+	assert.Eventually(t, func() bool {
+		return delegate.inflight.Load() == 3
+	}, 5*time.Second, 10*time.Millisecond)
+	close(delegate.release)
+	wg.Wait()
+	assert.Equal(t, int32(3), delegate.maxInflight.Load())
+}
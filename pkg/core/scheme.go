@@ -17,8 +17,11 @@ package core
 import (
 	admissionv1 "k8s.io/api/admissionregistration/v1"
 	appsv1 "k8s.io/api/apps/v1"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	coordinationv1beta1 "k8s.io/api/coordination/v1beta1"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	policyv1beta1 "k8s.io/api/policy/v1beta1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	rbacv1beta1 "k8s.io/api/rbac/v1beta1"
@@ -95,6 +98,9 @@ func mustRegisterKubernetesResources() {
 	utilruntime.Must(policyv1beta1.AddToScheme(scheme.Scheme))
 	utilruntime.Must(k8spolicyv1beta1.RegisterDefaults(scheme.Scheme))
 	utilruntime.Must(k8spolicyv1beta1.RegisterConversions(scheme.Scheme))
+	utilruntime.Must(scheme.Scheme.SetVersionPriority(policyv1.SchemeGroupVersion, policyv1beta1.SchemeGroupVersion))
+
+	utilruntime.Must(scheme.Scheme.SetVersionPriority(coordinationv1.SchemeGroupVersion, coordinationv1beta1.SchemeGroupVersion))
 
 	utilruntime.Must(networkingv1.AddToScheme(scheme.Scheme))
 	utilruntime.Must(k8snetworkingv1.RegisterDefaults(scheme.Scheme))
@@ -26,6 +26,7 @@ import (
 	"k8s.io/klog/v2"
 	"kpt.dev/configsync/pkg/core"
 	"kpt.dev/configsync/pkg/kinds"
+	"kpt.dev/configsync/pkg/metadata"
 	"kpt.dev/configsync/pkg/metrics"
 	"kpt.dev/configsync/pkg/remediator/queue"
 	"kpt.dev/configsync/pkg/status"
@@ -34,6 +35,15 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// Source is the location of a declared object in the source, and the chain of
+// files it was rendered from.
+type Source struct {
+	// Location is the path, line and column where the object is declared.
+	Location string
+	// Chain lists the files the object was rendered from.
+	Chain string
+}
+
 // Resources is a threadsafe container for a set of resources declared in a Git
 // repo.
 type Resources struct {
@@ -45,6 +55,11 @@ type Resources struct {
 	// this reference; it should be treated as read-only from then on.
 	declaredObjectsMap *orderedmap.OrderedMap[core.ID, *unstructured.Unstructured]
 
+	// sourcesMap is a map of object IDs to their locations in the source. Like
+	// declaredObjectsMap, it should be treated as read-only once it has been
+	// assigned to this reference.
+	sourcesMap map[core.ID]Source
+
 	// mutationIgnoredObjectsMap is a map of object IDs to the cluster-state of mutation-ignored objects.
 	// The cluster-state is initialized by the applier and updated by the remediator.
 	mutationIgnoredObjectsMap *orderedmap.OrderedMap[core.ID, client.Object]
@@ -124,6 +139,7 @@ func (r *Resources) UpdateDeclared(ctx context.Context, objects []client.Object,
 	defer r.mutex.Unlock()
 	// First build up the new map using a local pointer/reference.
 	newSet := orderedmap.NewOrderedMap[core.ID, *unstructured.Unstructured]()
+	newSources := make(map[core.ID]Source)
 	newObjects := []client.Object{}
 	for _, obj := range objects {
 		if obj == nil {
@@ -138,6 +154,10 @@ func (r *Resources) UpdateDeclared(ctx context.Context, objects []client.Object,
 			return nil, status.InternalErrorBuilder.Wrap(err).
 				Sprintf("converting %v to unstructured.Unstructured", id).Build()
 		}
+		// The source location is only reported in the ResourceGroup status, so
+		// it is removed from the declared object before it is applied.
+		newSources[id] = sourceOf(u)
+		core.RemoveAnnotations(u, metadata.SourceLocationAnnotationKey, metadata.SourceChainAnnotationKey)
 		newSet.Set(id, u)
 		newObjects = append(newObjects, obj)
 	}
@@ -159,10 +179,33 @@ func (r *Resources) UpdateDeclared(ctx context.Context, objects []client.Object,
 
 	r.previousCommit = commit
 	r.declaredObjectsMap = newSet
+	r.sourcesMap = newSources
 	r.commit = commit
 	return newObjects, nil
 }
 
+// sourceOf returns the source of the object, located at its source path if its
+// location is not recorded.
+func sourceOf(obj client.Object) Source {
+	annotations := obj.GetAnnotations()
+	location := annotations[metadata.SourceLocationAnnotationKey]
+	if location == "" {
+		location = annotations[metadata.SourcePathAnnotationKey]
+	}
+	return Source{
+		Location: location,
+		Chain:    annotations[metadata.SourceChainAnnotationKey],
+	}
+}
+
+// GetSource returns the source of the declared object.
+func (r *Resources) GetSource(id core.ID) (Source, bool) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	source, found := r.sourcesMap[id]
+	return source, found
+}
+
 // GetDeclared returns a copy of the resource declaration as read from Git
 func (r *Resources) GetDeclared(id core.ID) (*unstructured.Unstructured, string, bool) {
 	r.mutex.RLock()
@@ -207,6 +250,16 @@ func (r *Resources) DeclaredObjects() []client.Object {
 	return objects
 }
 
+// DeclaredCount returns the number of resource objects declared in the source.
+func (r *Resources) DeclaredCount() int {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	if r.declaredObjectsMap == nil {
+		return 0
+	}
+	return r.declaredObjectsMap.Len()
+}
+
 // DeclaredGVKs returns the set of all GroupVersionKind found in the source,
 // along with the source commit.
 func (r *Resources) DeclaredGVKs() (map[schema.GroupVersionKind]struct{}, string) {
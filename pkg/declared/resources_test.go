@@ -191,6 +191,36 @@ func TestGetDeclared(t *testing.T) {
 	}
 }
 
+func TestGetSource(t *testing.T) {
+	dr := Resources{}
+	role := k8sobjects.RoleObject(core.Name("writer"), core.Namespace("foo"),
+		core.Annotation(metadata.SourcePathAnnotationKey, "acme/apps_v1_role_writer.yaml"),
+		core.Annotation(metadata.SourceLocationAnnotationKey, "acme/base/role.yaml:3:1"),
+		core.Annotation(metadata.SourceChainAnnotationKey, "acme/base/role.yaml -> acme/kustomization.yaml"))
+	cm := k8sobjects.ConfigMapObject(core.Name("cm"), core.Namespace("foo"),
+		core.Annotation(metadata.SourcePathAnnotationKey, "acme/cm.yaml"))
+	_, err := dr.UpdateDeclared(context.Background(), []client.Object{role, cm}, "example")
+	require.NoError(t, err)
+
+	source, found := dr.GetSource(core.IDOf(role))
+	require.True(t, found)
+	assert.Equal(t, Source{
+		Location: "acme/base/role.yaml:3:1",
+		Chain:    "acme/base/role.yaml -> acme/kustomization.yaml",
+	}, source)
+	source, found = dr.GetSource(core.IDOf(cm))
+	require.True(t, found)
+	assert.Equal(t, Source{Location: "acme/cm.yaml"}, source)
+
+	// The source is not applied.
+	declared, _, _ := dr.GetDeclared(core.IDOf(role))
+	assert.Equal(t, map[string]string{
+		metadata.SourcePathAnnotationKey: "acme/apps_v1_role_writer.yaml",
+	}, declared.GetAnnotations())
+	// The parsed object is not modified.
+	assert.Contains(t, role.GetAnnotations(), metadata.SourceLocationAnnotationKey)
+}
+
 func TestGVKSet(t *testing.T) {
 	dr := Resources{}
 	expectedCommit := "example"
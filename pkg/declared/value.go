@@ -66,6 +66,11 @@ func (v *ValueConverter) Refresh() error {
 	return nil
 }
 
+// OpenAPIResources returns the OpenAPI schemas last pulled by Refresh.
+func (v *ValueConverter) OpenAPIResources() openapi.Resources {
+	return v.openAPIResources
+}
+
 // TypedValue returns the equivalent TypedValue for the given Object.
 func (v *ValueConverter) TypedValue(obj runtime.Object) (*typed.TypedValue, error) {
 	gvk := obj.GetObjectKind().GroupVersionKind()
@@ -145,6 +145,11 @@ func (d Diff) updateType(scope declared.Scope, syncName string) Operation {
 		}
 		return Update
 	case metadata.IsManagementEnabled(d.Declared) && !canManage:
+		if IsOwnershipTransferred(scope, syncName, d.Actual) {
+			// The object was claimed by a reconciler with higher precedence,
+			// so this reconciler has handed off management.
+			return NoOp
+		}
 		// This reconciler can't manage this object but is erroneously being told to.
 		return ManagementConflict
 	case metadata.IsManagementDisabled(d.Declared) && canManage:
@@ -129,6 +129,17 @@ func TestDiffType(t *testing.T) {
 				difftest.ManagedBy(declared.RootScope, "any-rs")),
 			want: ManagementConflict,
 		},
+		{
+			name:  "declared + actual, management enabled, namespace scope / root-claimed object: no op",
+			scope: "foo",
+			declared: k8sobjects.RoleObject(syncertest.ManagementEnabled,
+				core.Namespace("foo")),
+			actual: k8sobjects.RoleObject(syncertest.ManagementEnabled,
+				core.Namespace("foo"),
+				difftest.ManagedBy(declared.RootScope, "any-rs"),
+				metadata.WithOwnershipTransfer(metadata.OwnershipTransferClaim)),
+			want: NoOp,
+		},
 		{
 			name:     "declared + actual, management disabled, root scope, can manage, with meta, no manager: abandon",
 			scope:    declared.RootScope,
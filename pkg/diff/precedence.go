@@ -49,6 +49,26 @@ func IsManager(scope declared.Scope, syncName string, obj client.Object) bool {
 	return oldManager == newManager
 }
 
+// IsOwnershipTransferred returns true if the given namespace reconciler has
+// handed off management of the resource object to a root reconciler, which
+// claimed it with the `configsync.gke.io/ownership-transfer: claim` annotation.
+//
+// Only a reconciler with higher precedence can claim an object, so this is
+// always false for root reconcilers.
+func IsOwnershipTransferred(scope declared.Scope, syncName string, obj client.Object) bool {
+	if scope == declared.RootScope {
+		return false
+	}
+	if !metadata.IsManagementEnabled(obj) || !metadata.IsOwnershipClaimed(obj) {
+		return false
+	}
+	manager := core.GetAnnotation(obj, metadata.ResourceManagerKey)
+	if manager == "" || manager == declared.ResourceManager(scope, syncName) {
+		return false
+	}
+	return declared.IsRootManager(manager)
+}
+
 // CanManage returns true if the given reconciler is allowed to perform the
 // specified operation on the specified resource object.
 func CanManage(scope declared.Scope, syncName string, obj client.Object, op admissionv1.Operation) bool {
@@ -25,6 +25,7 @@ import (
 	"kpt.dev/configsync/pkg/core/k8sobjects"
 	"kpt.dev/configsync/pkg/declared"
 	"kpt.dev/configsync/pkg/diff/difftest"
+	"kpt.dev/configsync/pkg/metadata"
 	"kpt.dev/configsync/pkg/syncer/syncertest"
 	"kpt.dev/configsync/pkg/testing/testerrors"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -129,6 +130,62 @@ func TestCanManage(t *testing.T) {
 	}
 }
 
+func TestIsOwnershipTransferred(t *testing.T) {
+	claimed := metadata.WithOwnershipTransfer(metadata.OwnershipTransferClaim)
+	testCases := []struct {
+		name   string
+		scope  declared.Scope
+		object client.Object
+		want   bool
+	}{
+		{
+			"Root never transfers ownership",
+			declared.RootScope,
+			k8sobjects.DeploymentObject(syncertest.ManagementEnabled, difftest.ManagedBy(declared.RootScope, "other-rs"), claimed),
+			false,
+		},
+		{
+			"Non-root transfers ownership of root-claimed object",
+			"foo",
+			k8sobjects.DeploymentObject(syncertest.ManagementEnabled, difftest.ManagedBy(declared.RootScope, "any-rs"), claimed),
+			true,
+		},
+		{
+			"Non-root does NOT transfer ownership of root-managed object without claim",
+			"foo",
+			k8sobjects.DeploymentObject(syncertest.ManagementEnabled, difftest.ManagedBy(declared.RootScope, "any-rs")),
+			false,
+		},
+		{
+			"Non-root does NOT transfer ownership of unmanaged object with claim",
+			"foo",
+			k8sobjects.DeploymentObject(difftest.ManagedBy(declared.RootScope, "any-rs"), claimed),
+			false,
+		},
+		{
+			"Non-root does NOT transfer ownership of other non-root-claimed object",
+			"foo",
+			k8sobjects.DeploymentObject(syncertest.ManagementEnabled, difftest.ManagedBy("foo", "other-rs"), claimed),
+			false,
+		},
+		{
+			"Non-root does NOT transfer ownership of self-managed object",
+			"foo",
+			k8sobjects.DeploymentObject(syncertest.ManagementEnabled, difftest.ManagedBy("foo", rsName), claimed),
+			false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := IsOwnershipTransferred(tc.scope, rsName, tc.object)
+			if got != tc.want {
+				t.Errorf("IsOwnershipTransferred() = %v; want %v", got, tc.want)
+			}
+		})
+	}
+}
+
 func TestValidateManager(t *testing.T) {
 	cmID := core.ID{
 		GroupKind: schema.GroupKind{Group: "example.com", Kind: "ConfigMap"},
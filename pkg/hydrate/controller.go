@@ -21,12 +21,14 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/klog/v2"
 	"kpt.dev/configsync/pkg/api/configsync"
 	"kpt.dev/configsync/pkg/importer/filesystem/cmpath"
+	"kpt.dev/configsync/pkg/kmetrics"
 	"kpt.dev/configsync/pkg/metadata"
 	"kpt.dev/configsync/pkg/reconcilermanager"
 	"kpt.dev/configsync/pkg/status"
@@ -69,6 +71,15 @@ type Hydrator struct {
 	RehydratePeriod time.Duration
 	// ReconcilerName is the name of the reconciler.
 	ReconcilerName string
+	// RenderingEngine is the rendering engine. If empty, the engine is
+	// detected from the files in the sync directory.
+	RenderingEngine configsync.RenderingEngine
+	// EnableShellInRendering indicates whether the shell is enabled in the
+	// rendering process.
+	EnableShellInRendering bool
+	// RenderCache is the cache of the rendered output. If nil, the configs
+	// are rendered for every new source commit.
+	RenderCache *RenderCache
 }
 
 // Run runs the hydration process periodically.
@@ -128,13 +139,20 @@ func (h *Hydrator) Run(ctx context.Context) {
 	}
 }
 
-// runHydrate runs `kustomize build` on the source configs.
+// runHydrate renders the source configs with the Renderer of the rendering engine.
 func (h *Hydrator) runHydrate(sourceCommit string, syncPath cmpath.Absolute) HydrationError {
 	newHydratedDir := h.HydratedRoot.Join(cmpath.RelativeOS(sourceCommit))
 	dest := newHydratedDir.Join(h.SyncDir).OSPath()
 
 	osSyncPath := syncPath.OSPath()
-	if err := kustomizeBuild(osSyncPath, dest, true); err != nil {
+	renderer, err := h.newRenderer(osSyncPath)
+	if err != nil {
+		return NewActionableError(err)
+	}
+	if renderer == nil {
+		return NewActionableError(fmt.Errorf("no rendering engine detected for the sync directory %s", osSyncPath))
+	}
+	if err := h.render(renderer, osSyncPath, dest); err != nil {
 		return err
 	}
 
@@ -142,7 +160,7 @@ func (h *Hydrator) runHydrate(sourceCommit string, syncPath cmpath.Absolute) Hyd
 	if err != nil {
 		return NewTransientError(err)
 	} else if sourceCommit != newCommit {
-		return NewTransientError(fmt.Errorf("source commit changed while running Kustomize build, was %s, now %s. It will be retried in the next sync", sourceCommit, newCommit))
+		return NewTransientError(fmt.Errorf("source commit changed while rendering, was %s, now %s. It will be retried in the next sync", sourceCommit, newCommit))
 	}
 
 	if err := updateSymlink(h.HydratedRoot.OSPath(), h.HydratedLink, newHydratedDir.OSPath()); err != nil {
@@ -152,6 +170,57 @@ func (h *Hydrator) runHydrate(sourceCommit string, syncPath cmpath.Absolute) Hyd
 	return nil
 }
 
+// render renders the sync directory to the output directory. If the render
+// cache is enabled, the cached output is reused if the rendering inputs are
+// unchanged. Cache failures are logged, and fall back to rendering. The cache
+// is skipped if the sync directory references unpinned remote bases or Helm
+// charts.
+func (h *Hydrator) render(renderer Renderer, syncPath, output string) HydrationError {
+	if h.RenderCache == nil {
+		return renderer.Render(syncPath, output)
+	}
+	ctx := context.Background()
+	sourceRoot, err := filepath.EvalSymlinks(h.sourcePath().OSPath())
+	if err != nil {
+		klog.Warningf("Unable to evaluate the source directory, skipping the rendering cache: %v", err)
+		return renderer.Render(syncPath, output)
+	}
+	unpinned, err := unpinnedReferences(renderer, syncPath)
+	if err != nil {
+		klog.Warningf("Unable to check the remote references, skipping the rendering cache: %v", err)
+		return renderer.Render(syncPath, output)
+	}
+	if len(unpinned) > 0 {
+		klog.Infof("Skipping the rendering cache because of the unpinned remote references: %s", strings.Join(unpinned, ", "))
+		return renderer.Render(syncPath, output)
+	}
+	key, err := renderCacheKey(renderer, sourceRoot, syncPath, h.EnableShellInRendering)
+	if err != nil {
+		klog.Warningf("Unable to compute the rendering cache key, skipping the rendering cache: %v", err)
+		return renderer.Render(syncPath, output)
+	}
+	hit, err := h.RenderCache.restore(key, output)
+	if err != nil {
+		klog.Warningf("Unable to restore the rendering cache entry %s: %v", key, err)
+	}
+	if hit {
+		kmetrics.RecordRenderingCacheLookup(ctx, kmetrics.CacheHit)
+		klog.Infof("Reused the cached rendered output %s for %s", key, syncPath)
+		return nil
+	}
+	kmetrics.RecordRenderingCacheLookup(ctx, kmetrics.CacheMiss)
+	if err := renderer.Render(syncPath, output); err != nil {
+		return err
+	}
+	size, err := h.RenderCache.store(key, output)
+	if err != nil {
+		klog.Warningf("Unable to store the rendered output in the rendering cache: %v", err)
+		return nil
+	}
+	kmetrics.RecordRenderingCacheSize(ctx, size)
+	return nil
+}
+
 // ComputeCommit returns the computed commit from given sourceDir, or error
 // if the sourceDir fails symbolic link evaluation
 func ComputeCommit(sourceDir cmpath.Absolute) (string, error) {
@@ -169,14 +238,33 @@ func (h *Hydrator) sourcePath() cmpath.Absolute {
 	return h.SourceRoot.Join(cmpath.RelativeSlash(h.SourceLink))
 }
 
+// newRenderer returns the Renderer of the sync directory, which may only read
+// the files of the source, and may only fetch remote bases or run exec
+// functions if the shell is enabled in the rendering process.
+func (h *Hydrator) newRenderer(osSyncPath string) (Renderer, error) {
+	sourceRoot, err := h.sourcePath().EvalSymlinks()
+	if err != nil {
+		return nil, fmt.Errorf("unable to evaluate the symbolic links of the source directory: %w", err)
+	}
+	return NewRenderer(h.RenderingEngine, osSyncPath, RendererOptions{
+		SendMetrics:      true,
+		SourceRoot:       sourceRoot.OSPath(),
+		AllowRemoteBases: h.EnableShellInRendering,
+		AllowExec:        h.EnableShellInRendering,
+	})
+}
+
 // hydrate renders the source git repo to hydrated configs.
 func (h *Hydrator) hydrate(sourceCommit string, syncPath cmpath.Absolute) HydrationError {
 	osSyncPath := syncPath.OSPath()
-	hydrate, err := needsKustomize(osSyncPath)
+	renderer, err := h.newRenderer(osSyncPath)
 	if err != nil {
+		if h.RenderingEngine != "" {
+			return NewActionableError(err)
+		}
 		return NewInternalError(fmt.Errorf("unable to check if rendering is needed for the source directory: %s: %w", osSyncPath, err))
 	}
-	if !hydrate {
+	if renderer == nil {
 		found, err := hasKustomizeSubdir(osSyncPath)
 		if err != nil {
 			return NewInternalError(err)
@@ -0,0 +1,198 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hydrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"strings"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/cuecontext"
+	cueerrors "cuelang.org/go/cue/errors"
+	"cuelang.org/go/cue/load"
+	"cuelang.org/go/mod/module"
+	"kpt.dev/configsync/pkg/api/configsync"
+)
+
+const (
+	// cueModule is the Go module of the CUE evaluator.
+	cueModule = "cuelang.org/go"
+	// CUEObjectsField is the field of the CUE package which contains the
+	// objects to render. If the field doesn't exist, the whole package value
+	// is rendered.
+	CUEObjectsField = "objects"
+)
+
+// cueRenderer renders the configs by evaluating the CUE package in the sync
+// directory in-process.
+type cueRenderer struct {
+	RendererOptions
+}
+
+// Engine implements Renderer.
+func (cueRenderer) Engine() configsync.RenderingEngine {
+	return configsync.RenderingEngineCUE
+}
+
+// Detect implements Renderer. The directory must be the root of a CUE
+// module, so a stray CUE file doesn't enable rendering.
+func (cueRenderer) Detect(dir string) (bool, error) {
+	fi, err := os.Stat(filepath.Join(dir, cueModuleDir))
+	switch {
+	case err == nil:
+		return fi.IsDir(), nil
+	case os.IsNotExist(err):
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+// Version implements Renderer. It returns the version of the CUE module in
+// the build info of the binary.
+func (cueRenderer) Version() (string, error) {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "", errors.New("unable to read the build info")
+	}
+	for _, dep := range info.Deps {
+		if dep.Path == cueModule {
+			return dep.Version, nil
+		}
+	}
+	return "", fmt.Errorf("module %s not found in the build info", cueModule)
+}
+
+// Render implements Renderer. The rendered value must be concrete.
+func (r cueRenderer) Render(input, output string) HydrationError {
+	b, err := newBoundary(r.SourceRoot, input, false)
+	if err != nil {
+		return NewInternalError(err)
+	}
+	if err := prepareOutput(output); err != nil {
+		return err
+	}
+	out, err := r.evaluate(b, input)
+	if err != nil {
+		renderErr := fmt.Errorf("failed to evaluate the CUE package in %s: %w", input, err)
+		mustDeleteOutput(renderErr, output)
+		return NewActionableError(renderErr)
+	}
+	if err := writeRenderedJSON(configsync.RenderingEngineCUE, out, output); err != nil {
+		mustDeleteOutput(err, output)
+		return err
+	}
+	return nil
+}
+
+// evaluate evaluates the CUE package in the directory, and returns the
+// objects field, or the whole value, as JSON.
+//
+// The package is loaded from the CUE module within the boundary which contains
+// the directory, and the files of the module may not link outside of the
+// boundary. The module dependencies are only fetched from the registry if
+// remote bases are allowed.
+func (r cueRenderer) evaluate(b boundary, dir string) ([]byte, error) {
+	resolved, err := resolvePath(dir)
+	if err != nil {
+		return nil, err
+	}
+	moduleRoot := findCUEModuleRoot(b.root, resolved)
+	if moduleRoot == "" {
+		moduleRoot = resolved
+	}
+	if err := checkLinks(b, moduleRoot); err != nil {
+		return nil, err
+	}
+	cfg := &load.Config{Dir: resolved, ModuleRoot: moduleRoot}
+	if !r.AllowRemoteBases {
+		cfg.Registry = offlineCUERegistry{}
+	}
+	instances := load.Instances([]string{"."}, cfg)
+	if len(instances) == 0 {
+		return nil, errors.New("no CUE package found")
+	}
+	if err := instances[0].Err; err != nil {
+		return nil, cueError(err)
+	}
+	value := cuecontext.New().BuildInstance(instances[0])
+	if err := value.Err(); err != nil {
+		return nil, cueError(err)
+	}
+	if objects := value.LookupPath(cue.ParsePath(CUEObjectsField)); objects.Exists() {
+		value = objects
+	}
+	if err := value.Validate(cue.Concrete(true)); err != nil {
+		return nil, cueError(err)
+	}
+	out, err := value.MarshalJSON()
+	if err != nil {
+		return nil, cueError(err)
+	}
+	return out, nil
+}
+
+// checkLinks returns an error if a symbolic link under the root points
+// outside of the boundary.
+func checkLinks(b boundary, root string) error {
+	return filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && d.Name() == gitDir {
+			return filepath.SkipDir
+		}
+		if d.Type()&fs.ModeSymlink != 0 {
+			return b.check(path)
+		}
+		return nil
+	})
+}
+
+// offlineCUERegistry is a CUE module registry which fails to fetch any
+// module, used when remote access isn't allowed in the rendering process.
+type offlineCUERegistry struct{}
+
+func (offlineCUERegistry) err(m string) error {
+	return fmt.Errorf("fetching the CUE module %s requires remote access in the rendering process; "+
+		"to fix, set `spec.override.enableShellInRendering: true`", m)
+}
+
+// Requirements implements modconfig.Registry.
+func (r offlineCUERegistry) Requirements(_ context.Context, m module.Version) ([]module.Version, error) {
+	return nil, r.err(m.String())
+}
+
+// Fetch implements modconfig.Registry.
+func (r offlineCUERegistry) Fetch(_ context.Context, m module.Version) (module.SourceLoc, error) {
+	return module.SourceLoc{}, r.err(m.String())
+}
+
+// ModuleVersions implements modconfig.Registry.
+func (r offlineCUERegistry) ModuleVersions(_ context.Context, mpath string) ([]string, error) {
+	return nil, r.err(mpath)
+}
+
+// cueError returns an error with the details of all the CUE errors,
+// including their positions.
+func cueError(err error) error {
+	return errors.New(strings.TrimSpace(cueerrors.Details(err, nil)))
+}
@@ -0,0 +1,152 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hydrate
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/google/go-jsonnet"
+	"k8s.io/utils/ptr"
+	"kpt.dev/configsync/pkg/api/configsync"
+)
+
+const (
+	// JsonnetMainFile is the Jsonnet file evaluated to render the configs.
+	JsonnetMainFile = "main.jsonnet"
+	// JsonnetBundlerFile is the jsonnet-bundler manifest which, along with
+	// main.jsonnet, marks a directory rendered with Jsonnet.
+	JsonnetBundlerFile = "jsonnetfile.json"
+	// jsonnetVendorDir is the directory of the Jsonnet libraries, as installed
+	// by jsonnet-bundler, relative to the sync directory.
+	jsonnetVendorDir = "vendor"
+)
+
+// jsonnetRenderer renders the configs by evaluating main.jsonnet in-process.
+type jsonnetRenderer struct {
+	RendererOptions
+}
+
+// Engine implements Renderer.
+func (jsonnetRenderer) Engine() configsync.RenderingEngine {
+	return configsync.RenderingEngineJsonnet
+}
+
+// Detect implements Renderer. The directory must contain both main.jsonnet
+// and jsonnetfile.json, so a stray Jsonnet file doesn't enable rendering.
+func (jsonnetRenderer) Detect(dir string) (bool, error) {
+	for _, name := range []string{JsonnetMainFile, JsonnetBundlerFile} {
+		_, err := os.Stat(filepath.Join(dir, name))
+		switch {
+		case err == nil:
+		case os.IsNotExist(err):
+			return false, nil
+		default:
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// Version implements Renderer.
+func (jsonnetRenderer) Version() (string, error) {
+	return jsonnet.Version(), nil
+}
+
+// Render implements Renderer. Imports are resolved relative to the importing
+// file, then in the vendor directory, and may not read files outside of the
+// source root.
+func (r jsonnetRenderer) Render(input, output string) HydrationError {
+	b, err := newBoundary(r.SourceRoot, input, false)
+	if err != nil {
+		return NewInternalError(err)
+	}
+	if err := prepareOutput(output); err != nil {
+		return err
+	}
+	vm := jsonnet.MakeVM()
+	vm.Importer(&boundedImporter{
+		boundary: b,
+		jpaths:   []string{filepath.Join(input, jsonnetVendorDir)},
+	})
+	out, err := vm.EvaluateFile(filepath.Join(input, JsonnetMainFile))
+	if err != nil {
+		renderErr := fmt.Errorf("failed to evaluate %s in %s: %w", JsonnetMainFile, input, err)
+		mustDeleteOutput(renderErr, output)
+		return NewActionableError(renderErr)
+	}
+	if err := writeRenderedJSON(configsync.RenderingEngineJsonnet, []byte(out), output); err != nil {
+		mustDeleteOutput(err, output)
+		return err
+	}
+	return nil
+}
+
+// boundedImporter is a Jsonnet importer which resolves the imports like
+// jsonnet.FileImporter, but rejects the paths outside of its boundary,
+// before reading them. It applies to import, importstr and importbin.
+type boundedImporter struct {
+	boundary boundary
+	// jpaths are the library directories, searched in order after the
+	// directory of the importing file.
+	jpaths []string
+	// cache maps the paths read to their contents, so importing the same
+	// file again returns the same contents.
+	cache map[string]*jsonnet.Contents
+}
+
+// Import implements jsonnet.Importer.
+func (i *boundedImporter) Import(importedFrom, importedPath string) (jsonnet.Contents, string, error) {
+	dirs := append([]string{filepath.Dir(importedFrom)}, i.jpaths...)
+	if filepath.IsAbs(importedPath) {
+		dirs = []string{""}
+	}
+	for _, dir := range dirs {
+		path := filepath.Join(dir, importedPath)
+		contents, found, err := i.read(path)
+		if err != nil {
+			return jsonnet.Contents{}, "", err
+		}
+		if found {
+			return contents, path, nil
+		}
+	}
+	return jsonnet.Contents{}, "", fmt.Errorf("couldn't open import %q: no match locally or in the Jsonnet library paths", importedPath)
+}
+
+// read returns the contents of the file, and whether it exists.
+func (i *boundedImporter) read(path string) (jsonnet.Contents, bool, error) {
+	if contents, found := i.cache[path]; found {
+		return ptr.Deref(contents, jsonnet.Contents{}), contents != nil, nil
+	}
+	if err := i.boundary.check(path); err != nil {
+		return jsonnet.Contents{}, false, err
+	}
+	if i.cache == nil {
+		i.cache = make(map[string]*jsonnet.Contents)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			i.cache[path] = nil
+			return jsonnet.Contents{}, false, nil
+		}
+		return jsonnet.Contents{}, false, err
+	}
+	contents := jsonnet.MakeContentsRaw(data)
+	i.cache[path] = &contents
+	return contents, true, nil
+}
@@ -0,0 +1,506 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hydrate
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"k8s.io/klog/v2"
+	"kpt.dev/configsync/pkg/api/configsync"
+	"sigs.k8s.io/kustomize/kyaml/kio"
+	"sigs.k8s.io/kustomize/kyaml/kio/kioutil"
+	kyaml "sigs.k8s.io/kustomize/kyaml/yaml"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// Kptfile is the file name of the kpt package configuration.
+	Kptfile = "Kptfile"
+
+	// kptFunctionTimeout is the maximum duration of a single function in the
+	// Kptfile pipeline.
+	kptFunctionTimeout = 5 * time.Minute
+
+	// resultSeverityError is the severity of the function results which fail
+	// the pipeline.
+	resultSeverityError = "error"
+)
+
+// containerRuntimes are the container runtimes used to run the functions
+// declared with an image, in order of preference.
+var containerRuntimes = []string{"docker", "podman"}
+
+// kptfile is the subset of the Kptfile used for rendering.
+type kptfile struct {
+	Pipeline *kptPipeline `json:"pipeline,omitempty"`
+}
+
+// kptPipeline declares the functions run when rendering the package.
+type kptPipeline struct {
+	Mutators   []kptFunction `json:"mutators,omitempty"`
+	Validators []kptFunction `json:"validators,omitempty"`
+}
+
+// kptFunction is a function in the Kptfile pipeline.
+type kptFunction struct {
+	// Name is the optional name of the function.
+	Name string `json:"name,omitempty"`
+	// Image is the container image of the function.
+	Image string `json:"image,omitempty"`
+	// Exec is the path of the function executable, relative to the Kptfile.
+	Exec string `json:"exec,omitempty"`
+	// ConfigPath is the path of the function config, relative to the Kptfile.
+	ConfigPath string `json:"configPath,omitempty"`
+	// ConfigMap is the data of a ConfigMap passed as the function config.
+	ConfigMap map[string]string `json:"configMap,omitempty"`
+}
+
+// String returns the name of the function, defaulting to its image or
+// executable.
+func (f kptFunction) String() string {
+	switch {
+	case f.Name != "":
+		return f.Name
+	case f.Image != "":
+		return f.Image
+	default:
+		return f.Exec
+	}
+}
+
+// kptResult is an item of the results of a function.
+type kptResult struct {
+	Message     string `json:"message,omitempty"`
+	Severity    string `json:"severity,omitempty"`
+	ResourceRef *struct {
+		APIVersion string `json:"apiVersion,omitempty"`
+		Kind       string `json:"kind,omitempty"`
+		Name       string `json:"name,omitempty"`
+		Namespace  string `json:"namespace,omitempty"`
+	} `json:"resourceRef,omitempty"`
+	Field *struct {
+		Path string `json:"path,omitempty"`
+	} `json:"field,omitempty"`
+	File *struct {
+		Path string `json:"path,omitempty"`
+	} `json:"file,omitempty"`
+}
+
+// String formats the result as `[severity] message (resource, field, file)`.
+func (r kptResult) String() string {
+	severity := r.Severity
+	if severity == "" {
+		severity = resultSeverityError
+	}
+	var details []string
+	if ref := r.ResourceRef; ref != nil {
+		id := ref.Kind + "/" + ref.Name
+		if ref.Namespace != "" {
+			id = ref.Namespace + "/" + id
+		}
+		details = append(details, id)
+	}
+	if r.Field != nil && r.Field.Path != "" {
+		details = append(details, "field: "+r.Field.Path)
+	}
+	if r.File != nil && r.File.Path != "" {
+		details = append(details, "file: "+r.File.Path)
+	}
+	msg := fmt.Sprintf("[%s] %s", severity, r.Message)
+	if len(details) > 0 {
+		msg = fmt.Sprintf("%s (%s)", msg, strings.Join(details, ", "))
+	}
+	return msg
+}
+
+// readKptfile reads the Kptfile in the directory. It returns nil if the
+// directory has no Kptfile.
+func readKptfile(dir string) (*kptfile, error) {
+	data, err := os.ReadFile(filepath.Join(dir, Kptfile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	kf := &kptfile{}
+	if err := yaml.Unmarshal(data, kf); err != nil {
+		return nil, fmt.Errorf("unable to parse %s: %w", filepath.Join(dir, Kptfile), err)
+	}
+	return kf, nil
+}
+
+// hasPipeline checks if the Kptfile declares a pipeline of functions.
+func (kf *kptfile) hasPipeline() bool {
+	return kf != nil && kf.Pipeline != nil &&
+		len(kf.Pipeline.Mutators)+len(kf.Pipeline.Validators) > 0
+}
+
+// functions returns the mutators and validators of the pipeline.
+func (kf *kptfile) functions() []kptFunction {
+	if !kf.hasPipeline() {
+		return nil
+	}
+	return append(append([]kptFunction{}, kf.Pipeline.Mutators...), kf.Pipeline.Validators...)
+}
+
+// kptPackage is a kpt package, with the resources it contains directly and
+// its subpackages.
+type kptPackage struct {
+	// dir is the slash-separated path of the package, relative to the root
+	// package.
+	dir         string
+	kptfile     *kptfile
+	resources   []*kyaml.RNode
+	subpackages []*kptPackage
+}
+
+// readKptPackages reads the Kptfiles of the root package in the directory
+// and its subpackages, which are the directories with a Kptfile. It returns
+// the root package, and the packages by directory.
+func readKptPackages(root string) (*kptPackage, map[string]*kptPackage, error) {
+	rootPkg := &kptPackage{dir: "."}
+	pkgs := map[string]*kptPackage{".": rootPkg}
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if path != root && strings.HasPrefix(d.Name(), ".") {
+			return filepath.SkipDir
+		}
+		kf, err := readKptfile(path)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if rel == "." {
+			rootPkg.kptfile = kf
+			return nil
+		}
+		if kf == nil {
+			return nil
+		}
+		pkg := &kptPackage{dir: rel, kptfile: kf}
+		pkgs[rel] = pkg
+		parent := pkgs[kptPackageOf(pkgs, rel)]
+		parent.subpackages = append(parent.subpackages, pkg)
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return rootPkg, pkgs, nil
+}
+
+// kptPackageOf returns the directory of the innermost package which contains
+// the slash-separated path, excluding the path itself.
+func kptPackageOf(pkgs map[string]*kptPackage, p string) string {
+	for dir := path.Dir(p); dir != "."; dir = path.Dir(dir) {
+		if _, found := pkgs[dir]; found {
+			return dir
+		}
+	}
+	return "."
+}
+
+// checkKptFunctions checks that the functions of all the packages can run, so
+// rendering fails before running any function if one of them can't.
+// Functions declared with exec are only allowed with allowExec, and functions
+// declared with an image require a container runtime.
+func checkKptFunctions(pkgs map[string]*kptPackage, allowExec bool) error {
+	dirs := make([]string, 0, len(pkgs))
+	for dir := range pkgs {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+	for _, dir := range dirs {
+		for _, fn := range pkgs[dir].kptfile.functions() {
+			switch {
+			case fn.Exec != "":
+				if !allowExec {
+					return fmt.Errorf("function %q in the package %s: exec functions run executables from the source, "+
+						"which requires the shell in the rendering process; "+
+						"to fix, set `spec.override.enableShellInRendering: true`", fn, dir)
+				}
+			case fn.Image != "":
+				if _, err := containerRuntime(); err != nil {
+					return fmt.Errorf("function %q in the package %s: %w", fn, dir, err)
+				}
+			default:
+				return fmt.Errorf("function %q in the package %s: either image or exec must be specified", fn, dir)
+			}
+		}
+	}
+	return nil
+}
+
+// kptRender runs the functions of the Kptfile pipelines in the input
+// directory on the resources of the packages, and writes the rendered
+// resources to the output directory. Like `kpt fn render`, the subpackages
+// are rendered first, and the pipeline of a package runs on its resources
+// and the rendered resources of its subpackages. The mutators run in order,
+// followed by the validators.
+//
+// Functions declared with exec run the executable, and are only allowed with
+// allowExec, while functions declared with an image require a container
+// runtime.
+func kptRender(input, output string, allowExec bool) HydrationError {
+	rootPkg, pkgs, err := readKptPackages(input)
+	if err != nil {
+		return NewActionableError(err)
+	}
+	hasPipeline := false
+	for _, pkg := range pkgs {
+		hasPipeline = hasPipeline || pkg.kptfile.hasPipeline()
+	}
+	if !hasPipeline {
+		return NewActionableError(fmt.Errorf("the %s rendering engine requires a %s with a pipeline in %s or its subpackages",
+			configsync.RenderingEngineKpt, Kptfile, input))
+	}
+	if err := checkKptFunctions(pkgs, allowExec); err != nil {
+		return NewActionableError(err)
+	}
+
+	if err := prepareOutput(output); err != nil {
+		return err
+	}
+
+	nodes, err := (&kio.LocalPackageReader{
+		PackagePath:        input,
+		IncludeSubpackages: true,
+	}).Read()
+	if err != nil {
+		renderErr := fmt.Errorf("failed to read the resources in %s: %w", input, err)
+		mustDeleteOutput(renderErr, output)
+		return NewActionableError(renderErr)
+	}
+	for _, node := range nodes {
+		p, _, err := kioutil.GetFileAnnotations(node)
+		if err != nil {
+			renderErr := fmt.Errorf("failed to read the path of a resource in %s: %w", input, err)
+			mustDeleteOutput(renderErr, output)
+			return NewInternalError(renderErr)
+		}
+		pkg := pkgs[kptPackageOf(pkgs, filepath.ToSlash(p))]
+		pkg.resources = append(pkg.resources, node)
+	}
+
+	nodes, err = renderKptPackage(input, rootPkg)
+	if err != nil {
+		mustDeleteOutput(err, output)
+		return NewActionableError(err)
+	}
+
+	if err := (kio.LocalPackageWriter{PackagePath: output}).Write(nodes); err != nil {
+		renderErr := fmt.Errorf("failed to write the rendered resources to %s: %w", output, err)
+		mustDeleteOutput(renderErr, output)
+		return NewInternalError(renderErr)
+	}
+	return nil
+}
+
+// renderKptPackage renders the subpackages of the package, then runs the
+// pipeline of the package on its resources and the rendered resources of its
+// subpackages, and returns the rendered resources.
+func renderKptPackage(root string, pkg *kptPackage) ([]*kyaml.RNode, error) {
+	nodes := pkg.resources
+	for _, subpkg := range pkg.subpackages {
+		rendered, err := renderKptPackage(root, subpkg)
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, rendered...)
+	}
+	if !pkg.kptfile.hasPipeline() {
+		return nodes, nil
+	}
+	pkgDir := filepath.Join(root, filepath.FromSlash(pkg.dir))
+	var err error
+	for _, fn := range pkg.kptfile.Pipeline.Mutators {
+		if nodes, err = runKptFunction(pkgDir, fn, nodes); err != nil {
+			return nil, err
+		}
+	}
+	for _, fn := range pkg.kptfile.Pipeline.Validators {
+		// Validators must not change the resources, so their output is ignored.
+		if _, err = runKptFunction(pkgDir, fn, nodes); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// runKptFunction runs the function on the resources, and returns the
+// resources output by the function. It returns an error including the
+// function results if the function fails or reports an error.
+func runKptFunction(pkgDir string, fn kptFunction, nodes []*kyaml.RNode) ([]*kyaml.RNode, error) {
+	fnConfig, err := kptFunctionConfig(pkgDir, fn)
+	if err != nil {
+		return nil, fmt.Errorf("function %q: %w", fn, err)
+	}
+	cmd, err := kptFunctionCommand(pkgDir, fn)
+	if err != nil {
+		return nil, fmt.Errorf("function %q: %w", fn, err)
+	}
+
+	in := &bytes.Buffer{}
+	if err := (kio.ByteWriter{
+		Writer:                in,
+		KeepReaderAnnotations: true,
+		FunctionConfig:        fnConfig,
+		WrappingKind:          kio.ResourceListKind,
+		WrappingAPIVersion:    kio.ResourceListAPIVersion,
+	}).Write(nodes); err != nil {
+		return nil, fmt.Errorf("function %q: unable to write the input ResourceList: %w", fn, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), kptFunctionTimeout)
+	defer cancel()
+	out, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	c := exec.CommandContext(ctx, cmd[0], cmd[1:]...)
+	c.Dir = pkgDir
+	c.Stdin = in
+	c.Stdout = out
+	c.Stderr = stderr
+	klog.V(3).Infof("Running function %q: %s", fn, strings.Join(cmd, " "))
+	runErr := c.Run()
+
+	reader := &kio.ByteReader{Reader: out, OmitReaderAnnotations: true}
+	outNodes, readErr := reader.Read()
+	results, resultsErr := kptFunctionResults(reader.Results)
+	if resultsErr != nil {
+		klog.Warningf("unable to parse the results of function %q: %v", fn, resultsErr)
+	}
+
+	var failures []string
+	for _, r := range results {
+		if r.Severity == resultSeverityError || r.Severity == "" {
+			failures = append(failures, r.String())
+		}
+	}
+	switch {
+	case runErr != nil:
+		return nil, kptFunctionError(fn, fmt.Errorf("%w, stderr: %s", runErr, strings.TrimSpace(stderr.String())), results)
+	case len(failures) > 0:
+		return nil, kptFunctionError(fn, errors.New("reported errors"), results)
+	case readErr != nil:
+		return nil, fmt.Errorf("function %q: unable to read the output ResourceList: %w", fn, readErr)
+	}
+	return outNodes, nil
+}
+
+// kptFunctionError returns the error of a failed function, including the
+// function results.
+func kptFunctionError(fn kptFunction, err error, results []kptResult) error {
+	msg := fmt.Sprintf("function %q failed: %v", fn, err)
+	for _, r := range results {
+		msg += "\n  " + r.String()
+	}
+	return errors.New(msg)
+}
+
+// kptFunctionResults parses the results of the output ResourceList.
+func kptFunctionResults(node *kyaml.RNode) ([]kptResult, error) {
+	if node == nil {
+		return nil, nil
+	}
+	data, err := node.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	var results []kptResult
+	if err := yaml.Unmarshal(data, &results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// kptFunctionConfig returns the config of the function, read from the
+// ConfigPath or built from the ConfigMap, or nil if the function has no
+// config.
+func kptFunctionConfig(pkgDir string, fn kptFunction) (*kyaml.RNode, error) {
+	switch {
+	case fn.ConfigPath != "":
+		node, err := kyaml.ReadFile(filepath.Join(pkgDir, fn.ConfigPath))
+		if err != nil {
+			return nil, fmt.Errorf("unable to read the function config %s: %w", fn.ConfigPath, err)
+		}
+		return node, nil
+	case len(fn.ConfigMap) > 0:
+		node, err := kyaml.FromMap(map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata":   map[string]interface{}{"name": "function-input"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if err := node.PipeE(kyaml.SetField("data", kyaml.NewMapRNode(&fn.ConfigMap))); err != nil {
+			return nil, err
+		}
+		return node, nil
+	default:
+		return nil, nil
+	}
+}
+
+// kptFunctionCommand returns the command which runs the function.
+func kptFunctionCommand(pkgDir string, fn kptFunction) ([]string, error) {
+	if fn.Exec != "" {
+		cmd := strings.Fields(fn.Exec)
+		if !filepath.IsAbs(cmd[0]) && strings.ContainsRune(cmd[0], filepath.Separator) {
+			cmd[0] = filepath.Join(pkgDir, cmd[0])
+		}
+		return cmd, nil
+	}
+	if fn.Image == "" {
+		return nil, errors.New("either image or exec must be specified")
+	}
+	runtime, err := containerRuntime()
+	if err != nil {
+		return nil, err
+	}
+	return []string{runtime, "run", "--rm", "-i", "--network", "none", fn.Image}, nil
+}
+
+// containerRuntime returns the path of the first available container runtime.
+func containerRuntime() (string, error) {
+	for _, runtime := range containerRuntimes {
+		if path, err := exec.LookPath(runtime); err == nil {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("no container runtime (%s) is available in the rendering process to run images; "+
+		"to fix, use exec functions instead, with `spec.override.enableShellInRendering: true`",
+		strings.Join(containerRuntimes, ", "))
+}
@@ -0,0 +1,170 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hydrate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"kpt.dev/configsync/pkg/status"
+)
+
+const (
+	configMap = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm
+  namespace: foo
+data:
+  value: original
+`
+	// setValueFn replaces the value of the ConfigMap.
+	setValueFn = `#!/bin/sh
+sed 's/value: original/value: mutated/'
+`
+	// failingFn reports an error result and exits with a non-zero code.
+	failingFn = `#!/bin/sh
+cat <<EOF
+apiVersion: config.kubernetes.io/v1
+kind: ResourceList
+items: []
+results:
+- message: value must not be mutated
+  severity: error
+  resourceRef:
+    apiVersion: v1
+    kind: ConfigMap
+    name: cm
+    namespace: foo
+  field:
+    path: data.value
+EOF
+exit 1
+`
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0755))
+	}
+}
+
+// kptfileHeader is the header of a Kptfile, followed by the pipeline.
+const kptfileHeader = "apiVersion: kpt.dev/v1\nkind: Kptfile\nmetadata:\n  name: pkg\n"
+
+func TestKptRender(t *testing.T) {
+	testCases := []struct {
+		name         string
+		pipeline     string
+		files        map[string]string
+		disallowExec bool
+		wantValues   map[string]string
+		wantErrMsg   []string
+	}{
+		{
+			name:       "mutator",
+			pipeline:   "  mutators:\n  - exec: ./set-value.sh\n",
+			wantValues: map[string]string{"configmap.yaml": "value: mutated"},
+		},
+		{
+			name:       "failing validator",
+			pipeline:   "  mutators:\n  - exec: ./set-value.sh\n  validators:\n  - name: check-value\n    exec: ./failing.sh\n",
+			wantErrMsg: []string{`function "check-value" failed`, "[error] value must not be mutated (foo/ConfigMap/cm, field: data.value)"},
+		},
+		{
+			name:       "image without container runtime",
+			pipeline:   "  mutators:\n  - image: example.com/fn:v1\n",
+			wantErrMsg: []string{`function "example.com/fn:v1"`, "no container runtime (docker, podman) is available"},
+		},
+		{
+			name:         "exec without shell",
+			pipeline:     "  mutators:\n  - exec: ./set-value.sh\n",
+			disallowExec: true,
+			wantErrMsg:   []string{`function "./set-value.sh"`, "spec.override.enableShellInRendering: true"},
+		},
+		{
+			name:       "no pipeline",
+			wantErrMsg: []string{"the kpt rendering engine requires a Kptfile with a pipeline"},
+		},
+		{
+			name: "subpackage pipeline",
+			files: map[string]string{
+				"sub/" + Kptfile:        kptfileHeader + "pipeline:\n  mutators:\n  - exec: ./set-value.sh\n",
+				"sub/configmap.yaml":    configMap,
+				"sub/set-value.sh":      setValueFn,
+				"sub/nested/other.yaml": configMap,
+			},
+			wantValues: map[string]string{
+				"configmap.yaml":        "value: original",
+				"sub/configmap.yaml":    "value: mutated",
+				"sub/nested/other.yaml": "value: mutated",
+			},
+		},
+		{
+			name:     "root pipeline runs on the rendered subpackages",
+			pipeline: "  validators:\n  - name: check-value\n    exec: ./failing.sh\n",
+			files: map[string]string{
+				"sub/" + Kptfile:     kptfileHeader + "pipeline:\n  mutators:\n  - exec: ./set-value.sh\n",
+				"sub/configmap.yaml": configMap,
+				"sub/set-value.sh":   setValueFn,
+			},
+			wantErrMsg: []string{`function "check-value" failed`},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.name == "image without container runtime" {
+				// Force the lookup of the container runtimes to fail.
+				t.Setenv("PATH", "")
+			}
+			input := t.TempDir()
+			output := filepath.Join(t.TempDir(), "hydrated")
+			kptfile := kptfileHeader
+			if tc.pipeline != "" {
+				kptfile += "pipeline:\n" + tc.pipeline
+			}
+			writeFiles(t, input, map[string]string{
+				Kptfile:          kptfile,
+				"configmap.yaml": configMap,
+				"set-value.sh":   setValueFn,
+				"failing.sh":     failingFn,
+			})
+			writeFiles(t, input, tc.files)
+
+			err := kptRender(input, output, !tc.disallowExec)
+			if len(tc.wantErrMsg) > 0 {
+				require.Error(t, err)
+				assert.Equal(t, status.ActionableHydrationErrorCode, err.Code())
+				for _, msg := range tc.wantErrMsg {
+					assert.Contains(t, err.Error(), msg)
+				}
+				assert.NoDirExists(t, output)
+				return
+			}
+			require.NoError(t, err)
+			for file, want := range tc.wantValues {
+				rendered, readErr := os.ReadFile(filepath.Join(output, file))
+				require.NoError(t, readErr)
+				assert.Contains(t, string(rendered), want, file)
+			}
+		})
+	}
+}
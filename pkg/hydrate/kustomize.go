@@ -0,0 +1,751 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hydrate
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/klog/v2"
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/kmetrics"
+	"kpt.dev/configsync/pkg/metadata"
+	"sigs.k8s.io/kustomize/api/krusty"
+	"sigs.k8s.io/kustomize/api/resmap"
+	"sigs.k8s.io/kustomize/api/resource"
+	"sigs.k8s.io/kustomize/api/types"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+	kyaml "sigs.k8s.io/kustomize/kyaml/yaml"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// kustomizeAPIModule is the module of the kustomize API which renders the
+	// configs in-process.
+	kustomizeAPIModule = "sigs.k8s.io/kustomize/api"
+	// builtinPluginAPIVersion is the apiVersion of the configs of the builtin
+	// generators and transformers.
+	builtinPluginAPIVersion = "builtin"
+	// kustomizeTmpDirPrefix is the prefix of the temporary directories to
+	// which kustomize clones the remote bases.
+	kustomizeTmpDirPrefix = "kustomize-"
+)
+
+// builtinPlugins are the kinds of the builtin generators and transformers,
+// which are run in-process.
+var builtinPlugins = sets.New(krusty.GetBuiltinPluginNames()...)
+
+var (
+	// commitPattern matches a full git commit SHA.
+	commitPattern = regexp.MustCompile(`^[0-9a-f]{40}$`)
+	// exactVersionPattern matches an exact semantic version, as opposed to a
+	// version range.
+	exactVersionPattern = regexp.MustCompile(`^v?[0-9]+\.[0-9]+\.[0-9]+([-+][0-9A-Za-z.+-]+)?$`)
+)
+
+// kustomizeRenderer renders the configs with the kustomize API in-process.
+// Kustomizations which use generator, transformer or validator plugins are
+// rendered with `kustomize build` instead, which can run the plugins.
+//
+// The in-process rendering uses the vendored kustomize API module
+// (sigs.k8s.io/kustomize/api v0.20.0), rather than the kustomize binary
+// built into the hydration-controller image (KUSTOMIZE_VERSION in the
+// Makefile, v5.4.2-gke.0), so the output of the kustomizations without
+// plugins follows the behavior of the vendored version.
+type kustomizeRenderer struct {
+	RendererOptions
+}
+
+// Engine implements Renderer.
+func (kustomizeRenderer) Engine() configsync.RenderingEngine {
+	return configsync.RenderingEngineKustomize
+}
+
+// Detect implements Renderer.
+func (kustomizeRenderer) Detect(dir string) (bool, error) {
+	return needsKustomize(dir)
+}
+
+// Version implements Renderer. The kustomize and Helm binaries are optional,
+// so their versions are empty if they are not installed.
+func (kustomizeRenderer) Version() (string, error) {
+	kustomizeVersion, _ := getVersion(Kustomize)
+	helmVersion, _ := getVersion(Helm)
+	return fmt.Sprintf("%s/%s,%s/%s,%s/%s", kustomizeAPIModule, kustomizeAPIVersion(),
+		Kustomize, kustomizeVersion, Helm, helmVersion), nil
+}
+
+// Render implements Renderer.
+func (r kustomizeRenderer) Render(input, output string) HydrationError {
+	usage, err := r.inspect(input)
+	if err != nil {
+		return NewActionableError(err)
+	}
+	if len(usage.remoteBases) > 0 && !r.AllowRemoteBases {
+		return NewActionableError(fmt.Errorf("the kustomization in %s references the remote bases %q, "+
+			"which can only be fetched when the shell is enabled in the rendering process. "+
+			"To fix, either set spec.override.enableShellInRendering to true, or vendor the bases in the repository",
+			input, usage.remoteBases))
+	}
+	if len(usage.plugins) > 0 {
+		klog.Infof("Rendering %s with the %s binary, because the kustomization uses the plugins in %q", input, Kustomize, usage.plugins)
+		return kustomizeBuild(input, output, r.SendMetrics)
+	}
+	if err := prepareOutput(output); err != nil {
+		return err
+	}
+	if err := r.build(input, output); err != nil {
+		mustDeleteOutput(err, output)
+		return NewActionableError(err)
+	}
+	return nil
+}
+
+// UsesPlugins returns whether rendering the directory requires the
+// `kustomize` binary.
+func (r kustomizeRenderer) UsesPlugins(dir string) (bool, error) {
+	usage, err := r.inspect(dir)
+	if err != nil {
+		return false, err
+	}
+	return len(usage.plugins) > 0, nil
+}
+
+// build renders the kustomization in the input directory in-process, and
+// writes each rendered object to a separate file in the output directory, with
+// the same file names as `kustomize build --output`.
+func (r kustomizeRenderer) build(input, output string) error {
+	fSys, err := r.fileSystem(input)
+	if err != nil {
+		return err
+	}
+	opts := krusty.MakeDefaultOptions()
+	// Files may be loaded from outside of the kustomization root, as with
+	// `kustomize build --load-restrictor=LoadRestrictionsNone`, but only
+	// from within the source root, which is enforced by the file system.
+	opts.LoadRestrictions = types.LoadRestrictionsNone
+	// Match the default order of `kustomize build`.
+	opts.Reorder = krusty.ReorderOptionLegacy
+	opts.PluginConfig.HelmConfig.Enabled = true
+	opts.PluginConfig.HelmConfig.Command = Helm
+
+	start := time.Now()
+	m, err := krusty.MakeKustomizer(opts).Run(fSys, input)
+	executionTime := time.Since(start).Nanoseconds()
+	if err != nil {
+		return fmt.Errorf("failed to run kustomize build in %s: %w", input, err)
+	}
+	if r.SendMetrics {
+		kmetrics.RecordKustomizeBuild(context.Background(), input, executionTime, m.Size())
+	}
+	if err := setSourceLines(m, fSys, input); err != nil {
+		return err
+	}
+	return writeResources(m, output)
+}
+
+// setSourceLines annotates the resources declared or generated by the local
+// files with their lines in those files. The resources fetched from remote
+// bases are not annotated.
+func setSourceLines(m resmap.ResMap, fSys filesys.FileSystem, input string) error {
+	files := make(map[string][]sourceDocument)
+	for _, res := range m.Resources() {
+		origin, err := res.GetOrigin()
+		if err != nil {
+			return fmt.Errorf("invalid origin of %s: %w", res.CurId(), err)
+		}
+		if origin == nil || origin.Repo != "" {
+			continue
+		}
+		path, kind, name := origin.Path, res.OrgId().Kind, res.OrgId().Name
+		if origin.ConfiguredIn != "" {
+			// The resource is generated, so the generator config is located.
+			path, kind, name = origin.ConfiguredIn, origin.ConfiguredBy.Kind, origin.ConfiguredBy.Name
+		}
+		docs, found := files[path]
+		if !found {
+			docs = readSourceDocuments(fSys, filepath.Join(input, path))
+			files[path] = docs
+		}
+		for _, doc := range docs {
+			if doc.kind == kind && doc.name == name {
+				annotations := res.GetAnnotations()
+				annotations[metadata.SourceLineAnnotationKey] = fmt.Sprintf("%d:%d", doc.line, doc.column)
+				if err := res.SetAnnotations(annotations); err != nil {
+					return fmt.Errorf("unable to annotate %s: %w", res.CurId(), err)
+				}
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// sourceDocument is the position of a YAML document in a source file.
+type sourceDocument struct {
+	kind, name   string
+	line, column int
+}
+
+// readSourceDocuments returns the positions of the documents in the YAML file.
+// It returns no documents if the file can't be read or parsed, as the
+// positions are informational.
+func readSourceDocuments(fSys filesys.FileSystem, path string) []sourceDocument {
+	data, err := fSys.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var docs []sourceDocument
+	decoder := kyaml.NewDecoder(bytes.NewReader(data))
+	for {
+		node := &kyaml.Node{}
+		if err := decoder.Decode(node); err != nil {
+			return docs
+		}
+		if len(node.Content) == 0 {
+			continue
+		}
+		rn := kyaml.NewRNode(node.Content[0])
+		docs = append(docs, sourceDocument{
+			kind:   rn.GetKind(),
+			name:   rn.GetName(),
+			line:   node.Content[0].Line,
+			column: node.Content[0].Column,
+		})
+	}
+}
+
+// fileSystem returns the file system from which the kustomization in the
+// input directory is loaded.
+func (r kustomizeRenderer) fileSystem(input string) (filesys.FileSystem, error) {
+	b, err := newBoundary(r.SourceRoot, input, r.AllowRemoteBases)
+	if err != nil {
+		return nil, err
+	}
+	kustomization, err := kustomizationFile(input)
+	if err != nil {
+		return nil, err
+	}
+	// kustomize reads the files at their paths with the symbolic links
+	// evaluated.
+	if kustomization, err = resolvePath(kustomization); err != nil {
+		return nil, err
+	}
+	return originAnnotationsFS{
+		FileSystem:    boundedFS{FileSystem: filesys.MakeFsOnDisk(), boundary: b},
+		kustomization: kustomization,
+	}, nil
+}
+
+// kustomizeUsage lists the features used by a kustomization and the
+// kustomizations it references, which affect how it is rendered.
+type kustomizeUsage struct {
+	// remoteBases are the resources and components cloned from remote
+	// repositories. Remote files are not included, as they are fetched
+	// in-process.
+	remoteBases []string
+	// plugins are the generators, transformers and validators which aren't
+	// builtin plugins.
+	plugins []string
+	// unpinned are the remote resources, components and Helm charts which
+	// aren't pinned to an immutable version, so their content may change
+	// without any change to the source.
+	unpinned []string
+}
+
+// inspect returns the features used by the kustomization in the directory
+// and by the local kustomizations it references.
+func (r kustomizeRenderer) inspect(dir string) (*kustomizeUsage, error) {
+	b, err := newBoundary(r.SourceRoot, dir, r.AllowRemoteBases)
+	if err != nil {
+		return nil, err
+	}
+	usage := &kustomizeUsage{}
+	if err := usage.add(b, dir, sets.New[string]()); err != nil {
+		return nil, err
+	}
+	return usage, nil
+}
+
+func (u *kustomizeUsage) add(b boundary, dir string, visited sets.Set[string]) error {
+	if visited.Has(dir) {
+		return nil
+	}
+	visited.Insert(dir)
+	path, err := kustomizationFile(dir)
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("unable to read %s: %w", path, err)
+	}
+	k := &types.Kustomization{}
+	if err := k.Unmarshal(data); err != nil {
+		return fmt.Errorf("invalid kustomization %s: %w", path, err)
+	}
+	k.FixKustomization()
+
+	var refs []string
+	refs = append(refs, k.Resources...)
+	refs = append(refs, k.Components...)
+	for _, ref := range refs {
+		local := filepath.Join(dir, ref)
+		if _, err := os.Stat(local); err != nil {
+			if isRemoteRepo(ref) {
+				u.remoteBases = append(u.remoteBases, ref)
+			}
+			if !isPinned(ref) {
+				u.unpinned = append(u.unpinned, ref)
+			}
+			// Let kustomize report the missing files.
+			continue
+		}
+		if err := b.check(local); err != nil {
+			return fmt.Errorf("invalid reference %q in %s: %w", ref, path, err)
+		}
+		if needs, err := needsKustomize(local); err == nil && needs {
+			if err := u.add(b, filepath.Clean(local), visited); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, chart := range k.HelmCharts {
+		if chart.Repo != "" && !exactVersionPattern.MatchString(chart.Version) {
+			u.unpinned = append(u.unpinned, fmt.Sprintf("%s/%s:%s", chart.Repo, chart.Name, chart.Version))
+		}
+	}
+
+	var pluginRefs []string
+	pluginRefs = append(pluginRefs, k.Generators...)
+	pluginRefs = append(pluginRefs, k.Transformers...)
+	pluginRefs = append(pluginRefs, k.Validators...)
+	for _, ref := range pluginRefs {
+		builtin, err := isBuiltinPluginConfig(dir, ref)
+		if err != nil {
+			return fmt.Errorf("invalid plugin config %q in %s: %w", ref, path, err)
+		}
+		if !builtin {
+			u.plugins = append(u.plugins, ref)
+		}
+	}
+	return nil
+}
+
+// isRemoteRepo returns whether the reference is a remote repository, which
+// kustomize clones with the git binary. Remote files are fetched over HTTP(S)
+// in-process, so they are not remote repositories.
+func isRemoteRepo(ref string) bool {
+	if isRemoteFile(ref) {
+		return false
+	}
+	if strings.HasPrefix(ref, "https://") || strings.HasPrefix(ref, "http://") {
+		return true
+	}
+	return (&resource.Origin{}).Append(ref).Repo != ""
+}
+
+// isRemoteFile returns whether the reference is a YAML or JSON file fetched
+// over HTTP(S). URLs with the query parameters or the `//` subdirectory
+// separator of repository references are not remote files.
+func isRemoteFile(ref string) bool {
+	u, err := url.Parse(ref)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return false
+	}
+	if strings.Contains(u.Path, "//") || strings.Contains(u.Path, ".git/") || strings.HasSuffix(u.Path, ".git") {
+		return false
+	}
+	query := u.Query()
+	for _, param := range []string{"ref", "version", "submodules", "timeout"} {
+		if query.Has(param) {
+			return false
+		}
+	}
+	switch path.Ext(u.Path) {
+	case ".yaml", ".yml", ".json":
+		return true
+	default:
+		return false
+	}
+}
+
+// isPinned returns whether the remote reference is a repository pinned to a
+// commit. Remote files are never pinned.
+func isPinned(ref string) bool {
+	origin := (&resource.Origin{}).Append(ref)
+	return origin.Repo != "" && commitPattern.MatchString(origin.Ref)
+}
+
+// isBuiltinPluginConfig returns whether the generator, transformer or
+// validator config only declares builtin plugins. The config may be a file,
+// inline YAML, or a kustomization directory which generates the configs, in
+// which case it is not considered builtin.
+func isBuiltinPluginConfig(dir, ref string) (bool, error) {
+	data := []byte(ref)
+	if !strings.Contains(ref, "\n") {
+		local := filepath.Join(dir, ref)
+		info, err := os.Stat(local)
+		if err != nil {
+			// Remote or missing configs are left to kustomize.
+			return false, nil
+		}
+		if info.IsDir() {
+			return false, nil
+		}
+		if data, err = os.ReadFile(local); err != nil {
+			return false, err
+		}
+	}
+	decoder := utilyaml.NewYAMLOrJSONDecoder(bytes.NewReader(data), 4096)
+	for {
+		var obj map[string]interface{}
+		if err := decoder.Decode(&obj); err != nil {
+			if errors.Is(err, io.EOF) {
+				return true, nil
+			}
+			return false, err
+		}
+		if obj == nil {
+			continue
+		}
+		if obj["apiVersion"] != builtinPluginAPIVersion {
+			return false, nil
+		}
+		kind, _ := obj["kind"].(string)
+		if !builtinPlugins.Has(kind) {
+			return false, nil
+		}
+	}
+}
+
+// kustomizationFile returns the path of the kustomization file in the
+// directory.
+func kustomizationFile(dir string) (string, error) {
+	for _, name := range validKustomizationFiles {
+		path := filepath.Join(dir, name)
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("unable to find one of %q in directory %s", validKustomizationFiles, dir)
+}
+
+// writeResources writes each resource to a separate file in the output
+// directory. Namespaced resources are prefixed with their namespace if the
+// resources are in more than one namespace.
+func writeResources(m resmap.ResMap, output string) error {
+	byNamespace := m.GroupedByCurrentNamespace()
+	for namespace, resList := range byNamespace {
+		for _, res := range resList {
+			name := resourceFileName(res)
+			if len(byNamespace) > 1 {
+				name = strings.ToLower(namespace) + "_" + name
+			}
+			if err := writeResource(res, filepath.Join(output, name)); err != nil {
+				return err
+			}
+		}
+	}
+	for _, res := range m.ClusterScoped() {
+		if err := writeResource(res, filepath.Join(output, resourceFileName(res))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func resourceFileName(res *resource.Resource) string {
+	return strings.ToLower(res.GetGvk().StringWoEmptyField()) + "_" + strings.ToLower(res.GetName()) + ".yaml"
+}
+
+func writeResource(res *resource.Resource, path string) error {
+	data, err := res.AsYAML()
+	if err != nil {
+		return fmt.Errorf("unable to marshal %s: %w", res.CurId(), err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("unable to write the rendered configs to %s: %w", path, err)
+	}
+	return nil
+}
+
+// kustomizeAPIVersion returns the version of the kustomize API module built
+// into the binary.
+func kustomizeAPIVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return ""
+	}
+	for _, dep := range info.Deps {
+		if dep.Path == kustomizeAPIModule {
+			return dep.Version
+		}
+	}
+	return ""
+}
+
+// boundary restricts the files which kustomize may load to the source root,
+// so the rendering doesn't depend on the files of the machine or container
+// it runs on.
+type boundary struct {
+	// root is the source root, with the symbolic links evaluated.
+	root string
+	// tmpPrefix is the path prefix of the directories to which the remote
+	// bases are cloned, if remote bases are allowed.
+	tmpPrefix string
+}
+
+// newBoundary returns the boundary of the source root, which defaults to the
+// directory of the kustomization.
+func newBoundary(sourceRoot, dir string, allowRemoteBases bool) (boundary, error) {
+	if sourceRoot == "" {
+		sourceRoot = dir
+	}
+	root, err := resolvePath(sourceRoot)
+	if err != nil {
+		return boundary{}, fmt.Errorf("unable to resolve the source root %s: %w", sourceRoot, err)
+	}
+	b := boundary{root: root}
+	if allowRemoteBases {
+		tmpDir, err := resolvePath(os.TempDir())
+		if err != nil {
+			return boundary{}, fmt.Errorf("unable to resolve the temporary directory: %w", err)
+		}
+		b.tmpPrefix = filepath.Join(tmpDir, kustomizeTmpDirPrefix)
+	}
+	return b, nil
+}
+
+// check returns an error if the path is outside of the boundary, after the
+// symbolic links are evaluated.
+func (b boundary) check(path string) error {
+	resolved, err := resolvePath(path)
+	if err != nil {
+		return err
+	}
+	if resolved == b.root || strings.HasPrefix(resolved, b.root+string(filepath.Separator)) {
+		return nil
+	}
+	if b.tmpPrefix != "" && strings.HasPrefix(resolved, b.tmpPrefix) {
+		return nil
+	}
+	return fmt.Errorf("%s is outside of the source directory %s", path, b.root)
+}
+
+// resolvePath returns the absolute path with the symbolic links evaluated.
+// The symbolic links are evaluated in the longest existing parent directory
+// if the path doesn't exist.
+func resolvePath(path string) (string, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	resolved, err := filepath.EvalSymlinks(abs)
+	if err == nil {
+		return resolved, nil
+	}
+	if !os.IsNotExist(err) {
+		return "", err
+	}
+	parent := filepath.Dir(abs)
+	if parent == abs {
+		return abs, nil
+	}
+	resolvedParent, err := resolvePath(parent)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(resolvedParent, filepath.Base(abs)), nil
+}
+
+// boundedFS is a file system which rejects the paths outside of its boundary.
+type boundedFS struct {
+	filesys.FileSystem
+	boundary boundary
+}
+
+var _ filesys.FileSystem = boundedFS{}
+
+// Create implements filesys.FileSystem.
+func (fs boundedFS) Create(path string) (filesys.File, error) {
+	if err := fs.boundary.check(path); err != nil {
+		return nil, err
+	}
+	return fs.FileSystem.Create(path)
+}
+
+// Mkdir implements filesys.FileSystem.
+func (fs boundedFS) Mkdir(path string) error {
+	if err := fs.boundary.check(path); err != nil {
+		return err
+	}
+	return fs.FileSystem.Mkdir(path)
+}
+
+// MkdirAll implements filesys.FileSystem.
+func (fs boundedFS) MkdirAll(path string) error {
+	if err := fs.boundary.check(path); err != nil {
+		return err
+	}
+	return fs.FileSystem.MkdirAll(path)
+}
+
+// RemoveAll implements filesys.FileSystem.
+func (fs boundedFS) RemoveAll(path string) error {
+	if err := fs.boundary.check(path); err != nil {
+		return err
+	}
+	return fs.FileSystem.RemoveAll(path)
+}
+
+// Open implements filesys.FileSystem.
+func (fs boundedFS) Open(path string) (filesys.File, error) {
+	if err := fs.boundary.check(path); err != nil {
+		return nil, err
+	}
+	return fs.FileSystem.Open(path)
+}
+
+// IsDir implements filesys.FileSystem.
+func (fs boundedFS) IsDir(path string) bool {
+	return fs.boundary.check(path) == nil && fs.FileSystem.IsDir(path)
+}
+
+// ReadDir implements filesys.FileSystem.
+func (fs boundedFS) ReadDir(path string) ([]string, error) {
+	if err := fs.boundary.check(path); err != nil {
+		return nil, err
+	}
+	return fs.FileSystem.ReadDir(path)
+}
+
+// CleanedAbs implements filesys.FileSystem.
+func (fs boundedFS) CleanedAbs(path string) (filesys.ConfirmedDir, string, error) {
+	if err := fs.boundary.check(path); err != nil {
+		return "", "", err
+	}
+	return fs.FileSystem.CleanedAbs(path)
+}
+
+// Exists implements filesys.FileSystem.
+func (fs boundedFS) Exists(path string) bool {
+	return fs.boundary.check(path) == nil && fs.FileSystem.Exists(path)
+}
+
+// Glob implements filesys.FileSystem. The matches outside of the boundary are
+// dropped.
+func (fs boundedFS) Glob(pattern string) ([]string, error) {
+	matches, err := fs.FileSystem.Glob(pattern)
+	if err != nil {
+		return nil, err
+	}
+	var result []string
+	for _, match := range matches {
+		if fs.boundary.check(match) == nil {
+			result = append(result, match)
+		}
+	}
+	return result, nil
+}
+
+// ReadFile implements filesys.FileSystem.
+func (fs boundedFS) ReadFile(path string) ([]byte, error) {
+	if err := fs.boundary.check(path); err != nil {
+		return nil, err
+	}
+	return fs.FileSystem.ReadFile(path)
+}
+
+// WriteFile implements filesys.FileSystem.
+func (fs boundedFS) WriteFile(path string, data []byte) error {
+	if err := fs.boundary.check(path); err != nil {
+		return err
+	}
+	return fs.FileSystem.WriteFile(path, data)
+}
+
+// Walk implements filesys.FileSystem.
+func (fs boundedFS) Walk(path string, walkFn filepath.WalkFunc) error {
+	if err := fs.boundary.check(path); err != nil {
+		return err
+	}
+	return fs.FileSystem.Walk(path, walkFn)
+}
+
+// originAnnotationsFS is a file system which enables the origin annotations in
+// the build metadata of the root kustomization, as RunKustomizeBuild does,
+// without modifying the kustomization file.
+type originAnnotationsFS struct {
+	filesys.FileSystem
+	// kustomization is the path of the root kustomization file, with the
+	// symbolic links evaluated.
+	kustomization string
+}
+
+// ReadFile implements filesys.FileSystem.
+func (fs originAnnotationsFS) ReadFile(path string) ([]byte, error) {
+	data, err := fs.FileSystem.ReadFile(path)
+	if err != nil {
+		return data, err
+	}
+	if resolved, err := resolvePath(path); err != nil || resolved != fs.kustomization {
+		return data, nil
+	}
+	return withOriginAnnotations(data)
+}
+
+// withOriginAnnotations adds originAnnotations to the buildMetadata of the
+// kustomization, if it is not set.
+func withOriginAnnotations(data []byte) ([]byte, error) {
+	k := &types.Kustomization{}
+	if err := yaml.Unmarshal(data, k); err != nil {
+		// Let kustomize report the invalid kustomization.
+		return data, nil
+	}
+	for _, opt := range k.BuildMetadata {
+		if opt == types.OriginAnnotations {
+			return data, nil
+		}
+	}
+	node, err := kyaml.Parse(string(data))
+	if err != nil {
+		return data, nil
+	}
+	list, err := node.Pipe(kyaml.LookupCreate(kyaml.SequenceNode, "buildMetadata"))
+	if err != nil {
+		return nil, err
+	}
+	list.YNode().Content = append(list.YNode().Content, kyaml.NewScalarRNode(types.OriginAnnotations).YNode())
+	out, err := node.String()
+	if err != nil {
+		return nil, err
+	}
+	return []byte(out), nil
+}
@@ -0,0 +1,207 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hydrate
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"kpt.dev/configsync/pkg/status"
+)
+
+func TestKustomizeRender(t *testing.T) {
+	testCases := []struct {
+		name        string
+		opts        RendererOptions
+		files       map[string]string
+		syncDir     string
+		outsideFile string
+		want        map[string]string
+		wantErrMsg  string
+	}{
+		{
+			name: "namespace and base",
+			files: map[string]string{
+				"overlay/kustomization.yaml": "namespace: foo\nresources:\n- ../base\n",
+				"base/kustomization.yaml":    "resources:\n- configmap.yaml\n- clusterrole.yaml\n",
+				"base/configmap.yaml":        "# The configuration.\napiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: CM\n",
+				"base/clusterrole.yaml":      "apiVersion: rbac.authorization.k8s.io/v1\nkind: ClusterRole\nmetadata:\n  name: reader\n",
+			},
+			syncDir: "overlay",
+			want: map[string]string{
+				"v1_configmap_cm.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n" +
+					"  annotations:\n    config.kubernetes.io/origin: |\n      path: ../base/configmap.yaml\n" +
+					"    internal.configsync.gke.io/source-line: \"2:1\"\n" +
+					"  name: CM\n  namespace: foo\n",
+				"rbac.authorization.k8s.io_v1_clusterrole_reader.yaml": "apiVersion: rbac.authorization.k8s.io/v1\nkind: ClusterRole\nmetadata:\n" +
+					"  annotations:\n    config.kubernetes.io/origin: |\n      path: ../base/clusterrole.yaml\n" +
+					"    internal.configsync.gke.io/source-line: \"1:1\"\n" +
+					"  name: reader\n",
+			},
+		},
+		{
+			name: "builtin generator",
+			files: map[string]string{
+				"kustomization.yaml": "generators:\n- generator.yaml\n",
+				"generator.yaml": "apiVersion: builtin\nkind: ConfigMapGenerator\nmetadata:\n  name: gen\n" +
+					"options:\n  disableNameSuffixHash: true\nliterals:\n- key=value\n",
+			},
+			want: map[string]string{
+				"v1_configmap_gen.yaml": "apiVersion: v1\ndata:\n  key: value\nkind: ConfigMap\nmetadata:\n" +
+					"  annotations:\n    config.kubernetes.io/origin: |\n      configuredIn: generator.yaml\n" +
+					"      configuredBy:\n        apiVersion: builtin\n        kind: ConfigMapGenerator\n        name: gen\n" +
+					"    internal.configsync.gke.io/source-line: \"1:1\"\n" +
+					"  name: gen\n",
+			},
+		},
+		{
+			name: "remote base without shell",
+			files: map[string]string{
+				"kustomization.yaml": "resources:\n- https://github.com/kubernetes-sigs/kustomize//examples/multibases?ref=v3.3.1\n",
+			},
+			wantErrMsg: "can only be fetched when the shell is enabled",
+		},
+		{
+			name:        "file outside of the source root",
+			files:       map[string]string{"kustomization.yaml": "resources:\n- ../outside/configmap.yaml\n"},
+			outsideFile: "configmap.yaml",
+			wantErrMsg:  "is outside of the source directory",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			parent := t.TempDir()
+			sourceRoot := filepath.Join(parent, "source")
+			writeTree(t, sourceRoot, tc.files)
+			if tc.outsideFile != "" {
+				writeTree(t, filepath.Join(parent, "outside"), map[string]string{tc.outsideFile: configMap})
+			}
+			output := filepath.Join(t.TempDir(), "hydrated")
+			tc.opts.SourceRoot = sourceRoot
+			r := kustomizeRenderer{RendererOptions: tc.opts}
+
+			err := r.Render(filepath.Join(sourceRoot, tc.syncDir), output)
+			if tc.wantErrMsg != "" {
+				require.Error(t, err)
+				assert.Equal(t, status.ActionableHydrationErrorCode, err.Code())
+				assert.Contains(t, err.Error(), tc.wantErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			entries, readErr := os.ReadDir(output)
+			require.NoError(t, readErr)
+			got := make(map[string]string, len(entries))
+			for _, entry := range entries {
+				data, readErr := os.ReadFile(filepath.Join(output, entry.Name()))
+				require.NoError(t, readErr)
+				got[entry.Name()] = string(data)
+			}
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestKustomizeRenderRemoteFile(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(configMap))
+	}))
+	defer server.Close()
+
+	// Remote files are fetched in-process, so they don't need the shell.
+	sourceRoot := t.TempDir()
+	writeTree(t, sourceRoot, map[string]string{
+		"kustomization.yaml": fmt.Sprintf("resources:\n- %s/configmap.yaml\n", server.URL),
+	})
+	output := filepath.Join(t.TempDir(), "hydrated")
+	r := kustomizeRenderer{RendererOptions: RendererOptions{SourceRoot: sourceRoot}}
+	require.NoError(t, r.Render(sourceRoot, output))
+	entries, err := os.ReadDir(output)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestIsRemoteRepo(t *testing.T) {
+	testCases := []struct {
+		ref  string
+		want bool
+	}{
+		{ref: "base"},
+		{ref: "../base/configmap.yaml"},
+		{ref: "https://example.com/configmap.yaml"},
+		{ref: "https://example.com/manifests/deploy.json?token=abc"},
+		{ref: "https://github.com/example/repo//base?ref=v1.0.0", want: true},
+		{ref: "https://github.com/example/repo/base.yaml?ref=v1.0.0", want: true},
+		{ref: "https://example.com/repo.git", want: true},
+		{ref: "https://github.com/example/repo", want: true},
+		{ref: "github.com/example/repo/base?ref=v1.0.0", want: true},
+		{ref: "git@github.com:example/repo.git", want: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.ref, func(t *testing.T) {
+			assert.Equal(t, tc.want, isRemoteRepo(tc.ref))
+		})
+	}
+}
+
+func TestKustomizeUsesPlugins(t *testing.T) {
+	testCases := []struct {
+		name  string
+		files map[string]string
+		want  bool
+	}{
+		{
+			name:  "no plugins",
+			files: map[string]string{"kustomization.yaml": "resources:\n- configmap.yaml\n", "configmap.yaml": configMap},
+		},
+		{
+			name: "inline builtin transformer",
+			files: map[string]string{
+				"kustomization.yaml": "transformers:\n- |-\n  apiVersion: builtin\n  kind: NamespaceTransformer\n  metadata:\n    name: ns\n    namespace: foo\n",
+			},
+		},
+		{
+			name: "exec transformer",
+			files: map[string]string{
+				"kustomization.yaml": "transformers:\n- transformer.yaml\n",
+				"transformer.yaml":   "apiVersion: example.com/v1\nkind: Transformer\nmetadata:\n  name: t\n  annotations:\n    config.kubernetes.io/function: |\n      exec:\n        path: ./fn.sh\n",
+			},
+			want: true,
+		},
+		{
+			name: "generator in a base",
+			files: map[string]string{
+				"kustomization.yaml":      "resources:\n- base\n",
+				"base/kustomization.yaml": "generators:\n- generator.yaml\n",
+				"base/generator.yaml":     "apiVersion: example.com/v1\nkind: Generator\nmetadata:\n  name: g\n",
+			},
+			want: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeTree(t, dir, tc.files)
+			got, err := kustomizeRenderer{}.UsesPlugins(dir)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,422 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hydrate
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"k8s.io/klog/v2"
+	"kpt.dev/configsync/pkg/api/configsync"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// cacheTmpPrefix is the prefix of the temporary directories in which new
+	// cache entries are written before being renamed to their key.
+	cacheTmpPrefix = "tmp-"
+	// cueModuleDir is the directory which marks the root of a CUE module.
+	cueModuleDir = "cue.mod"
+	// gitDir is the directory of the git metadata, which is not a rendering input.
+	gitDir = ".git"
+)
+
+// jsonnetImportPattern matches the paths imported by Jsonnet files.
+var jsonnetImportPattern = regexp.MustCompile(`\bimport(?:str|bin)?\s*['"]([^'"]+)['"]`)
+
+// RenderCache is a content-addressed cache of the rendered output, persisted on
+// the hydration volume. The output is cached by the hash of the rendering
+// inputs, so a new source commit which doesn't change the inputs of the sync
+// directory reuses the previously rendered output.
+//
+// Remote bases and Helm charts are part of the inputs only by their
+// references, so the output is only cached if they are pinned to a commit or
+// an exact version.
+type RenderCache struct {
+	// Dir is the absolute path to the cache directory.
+	Dir string
+	// MaxBytes is the maximum total size of the cache entries. The least
+	// recently used entries are evicted when the size is exceeded.
+	MaxBytes int64
+}
+
+// renderCacheKey returns the cache key of the rendered output of the sync
+// directory, which is the hash of:
+//   - the rendering engine and the versions of its tools,
+//   - whether the shell is enabled in the rendering process,
+//   - the file trees of the sync directory and of the local files it
+//     references outside the sync directory, within the source root.
+func renderCacheKey(renderer Renderer, sourceRoot, syncPath string, enableShell bool) (string, error) {
+	version, err := renderer.Version()
+	if err != nil {
+		return "", err
+	}
+	h := sha256.New()
+	fmt.Fprintf(h, "engine=%s\nversion=%s\nshell=%t\n", renderer.Engine(), version, enableShell)
+
+	roots := []string{syncPath}
+	if renderer.Engine() == configsync.RenderingEngineCUE {
+		// The CUE packages are loaded from the module root, which may be a
+		// parent of the sync directory.
+		if moduleRoot := findCUEModuleRoot(sourceRoot, syncPath); moduleRoot != "" {
+			roots = append(roots, moduleRoot)
+		}
+	}
+	var hashed []string
+	for len(roots) > 0 {
+		root := roots[0]
+		roots = roots[1:]
+		if !isWithin(sourceRoot, root) || isWithinAny(hashed, root) {
+			continue
+		}
+		if _, err := os.Lstat(root); os.IsNotExist(err) {
+			// A missing reference fails the rendering, which isn't cached.
+			continue
+		}
+		refs, err := hashTree(h, sourceRoot, root)
+		if err != nil {
+			return "", err
+		}
+		hashed = append(hashed, root)
+		roots = append(roots, refs...)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// hashTree writes the relative paths, types and contents of the files under
+// the root to the hash, and returns the paths referenced by the rendering
+// config files which are outside the root.
+func hashTree(h hash.Hash, sourceRoot, root string) ([]string, error) {
+	var refs []string
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && d.Name() == gitDir {
+			return filepath.SkipDir
+		}
+		rel, err := filepath.Rel(sourceRoot, path)
+		if err != nil {
+			return err
+		}
+		switch {
+		case d.IsDir():
+			fmt.Fprintf(h, "dir=%s\n", filepath.ToSlash(rel))
+		case d.Type()&fs.ModeSymlink != 0:
+			target, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(h, "symlink=%s:%s\n", filepath.ToSlash(rel), target)
+			if !filepath.IsAbs(target) {
+				refs = append(refs, filepath.Join(filepath.Dir(path), target))
+			}
+		default:
+			content, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(h, "file=%s:%d\n", filepath.ToSlash(rel), len(content))
+			h.Write(content)
+			refs = append(refs, localReferences(path, content)...)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to hash the rendering inputs in %s: %w", root, err)
+	}
+	var external []string
+	for _, ref := range refs {
+		if !isWithin(root, ref) {
+			external = append(external, ref)
+		}
+	}
+	return external, nil
+}
+
+// localReferences returns the absolute paths of the parent-relative local
+// files referenced by the kustomization files, Kptfiles and Jsonnet files.
+// Any string value of a kustomization file or a Kptfile starting with `..` is
+// considered a reference, which may include false positives, but they only
+// make the cache key more specific.
+func localReferences(path string, content []byte) []string {
+	var values []string
+	name := filepath.Base(path)
+	switch {
+	case HasKustomization(name) || name == Kptfile:
+		var obj interface{}
+		if err := yaml.Unmarshal(content, &obj); err != nil {
+			return nil
+		}
+		collectStrings(obj, &values)
+	case strings.HasSuffix(name, ".jsonnet") || strings.HasSuffix(name, ".libsonnet"):
+		for _, match := range jsonnetImportPattern.FindAllSubmatch(content, -1) {
+			values = append(values, string(match[1]))
+		}
+	}
+	var refs []string
+	for _, v := range values {
+		if strings.HasPrefix(v, "..") {
+			refs = append(refs, filepath.Join(filepath.Dir(path), v))
+		}
+	}
+	return refs
+}
+
+// collectStrings appends the string values in the value to values.
+func collectStrings(value interface{}, values *[]string) {
+	switch v := value.(type) {
+	case string:
+		*values = append(*values, v)
+	case []interface{}:
+		for _, item := range v {
+			collectStrings(item, values)
+		}
+	case map[string]interface{}:
+		for _, item := range v {
+			collectStrings(item, values)
+		}
+	}
+}
+
+// findCUEModuleRoot returns the closest parent of the sync directory, within
+// the source root, which contains the cue.mod directory, or an empty string if
+// there is none.
+func findCUEModuleRoot(sourceRoot, syncPath string) string {
+	for dir := syncPath; isWithin(sourceRoot, dir); dir = filepath.Dir(dir) {
+		if fi, err := os.Stat(filepath.Join(dir, cueModuleDir)); err == nil && fi.IsDir() {
+			return dir
+		}
+		if dir == sourceRoot {
+			break
+		}
+	}
+	return ""
+}
+
+// isWithin checks if the path is the root or is under the root.
+func isWithin(root, path string) bool {
+	rel, err := filepath.Rel(root, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// isWithinAny checks if the path is within any of the roots.
+func isWithinAny(roots []string, path string) bool {
+	for _, root := range roots {
+		if isWithin(root, path) {
+			return true
+		}
+	}
+	return false
+}
+
+// unpinnedReferences returns the remote references of the sync directory
+// which aren't pinned to an immutable version, whose rendered output can't be
+// cached safely.
+func unpinnedReferences(renderer Renderer, syncPath string) ([]string, error) {
+	kr, ok := renderer.(kustomizeRenderer)
+	if !ok {
+		return nil, nil
+	}
+	usage, err := kr.inspect(syncPath)
+	if err != nil {
+		return nil, err
+	}
+	return usage.unpinned, nil
+}
+
+// restore copies the cached output of the key to the output directory.
+// It returns false if the key is not cached.
+func (c *RenderCache) restore(key, output string) (bool, error) {
+	entry := filepath.Join(c.Dir, key)
+	if _, err := os.Stat(entry); err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	if err := os.RemoveAll(output); err != nil {
+		return false, err
+	}
+	if err := copyTree(entry, output); err != nil {
+		return false, fmt.Errorf("unable to copy the cache entry %s to %s: %w", entry, output, err)
+	}
+	// Update the modification time for the least recently used eviction.
+	now := time.Now()
+	if err := os.Chtimes(entry, now, now); err != nil {
+		klog.Warningf("Unable to update the modification time of the cache entry %s: %v", entry, err)
+	}
+	return true, nil
+}
+
+// store copies the rendered output to the cache entry of the key, and evicts
+// the least recently used entries if the cache exceeds the size bound.
+// It returns the total size of the cache entries.
+func (c *RenderCache) store(key, output string) (int64, error) {
+	if err := os.MkdirAll(c.Dir, os.FileMode(0755)); err != nil {
+		return 0, err
+	}
+	tmp, err := os.MkdirTemp(c.Dir, cacheTmpPrefix)
+	if err != nil {
+		return 0, err
+	}
+	if err := copyTree(output, tmp); err != nil {
+		if rmErr := os.RemoveAll(tmp); rmErr != nil {
+			klog.Warningf("Unable to remove the temporary cache entry %s: %v", tmp, rmErr)
+		}
+		return 0, fmt.Errorf("unable to copy %s to the cache: %w", output, err)
+	}
+	entry := filepath.Join(c.Dir, key)
+	if err := os.RemoveAll(entry); err != nil {
+		return 0, err
+	}
+	if err := os.Rename(tmp, entry); err != nil {
+		return 0, err
+	}
+	return c.evict()
+}
+
+// cacheEntry is an entry of the RenderCache.
+type cacheEntry struct {
+	path    string
+	size    int64
+	modTime time.Time
+}
+
+// evict deletes the least recently used entries until the total size of the
+// entries is within MaxBytes. Leftover temporary entries are deleted.
+// It returns the total size of the remaining entries.
+func (c *RenderCache) evict() (int64, error) {
+	files, err := os.ReadDir(c.Dir)
+	if err != nil {
+		return 0, err
+	}
+	var entries []cacheEntry
+	var total int64
+	for _, f := range files {
+		path := filepath.Join(c.Dir, f.Name())
+		if strings.HasPrefix(f.Name(), cacheTmpPrefix) {
+			if err := os.RemoveAll(path); err != nil {
+				return 0, err
+			}
+			continue
+		}
+		info, err := f.Info()
+		if err != nil {
+			return 0, err
+		}
+		size, err := treeSize(path)
+		if err != nil {
+			return 0, err
+		}
+		entries = append(entries, cacheEntry{path: path, size: size, modTime: info.ModTime()})
+		total += size
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].modTime.Before(entries[j].modTime)
+	})
+	for _, e := range entries {
+		if total <= c.MaxBytes {
+			break
+		}
+		klog.V(3).Infof("Evicting the rendering cache entry %s (%d bytes)", e.path, e.size)
+		if err := os.RemoveAll(e.path); err != nil {
+			return 0, err
+		}
+		total -= e.size
+	}
+	return total, nil
+}
+
+// treeSize returns the total size of the regular files under the root.
+func treeSize(root string) (int64, error) {
+	var size int64
+	err := filepath.WalkDir(root, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.Type().IsRegular() {
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			size += info.Size()
+		}
+		return nil
+	})
+	return size, err
+}
+
+// copyTree copies the directories, regular files and symlinks under src to dst.
+func copyTree(src, dst string) error {
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		switch {
+		case d.IsDir():
+			return os.MkdirAll(target, os.FileMode(0755))
+		case d.Type()&fs.ModeSymlink != 0:
+			link, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			return os.Symlink(link, target)
+		default:
+			return copyFile(path, target)
+		}
+	})
+}
+
+// copyFile copies the content and the permissions of the regular file.
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := in.Close(); err != nil {
+			klog.Warningf("Unable to close %s: %v", src, err)
+		}
+	}()
+	info, err := in.Stat()
+	if err != nil {
+		return err
+	}
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, info.Mode().Perm())
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		_ = out.Close()
+		return err
+	}
+	return out.Close()
+}
@@ -0,0 +1,236 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hydrate
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/importer/filesystem/cmpath"
+)
+
+// countingRenderer is a Renderer which writes a fixed file and counts the
+// renderings.
+type countingRenderer struct {
+	count int
+}
+
+func (r *countingRenderer) Engine() configsync.RenderingEngine {
+	return configsync.RenderingEngineKustomize
+}
+
+func (r *countingRenderer) Detect(string) (bool, error) {
+	return true, nil
+}
+
+func (r *countingRenderer) Version() (string, error) {
+	return "v1", nil
+}
+
+func (r *countingRenderer) Render(_, output string) HydrationError {
+	r.count++
+	if err := prepareOutput(output); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(output, renderedFile), []byte(configMap), 0644); err != nil {
+		return NewInternalError(err)
+	}
+	return nil
+}
+
+// writeTree writes the files, keyed by their slash-separated paths relative
+// to the root.
+func writeTree(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+}
+
+func TestRenderCacheKey(t *testing.T) {
+	baseFiles := map[string]string{
+		"apps/foo/kustomization.yaml":  "resources:\n- ../../bases/app\n",
+		"apps/foo/configmap.yaml":      configMap,
+		"bases/app/kustomization.yaml": "resources:\n- deployment.yaml\n",
+		"bases/app/deployment.yaml":    "kind: Deployment\n",
+		"other/README.md":              "docs",
+	}
+	testCases := []struct {
+		name        string
+		files       map[string]string
+		enableShell bool
+		wantChanged bool
+	}{
+		{
+			name:        "unchanged inputs",
+			wantChanged: false,
+		},
+		{
+			name:        "changed file outside of the inputs",
+			files:       map[string]string{"other/README.md": "new docs"},
+			wantChanged: false,
+		},
+		{
+			name:        "changed file in the sync directory",
+			files:       map[string]string{"apps/foo/configmap.yaml": strings.Replace(configMap, "original", "changed", 1)},
+			wantChanged: true,
+		},
+		{
+			name:        "new file in the sync directory",
+			files:       map[string]string{"apps/foo/new.yaml": configMap},
+			wantChanged: true,
+		},
+		{
+			name:        "changed file in a referenced base",
+			files:       map[string]string{"bases/app/deployment.yaml": "kind: StatefulSet\n"},
+			wantChanged: true,
+		},
+		{
+			name:        "shell enabled",
+			enableShell: true,
+			wantChanged: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			renderer := &countingRenderer{}
+			sourceRoot := t.TempDir()
+			syncPath := filepath.Join(sourceRoot, "apps", "foo")
+			writeTree(t, sourceRoot, baseFiles)
+			before, err := renderCacheKey(renderer, sourceRoot, syncPath, false)
+			require.NoError(t, err)
+
+			writeTree(t, sourceRoot, tc.files)
+			after, err := renderCacheKey(renderer, sourceRoot, syncPath, tc.enableShell)
+			require.NoError(t, err)
+			if tc.wantChanged {
+				assert.NotEqual(t, before, after)
+			} else {
+				assert.Equal(t, before, after)
+			}
+		})
+	}
+}
+
+func TestRenderCacheKeyIndependentOfSourceRoot(t *testing.T) {
+	renderer := &countingRenderer{}
+	var keys []string
+	for i := 0; i < 2; i++ {
+		// Each commit is checked out in a different directory.
+		sourceRoot := t.TempDir()
+		writeTree(t, sourceRoot, map[string]string{"foo/kustomization.yaml": kustomization})
+		key, err := renderCacheKey(renderer, sourceRoot, filepath.Join(sourceRoot, "foo"), false)
+		require.NoError(t, err)
+		keys = append(keys, key)
+	}
+	assert.Equal(t, keys[0], keys[1])
+}
+
+func TestRenderCacheEviction(t *testing.T) {
+	cache := &RenderCache{Dir: t.TempDir(), MaxBytes: 3 * int64(len(configMap))}
+	output := t.TempDir()
+	writeTree(t, output, map[string]string{"configmap.yaml": configMap})
+
+	start := time.Now().Add(-time.Hour)
+	for i, key := range []string{"a", "b", "c"} {
+		_, err := cache.store(key, output)
+		require.NoError(t, err)
+		// Make the entries ordered by the time they were used.
+		used := start.Add(time.Duration(i) * time.Minute)
+		require.NoError(t, os.Chtimes(filepath.Join(cache.Dir, key), used, used))
+	}
+	// Restoring "a" makes it the most recently used entry.
+	hit, err := cache.restore("a", filepath.Join(t.TempDir(), "restored"))
+	require.NoError(t, err)
+	require.True(t, hit)
+
+	size, err := cache.store("d", output)
+	require.NoError(t, err)
+	assert.Equal(t, cache.MaxBytes, size)
+	for key, wantCached := range map[string]bool{"a": true, "b": false, "c": true, "d": true} {
+		_, err := os.Stat(filepath.Join(cache.Dir, key))
+		assert.Equal(t, wantCached, err == nil, "entry %s", key)
+	}
+}
+
+func TestHydratorRenderWithCache(t *testing.T) {
+	repoRoot := t.TempDir()
+	hydratedRoot := filepath.Join(repoRoot, "hydrated")
+	renderer := &countingRenderer{}
+
+	render := func(commit string, files map[string]string) {
+		t.Helper()
+		commitDir := filepath.Join(repoRoot, "source", commit)
+		writeTree(t, commitDir, files)
+		link := filepath.Join(repoRoot, "source", "rev")
+		require.NoError(t, os.RemoveAll(link))
+		require.NoError(t, os.Symlink(commitDir, link))
+
+		hydrator := &Hydrator{
+			SourceRoot:  cmpath.Absolute(filepath.Join(repoRoot, "source")),
+			SourceLink:  "rev",
+			RenderCache: &RenderCache{Dir: filepath.Join(repoRoot, "render-cache"), MaxBytes: 1 << 20},
+		}
+		output := filepath.Join(hydratedRoot, commit)
+		require.NoError(t, hydrator.render(renderer, filepath.Join(commitDir, "foo"), output))
+		rendered, err := os.ReadFile(filepath.Join(output, renderedFile))
+		require.NoError(t, err)
+		assert.Equal(t, configMap, string(rendered))
+	}
+
+	render("commit-1", map[string]string{"foo/kustomization.yaml": kustomization, "README.md": "v1"})
+	assert.Equal(t, 1, renderer.count)
+	// The new commit only changes a file outside of the sync directory.
+	render("commit-2", map[string]string{"foo/kustomization.yaml": kustomization, "README.md": "v2"})
+	assert.Equal(t, 1, renderer.count)
+	// The new commit changes the kustomization.
+	render("commit-3", map[string]string{"foo/kustomization.yaml": kustomization + "\nnamePrefix: bar-\n"})
+	assert.Equal(t, 2, renderer.count)
+}
+
+func TestUnpinnedReferences(t *testing.T) {
+	dir := t.TempDir()
+	writeTree(t, dir, map[string]string{"kustomization.yaml": `resources:
+- https://github.com/example/repo//base?ref=v1.0.0
+- https://github.com/example/repo//pinned?ref=0123456789abcdef0123456789abcdef01234567
+- https://example.com/configmap.yaml
+helmCharts:
+- name: pinned
+  repo: https://charts.example.com
+  version: 1.2.3
+- name: range
+  repo: https://charts.example.com
+  version: ^1.2.0
+`})
+	got, err := unpinnedReferences(kustomizeRenderer{}, dir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"https://github.com/example/repo//base?ref=v1.0.0",
+		"https://example.com/configmap.yaml",
+		"https://charts.example.com/range:^1.2.0",
+	}, got)
+
+	got, err = unpinnedReferences(&countingRenderer{}, dir)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
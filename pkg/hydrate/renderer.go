@@ -0,0 +1,208 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hydrate
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"kpt.dev/configsync/pkg/api/configsync"
+	"sigs.k8s.io/yaml"
+)
+
+// renderedFile is the name of the file which the in-process renderers write
+// the rendered objects to, under the output directory.
+const renderedFile = "rendered.yaml"
+
+// Renderer renders the source configs with a rendering engine.
+type Renderer interface {
+	// Engine returns the rendering engine of the Renderer.
+	Engine() configsync.RenderingEngine
+	// Detect checks if the directory contains the marker files of the engine.
+	Detect(dir string) (bool, error)
+	// Version returns the versions of the tools used by the engine, which
+	// affect the rendered output.
+	Version() (string, error)
+	// Render renders the configs in the input directory, and writes the
+	// rendered configs to the output directory.
+	Render(input, output string) HydrationError
+}
+
+// RendererOptions configures the Renderers.
+type RendererOptions struct {
+	// SendMetrics enables the rendering metrics.
+	SendMetrics bool
+	// SourceRoot is the root directory of the source, outside of which the
+	// renderers may not read files. If empty, the renderers may only read
+	// files in the rendered directory.
+	SourceRoot string
+	// AllowRemoteBases allows the Kustomizations to fetch remote bases, and
+	// the CUE modules to fetch their dependencies from the registry.
+	AllowRemoteBases bool
+	// AllowExec allows the Kptfile pipelines to run exec functions, which
+	// requires the shell in the rendering process.
+	AllowExec bool
+}
+
+// renderers are the supported renderers, in order of detection precedence.
+// A Kustomization config file takes precedence over the other marker files,
+// so packages with both are still rendered with kustomize.
+func renderers(opts RendererOptions) []Renderer {
+	return []Renderer{
+		kustomizeRenderer{RendererOptions: opts},
+		kptRenderer{RendererOptions: opts},
+		jsonnetRenderer{RendererOptions: opts},
+		cueRenderer{RendererOptions: opts},
+	}
+}
+
+// NewRenderer returns the Renderer of the engine, or the Renderer detected
+// from the marker files in the directory if the engine is empty.
+// It returns nil if the engine is empty and no marker file is found.
+func NewRenderer(engine configsync.RenderingEngine, dir string, opts RendererOptions) (Renderer, error) {
+	for _, r := range renderers(opts) {
+		if engine != "" {
+			if r.Engine() == engine {
+				return r, nil
+			}
+			continue
+		}
+		found, err := r.Detect(dir)
+		if err != nil {
+			return nil, fmt.Errorf("unable to check if the %s rendering is needed for the directory %s: %w", r.Engine(), dir, err)
+		}
+		if found {
+			return r, nil
+		}
+	}
+	if engine != "" {
+		return nil, fmt.Errorf("unsupported rendering engine %q", engine)
+	}
+	return nil, nil
+}
+
+// kptRenderer renders the configs with the Kptfile pipelines.
+type kptRenderer struct {
+	RendererOptions
+}
+
+// Engine implements Renderer.
+func (kptRenderer) Engine() configsync.RenderingEngine {
+	return configsync.RenderingEngineKpt
+}
+
+// Detect implements Renderer. The kpt rendering is never detected, since the
+// pipeline functions run executables or container images, so it must be
+// enabled explicitly with the kpt rendering engine.
+func (kptRenderer) Detect(string) (bool, error) {
+	return false, nil
+}
+
+// Version implements Renderer. The functions are pinned by the image tags or
+// the executables in the Kptfile, so the version is empty.
+func (kptRenderer) Version() (string, error) {
+	return "", nil
+}
+
+// Render implements Renderer.
+func (r kptRenderer) Render(input, output string) HydrationError {
+	return kptRender(input, output, r.AllowExec)
+}
+
+// prepareOutput deletes the output directory if it exists, and recreates it.
+func prepareOutput(output string) HydrationError {
+	if _, err := os.Stat(output); err == nil {
+		mustDeleteOutput(err, output)
+	}
+	if err := os.MkdirAll(output, os.FileMode(0755)); err != nil {
+		return NewInternalError(fmt.Errorf("unable to make directory: %s: %w", output, err))
+	}
+	return nil
+}
+
+// writeRenderedJSON writes the objects in the JSON output of an in-process
+// renderer to the output directory, as a multi-document YAML file.
+//
+// The output may be a single object, a List object, an array of objects, or
+// a map whose values are objects, in which case the objects are written in
+// the order of the map keys. Arrays and maps may be nested.
+func writeRenderedJSON(engine configsync.RenderingEngine, data []byte, output string) HydrationError {
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return NewInternalError(fmt.Errorf("unable to parse the %s output: %w", engine, err))
+	}
+	var objs []map[string]interface{}
+	if err := flattenObjects(value, "", &objs); err != nil {
+		return NewActionableError(fmt.Errorf("invalid %s output: %w", engine, err))
+	}
+	var buf bytes.Buffer
+	for _, obj := range objs {
+		b, err := yaml.Marshal(obj)
+		if err != nil {
+			return NewInternalError(fmt.Errorf("unable to marshal the %s output: %w", engine, err))
+		}
+		buf.WriteString("---\n")
+		buf.Write(b)
+	}
+	path := filepath.Join(output, renderedFile)
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		return NewInternalError(fmt.Errorf("unable to write the rendered configs to %s: %w", path, err))
+	}
+	return nil
+}
+
+// flattenObjects appends the Kubernetes objects in the value to objs. The
+// field path of the value is used in error messages.
+func flattenObjects(value interface{}, field string, objs *[]map[string]interface{}) error {
+	switch v := value.(type) {
+	case []interface{}:
+		for i, item := range v {
+			if err := flattenObjects(item, fmt.Sprintf("%s[%d]", field, i), objs); err != nil {
+				return err
+			}
+		}
+		return nil
+	case map[string]interface{}:
+		_, hasAPIVersion := v["apiVersion"]
+		_, hasKind := v["kind"]
+		if hasAPIVersion && hasKind {
+			if v["kind"] == "List" {
+				return flattenObjects(v["items"], field+".items", objs)
+			}
+			*objs = append(*objs, v)
+			return nil
+		}
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if err := flattenObjects(v[k], field+"."+k, objs); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		if field == "" {
+			field = "."
+		}
+		return fmt.Errorf("%s: expected an object, a list or a map of objects, got %T", field, value)
+	}
+}
@@ -0,0 +1,230 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hydrate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/status"
+)
+
+// kptfileWithPipeline is a Kptfile which declares a pipeline of functions.
+const kptfileWithPipeline = "apiVersion: kpt.dev/v1\nkind: Kptfile\nmetadata:\n  name: pkg\npipeline:\n  mutators:\n  - exec: ./fn.sh\n"
+
+// cueModuleFile is the module file of a CUE module without dependencies.
+const cueModuleFile = "module: \"example.com/pkg@v0\"\nlanguage: version: \"v0.12.0\"\n"
+
+func TestNewRenderer(t *testing.T) {
+	testCases := []struct {
+		name       string
+		engine     configsync.RenderingEngine
+		files      map[string]string
+		want       configsync.RenderingEngine
+		wantErrMsg string
+	}{
+		{
+			name:  "no marker file",
+			files: map[string]string{"configmap.yaml": configMap},
+		},
+		{
+			name:  "kustomization",
+			files: map[string]string{"kustomization.yaml": kustomization},
+			want:  configsync.RenderingEngineKustomize,
+		},
+		{
+			name:  "kustomization takes precedence over Kptfile",
+			files: map[string]string{"kustomization.yaml": kustomization, Kptfile: kptfileWithPipeline},
+			want:  configsync.RenderingEngineKustomize,
+		},
+		{
+			name:  "Kptfile pipeline is not detected",
+			files: map[string]string{Kptfile: kptfileWithPipeline},
+		},
+		{
+			name:  "Jsonnet",
+			files: map[string]string{JsonnetMainFile: "{}", JsonnetBundlerFile: "{}"},
+			want:  configsync.RenderingEngineJsonnet,
+		},
+		{
+			name:  "Jsonnet without jsonnetfile.json is not detected",
+			files: map[string]string{JsonnetMainFile: "{}"},
+		},
+		{
+			name:  "CUE module",
+			files: map[string]string{"cue.mod/module.cue": cueModuleFile, "objects.cue": "package pkg\n"},
+			want:  configsync.RenderingEngineCUE,
+		},
+		{
+			name:  "CUE file without module is not detected",
+			files: map[string]string{"objects.cue": "package pkg\n"},
+		},
+		{
+			name:   "explicit engine without marker file",
+			engine: configsync.RenderingEngineJsonnet,
+			files:  map[string]string{"configmap.yaml": configMap},
+			want:   configsync.RenderingEngineJsonnet,
+		},
+		{
+			name:   "explicit engine overrides detection",
+			engine: configsync.RenderingEngineKpt,
+			files:  map[string]string{"kustomization.yaml": kustomization, Kptfile: kptfileWithPipeline},
+			want:   configsync.RenderingEngineKpt,
+		},
+		{
+			name:       "unsupported engine",
+			engine:     "ytt",
+			wantErrMsg: `unsupported rendering engine "ytt"`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeFiles(t, dir, tc.files)
+			got, err := NewRenderer(tc.engine, dir, RendererOptions{})
+			if tc.wantErrMsg != "" {
+				require.ErrorContains(t, err, tc.wantErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			if tc.want == "" {
+				assert.Nil(t, got)
+				return
+			}
+			require.NotNil(t, got)
+			assert.Equal(t, tc.want, got.Engine())
+		})
+	}
+}
+
+func TestInProcessRender(t *testing.T) {
+	testCases := []struct {
+		name       string
+		renderer   Renderer
+		files      map[string]string
+		want       string
+		wantErrMsg string
+	}{
+		{
+			name:     "Jsonnet map of objects",
+			renderer: jsonnetRenderer{},
+			files: map[string]string{
+				"lib.libsonnet": `{ configMap(name):: { apiVersion: "v1", kind: "ConfigMap", metadata: { name: name } } }`,
+				JsonnetMainFile: `local lib = import "lib.libsonnet"; { b: lib.configMap("b"), a: lib.configMap("a") }`,
+			},
+			want: "---\napiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: a\n" +
+				"---\napiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: b\n",
+		},
+		{
+			name:     "Jsonnet List",
+			renderer: jsonnetRenderer{},
+			files: map[string]string{
+				JsonnetMainFile: `{ apiVersion: "v1", kind: "List", items: [{ apiVersion: "v1", kind: "Namespace", metadata: { name: "foo" } }] }`,
+			},
+			want: "---\napiVersion: v1\nkind: Namespace\nmetadata:\n  name: foo\n",
+		},
+		{
+			name:     "Jsonnet non-object value",
+			renderer: jsonnetRenderer{},
+			files: map[string]string{
+				JsonnetMainFile: `{ a: "not an object" }`,
+			},
+			wantErrMsg: ".a: expected an object, a list or a map of objects, got string",
+		},
+		{
+			name:     "Jsonnet evaluation error",
+			renderer: jsonnetRenderer{},
+			files: map[string]string{
+				JsonnetMainFile: `error "boom"`,
+			},
+			wantErrMsg: "boom",
+		},
+		{
+			name:     "Jsonnet import outside of the source",
+			renderer: jsonnetRenderer{},
+			files: map[string]string{
+				JsonnetMainFile: `import "../outside.libsonnet"`,
+			},
+			wantErrMsg: "is outside of the source directory",
+		},
+		{
+			name:     "Jsonnet absolute importstr",
+			renderer: jsonnetRenderer{},
+			files: map[string]string{
+				JsonnetMainFile: `{ data: importstr "/etc/passwd" }`,
+			},
+			wantErrMsg: "/etc/passwd is outside of the source directory",
+		},
+		{
+			name:     "CUE objects field",
+			renderer: cueRenderer{},
+			files: map[string]string{
+				"objects.cue": `package pkg
+
+#Namespace: {
+	apiVersion: "v1"
+	kind:       "Namespace"
+	metadata: name: string
+}
+
+names: ["foo"]
+objects: [ for n in names {#Namespace & {metadata: name: n}}]
+`,
+			},
+			want: "---\napiVersion: v1\nkind: Namespace\nmetadata:\n  name: foo\n",
+		},
+		{
+			name:     "CUE non-concrete value",
+			renderer: cueRenderer{},
+			files: map[string]string{
+				"objects.cue": "package pkg\n\nobjects: [{apiVersion: \"v1\", kind: \"Namespace\", metadata: name: string}]\n",
+			},
+			wantErrMsg: "failed to evaluate the CUE package",
+		},
+		{
+			name:     "CUE module dependency without remote access",
+			renderer: cueRenderer{},
+			files: map[string]string{
+				"cue.mod/module.cue": cueModuleFile + "deps: \"example.com/dep@v0\": v: \"v0.1.0\"\n",
+				"objects.cue":        "package pkg\n\nimport \"example.com/dep\"\n\nobjects: dep.objects\n",
+			},
+			wantErrMsg: "requires remote access in the rendering process",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			input := t.TempDir()
+			output := filepath.Join(t.TempDir(), "hydrated")
+			writeFiles(t, input, tc.files)
+
+			err := tc.renderer.Render(input, output)
+			if tc.wantErrMsg != "" {
+				require.Error(t, err)
+				assert.Equal(t, status.ActionableHydrationErrorCode, err.Code())
+				assert.Contains(t, err.Error(), tc.wantErrMsg)
+				assert.NoDirExists(t, output)
+				return
+			}
+			require.NoError(t, err)
+			rendered, readErr := os.ReadFile(filepath.Join(output, renderedFile))
+			require.NoError(t, readErr)
+			assert.Equal(t, tc.want, string(rendered))
+		})
+	}
+}
@@ -35,6 +35,7 @@ import (
 	"kpt.dev/configsync/pkg/client/restconfig"
 	"kpt.dev/configsync/pkg/core"
 	"kpt.dev/configsync/pkg/declared"
+	"kpt.dev/configsync/pkg/importer/analyzer/transform/selectors"
 	"kpt.dev/configsync/pkg/importer/filesystem/cmpath"
 	"kpt.dev/configsync/pkg/kmetrics"
 	"kpt.dev/configsync/pkg/reconcilermanager"
@@ -44,6 +45,8 @@ import (
 	"kpt.dev/configsync/pkg/util/discovery"
 	"kpt.dev/configsync/pkg/util/namespaceconfig"
 	"kpt.dev/configsync/pkg/validate"
+	"kpt.dev/configsync/pkg/validate/openapi"
+	"kpt.dev/configsync/pkg/validate/variables"
 	"kpt.dev/configsync/pkg/vet"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
@@ -227,67 +230,96 @@ func validateHelm() error {
 	return nil
 }
 
-// ValidateAndRunKustomize validates if the Kustomize and Helm binaries are supported.
-// If supported, it copies the source configs to a temp directory, run 'kustomize build',
-// save the output to another temp directory, and return the output path for further
-// parsing and validation.
-func ValidateAndRunKustomize(sourcePath string) (cmpath.Absolute, error) {
+// ValidateAndRender renders the source configs with the Renderer, saves the
+// output to a temp directory, and returns the output path for further parsing
+// and validation. For Kustomize, it first validates if the Helm binary is
+// supported, and if the Kustomize binary is supported when the Kustomization
+// uses plugins, which can't be run in-process.
+func ValidateAndRender(renderer Renderer, sourcePath string) (cmpath.Absolute, error) {
 	var output cmpath.Absolute
-	if err := validateKustomize(); err != nil {
-		return output, err
-	}
-	if err := validateHelm(); err != nil {
-		return output, err
+	if kr, ok := renderer.(kustomizeRenderer); ok {
+		usesPlugins, err := kr.UsesPlugins(sourcePath)
+		if err != nil {
+			return output, err
+		}
+		if usesPlugins {
+			if err := validateKustomize(); err != nil {
+				return output, err
+			}
+		}
+		if err := validateHelm(); err != nil {
+			return output, err
+		}
 	}
 
-	// Save the 'kustomize build' output to a temp directory for further
+	// Save the rendered output to a temp directory for further
 	// parsing or validation.
 	tmpHydratedDir, err := os.MkdirTemp(os.TempDir(), "hydrated-")
 	if err != nil {
 		return output, err
 	}
 
-	if err := kustomizeBuild(sourcePath, tmpHydratedDir, false); err != nil {
+	if err := renderer.Render(sourcePath, tmpHydratedDir); err != nil {
 		return output, fmt.Errorf("unable to render the source configs in %s: %w", sourcePath, err)
 	}
 
-	fmt.Println("NOTICE: The command will save the remote Helm charts to a local directory defined in the `helmGlobals.chartHome` field if the Kustomization file references remote Helm charts. " +
-		"The default value is `charts`, which is relative to the Kustomization root. Please delete or ignore the directory in your Git repository.")
+	if renderer.Engine() == configsync.RenderingEngineKustomize {
+		fmt.Println("NOTICE: The command will save the remote Helm charts to a local directory defined in the `helmGlobals.chartHome` field if the Kustomization file references remote Helm charts. " +
+			"The default value is `charts`, which is relative to the Kustomization root. Please delete or ignore the directory in your Git repository.")
+	}
 	return cmpath.AbsoluteOS(tmpHydratedDir)
 }
 
 // ValidateHydrateFlags validates the hydrate and vet flags.
-// It returns the absolute path of the source directory, if hydration is needed, and errors.
-func ValidateHydrateFlags(sourceFormat configsync.SourceFormat) (cmpath.Absolute, bool, error) {
+// It returns the absolute path of the source directory, the Renderer if
+// rendering is needed, and errors.
+func ValidateHydrateFlags(sourceFormat configsync.SourceFormat) (cmpath.Absolute, Renderer, error) {
 	abs, err := filepath.Abs(flags.Path)
 	if err != nil {
-		return "", false, err
+		return "", nil, err
 	}
 	rootDir, err := cmpath.AbsoluteOS(abs)
 	if err != nil {
-		return "", false, err
+		return "", nil, err
 	}
 	rootDir, err = rootDir.EvalSymlinks()
 	if err != nil {
-		return "", false, err
+		return "", nil, err
 	}
 
 	switch flags.OutputFormat {
 	case flags.OutputYAML, flags.OutputJSON: // do nothing
 	default:
-		return "", false, fmt.Errorf("format argument must be %q or %q", flags.OutputYAML, flags.OutputJSON)
+		return "", nil, fmt.Errorf("format argument must be %q or %q", flags.OutputYAML, flags.OutputJSON)
 	}
 
-	needsKustomize, err := needsKustomize(abs)
+	renderer, err := NewRenderer(configsync.RenderingEngine(flags.RenderingEngine), abs, RendererOptions{
+		SourceRoot:       gitRoot(rootDir.OSPath()),
+		AllowRemoteBases: true,
+		AllowExec:        true,
+	})
 	if err != nil {
-		return "", false, fmt.Errorf("unable to check if Kustomize is needed for the source directory: %s: %w", abs, err)
+		return "", nil, err
 	}
 
-	if needsKustomize && sourceFormat == configsync.SourceFormatHierarchy {
-		return "", false, fmt.Errorf("%s must be %s when Kustomization is needed", reconcilermanager.SourceFormat, configsync.SourceFormatUnstructured)
+	if renderer != nil && sourceFormat == configsync.SourceFormatHierarchy {
+		return "", nil, fmt.Errorf("%s must be %s when rendering with %s is needed", reconcilermanager.SourceFormat, configsync.SourceFormatUnstructured, renderer.Engine())
 	}
 
-	return rootDir, needsKustomize, nil
+	return rootDir, renderer, nil
+}
+
+// gitRoot returns the root directory of the git repository which contains the
+// directory, or the directory itself if it is not in a git repository.
+func gitRoot(dir string) string {
+	for d := dir; ; d = filepath.Dir(d) {
+		if _, err := os.Stat(filepath.Join(d, ".git")); err == nil {
+			return d
+		}
+		if filepath.Dir(d) == d {
+			return dir
+		}
+	}
 }
 
 // ValidateOptions returns the validate options for nomos hydrate and vet commands.
@@ -320,12 +352,36 @@ func ValidateOptions(ctx context.Context, rootDir cmpath.Absolute, apiServerTime
 		if err != nil {
 			return options, err
 		}
+		options.Schemas = openapi.NewSourceResources(options.Converter)
 	}
 
 	options.PolicyDir = cmpath.RelativeOS(rootDir.OSPath())
 	options.BuildScoper = discovery.ScoperBuilder(serverResourcer,
 		vet.AddCachedAPIResources(rootDir.Join(vet.APIResourcesPath)))
 	options.AllowUnknownKinds = flags.SkipAPIServer
+
+	if flags.ClusterLabelsFile != "" {
+		data, err := os.ReadFile(flags.ClusterLabelsFile)
+		if err != nil {
+			return options, fmt.Errorf("unable to read the cluster labels file %s: %w", flags.ClusterLabelsFile, err)
+		}
+		labels, labelsErr := selectors.ClusterLabelsFromFile(data, flags.ClusterLabelsFile)
+		if labelsErr != nil {
+			return options, labelsErr
+		}
+		options.ClusterLabels = labels
+	}
+	if flags.VarsFile != "" {
+		data, err := os.ReadFile(flags.VarsFile)
+		if err != nil {
+			return options, fmt.Errorf("unable to read the variables file %s: %w", flags.VarsFile, err)
+		}
+		vars, varsErr := variables.FromFile(data, flags.VarsFile)
+		if varsErr != nil {
+			return options, varsErr
+		}
+		options.Variables = vars
+	}
 	return options, nil
 }
 
@@ -0,0 +1,92 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selectors
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"kpt.dev/configsync/pkg/kinds"
+	"kpt.dev/configsync/pkg/status"
+)
+
+// ClusterLabelsConfigMapName is the name of the ConfigMap in the
+// config-management-system namespace which declares the labels of the cluster,
+// such as its region, environment or tier. Each data key is a label key.
+//
+// ClusterSelectors match these labels in addition to the labels of the Cluster
+// object declared in the repository, which they override.
+const ClusterLabelsConfigMapName = "config-sync-cluster-labels"
+
+// ClusterLabelsFromConfigMap returns the cluster labels declared in the data
+// of the ConfigMap.
+func ClusterLabelsFromConfigMap(cm *corev1.ConfigMap) (labels.Set, status.Error) {
+	source := fmt.Sprintf("ConfigMap %s/%s", cm.Namespace, cm.Name)
+	return validateClusterLabels(cm.Data, source)
+}
+
+// ClusterLabelsFromFile returns the cluster labels declared in a file, which
+// may contain a map of labels or the ConfigMap which declares them, for example
+// exported from a cluster.
+func ClusterLabelsFromFile(data []byte, source string) (labels.Set, status.Error) {
+	var content map[string]interface{}
+	if err := yaml.Unmarshal(data, &content); err != nil {
+		return nil, InvalidClusterLabelsError(source, err)
+	}
+	if content["kind"] == kinds.ConfigMap().Kind {
+		cm := &corev1.ConfigMap{}
+		if err := yaml.Unmarshal(data, cm); err != nil {
+			return nil, InvalidClusterLabelsError(source, err)
+		}
+		return validateClusterLabels(cm.Data, source)
+	}
+	values := make(map[string]string, len(content))
+	for k, v := range content {
+		s, isString := v.(string)
+		if !isString {
+			return nil, InvalidClusterLabelsError(source,
+				fmt.Errorf("the value of label %q must be a string, got %T", k, v))
+		}
+		values[k] = s
+	}
+	return validateClusterLabels(values, source)
+}
+
+// validateClusterLabels checks that the keys and values are valid labels.
+func validateClusterLabels(values map[string]string, source string) (labels.Set, status.Error) {
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var problems []string
+	for _, k := range keys {
+		for _, msg := range validation.IsQualifiedName(k) {
+			problems = append(problems, fmt.Sprintf("invalid label key %q: %s", k, msg))
+		}
+		for _, msg := range validation.IsValidLabelValue(values[k]) {
+			problems = append(problems, fmt.Sprintf("invalid value %q of label %q: %s", values[k], k, msg))
+		}
+	}
+	if len(problems) > 0 {
+		return nil, InvalidClusterLabelsError(source, fmt.Errorf("%s", strings.Join(problems, "; ")))
+	}
+	return labels.Set(values), nil
+}
@@ -0,0 +1,83 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selectors
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+func TestClusterLabelsFromFile(t *testing.T) {
+	testCases := []struct {
+		name       string
+		data       string
+		want       labels.Set
+		wantErrMsg string
+	}{
+		{
+			name: "map of labels",
+			data: "region: us-east1\nenv: prod\n",
+			want: labels.Set{"region": "us-east1", "env": "prod"},
+		},
+		{
+			name: "ConfigMap",
+			data: `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config-sync-cluster-labels
+  namespace: config-management-system
+data:
+  region: us-east1
+  example.com/tier: "1"
+`,
+			want: labels.Set{"region": "us-east1", "example.com/tier": "1"},
+		},
+		{
+			name: "empty file",
+			want: labels.Set{},
+		},
+		{
+			name:       "non-string value",
+			data:       "tier: 1\n",
+			wantErrMsg: `the value of label "tier" must be a string, got int64`,
+		},
+		{
+			name:       "invalid key",
+			data:       "not/a/key: prod\n",
+			wantErrMsg: `invalid label key "not/a/key"`,
+		},
+		{
+			name:       "invalid value",
+			data:       "env: not a value\n",
+			wantErrMsg: `invalid value "not a value" of label "env"`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ClusterLabelsFromFile([]byte(tc.data), "labels.yaml")
+			if tc.wantErrMsg != "" {
+				require.Error(t, err)
+				assert.Equal(t, InvalidClusterLabelsErrorCode, err.Code())
+				assert.Contains(t, err.Error(), tc.wantErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -55,3 +55,14 @@ func ClusterSelectorAnnotationConflictError(resource client.Object) status.Error
 			"To fix, remove one of the annotations from:", resource.GetName(),
 		metadata.ClusterNameSelectorAnnotationKey, metadata.LegacyClusterSelectorAnnotationKey).BuildWithResources(resource)
 }
+
+// InvalidClusterLabelsErrorCode is the error code for InvalidClusterLabelsError
+const InvalidClusterLabelsErrorCode = "1073"
+
+var invalidClusterLabels = status.NewErrorBuilder(InvalidClusterLabelsErrorCode)
+
+// InvalidClusterLabelsError reports that the cluster labels declared in the
+// source can't be used by ClusterSelectors.
+func InvalidClusterLabelsError(source string, err error) status.Error {
+	return invalidClusterLabels.Sprintf("invalid cluster labels in %s", source).Wrap(err).Build()
+}
@@ -32,11 +32,16 @@ import (
 	ft "kpt.dev/configsync/pkg/importer/filesystem/filesystemtest"
 	"kpt.dev/configsync/pkg/importer/reader"
 	"kpt.dev/configsync/pkg/kinds"
+	"kpt.dev/configsync/pkg/metadata"
 	"kpt.dev/configsync/pkg/resourcequota"
 	"kpt.dev/configsync/pkg/status"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// sourceLine is the line and column recorded by the reader for the objects
+// declared after the leading line break of the test files.
+var sourceLine = core.Annotation(metadata.SourceLineAnnotationKey, "2:1")
+
 func aNamespace(name string) string {
 	return fmt.Sprintf(`
 apiVersion: v1
@@ -90,7 +95,7 @@ func TestFilesystemReader(t *testing.T) {
 			testFiles: ft.FileContentMap{
 				"namespaces/bar/namespace.yaml": aNamespace("bar"),
 			},
-			expectObject: pointer(k8sobjects.UnstructuredAtPath(kinds.Namespace(), "namespaces/bar/namespace.yaml", core.Name("bar"))),
+			expectObject: pointer(k8sobjects.UnstructuredAtPath(kinds.Namespace(), "namespaces/bar/namespace.yaml", core.Name("bar"), sourceLine)),
 		},
 		{
 			testName: "Namespace dir with JSON Namespace",
@@ -105,7 +110,7 @@ func TestFilesystemReader(t *testing.T) {
 }
 `,
 			},
-			expectObject: pointer(k8sobjects.UnstructuredAtPath(kinds.Namespace(), "namespaces/bar/namespace.json", core.Name("bar"))),
+			expectObject: pointer(k8sobjects.UnstructuredAtPath(kinds.Namespace(), "namespaces/bar/namespace.json", core.Name("bar"), sourceLine)),
 		},
 		{
 			testName: "Namespaces dir with ignored file",
@@ -120,7 +125,7 @@ func TestFilesystemReader(t *testing.T) {
 				"namespaces/bar/ignore":         "",
 				"namespaces/bar/ignore2":        "blah blah blah",
 			},
-			expectObject: pointer(k8sobjects.UnstructuredAtPath(kinds.Namespace(), "namespaces/bar/namespace.yaml", core.Name("bar"))),
+			expectObject: pointer(k8sobjects.UnstructuredAtPath(kinds.Namespace(), "namespaces/bar/namespace.yaml", core.Name("bar"), sourceLine)),
 		},
 		{
 			testName: "Namespace with labels/annotations",
@@ -136,7 +141,7 @@ metadata:
     audit: "true"
 `,
 			},
-			expectObject: pointer(k8sobjects.UnstructuredAtPath(kinds.Namespace(), "namespaces/bar/namespace.yaml", core.Name("bar"), core.Label("env", "prod"), core.Annotation("audit", "true"))),
+			expectObject: pointer(k8sobjects.UnstructuredAtPath(kinds.Namespace(), "namespaces/bar/namespace.yaml", core.Name("bar"), core.Label("env", "prod"), core.Annotation("audit", "true"), sourceLine)),
 		},
 		{
 			testName: "Abstract Namespace dir with ignored file",
@@ -157,7 +162,7 @@ spec:
     pods: "10"
 `,
 			},
-			expectObject: pointer(ast.NewFileObject(k8sobjects.UnstructuredObject(kinds.ResourceQuota(), specHardPods, core.Name("pod-quota")), cmpath.RelativeSlash("namespaces/bar/rq.yaml"))),
+			expectObject: pointer(ast.NewFileObject(k8sobjects.UnstructuredObject(kinds.ResourceQuota(), specHardPods, core.Name("pod-quota"), sourceLine), cmpath.RelativeSlash("namespaces/bar/rq.yaml"))),
 		},
 		{
 			testName: "Namespace dir with Custom Resource",
@@ -177,7 +182,7 @@ spec:
 					"kind":       "Engineer",
 					"metadata": map[string]interface{}{
 						"name":        "philo",
-						"annotations": map[string]interface{}{},
+						"annotations": map[string]interface{}{metadata.SourceLineAnnotationKey: "2:1"},
 						"labels":      map[string]interface{}{},
 					},
 					"spec": map[string]interface{}{
@@ -206,7 +211,7 @@ spec:
 					"kind":       "HierarchyConfig",
 					"metadata": map[string]interface{}{
 						"name":        "config",
-						"annotations": map[string]interface{}{},
+						"annotations": map[string]interface{}{metadata.SourceLineAnnotationKey: "2:1"},
 						"labels":      map[string]interface{}{},
 					},
 					"spec": map[string]interface{}{
@@ -248,7 +253,7 @@ metadata:
     number: "0000"
 `,
 			},
-			expectObject: pointer(k8sobjects.UnstructuredAtPath(kinds.Namespace(), "namespaces/backend/namespace.yaml", core.Name("backend"), core.Annotation("number", "0000"))),
+			expectObject: pointer(k8sobjects.UnstructuredAtPath(kinds.Namespace(), "namespaces/backend/namespace.yaml", core.Name("backend"), core.Annotation("number", "0000"), sourceLine)),
 		},
 		{
 			testName: "metadata.annotations with boolean value",
@@ -276,7 +281,7 @@ metadata:
     boolean: "true"
 `,
 			},
-			expectObject: pointer(k8sobjects.UnstructuredAtPath(kinds.Namespace(), "namespaces/backend/namespace.yaml", core.Name("backend"), core.Annotation("boolean", "true"))),
+			expectObject: pointer(k8sobjects.UnstructuredAtPath(kinds.Namespace(), "namespaces/backend/namespace.yaml", core.Name("backend"), core.Annotation("boolean", "true"), sourceLine)),
 		},
 		{
 			testName: "metadata.labels with boolean value",
@@ -304,7 +309,7 @@ metadata:
     boolean: "true"
 `,
 			},
-			expectObject: pointer(k8sobjects.UnstructuredAtPath(kinds.Namespace(), "namespaces/backend/namespace.yaml", core.Name("backend"), core.Label("boolean", "true"))),
+			expectObject: pointer(k8sobjects.UnstructuredAtPath(kinds.Namespace(), "namespaces/backend/namespace.yaml", core.Name("backend"), core.Label("boolean", "true"), sourceLine)),
 		},
 		{
 			testName: "metadata.labels with numerical value",
@@ -332,7 +337,7 @@ metadata:
     number: "123456789"
 `,
 			},
-			expectObject: pointer(k8sobjects.UnstructuredAtPath(kinds.Namespace(), "namespaces/backend/namespace.yaml", core.Name("backend"), core.Label("number", "123456789"))),
+			expectObject: pointer(k8sobjects.UnstructuredAtPath(kinds.Namespace(), "namespaces/backend/namespace.yaml", core.Name("backend"), core.Label("number", "123456789"), sourceLine)),
 		},
 		{
 			testName: "parses nested List",
@@ -16,6 +16,7 @@ package reader
 
 import (
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
@@ -71,6 +72,36 @@ func isEmptyYAMLDocument(document string) bool {
 	return true
 }
 
+// setSourceLine annotates the object with the line and column of the first
+// line of the document which declares it. firstLine is the line of the file
+// where the document starts. Objects rendered by Kustomize are not annotated,
+// as their lines in the source are set by the renderer, and neither are
+// objects with invalid annotations, which are reported by the reader.
+func setSourceLine(u *unstructured.Unstructured, document string, firstLine int) {
+	annotations, found, err := unstructured.NestedFieldNoCopy(u.Object, "metadata", "annotations")
+	if err != nil {
+		return
+	}
+	if _, isMap := annotations.(map[string]interface{}); found && annotations != nil && !isMap {
+		return
+	}
+	if _, rendered := u.GetAnnotations()[metadata.KustomizeOrigin]; rendered {
+		return
+	}
+	for i, line := range strings.Split(document, "\n") {
+		trimmed := strings.TrimLeftFunc(line, unicode.IsSpace)
+		if len(trimmed) == 0 || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		column := len(line) - len(trimmed) + 1
+		// Set the field directly, as setting the annotations would drop the
+		// invalid values which are reported by the reader.
+		_ = unstructured.SetNestedField(u.Object, fmt.Sprintf("%d:%d", firstLine+i, column),
+			"metadata", "annotations", metadata.SourceLineAnnotationKey)
+		return
+	}
+}
+
 // parseYAMLFile parses a byte array as a YAML document stream.
 // Each document, if not empty, is decoded into an Unstructured object.
 //
@@ -96,7 +127,12 @@ func parseYAMLFile(contents []byte) ([]*unstructured.Unstructured, error) {
 	// Split on directive end markers.
 	// Prepend line break to ensure leading directive end markers are handled.
 	documents := strings.Split("\n"+string(contents), "\n---")
+	// line is the line of the file where the document starts, which is line 0
+	// for the prepended line break.
+	line := 0
 	for _, document := range documents {
+		firstLine := line
+		line += strings.Count(document, "\n") + 1
 		// Ignore empty documents
 		if isEmptyYAMLDocument(document) {
 			continue
@@ -107,6 +143,7 @@ func parseYAMLFile(contents []byte) ([]*unstructured.Unstructured, error) {
 		if err != nil {
 			return nil, err
 		}
+		setSourceLine(&u, document, firstLine)
 		result = append(result, &u)
 	}
 	return filterLocalConfigUnstructured(result), nil
@@ -126,6 +163,7 @@ func parseJSONFile(contents []byte) ([]*unstructured.Unstructured, error) {
 	if err != nil {
 		return nil, err
 	}
+	setSourceLine(&u, string(contents), 1)
 	return filterLocalConfigUnstructured([]*unstructured.Unstructured{&u}), nil
 }
 
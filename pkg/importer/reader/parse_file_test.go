@@ -24,8 +24,14 @@ import (
 	"kpt.dev/configsync/pkg/core"
 	"kpt.dev/configsync/pkg/core/k8sobjects"
 	"kpt.dev/configsync/pkg/kinds"
+	"kpt.dev/configsync/pkg/metadata"
 )
 
+// sourceLine sets the line and column recorded by the parser.
+func sourceLine(line string) core.MetaMutator {
+	return core.Annotation(metadata.SourceLineAnnotationKey, line)
+}
+
 func TestParseYAMLFile(t *testing.T) {
 	testCases := []struct {
 		name      string
@@ -78,7 +84,7 @@ metadata:
   name: shipping
 `,
 			expected: []*unstructured.Unstructured{
-				k8sobjects.UnstructuredObject(kinds.Namespace(), core.Name("shipping")),
+				k8sobjects.UnstructuredObject(kinds.Namespace(), core.Name("shipping"), sourceLine("1:1")),
 			},
 		}, {
 			name: "one document with triple-dash in a string",
@@ -90,7 +96,7 @@ metadata:
     "a": "---"
 `,
 			expected: []*unstructured.Unstructured{
-				k8sobjects.UnstructuredObject(kinds.Namespace(), core.Name("shipping"), core.Label("a", "---")),
+				k8sobjects.UnstructuredObject(kinds.Namespace(), core.Name("shipping"), core.Label("a", "---"), sourceLine("1:1")),
 			},
 		},
 		{
@@ -110,16 +116,18 @@ rules:
   verbs: [all]
 `,
 			expected: []*unstructured.Unstructured{
-				k8sobjects.UnstructuredObject(kinds.Namespace(), core.Name("shipping")),
+				k8sobjects.UnstructuredObject(kinds.Namespace(), core.Name("shipping"), sourceLine("1:1")),
 				{
 					Object: map[string]interface{}{
 						"apiVersion": "rbac/v1",
 						"kind":       "Role",
 						"metadata": map[string]interface{}{
-							"name":        "admin",
-							"namespace":   "shipping",
-							"labels":      make(map[string]interface{}),
-							"annotations": make(map[string]interface{}),
+							"name":      "admin",
+							"namespace": "shipping",
+							"labels":    make(map[string]interface{}),
+							"annotations": map[string]interface{}{
+								metadata.SourceLineAnnotationKey: "6:1",
+							},
 						},
 						"rules": []interface{}{
 							map[string]interface{}{
@@ -139,7 +147,7 @@ kind: Namespace
 metadata:
   name: foo`,
 			expected: []*unstructured.Unstructured{
-				k8sobjects.UnstructuredObject(kinds.Namespace(), core.Name("foo")),
+				k8sobjects.UnstructuredObject(kinds.Namespace(), core.Name("foo"), sourceLine("2:1")),
 			},
 		},
 		{
@@ -157,7 +165,7 @@ metadata:
 `,
 			expected: []*unstructured.Unstructured{
 				k8sobjects.UnstructuredObject(kinds.Namespace(), core.Name("foo"),
-					core.Label("a", "this --- is not a separator\n")),
+					core.Label("a", "this --- is not a separator\n"), sourceLine("2:1")),
 			},
 		},
 		{
@@ -176,8 +184,23 @@ metadata:
   name: bar
 `,
 			expected: []*unstructured.Unstructured{
-				k8sobjects.UnstructuredObject(kinds.Namespace(), core.Name("foo")),
-				k8sobjects.UnstructuredObject(kinds.Namespace(), core.Name("bar")),
+				k8sobjects.UnstructuredObject(kinds.Namespace(), core.Name("foo"), sourceLine("2:1")),
+				k8sobjects.UnstructuredObject(kinds.Namespace(), core.Name("bar"), sourceLine("9:1")),
+			},
+		},
+		{
+			name: "document after comments",
+			contents: `# comment
+
+---
+# comment
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: foo
+`,
+			expected: []*unstructured.Unstructured{
+				k8sobjects.UnstructuredObject(kinds.Namespace(), core.Name("foo"), sourceLine("5:1")),
 			},
 		},
 		{
@@ -255,6 +278,9 @@ func TestParseJsonFile(t *testing.T) {
 						"metadata": map[string]interface{}{
 							"name":      "admin",
 							"namespace": "shipping",
+							"annotations": map[string]interface{}{
+								metadata.SourceLineAnnotationKey: "1:1",
+							},
 						},
 						"rules": []interface{}{
 							map[string]interface{}{
@@ -296,6 +322,7 @@ func TestParseJsonFile(t *testing.T) {
 							"namespace": "shipping",
 							"annotations": map[string]interface{}{
 								"config.kubernetes.io/local-config": "false",
+								metadata.SourceLineAnnotationKey:    "1:1",
 							},
 						},
 						"rules": []interface{}{
@@ -238,6 +238,11 @@ func RootSyncV1Beta1() schema.GroupVersionKind {
 	return configsyncv1beta1.SchemeGroupVersion.WithKind(configsync.RootSyncKind)
 }
 
+// ConfigSyncInstallationV1Beta1 returns the v1beta1 ConfigSyncInstallation GroupVersionKind.
+func ConfigSyncInstallationV1Beta1() schema.GroupVersionKind {
+	return configsyncv1beta1.SchemeGroupVersion.WithKind(configsync.ConfigSyncInstallationKind)
+}
+
 // Service returns the canonical Service GroupVersionKind.
 func Service() schema.GroupVersionKind {
 	return corev1.SchemeGroupVersion.WithKind("Service")
@@ -262,3 +267,8 @@ func APIService() schema.GroupVersionKind {
 func ValidatingWebhookConfiguration() schema.GroupVersionKind {
 	return admissionv1.SchemeGroupVersion.WithKind("ValidatingWebhookConfiguration")
 }
+
+// MutatingWebhookConfiguration returns the MutatingWebhookConfiguration kind.
+func MutatingWebhookConfiguration() schema.GroupVersionKind {
+	return admissionv1.SchemeGroupVersion.WithKind("MutatingWebhookConfiguration")
+}
@@ -152,3 +152,18 @@ func runCommand(cmd *exec.Cmd) (int64, string, error) {
 	}
 	return executionTime, stdout.String(), nil
 }
+
+// RecordKustomizeBuild records the same measurements as RunKustomizeBuild, for
+// a build of inputDir which doesn't run the `kustomize` binary.
+// executionTime is the duration of the build in nanoseconds.
+func RecordKustomizeBuild(ctx context.Context, inputDir string, executionTime int64, resourceCount int) {
+	RecordKustomizeResourceCount(ctx, resourceCount)
+	RecordKustomizeExecutionTime(ctx, float64(executionTime))
+	kt, err := readKustomizeFile(inputDir)
+	if kt == nil || err != nil {
+		return
+	}
+	if fieldMetrics, err := kustomizeFieldUsage(kt, inputDir); err == nil && fieldMetrics != nil {
+		RecordKustomizeFieldCountData(ctx, fieldMetrics)
+	}
+}
@@ -80,4 +80,16 @@ var (
 		"kustomize_build_latency",
 		"Kustomize build latency",
 		stats.UnitMilliseconds)
+
+	// RenderingCacheLookups is the number of lookups of the rendering cache
+	RenderingCacheLookups = stats.Int64(
+		"rendering_cache_lookups",
+		"The number of lookups of the rendering cache, by result (hit or miss)",
+		stats.UnitDimensionless)
+
+	// RenderingCacheSize is the total size of the rendering cache entries
+	RenderingCacheSize = stats.Int64(
+		"rendering_cache_size_bytes",
+		"The total size of the rendering cache entries",
+		stats.UnitBytes)
 )
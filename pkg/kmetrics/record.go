@@ -33,6 +33,14 @@ var (
 	keyBaseCount, _              = tag.NewKey("base_source")
 	keyPatchCount, _             = tag.NewKey("patch_field")
 	keyTopTierCount, _           = tag.NewKey("top_tier_field")
+	keyCacheResult, _            = tag.NewKey("result")
+)
+
+const (
+	// CacheHit is the result of a rendering cache lookup which found the entry.
+	CacheHit = "hit"
+	// CacheMiss is the result of a rendering cache lookup which didn't find the entry.
+	CacheMiss = "miss"
 )
 
 // RecordKustomizeFieldCountData records all data relevant to the kustomization's field counts
@@ -66,6 +74,17 @@ func RecordKustomizeExecutionTime(ctx context.Context, executionTime float64) {
 	record(ctx, KustomizeExecutionTime.M(executionTime))
 }
 
+// RecordRenderingCacheLookup produces measurement for RenderingCacheLookups view
+func RecordRenderingCacheLookup(ctx context.Context, result string) {
+	tagCtx, _ := tag.New(ctx, tag.Upsert(keyCacheResult, result))
+	record(tagCtx, RenderingCacheLookups.M(1))
+}
+
+// RecordRenderingCacheSize produces measurement for RenderingCacheSize view
+func RecordRenderingCacheSize(ctx context.Context, bytes int64) {
+	record(ctx, RenderingCacheSize.M(bytes))
+}
+
 // recordKustomizeFieldCount produces measurement for KustomizeFieldCount view
 func recordKustomizeFieldCount(ctx context.Context, fieldCount map[string]int) {
 	for field, count := range fieldCount {
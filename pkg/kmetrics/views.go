@@ -109,6 +109,23 @@ var (
 		Description: "Execution time of `kustomize build`",
 		Aggregation: view.Distribution(0, 10, 20, 40, 80, 160, 320, 640, 1280, 2560, 5120, 10240),
 	}
+
+	// RenderingCacheLookupsView is the number of lookups of the rendering cache
+	RenderingCacheLookupsView = &view.View{
+		Name:        RenderingCacheLookups.Name(),
+		Measure:     RenderingCacheLookups,
+		Description: "The number of lookups of the rendering cache, by result (hit or miss)",
+		TagKeys:     []tag.Key{keyCacheResult},
+		Aggregation: view.Count(),
+	}
+
+	// RenderingCacheSizeView is the total size of the rendering cache entries
+	RenderingCacheSizeView = &view.View{
+		Name:        RenderingCacheSize.Name(),
+		Measure:     RenderingCacheSize,
+		Description: "The total size of the rendering cache entries",
+		Aggregation: view.LastValue(),
+	}
 )
 
 // RegisterKustomizeMetricsViews registers the views so that recorded metrics can be exported. .
@@ -126,3 +143,11 @@ func RegisterKustomizeMetricsViews() error {
 		KustomizeExecutionTimeView,
 	)
 }
+
+// RegisterRenderingCacheMetricsViews registers the views of the rendering cache metrics.
+func RegisterRenderingCacheMetricsViews() error {
+	return view.Register(
+		RenderingCacheLookupsView,
+		RenderingCacheSizeView,
+	)
+}
@@ -0,0 +1,74 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadata
+
+import (
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/core"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DriftPrevention is the type used to identify value enums to use with the
+// `configsync.gke.io/drift-prevention` annotation.
+type DriftPrevention string
+
+// String returns the string value of the DriftPrevention.
+// Implements the Stringer interface.
+func (p DriftPrevention) String() string {
+	return string(p)
+}
+
+const (
+	// DriftPreventionAnnotationKey is the annotation key set on RootSync,
+	// RepoSync, and Namespace objects to override whether the admission webhook
+	// prevents drift on the objects managed by the RootSync/RepoSync, or in the
+	// Namespace.
+	//
+	// By default, the admission webhook uses the cluster-wide default. An
+	// override on either the RootSync/RepoSync or the Namespace takes
+	// precedence over the default: when enabled by default, disabling it for
+	// either stops the protection; when disabled by default, enabling it for
+	// either protects the object.
+	//
+	// This annotation is set by Config Sync users.
+	DriftPreventionAnnotationKey = configsync.ConfigSyncPrefix + "drift-prevention"
+	// DriftPreventionEnabled indicates that the admission webhook should deny
+	// changes to the managed objects which would cause drift.
+	DriftPreventionEnabled DriftPrevention = "enabled"
+	// DriftPreventionDisabled indicates that the admission webhook should not
+	// intercept changes to the managed objects. Drift will still be reverted by
+	// the remediator.
+	DriftPreventionDisabled DriftPrevention = "disabled"
+)
+
+// DriftPreventionEnabledFor returns whether drift prevention is enabled for
+// the object, using the `configsync.gke.io/drift-prevention` annotation if set,
+// or the default otherwise.
+func DriftPreventionEnabledFor(obj client.Object, defaultEnabled bool) bool {
+	switch DriftPrevention(core.GetAnnotation(obj, DriftPreventionAnnotationKey)) {
+	case DriftPreventionEnabled:
+		return true
+	case DriftPreventionDisabled:
+		return false
+	default:
+		return defaultEnabled
+	}
+}
+
+// WithDriftPrevention returns a MetaMutator that sets the DriftPrevention
+// annotation on an Object.
+func WithDriftPrevention(p DriftPrevention) core.MetaMutator {
+	return core.Annotation(DriftPreventionAnnotationKey, p.String())
+}
@@ -0,0 +1,61 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadata
+
+import (
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/core"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// OwnershipTransfer is the type used to identify value enums to use with the
+// `configsync.gke.io/ownership-transfer` annotation.
+type OwnershipTransfer string
+
+// String returns the string value of the OwnershipTransfer.
+// Implements the Stringer interface.
+func (t OwnershipTransfer) String() string {
+	return string(t)
+}
+
+const (
+	// OwnershipTransferAnnotationKey is the annotation key set on objects in
+	// the source of truth of a RootSync to declare that the root reconciler
+	// should claim the object from the namespace reconciler that currently
+	// manages it.
+	//
+	// When a namespace reconciler finds that an object it declares has been
+	// claimed, it stops reporting a management conflict and removes the
+	// object from its ResourceGroup inventory, without pruning it.
+	//
+	// This annotation is set by Config Sync users on a managed resource.
+	OwnershipTransferAnnotationKey = configsync.ConfigSyncPrefix + "ownership-transfer"
+	// OwnershipTransferClaim is the value corresponding to
+	// OwnershipTransferAnnotationKey indicating that the reconciler with
+	// higher precedence should take over management of the object.
+	OwnershipTransferClaim OwnershipTransfer = "claim"
+)
+
+// WithOwnershipTransfer returns a MetaMutator that sets the ownership transfer
+// annotation on an Object.
+func WithOwnershipTransfer(t OwnershipTransfer) core.MetaMutator {
+	return core.Annotation(OwnershipTransferAnnotationKey, t.String())
+}
+
+// IsOwnershipClaimed returns true if the object has the annotation
+// `configsync.gke.io/ownership-transfer: claim`.
+func IsOwnershipClaimed(obj client.Object) bool {
+	return core.GetAnnotation(obj, OwnershipTransferAnnotationKey) == OwnershipTransferClaim.String()
+}
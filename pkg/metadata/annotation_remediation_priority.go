@@ -0,0 +1,55 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadata
+
+import (
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/core"
+)
+
+// RemediationPriority is the type used to identify value enums to use with the
+// `configsync.gke.io/remediation-priority` annotation.
+type RemediationPriority string
+
+// String returns the string value of the RemediationPriority.
+// Implements the Stringer interface.
+func (p RemediationPriority) String() string {
+	return string(p)
+}
+
+const (
+	// RemediationPriorityAnnotationKey is the annotation key set on objects in
+	// the source of truth to override the default priority with which the
+	// remediator reverts drift on the object.
+	//
+	// By default, the priority is derived from the object's GroupKind.
+	//
+	// This annotation is set by Config Sync users on a managed resource.
+	RemediationPriorityAnnotationKey = configsync.ConfigSyncPrefix + "remediation-priority"
+	// RemediationPriorityHigh indicates that drift on the object should be
+	// reverted before drift on objects with lower priority.
+	RemediationPriorityHigh RemediationPriority = "high"
+	// RemediationPriorityNormal is the priority of most objects.
+	RemediationPriorityNormal RemediationPriority = "normal"
+	// RemediationPriorityLow indicates that drift on the object should be
+	// reverted after drift on objects with higher priority.
+	RemediationPriorityLow RemediationPriority = "low"
+)
+
+// WithRemediationPriority returns a MetaMutator that sets the
+// RemediationPriority annotation on an Object.
+func WithRemediationPriority(p RemediationPriority) core.MetaMutator {
+	return core.Annotation(RemediationPriorityAnnotationKey, p.String())
+}
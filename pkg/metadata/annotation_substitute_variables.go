@@ -0,0 +1,45 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadata
+
+import (
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/core"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// SubstituteVariablesAnnotationKey is the annotation key set on objects in
+	// the source to substitute the `${var}` placeholders in their field values
+	// with the values of the cluster variables.
+	//
+	// This annotation is set by Config Sync users.
+	SubstituteVariablesAnnotationKey = configsync.ConfigSyncPrefix + "substitute-variables"
+	// SubstituteVariablesEnabled indicates that the placeholders in the object
+	// should be substituted.
+	SubstituteVariablesEnabled = "enabled"
+)
+
+// SubstituteVariablesEnabledFor returns whether the placeholders in the object
+// should be substituted.
+func SubstituteVariablesEnabledFor(obj client.Object) bool {
+	return core.GetAnnotation(obj, SubstituteVariablesAnnotationKey) == SubstituteVariablesEnabled
+}
+
+// WithSubstituteVariables returns a MetaMutator that enables the substitution
+// of the placeholders in an Object.
+func WithSubstituteVariables() core.MetaMutator {
+	return core.Annotation(SubstituteVariablesAnnotationKey, SubstituteVariablesEnabled)
+}
@@ -51,6 +51,25 @@ const (
 	// This annotation is set by Config Sync on a managed resource.
 	SourcePathAnnotationKey = ConfigManagementPrefix + "source-path"
 
+	// SourceLocationAnnotationKey is the annotation key representing the path,
+	// line and column where the object was originally declared, in the form
+	// <path>:<line>:<column>. Unlike SourcePathAnnotationKey, the path of a
+	// rendered object is the source file which declared it, rather than the
+	// rendered output. The line and column are omitted if they are unknown.
+	// This annotation is set by Config Sync on a parsed object, and removed
+	// before the object is applied.
+	SourceLocationAnnotationKey = ConfigManagementPrefix + "source-location"
+
+	// SourceChainAnnotationKey is the annotation key representing the rendering
+	// chain of a rendered object: the source file which declared it, followed by
+	// the files which configured the transformers that modified it, separated
+	// by " -> ". Kustomize only records the transformers if the kustomization
+	// opts in with `buildMetadata: [transformerAnnotations]`, so by default the
+	// chain only has the source file.
+	// This annotation is set by Config Sync on a parsed object, and removed
+	// before the object is applied.
+	SourceChainAnnotationKey = ConfigManagementPrefix + "source-chain"
+
 	// SyncTokenAnnotationKey is the annotation key representing the last version token that a Nomos-
 	// managed resource was successfully synced from.
 	// This annotation is set by Config Sync on a managed resource.
@@ -138,6 +157,15 @@ const (
 	// When the value is set to "disabled", the ResourceGroup controller
 	// ignores the ResourceGroup CR.
 	StatusModeAnnotationKey = configsync.ConfigSyncPrefix + "status"
+
+	// KeepOrphanAnnotationKey is the annotation key set on objects created by
+	// the reconciler-manager for a RootSync or RepoSync, to prevent the
+	// reconciler-manager from deleting the object after the RootSync or
+	// RepoSync is gone. Orphaned objects with this annotation set to "true"
+	// are still reported, but never deleted.
+	// This annotation is set by Config Sync users on a reconciler-manager
+	// managed resource.
+	KeepOrphanAnnotationKey = configsync.ConfigSyncPrefix + "keep-orphan"
 )
 
 // Lifecycle annotations
@@ -198,6 +226,16 @@ const AutoPilotAnnotation = "autopilot.gke.io/resource-adjustment"
 // KustomizeOrigin is the annotation generated by Kustomize to indicate the origin of the rendered resource.
 const KustomizeOrigin = "config.kubernetes.io/origin"
 
+// KustomizeTransformations is the annotation generated by Kustomize to list the
+// transformers which modified the rendered resource.
+const KustomizeTransformations = "alpha.config.kubernetes.io/transformations"
+
+// SourceLineAnnotationKey is the annotation which records the line and column
+// of an object in the file which declared it, in the form <line>:<column>.
+// It is set when the object is read or rendered, and replaced by
+// SourceLocationAnnotationKey when the object path is hydrated.
+const SourceLineAnnotationKey = "internal.configsync.gke.io/source-line"
+
 // FleetWorkloadIdentityCredentials is the key for the credentials file of the Fleet Workload Identity.
 const FleetWorkloadIdentityCredentials = "config.kubernetes.io/fleet-workload-identity"
 
@@ -61,6 +61,11 @@ const (
 	// the resource. Similar to the well known app.kubernetes.io/managed-by label,
 	// but scoped to Config Sync.
 	ConfigSyncManagedByLabel = configsync.ConfigSyncPrefix + "managed-by"
+
+	// ReconcilerPoolLabel indicates the name of the reconciler pool which owns
+	// the object.
+	// This label is set by Config Sync on the objects of a pooled reconciler.
+	ReconcilerPoolLabel = configsync.ConfigSyncPrefix + "reconciler-pool"
 )
 
 // DepthSuffix is a label suffix for hierarchical namespace depth.
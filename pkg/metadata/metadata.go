@@ -56,6 +56,10 @@ var sourceAnnotations = map[string]bool{
 	ManagementModeAnnotationKey:            true,
 	LifecycleMutationAnnotation:            true,
 	DeletionPropagationPolicyAnnotationKey: true,
+	OwnershipTransferAnnotationKey:         true,
+	RemediationPriorityAnnotationKey:       true,
+	DriftPreventionAnnotationKey:           true,
+	SubstituteVariablesAnnotationKey:       true,
 }
 
 // IsSourceAnnotation returns true if the annotation is a ConfigSync source
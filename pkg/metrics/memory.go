@@ -0,0 +1,55 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"context"
+	"runtime/metrics"
+	"sync"
+)
+
+// memoryTotalMetric is the runtime metric of all the memory mapped by the Go
+// runtime, which approximates the memory usage of the process.
+const memoryTotalMetric = "/memory/classes/total:bytes"
+
+var memoryHighWaterMark struct {
+	mux   sync.Mutex
+	bytes int64
+}
+
+// SampleMemoryHighWaterMark samples the memory usage of the process, records
+// the MemoryHighWaterMark metric, and returns the highest memory usage sampled
+// since the process started.
+//
+// The Go runtime releases memory to the OS lazily, so the samples approximate
+// the peaks between them.
+func SampleMemoryHighWaterMark(ctx context.Context) int64 {
+	sample := []metrics.Sample{{Name: memoryTotalMetric}}
+	metrics.Read(sample)
+	var current int64
+	if sample[0].Value.Kind() == metrics.KindUint64 {
+		current = int64(sample[0].Value.Uint64())
+	}
+
+	memoryHighWaterMark.mux.Lock()
+	if current > memoryHighWaterMark.bytes {
+		memoryHighWaterMark.bytes = current
+	}
+	highWaterMark := memoryHighWaterMark.bytes
+	memoryHighWaterMark.mux.Unlock()
+
+	RecordMemoryHighWaterMark(ctx, highWaterMark)
+	return highWaterMark
+}
@@ -45,6 +45,18 @@ const (
 	ResourceConflictsName = "resource_conflicts_total"
 	// InternalErrorsName is the name of internal error count metric
 	InternalErrorsName = "internal_errors_total"
+	// RemediatorQueueDepthName is the name of remediator queue depth metric
+	RemediatorQueueDepthName = "remediator_queue_depth"
+	// RemediatorQueueLatencyName is the name of remediator queue latency metric
+	RemediatorQueueLatencyName = "remediator_queue_latency_seconds"
+	// RemediatorWorkersName is the name of remediator worker count metric
+	RemediatorWorkersName = "remediator_workers"
+	// MemoryHighWaterMarkName is the name of memory high-water mark metric
+	MemoryHighWaterMarkName = "memory_high_water_mark_bytes"
+	// InstallationHealthyName is the name of installation health metric
+	InstallationHealthyName = "installation_healthy"
+	// OrphanedObjectsName is the name of orphaned objects metric
+	OrphanedObjectsName = "orphaned_objects"
 )
 
 var (
@@ -134,4 +146,43 @@ var (
 		InternalErrorsName,
 		"The number of internal errors triggered by Config Sync",
 		stats.UnitDimensionless)
+
+	// RemediatorQueueDepth metric measures the number of objects waiting in the remediator queue.
+	RemediatorQueueDepth = stats.Int64(
+		RemediatorQueueDepthName,
+		"The number of objects waiting to be remediated",
+		stats.UnitDimensionless)
+
+	// RemediatorQueueLatency metric measures how long objects wait in the remediator queue.
+	RemediatorQueueLatency = stats.Float64(
+		RemediatorQueueLatencyName,
+		"The duration objects wait to be remediated in seconds",
+		stats.UnitSeconds)
+
+	// RemediatorWorkers metric measures the number of running remediator workers.
+	RemediatorWorkers = stats.Int64(
+		RemediatorWorkersName,
+		"The number of concurrent remediator workers",
+		stats.UnitDimensionless)
+
+	// MemoryHighWaterMark metric measures the highest memory usage of the
+	// reconciler since it started.
+	MemoryHighWaterMark = stats.Int64(
+		MemoryHighWaterMarkName,
+		"The highest memory usage of the reconciler since it started",
+		stats.UnitBytes)
+
+	// InstallationHealthy metric measures whether the Config Sync installation
+	// is healthy.
+	InstallationHealthy = stats.Int64(
+		InstallationHealthyName,
+		"A boolean value indicates if the Config Sync installation is healthy",
+		stats.UnitDimensionless)
+
+	// OrphanedObjects metric measures the number of objects created by the
+	// reconciler-manager whose RootSync or RepoSync no longer exists.
+	OrphanedObjects = stats.Int64(
+		OrphanedObjectsName,
+		"The number of reconciler-manager managed objects whose RootSync or RepoSync no longer exists",
+		stats.UnitDimensionless)
 )
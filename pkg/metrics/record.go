@@ -154,6 +154,34 @@ func RecordRemediateDuration(ctx context.Context, status string, startTime time.
 	record(tagCtx, measurement)
 }
 
+// RecordRemediatorQueueDepth produces measurements for the RemediatorQueueDepth view.
+func RecordRemediatorQueueDepth(ctx context.Context, priority string, depth int) {
+	tagCtx, _ := tag.New(ctx,
+		tag.Upsert(KeyPriority, priority),
+	)
+	measurement := RemediatorQueueDepth.M(int64(depth))
+	record(tagCtx, measurement)
+}
+
+// RecordRemediatorQueueLatency produces measurements for the RemediatorQueueLatency view.
+func RecordRemediatorQueueLatency(ctx context.Context, priority string, latency time.Duration) {
+	tagCtx, _ := tag.New(ctx,
+		tag.Upsert(KeyPriority, priority),
+	)
+	measurement := RemediatorQueueLatency.M(latency.Seconds())
+	record(tagCtx, measurement)
+}
+
+// RecordRemediatorWorkers produces measurements for the RemediatorWorkers view.
+func RecordRemediatorWorkers(ctx context.Context, workers int) {
+	record(ctx, RemediatorWorkers.M(int64(workers)))
+}
+
+// RecordMemoryHighWaterMark produces measurements for the MemoryHighWaterMark view.
+func RecordMemoryHighWaterMark(ctx context.Context, bytes int64) {
+	record(ctx, MemoryHighWaterMark.M(bytes))
+}
+
 // RecordResourceConflict produces measurements for the ResourceConflicts view.
 func RecordResourceConflict(ctx context.Context, commit string) {
 	tagCtx, _ := tag.New(ctx,
@@ -170,3 +198,17 @@ func RecordInternalError(ctx context.Context, source string) {
 	measurement := InternalErrors.M(1)
 	record(tagCtx, measurement)
 }
+
+// RecordInstallationHealthy produces a measurement for the InstallationHealthy view.
+func RecordInstallationHealthy(ctx context.Context, healthy bool) {
+	if healthy {
+		record(ctx, InstallationHealthy.M(1))
+	} else {
+		record(ctx, InstallationHealthy.M(0))
+	}
+}
+
+// RecordOrphanedObjects produces a measurement for the OrphanedObjects view.
+func RecordOrphanedObjects(ctx context.Context, count int) {
+	record(ctx, OrphanedObjects.M(int64(count)))
+}
@@ -44,7 +44,7 @@ func RegisterOCAgentExporter(containerName string) (*ocagent.Exporter, error) {
 
 // RegisterReconcilerManagerMetricsViews registers the views so that recorded metrics can be exported in the reconciler manager.
 func RegisterReconcilerManagerMetricsViews() error {
-	return view.Register(ReconcileDurationView)
+	return view.Register(ReconcileDurationView, InstallationHealthyView, OrphanedObjectsView)
 }
 
 // RegisterReconcilerMetricsViews registers the views so that recorded metrics can be exported in the reconcilers.
@@ -63,5 +63,9 @@ func RegisterReconcilerMetricsViews() error {
 		ResourceConflictsView,
 		InternalErrorsView,
 		PipelineErrorView,
+		RemediatorQueueDepthView,
+		RemediatorQueueLatencyView,
+		RemediatorWorkersView,
+		MemoryHighWaterMarkView,
 	)
 }
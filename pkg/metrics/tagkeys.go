@@ -72,6 +72,9 @@ var (
 
 	// KeyResourceType groups metrics by their resource types. Possible values: cpu, memory.
 	KeyResourceType, _ = tag.NewKey("resource")
+
+	// KeyPriority groups metrics by the remediation priority of objects. Possible values: high, normal, low.
+	KeyPriority, _ = tag.NewKey("priority")
 )
 
 // The following metric tag keys are available from the otel-collector
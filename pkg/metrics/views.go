@@ -153,4 +153,54 @@ var (
 		TagKeys:     []tag.Key{KeyInternalErrorSource},
 		Aggregation: view.Count(),
 	}
+
+	// RemediatorQueueDepthView aggregates the RemediatorQueueDepth metric measurements.
+	RemediatorQueueDepthView = &view.View{
+		Name:        RemediatorQueueDepthName,
+		Measure:     RemediatorQueueDepth,
+		Description: "The current number of objects waiting to be remediated",
+		TagKeys:     []tag.Key{KeyPriority},
+		Aggregation: view.LastValue(),
+	}
+
+	// RemediatorQueueLatencyView aggregates the RemediatorQueueLatency metric measurements.
+	RemediatorQueueLatencyView = &view.View{
+		Name:        RemediatorQueueLatencyName,
+		Measure:     RemediatorQueueLatency,
+		Description: "The latency distribution of objects waiting to be remediated",
+		TagKeys:     []tag.Key{KeyPriority},
+		Aggregation: view.Distribution(distributionBounds...),
+	}
+
+	// RemediatorWorkersView aggregates the RemediatorWorkers metric measurements.
+	RemediatorWorkersView = &view.View{
+		Name:        RemediatorWorkersName,
+		Measure:     RemediatorWorkers,
+		Description: "The current number of concurrent remediator workers",
+		Aggregation: view.LastValue(),
+	}
+
+	// MemoryHighWaterMarkView aggregates the MemoryHighWaterMark metric measurements.
+	MemoryHighWaterMarkView = &view.View{
+		Name:        MemoryHighWaterMarkName,
+		Measure:     MemoryHighWaterMark,
+		Description: "The highest memory usage of the reconciler since it started",
+		Aggregation: view.LastValue(),
+	}
+
+	// InstallationHealthyView aggregates the InstallationHealthy metric measurements.
+	InstallationHealthyView = &view.View{
+		Name:        InstallationHealthyName,
+		Measure:     InstallationHealthy,
+		Description: "A boolean value indicates if the Config Sync installation is healthy",
+		Aggregation: view.LastValue(),
+	}
+
+	// OrphanedObjectsView aggregates the OrphanedObjects metric measurements.
+	OrphanedObjectsView = &view.View{
+		Name:        OrphanedObjectsName,
+		Measure:     OrphanedObjects,
+		Description: "The number of reconciler-manager managed objects whose RootSync or RepoSync no longer exists",
+		Aggregation: view.LastValue(),
+	}
 )
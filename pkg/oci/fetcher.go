@@ -138,6 +138,11 @@ func extract(image v1.Image, dir string) error {
 				klog.Warning(err)
 			}
 		default:
+			// Images built without directory entries, for example with
+			// `crane append`, only contain the files of nested directories.
+			if err := os.MkdirAll(filepath.Dir(path), os.FileMode(0755)); err != nil {
+				return err
+			}
 			file, err := os.OpenFile(path,
 				os.O_WRONLY|os.O_CREATE|os.O_TRUNC,
 				os.FileMode(hdr.Mode),
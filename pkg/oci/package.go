@@ -0,0 +1,127 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oci
+
+import (
+	"archive/tar"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"kpt.dev/configsync/pkg/api/configsync"
+)
+
+// SourceFormatAnnotationKey is the annotation key set on the manifest of the
+// images packaged by nomos, which records the source format of the package.
+const SourceFormatAnnotationKey = configsync.ConfigSyncPrefix + "source-format"
+
+// Package returns an OCI image with a single layer which contains the files
+// at their paths relative to the root directory, so the image can be synced
+// with the root directory as the sync directory. The annotations are set on
+// the manifest of the image.
+//
+// The files and their parent directories are written in lexical order with
+// zero timestamps, so packaging the same files returns the same digest.
+func Package(root string, files []string, annotations map[string]string) (v1.Image, error) {
+	data, err := tarFiles(root, files)
+	if err != nil {
+		return nil, err
+	}
+	layer, err := tarball.LayerFromOpener(func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(data)), nil
+	}, tarball.WithMediaType(types.OCILayer))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the image layer: %w", err)
+	}
+	image := mutate.MediaType(empty.Image, types.OCIManifestSchema1)
+	image = mutate.ConfigMediaType(image, types.OCIConfigJSON)
+	image, err = mutate.AppendLayers(image, layer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to append the image layer: %w", err)
+	}
+	return mutate.Annotations(image, annotations).(v1.Image), nil
+}
+
+// tarFiles returns the tar archive of the files, with their parent directories.
+func tarFiles(root string, files []string) ([]byte, error) {
+	names := make(map[string]string, len(files))
+	for _, file := range files {
+		rel, err := filepath.Rel(root, file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the path of %q relative to %q: %w", file, root, err)
+		}
+		names[filepath.ToSlash(rel)] = file
+	}
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	dirs := make(map[string]bool)
+	for _, name := range sorted {
+		if err := writeParentDirs(tw, path.Dir(name), dirs); err != nil {
+			return nil, err
+		}
+		content, err := os.ReadFile(names[name])
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %q: %w", names[name], err)
+		}
+		hdr := &tar.Header{
+			Typeflag: tar.TypeReg,
+			Name:     name,
+			Mode:     0644,
+			Size:     int64(len(content)),
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return nil, err
+		}
+		if _, err := tw.Write(content); err != nil {
+			return nil, err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// writeParentDirs writes the entries of the directory and its parents, which
+// aren't written yet.
+func writeParentDirs(tw *tar.Writer, dir string, written map[string]bool) error {
+	if dir == "." || written[dir] {
+		return nil
+	}
+	if err := writeParentDirs(tw, path.Dir(dir), written); err != nil {
+		return err
+	}
+	written[dir] = true
+	return tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeDir,
+		Name:     dir + "/",
+		Mode:     0755,
+	})
+}
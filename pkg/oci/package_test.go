@@ -0,0 +1,112 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oci
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackage(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"system/repo.yaml":                "kind: Repo\n",
+		"namespaces/hierarchyconfig.yaml": "kind: HierarchyConfig\n",
+		"namespaces/foo/namespace.yaml":   "kind: Namespace\n",
+		"cluster/clusterrole.yaml":        "kind: ClusterRole\n",
+	}
+	var paths []string
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+		paths = append(paths, path)
+	}
+	annotations := map[string]string{SourceFormatAnnotationKey: "hierarchy"}
+
+	image, err := Package(root, paths, annotations)
+	require.NoError(t, err)
+
+	manifest, err := image.Manifest()
+	require.NoError(t, err)
+	assert.Equal(t, types.OCIManifestSchema1, manifest.MediaType)
+	assert.Equal(t, types.OCIConfigJSON, manifest.Config.MediaType)
+	assert.Equal(t, annotations, manifest.Annotations)
+	require.Len(t, manifest.Layers, 1)
+	assert.Equal(t, types.OCILayer, manifest.Layers[0].MediaType)
+
+	// The digest doesn't depend on the order of the files or on the time.
+	reversed := make([]string, len(paths))
+	for i, path := range paths {
+		reversed[len(paths)-1-i] = path
+	}
+	other, err := Package(root, reversed, annotations)
+	require.NoError(t, err)
+	assert.Equal(t, digest(t, image), digest(t, other))
+
+	dir := t.TempDir()
+	require.NoError(t, extract(image, dir))
+	for name, content := range files {
+		got, err := os.ReadFile(filepath.Join(dir, name))
+		require.NoError(t, err)
+		assert.Equal(t, content, string(got))
+	}
+}
+
+func TestExtract_WithoutDirectoryEntries(t *testing.T) {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	content := []byte("kind: Namespace\n")
+	require.NoError(t, tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     "namespaces/foo/namespace.yaml",
+		Mode:     0644,
+		Size:     int64(len(content)),
+	}))
+	_, err := tw.Write(content)
+	require.NoError(t, err)
+	require.NoError(t, tw.Close())
+	layer, err := tarball.LayerFromOpener(func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(buf.Bytes())), nil
+	})
+	require.NoError(t, err)
+	image, err := mutate.AppendLayers(empty.Image, layer)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	require.NoError(t, extract(image, dir))
+	got, err := os.ReadFile(filepath.Join(dir, "namespaces/foo/namespace.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, content, got)
+}
+
+func digest(t *testing.T, image v1.Image) v1.Hash {
+	t.Helper()
+	d, err := image.Digest()
+	require.NoError(t, err)
+	return d
+}
@@ -20,6 +20,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/klog/v2"
+	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
 	"kpt.dev/configsync/pkg/core"
 	"kpt.dev/configsync/pkg/importer/analyzer/ast"
 	"kpt.dev/configsync/pkg/metadata"
@@ -127,6 +128,37 @@ func (p *parseResult) GKVs() []schema.GroupVersionKind {
 	return gvks
 }
 
+// DeclaredKinds returns the kinds of all the parsed objects, and the
+// namespaces they're declared in, sorted by group and kind.
+func (p *parseResult) DeclaredKinds() []v1beta1.DeclaredKind {
+	namespaces := make(map[schema.GroupKind]map[string]bool)
+	for _, o := range p.fileObjects() {
+		gk := o.GetObjectKind().GroupVersionKind().GroupKind()
+		if namespaces[gk] == nil {
+			namespaces[gk] = make(map[string]bool)
+		}
+		if ns := o.GetNamespace(); ns != "" {
+			namespaces[gk][ns] = true
+		}
+	}
+	kinds := make([]v1beta1.DeclaredKind, 0, len(namespaces))
+	for gk, nsSet := range namespaces {
+		kind := v1beta1.DeclaredKind{Group: gk.Group, Kind: gk.Kind}
+		for ns := range nsSet {
+			kind.Namespaces = append(kind.Namespaces, ns)
+		}
+		sort.Strings(kind.Namespaces)
+		kinds = append(kinds, kind)
+	}
+	sort.Slice(kinds, func(i, j int) bool {
+		if kinds[i].Group != kinds[j].Group {
+			return kinds[i].Group < kinds[j].Group
+		}
+		return kinds[i].Kind < kinds[j].Kind
+	})
+	return kinds
+}
+
 // fileObjects returns all the parsed objects.
 func (p *parseResult) fileObjects() []ast.FileObject {
 	objs := make([]ast.FileObject, 0, len(p.objsToApply)+len(p.objsSkipped))
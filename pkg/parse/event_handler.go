@@ -19,6 +19,7 @@ import (
 
 	"k8s.io/klog/v2"
 	"kpt.dev/configsync/pkg/parse/events"
+	"kpt.dev/configsync/pkg/reconciler/clusterlabelcontroller"
 	"kpt.dev/configsync/pkg/reconciler/namespacecontroller"
 	"kpt.dev/configsync/pkg/status"
 )
@@ -29,14 +30,17 @@ type EventHandler struct {
 	Context           context.Context
 	Reconciler        Reconciler
 	NSControllerState *namespacecontroller.State
+	// ClusterLabelsState is nil if the cluster labels aren't watched.
+	ClusterLabelsState *clusterlabelcontroller.State
 }
 
 // NewEventHandler builds an EventHandler
-func NewEventHandler(ctx context.Context, r Reconciler, nsControllerState *namespacecontroller.State) *EventHandler {
+func NewEventHandler(ctx context.Context, r Reconciler, nsControllerState *namespacecontroller.State, clusterLabelsState *clusterlabelcontroller.State) *EventHandler {
 	return &EventHandler{
-		Context:           ctx,
-		Reconciler:        r,
-		NSControllerState: nsControllerState,
+		Context:            ctx,
+		Reconciler:         r,
+		NSControllerState:  nsControllerState,
+		ClusterLabelsState: clusterLabelsState,
 	}
 }
 
@@ -45,6 +49,7 @@ func NewEventHandler(ctx context.Context, r Reconciler, nsControllerState *names
 // - SyncEventType          - Sync from the cache, priming the cache from disk, if necessary.
 // - StatusUpdateEventType  - Update the RSync status with status from the Remediator & NSController.
 // - NamespaceSyncEventType - Sync from the cache, if the NSController requested one.
+// - ClusterLabelSyncEventType - Sync from the cache, if the cluster labels, variables or ValidationPolicies changed.
 // - RetrySyncEventType     - Sync from the cache, if one of the following cases is detected:
 //   - Remediator or Reconciler reported a management conflict
 //   - Reconciler requested a retry due to error
@@ -86,6 +91,15 @@ func (s *EventHandler) Handle(event events.Event) events.Result {
 		}
 		runResult = runFn(ctx, triggerNamespaceUpdate)
 
+	case events.ClusterLabelSyncEventType:
+		// FullSync if the cluster label controller detected a change of the
+		// cluster labels or of the other ConfigMaps read by the parser.
+		if s.ClusterLabelsState == nil || !s.ClusterLabelsState.ScheduleSync() {
+			// No RunFunc call
+			break
+		}
+		runResult = runFn(ctx, triggerClusterLabelUpdate)
+
 	case events.RetrySyncEventType:
 		// Retry if there was an error, conflict, or any watches need to be updated.
 		var trigger string
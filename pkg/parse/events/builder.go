@@ -38,6 +38,9 @@ type PublishingGroupBuilder struct {
 	// the namespace-controller wants to trigger a resync.
 	// TODO: Use a channel, instead of a timer checking a locked variable.
 	NamespaceControllerPeriod time.Duration
+	// ClusterLabelControllerPeriod is how long to wait between checks to see
+	// if the cluster-label-controller wants to trigger a resync.
+	ClusterLabelControllerPeriod time.Duration
 	// RetryBackoff is how long the Parser waits between retries, after an error.
 	RetryBackoff wait.Backoff
 }
@@ -53,6 +56,9 @@ func (t *PublishingGroupBuilder) Build() []Publisher {
 	if t.NamespaceControllerPeriod > 0 {
 		publishers = append(publishers, NewTimeDelayPublisher(NamespaceSyncEventType, t.Clock, t.NamespaceControllerPeriod))
 	}
+	if t.ClusterLabelControllerPeriod > 0 {
+		publishers = append(publishers, NewTimeDelayPublisher(ClusterLabelSyncEventType, t.Clock, t.ClusterLabelControllerPeriod))
+	}
 	if t.RetryBackoff.Duration > 0 {
 		publishers = append(publishers, NewRetrySyncPublisher(t.Clock, t.RetryBackoff))
 	}
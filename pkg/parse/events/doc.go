@@ -24,6 +24,7 @@
 // Events and their Publisher:
 // - SyncEvent             - ResetOnRunAttemptPublisher (SyncPeriod)
 // - NamespaceResyncEvent  - TimeDelayPublisher (NamespaceControllerPeriod)
+// - ClusterLabelSyncEvent - TimeDelayPublisher (ClusterLabelControllerPeriod)
 // - RetrySyncEvent        - RetrySyncPublisher (RetryBackoff)
 // - StatusEvent           - TimeDelayPublisher (StatusUpdatePeriod)
 //
@@ -33,6 +33,10 @@ const (
 	// NamespaceSyncEventType is the EventType for a sync triggered by an
 	// update to a selected namespace.
 	NamespaceSyncEventType EventType = "NamespaceSyncEvent"
+	// ClusterLabelSyncEventType is the EventType for a sync triggered by an
+	// update to the cluster labels, or to the other ConfigMaps read by the
+	// parser.
+	ClusterLabelSyncEventType EventType = "ClusterLabelSyncEvent"
 	// RetrySyncEventType is the EventType for a sync triggered by an error
 	// during a previous sync attempt.
 	RetrySyncEventType EventType = "RetrySyncEvent"
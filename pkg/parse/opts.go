@@ -18,9 +18,12 @@ import (
 	"time"
 
 	"k8s.io/utils/clock"
+	"kpt.dev/configsync/pkg/api/configsync"
 	"kpt.dev/configsync/pkg/declared"
 	"kpt.dev/configsync/pkg/importer/filesystem"
+	"kpt.dev/configsync/pkg/reconciler/clusterlabelcontroller"
 	"kpt.dev/configsync/pkg/util/discovery"
+	"kpt.dev/configsync/pkg/validate/openapi"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -41,6 +44,10 @@ type Options struct {
 	// ClusterName is the name of the cluster we're syncing configuration to.
 	ClusterName string
 
+	// ClusterLabelsState caches the cluster labels read by the cluster label
+	// controller, which are matched by ClusterSelectors.
+	ClusterLabelsState *clusterlabelcontroller.State
+
 	// Client knows how to read objects from a Kubernetes cluster and update
 	// status.
 	Client client.Client
@@ -63,6 +70,11 @@ type Options struct {
 	// objects in Git.
 	Converter *declared.ValueConverter
 
+	// Schemas are the OpenAPI schemas of the resources served by the cluster,
+	// which the declared objects are validated against before they are
+	// applied.
+	Schemas openapi.Resources
+
 	// WebhookEnabled indicates whether the Webhook is currently enabled
 	WebhookEnabled bool
 
@@ -93,4 +105,18 @@ type ReconcilerOptions struct {
 	// RenderingEnabled indicates whether the hydration-controller is currently
 	// running for this reconciler.
 	RenderingEnabled bool
+
+	// RenderingEngine is the engine used to render the source configs. If
+	// empty, the engine is detected from the source files.
+	RenderingEngine configsync.RenderingEngine
+
+	// ResourceUsageReporting indicates whether to report the declared resource
+	// count and the memory high-water mark in the sync status.
+	ResourceUsageReporting bool
+
+	// MinimalRBAC indicates whether the reconciler-manager generates the
+	// permissions of the reconciler from the declared kinds. If true, the
+	// declared kinds are reported in the source status, and each commit is
+	// only applied after the reconciler has been granted access to them.
+	MinimalRBAC bool
 }
@@ -250,6 +250,12 @@ func (p *repoSyncStatusClient) GetReconcilerStatus(ctx context.Context) (*Reconc
 	return reconcilerStatusFromRSyncStatus(rs.Status.Status, opts.SourceType, syncing), nil
 }
 
+// GetRBACCommit always returns an empty commit, because the RepoSync
+// reconciler permissions are managed by the namespace admin.
+func (p *repoSyncStatusClient) GetRBACCommit(_ context.Context) (string, status.Error) {
+	return "", nil
+}
+
 // SetSyncStatus implements the Parser interface
 // SetSyncStatus sets the RepoSync sync status.
 // `errs` includes the errors encountered during the apply step;
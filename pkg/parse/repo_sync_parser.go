@@ -24,6 +24,8 @@ import (
 	"kpt.dev/configsync/pkg/status"
 	utildiscovery "kpt.dev/configsync/pkg/util/discovery"
 	"kpt.dev/configsync/pkg/validate"
+	"kpt.dev/configsync/pkg/validate/policy"
+	"kpt.dev/configsync/pkg/validate/variables"
 )
 
 type repoSyncParser struct {
@@ -57,6 +59,7 @@ func (p *repoSyncParser) ParseSource(ctx context.Context, state *sourceState) ([
 		PreviousCRDs: crds,
 		BuildScoper:  builder,
 		Converter:    opts.Converter,
+		Schemas:      opts.Schemas,
 		Scheme:       opts.Client.Scheme(),
 		// Namespaces and NamespaceSelectors should not be declared in a namespace repo.
 		// So disable the API call and dynamic mode of NamespaceSelector.
@@ -67,6 +70,26 @@ func (p *repoSyncParser) ParseSource(ctx context.Context, state *sourceState) ([
 	}
 	options = OptionsForScope(options, opts.Scope)
 
+	// Substitute the variables declared in the cluster.
+	options.Variables, err = variables.FromCluster(ctx, opts.Client)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.ClusterLabelsState != nil {
+		options.ClusterLabels, err = opts.ClusterLabelsState.Labels()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Enforce the ValidationPolicies declared in the cluster, in addition to
+	// the ones declared in the source.
+	options.ValidationPolicies, err = policy.FromCluster(ctx, opts.Client)
+	if err != nil {
+		return nil, err
+	}
+
 	objs, err = validate.Unstructured(ctx, opts.Client, objs, options)
 
 	if status.HasBlockingErrors(err) {
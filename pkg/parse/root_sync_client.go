@@ -33,6 +33,7 @@ import (
 	"kpt.dev/configsync/pkg/metadata"
 	"kpt.dev/configsync/pkg/metrics"
 	"kpt.dev/configsync/pkg/reconciler/namespacecontroller"
+	"kpt.dev/configsync/pkg/remediator/conflict"
 	"kpt.dev/configsync/pkg/rootsync"
 	"kpt.dev/configsync/pkg/status"
 	"kpt.dev/configsync/pkg/util/compare"
@@ -174,6 +175,9 @@ func setSourceStatusFields(source *v1beta1.SourceStatus, newStatus *SourceStatus
 	source.Errors = cse[0 : len(cse)/denominator]
 	source.ErrorSummary = errorSummary
 	source.LastUpdate = newStatus.LastUpdate
+	if newStatus.DeclaredKinds != nil {
+		source.DeclaredKinds = newStatus.DeclaredKinds.DeepCopy()
+	}
 }
 
 func (p *rootSyncStatusClient) SetImageToSyncAnnotation(ctx context.Context, commit string) status.Error {
@@ -435,8 +439,9 @@ func reconcilerStatusFromRSyncStatus(rsyncStatus v1beta1.Status, sourceType conf
 			Commit: rsyncStatus.Source.Commit,
 			// Can't parse errors.
 			// Errors will be reset the next time the reconciler updates the status.
-			Errs:       nil,
-			LastUpdate: rsyncStatus.Source.LastUpdate,
+			Errs:          nil,
+			LastUpdate:    rsyncStatus.Source.LastUpdate,
+			DeclaredKinds: rsyncStatus.Source.DeclaredKinds.DeepCopy(),
 		},
 		RenderingStatus: &RenderingStatus{
 			Spec:    renderSpec,
@@ -456,12 +461,27 @@ func reconcilerStatusFromRSyncStatus(rsyncStatus v1beta1.Status, sourceType conf
 			Commit:  rsyncStatus.Sync.Commit,
 			// Can't parse errors.
 			// Errors will be reset the next time the reconciler updates the status.
-			Errs:       nil,
-			LastUpdate: rsyncStatus.Sync.LastUpdate,
+			Errs:          nil,
+			LastUpdate:    rsyncStatus.Sync.LastUpdate,
+			ResourceUsage: rsyncStatus.Sync.ResourceUsage.DeepCopy(),
 		},
 	}
 }
 
+// GetRBACCommit gets the most recent commit whose declared kinds the RootSync
+// reconciler has been granted access to.
+func (p *rootSyncStatusClient) GetRBACCommit(ctx context.Context) (string, status.Error) {
+	opts := p.options
+	rs := &v1beta1.RootSync{}
+	if err := opts.Client.Get(ctx, rootsync.ObjectKey(opts.SyncName), rs); err != nil {
+		return "", status.APIServerError(err, "failed to get RootSync")
+	}
+	if rs.Status.RBAC == nil {
+		return "", nil
+	}
+	return rs.Status.RBAC.Commit, nil
+}
+
 // SetSyncStatus implements the Parser interface
 // SetSyncStatus sets the RootSync sync status.
 // `errs` includes the errors encountered during the apply step;
@@ -545,9 +565,34 @@ func setSyncStatusFields(syncStatus *v1beta1.Status, newStatus *SyncStatus, deno
 	syncStatus.Sync.Oci = syncStatus.Source.Oci
 	syncStatus.Sync.Helm = syncStatus.Source.Helm
 	setSyncStatusErrors(syncStatus, cse, denominator)
+	syncStatus.Sync.OwnershipTransfers = toOwnershipTransfers(newStatus.OwnershipTransfers)
+	syncStatus.Sync.ResourceUsage = newStatus.ResourceUsage.DeepCopy()
 	syncStatus.Sync.LastUpdate = newStatus.LastUpdate
 }
 
+// toOwnershipTransfers converts the ownership transfers recorded by the
+// conflict handler into the RSync status format.
+func toOwnershipTransfers(transfers []conflict.OwnershipTransfer) []v1beta1.OwnershipTransfer {
+	if len(transfers) == 0 {
+		return nil
+	}
+	result := make([]v1beta1.OwnershipTransfer, len(transfers))
+	for i, transfer := range transfers {
+		result[i] = v1beta1.OwnershipTransfer{
+			Resource: v1beta1.ResourceRef{
+				Name:      transfer.ID.Name,
+				Namespace: transfer.ID.Namespace,
+				GVK: metav1.GroupVersionKind{
+					Group: transfer.ID.Group,
+					Kind:  transfer.ID.Kind,
+				},
+			},
+			Manager: transfer.Manager,
+		}
+	}
+	return result
+}
+
 func setSyncStatusErrors(syncStatus *v1beta1.Status, cse []v1beta1.ConfigSyncError, denominator int) {
 	syncStatus.Sync.ErrorSummary = &v1beta1.ErrorSummary{
 		TotalCount:                len(cse),
@@ -35,9 +35,15 @@ import (
 	"kpt.dev/configsync/pkg/status"
 	"kpt.dev/configsync/pkg/util/discovery"
 	"kpt.dev/configsync/pkg/validate"
+	"kpt.dev/configsync/pkg/validate/policy"
+	"kpt.dev/configsync/pkg/validate/variables"
 	"sigs.k8s.io/cli-utils/pkg/common"
 )
 
+// helmTemplatesDir is the directory of a rendered Helm chart which contains
+// the rendered templates.
+var helmTemplatesDir = cmpath.RelativeSlash("templates")
+
 type rootSyncParser struct {
 	options *RootOptions
 }
@@ -47,15 +53,23 @@ func (p *rootSyncParser) ParseSource(ctx context.Context, state *sourceState) ([
 	opts := p.options
 
 	wantFiles := state.files
+	rootDir := state.syncPath
+	policyDir := p.options.SyncDir
 	if opts.SourceFormat == configsync.SourceFormatHierarchy {
+		if opts.SourceType == configsync.HelmSource {
+			// `helm template` renders the templates of the chart, including the
+			// hierarchy directories, to the templates directory of the chart.
+			rootDir = rootDir.Join(helmTemplatesDir)
+			policyDir = policyDir.Join(helmTemplatesDir)
+		}
 		// We're using hierarchical mode for the root repository, so ignore files
 		// outside of the allowed directories.
-		wantFiles = filesystem.FilterHierarchyFiles(state.syncPath, wantFiles)
+		wantFiles = filesystem.FilterHierarchyFiles(rootDir, wantFiles)
 	}
 
 	filePaths := reader.FilePaths{
-		RootDir:   state.syncPath,
-		PolicyDir: p.options.SyncDir,
+		RootDir:   rootDir,
+		PolicyDir: policyDir,
 		Files:     wantFiles,
 	}
 
@@ -78,6 +92,7 @@ func (p *rootSyncParser) ParseSource(ctx context.Context, state *sourceState) ([
 		PreviousCRDs: crds,
 		BuildScoper:  builder,
 		Converter:    opts.Converter,
+		Schemas:      opts.Schemas,
 		Scheme:       opts.Client.Scheme(),
 		// Enable API call so NamespaceSelector can talk to k8s-api-server.
 		AllowAPICall:             true,
@@ -88,6 +103,26 @@ func (p *rootSyncParser) ParseSource(ctx context.Context, state *sourceState) ([
 	}
 	options = OptionsForScope(options, opts.Scope)
 
+	// Substitute the variables declared in the cluster.
+	options.Variables, err = variables.FromCluster(ctx, opts.Client)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.ClusterLabelsState != nil {
+		options.ClusterLabels, err = opts.ClusterLabelsState.Labels()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Enforce the ValidationPolicies declared in the cluster, in addition to
+	// the ones declared in the source.
+	options.ValidationPolicies, err = policy.FromCluster(ctx, opts.Client)
+	if err != nil {
+		return nil, err
+	}
+
 	if opts.SourceFormat == configsync.SourceFormatUnstructured {
 		if opts.NamespaceStrategy == configsync.NamespaceStrategyImplicit {
 			options.Visitors = append(options.Visitors, p.addImplicitNamespaces)
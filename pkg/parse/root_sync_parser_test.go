@@ -0,0 +1,124 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parse
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/core/k8sobjects"
+	"kpt.dev/configsync/pkg/declared"
+	"kpt.dev/configsync/pkg/importer/analyzer/ast"
+	"kpt.dev/configsync/pkg/importer/filesystem/cmpath"
+	fsfake "kpt.dev/configsync/pkg/importer/filesystem/fake"
+	"kpt.dev/configsync/pkg/importer/reader"
+	"kpt.dev/configsync/pkg/kinds"
+	syncertest "kpt.dev/configsync/pkg/syncer/syncertest/fake"
+	"sigs.k8s.io/cli-utils/pkg/testutil"
+)
+
+func TestRootSyncParser_HierarchyFiles(t *testing.T) {
+	testCases := []struct {
+		name              string
+		sourceType        configsync.SourceType
+		syncDir           cmpath.Relative
+		syncPath          cmpath.Absolute
+		files             []cmpath.Absolute
+		expectedFilePaths reader.FilePaths
+	}{
+		{
+			name:     "OCI image",
+			syncDir:  "acme",
+			syncPath: "/repo/source/acme",
+			files: []cmpath.Absolute{
+				"/repo/source/acme/README.md",
+				"/repo/source/acme/cluster/clusterrole.yaml",
+				"/repo/source/acme/namespaces/foo/namespace.yaml",
+				"/repo/source/acme/system/repo.yaml",
+			},
+			sourceType: configsync.OciSource,
+			expectedFilePaths: reader.FilePaths{
+				RootDir:   "/repo/source/acme",
+				PolicyDir: "acme",
+				Files: []cmpath.Absolute{
+					"/repo/source/acme/cluster/clusterrole.yaml",
+					"/repo/source/acme/namespaces/foo/namespace.yaml",
+					"/repo/source/acme/system/repo.yaml",
+				},
+			},
+		},
+		{
+			name:     "Helm chart",
+			syncDir:  "acme",
+			syncPath: "/repo/source/acme",
+			files: []cmpath.Absolute{
+				"/repo/source/acme/charts/sub/templates/namespaces/bar/namespace.yaml",
+				"/repo/source/acme/templates/cluster/clusterrole.yaml",
+				"/repo/source/acme/templates/namespaces/foo/namespace.yaml",
+				"/repo/source/acme/templates/service.yaml",
+			},
+			sourceType: configsync.HelmSource,
+			expectedFilePaths: reader.FilePaths{
+				RootDir:   "/repo/source/acme/templates",
+				PolicyDir: "acme/templates",
+				Files: []cmpath.Absolute{
+					"/repo/source/acme/templates/cluster/clusterrole.yaml",
+					"/repo/source/acme/templates/namespaces/foo/namespace.yaml",
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeConfigParser := &fsfake.ConfigParser{
+				Outputs: []fsfake.ParserOutputs{
+					{FileObjects: []ast.FileObject{k8sobjects.Repo()}},
+				},
+			}
+			parser := &rootSyncParser{
+				options: &RootOptions{
+					Options: &Options{
+						ConfigParser:      fakeConfigParser,
+						SyncName:          rootSyncName,
+						Scope:             declared.RootScope,
+						Client:            syncertest.NewClient(t, core.Scheme),
+						DiscoveryClient:   syncertest.NewDiscoveryClient(kinds.Namespace(), kinds.ClusterRole()),
+						DeclaredResources: &declared.Resources{},
+						Files: Files{FileSource: FileSource{
+							SourceType: tc.sourceType,
+							SyncDir:    tc.syncDir,
+						}},
+					},
+					SourceFormat: configsync.SourceFormatHierarchy,
+				},
+			}
+			state := &sourceState{
+				syncPath: tc.syncPath,
+				files:    tc.files,
+			}
+
+			_, errs := parser.ParseSource(context.Background(), state)
+			require.NoError(t, errs)
+			expectedParseInputs := []fsfake.ParserInputs{
+				{FilePaths: tc.expectedFilePaths},
+			}
+			testutil.AssertEqual(t, expectedParseInputs, fakeConfigParser.Inputs, "unexpected Parser.Parse call inputs")
+		})
+	}
+}
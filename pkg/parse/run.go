@@ -21,10 +21,12 @@ import (
 	"os"
 	"path"
 
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/klog/v2"
 	"k8s.io/utils/clock"
 	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
 	"kpt.dev/configsync/pkg/core"
 	"kpt.dev/configsync/pkg/hydrate"
 	"kpt.dev/configsync/pkg/importer/analyzer/ast"
@@ -52,6 +54,7 @@ const (
 	triggerManagementConflict = "managementConflict"
 	triggerWatchUpdate        = "watchUpdate"
 	triggerNamespaceUpdate    = "namespaceEvent"
+	triggerClusterLabelUpdate = "clusterLabelEvent"
 )
 
 const (
@@ -121,7 +124,7 @@ func (r *reconciler) Reconcile(ctx context.Context, trigger string) ReconcileRes
 	klog.Infof("Starting sync attempt (trigger: %s)", trigger)
 
 	switch trigger {
-	case triggerFullSync, triggerManagementConflict, triggerNamespaceUpdate:
+	case triggerFullSync, triggerManagementConflict, triggerNamespaceUpdate, triggerClusterLabelUpdate:
 		// Force parsing and updating, but skip fetch, render, and read unless required.
 		state.RecordFullSyncStart(startTime)
 	}
@@ -175,6 +178,13 @@ func (r *reconciler) Reconcile(ctx context.Context, trigger string) ReconcileRes
 		return result
 	}
 
+	if opts.MinimalRBAC {
+		if err := r.checkPermissionsGranted(ctx); err != nil {
+			state.RecordFailure(opts.Clock, err)
+			return result
+		}
+	}
+
 	if opts.WebhookEnabled {
 		err := webhookconfiguration.Update(ctx, opts.Client, opts.DiscoveryClient,
 			state.cache.parse.GKVs(), client.FieldOwner(configsync.FieldManager))
@@ -427,18 +437,13 @@ func (r *reconciler) readFromSource(ctx context.Context, trigger string, srcStat
 	// Read all the files under srcState.syncPath
 	newSourceStatus.Errs = opts.readConfigFiles(srcState)
 
-	if !opts.RenderingEnabled {
-		// Check if any kustomization Files exist
-		for _, fi := range srcState.files {
-			if hydrate.HasKustomization(path.Base(fi.OSPath())) {
-				// Source of truth requires hydration, but the hydration-controller is not running
-				newRenderStatus.Message = RenderingRequired
-				newRenderStatus.RequiresRendering = true
-				err := hydrate.NewTransientError(fmt.Errorf("sync source contains dry configs and hydration-controller is not running"))
-				newRenderStatus.Errs = status.HydrationError(err.Code(), err)
-				return newRenderStatus, newSourceStatus
-			}
-		}
+	if !opts.RenderingEnabled && needsRendering(srcState, opts.RenderingEngine) {
+		// Source of truth requires hydration, but the hydration-controller is not running
+		newRenderStatus.Message = RenderingRequired
+		newRenderStatus.RequiresRendering = true
+		err := hydrate.NewTransientError(fmt.Errorf("sync source contains dry configs and hydration-controller is not running"))
+		newRenderStatus.Errs = status.HydrationError(err.Code(), err)
+		return newRenderStatus, newSourceStatus
 	}
 
 	if newSourceStatus.Errs != nil {
@@ -450,6 +455,22 @@ func (r *reconciler) readFromSource(ctx context.Context, trigger string, srcStat
 	return newRenderStatus, newSourceStatus
 }
 
+// needsRendering checks if a rendering engine is configured, if any
+// kustomization file exists in the source, or if the sync directory contains
+// the marker files of another rendering engine.
+func needsRendering(srcState *sourceState, engine configsync.RenderingEngine) bool {
+	for _, fi := range srcState.files {
+		if hydrate.HasKustomization(path.Base(fi.OSPath())) {
+			return true
+		}
+	}
+	renderer, err := hydrate.NewRenderer(engine, srcState.syncPath.OSPath(), hydrate.RendererOptions{})
+	if err != nil {
+		klog.Warningf("Unable to check if rendering is needed for %s: %v", srcState.syncPath.OSPath(), err)
+	}
+	return renderer != nil
+}
+
 // parse objects from the source files and perform scope validation.
 // If any objects have unknown scope, parse will return non-blocking errors.
 func (r *reconciler) parse(ctx context.Context, trigger string) status.MultiError {
@@ -484,6 +505,15 @@ func (r *reconciler) parse(ctx context.Context, trigger string) status.MultiErro
 		Errs:       parseErrs,
 		LastUpdate: nowMeta(opts.Clock),
 	}
+	if opts.MinimalRBAC && !status.HasBlockingErrors(parseErrs) {
+		newSourceStatus.DeclaredKinds = &v1beta1.DeclaredKinds{
+			Commit: state.cache.source.commit,
+			Kinds:  state.cache.parse.DeclaredKinds(),
+		}
+	} else if state.status.SourceStatus != nil {
+		// Keep the kinds of the last successfully parsed commit.
+		newSourceStatus.DeclaredKinds = state.status.SourceStatus.DeclaredKinds
+	}
 	if state.status.needToSetSourceStatus(newSourceStatus) {
 		klog.V(3).Info("Updating source status (after parse)")
 		if statusErr := r.syncStatusClient.SetSourceStatus(ctx, newSourceStatus); statusErr != nil {
@@ -494,6 +524,21 @@ func (r *reconciler) parse(ctx context.Context, trigger string) status.MultiErro
 	return parseErrs
 }
 
+// checkPermissionsGranted returns a transient error until the
+// reconciler-manager has granted the reconciler access to the kinds declared
+// in the parsed commit, so the commit isn't applied with missing permissions.
+func (r *reconciler) checkPermissionsGranted(ctx context.Context) status.Error {
+	commit := r.ReconcilerState().cache.source.commit
+	rbacCommit, err := r.syncStatusClient.GetRBACCommit(ctx)
+	if err != nil {
+		return err
+	}
+	if rbacCommit != commit {
+		return status.TransientError(fmt.Errorf("waiting for the reconciler-manager to grant the permissions required by commit %q", commit))
+	}
+	return nil
+}
+
 // update syncs the objects with known scope to the cluster.
 func (r *reconciler) update(ctx context.Context, trigger string) status.MultiError {
 	opts := r.Options()
@@ -519,11 +564,13 @@ func (r *reconciler) update(ctx context.Context, trigger string) status.MultiErr
 
 	// Copy the spec and commit from the source status
 	syncStatus := &SyncStatus{
-		Spec:       state.status.SourceStatus.Spec,
-		Syncing:    false,
-		Commit:     state.cache.source.commit,
-		Errs:       syncErrs,
-		LastUpdate: nowMeta(opts.Clock),
+		Spec:               state.status.SourceStatus.Spec,
+		Syncing:            false,
+		Commit:             state.cache.source.commit,
+		Errs:               syncErrs,
+		LastUpdate:         nowMeta(opts.Clock),
+		OwnershipTransfers: state.OwnershipTransfers(),
+		ResourceUsage:      r.resourceUsage(ctx),
 	}
 	if statusErr := r.setSyncStatus(ctx, syncStatus); statusErr != nil {
 		return status.Append(syncErrs, statusErr)
@@ -570,11 +617,13 @@ func (r *reconciler) startAsyncStatusUpdates(ctx context.Context) <-chan struct{
 				klog.V(3).Info("Updating sync status (periodic while syncing)")
 				// Copy the spec and commit from the source status
 				syncStatus := &SyncStatus{
-					Spec:       state.status.SourceStatus.Spec,
-					Syncing:    true,
-					Commit:     state.cache.source.commit,
-					Errs:       state.SyncErrors(),
-					LastUpdate: nowMeta(opts.Clock),
+					Spec:               state.status.SourceStatus.Spec,
+					Syncing:            true,
+					Commit:             state.cache.source.commit,
+					Errs:               state.SyncErrors(),
+					LastUpdate:         nowMeta(opts.Clock),
+					OwnershipTransfers: state.OwnershipTransfers(),
+					ResourceUsage:      r.resourceUsage(ctx),
 				}
 				if err := r.setSyncStatus(ctx, syncStatus); err != nil {
 					klog.Warningf("failed to update sync status: %v", err)
@@ -640,15 +689,34 @@ func (r *reconciler) UpdateSyncStatus(ctx context.Context) error {
 	state := r.ReconcilerState()
 	// Don't update the sync spec or commit, just the errors and status.
 	syncStatus := &SyncStatus{
-		Spec:       state.status.SyncStatus.Spec,
-		Syncing:    false,
-		Commit:     state.status.SyncStatus.Commit,
-		Errs:       state.SyncErrors(),
-		LastUpdate: nowMeta(opts.Clock),
+		Spec:               state.status.SyncStatus.Spec,
+		Syncing:            false,
+		Commit:             state.status.SyncStatus.Commit,
+		Errs:               state.SyncErrors(),
+		LastUpdate:         nowMeta(opts.Clock),
+		OwnershipTransfers: state.OwnershipTransfers(),
+		ResourceUsage:      r.resourceUsage(ctx),
 	}
 	return r.setSyncStatus(ctx, syncStatus)
 }
 
+// resourceUsage returns the resource usage of the reconciler to report in the
+// sync status, or nil if reporting is disabled. The memory high-water mark is
+// rounded up to the nearest MiB, to avoid updating the status too often.
+func (r *reconciler) resourceUsage(ctx context.Context) *v1beta1.ReconcilerResourceUsage {
+	opts := r.Options()
+	if !opts.ResourceUsageReporting {
+		return nil
+	}
+	const mebibyte = 1024 * 1024
+	highWaterMark := metrics.SampleMemoryHighWaterMark(ctx)
+	highWaterMark = (highWaterMark + mebibyte - 1) / mebibyte * mebibyte
+	return &v1beta1.ReconcilerResourceUsage{
+		DeclaredResources:   opts.DeclaredResources.DeclaredCount(),
+		MemoryHighWaterMark: *resource.NewQuantity(highWaterMark, resource.BinarySI),
+	}
+}
+
 func nowMeta(c clock.Clock) metav1.Time {
 	return metav1.Time{Time: c.Now()}
 }
@@ -21,6 +21,7 @@ import (
 	"k8s.io/klog/v2"
 	"k8s.io/utils/clock"
 	"kpt.dev/configsync/pkg/importer/filesystem/cmpath"
+	"kpt.dev/configsync/pkg/remediator/conflict"
 	"kpt.dev/configsync/pkg/status"
 )
 
@@ -170,3 +171,11 @@ func (s *ReconcilerState) RecordFullSyncStart(now metav1.Time) {
 func (s *ReconcilerState) SyncErrors() status.MultiError {
 	return s.syncErrorCache.Errors()
 }
+
+// OwnershipTransfers returns the declared objects that were claimed by a
+// reconciler with higher precedence during the last apply. The transfers are
+// reported by the Applier with OwnershipTransferEvents, and kept until the
+// next apply, so that every sync status update includes them.
+func (s *ReconcilerState) OwnershipTransfers() []conflict.OwnershipTransfer {
+	return s.syncErrorCache.ConflictHandler().OwnershipTransfers()
+}
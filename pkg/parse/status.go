@@ -15,10 +15,14 @@
 package parse
 
 import (
+	"slices"
 	"strings"
 
+	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
+	"kpt.dev/configsync/pkg/remediator/conflict"
 	"kpt.dev/configsync/pkg/status"
 )
 
@@ -202,6 +206,10 @@ type SourceStatus struct {
 	Commit     string
 	Errs       status.MultiError
 	LastUpdate metav1.Time
+	// DeclaredKinds are the kinds declared in the last successfully parsed
+	// commit, if minimal RBAC is enabled. If nil, the RSync status is not
+	// changed.
+	DeclaredKinds *v1beta1.DeclaredKinds
 }
 
 // DeepCopy returns a deep copy of the receiver.
@@ -211,9 +219,10 @@ func (gs *SourceStatus) DeepCopy() *SourceStatus {
 		return nil
 	}
 	return &SourceStatus{
-		Commit:     gs.Commit,
-		Errs:       gs.Errs,
-		LastUpdate: *gs.LastUpdate.DeepCopy(),
+		Commit:        gs.Commit,
+		Errs:          gs.Errs,
+		LastUpdate:    *gs.LastUpdate.DeepCopy(),
+		DeclaredKinds: gs.DeclaredKinds.DeepCopy(),
 	}
 }
 
@@ -225,6 +234,7 @@ func (gs *SourceStatus) Equals(other *SourceStatus) bool {
 	}
 	return gs.Commit == other.Commit &&
 		status.DeepEqual(gs.Errs, other.Errs) &&
+		equality.Semantic.DeepEqual(gs.DeclaredKinds, other.DeclaredKinds) &&
 		isSourceSpecEqual(gs.Spec, other.Spec)
 }
 
@@ -280,6 +290,12 @@ type SyncStatus struct {
 	Commit     string
 	Errs       status.MultiError
 	LastUpdate metav1.Time
+	// OwnershipTransfers are the declared objects that were claimed by a
+	// reconciler with higher precedence.
+	OwnershipTransfers []conflict.OwnershipTransfer
+	// ResourceUsage is the resource usage of the reconciler, if reporting is
+	// enabled.
+	ResourceUsage *v1beta1.ReconcilerResourceUsage
 }
 
 // DeepCopy returns a deep copy of the receiver.
@@ -289,10 +305,12 @@ func (ss *SyncStatus) DeepCopy() *SyncStatus {
 		return nil
 	}
 	return &SyncStatus{
-		Syncing:    ss.Syncing,
-		Commit:     ss.Commit,
-		Errs:       ss.Errs,
-		LastUpdate: *ss.LastUpdate.DeepCopy(),
+		Syncing:            ss.Syncing,
+		Commit:             ss.Commit,
+		Errs:               ss.Errs,
+		LastUpdate:         *ss.LastUpdate.DeepCopy(),
+		OwnershipTransfers: slices.Clone(ss.OwnershipTransfers),
+		ResourceUsage:      ss.ResourceUsage.DeepCopy(),
 	}
 }
 
@@ -305,6 +323,8 @@ func (ss *SyncStatus) Equals(other *SyncStatus) bool {
 	return ss.Syncing == other.Syncing &&
 		ss.Commit == other.Commit &&
 		status.DeepEqual(ss.Errs, other.Errs) &&
+		slices.Equal(ss.OwnershipTransfers, other.OwnershipTransfers) &&
+		equality.Semantic.DeepEqual(ss.ResourceUsage, other.ResourceUsage) &&
 		isSourceSpecEqual(ss.Spec, other.Spec)
 }
 
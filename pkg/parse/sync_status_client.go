@@ -34,4 +34,7 @@ type SyncStatusClient interface {
 	SetRequiresRenderingAnnotation(ctx context.Context, renderingRequired bool) status.Error
 	// SetImageToSyncAnnotation sets the source annotations on the RSync.
 	SetImageToSyncAnnotation(ctx context.Context, commit string) status.Error
+	// GetRBACCommit gets the most recent commit whose declared kinds the
+	// reconciler has been granted access to, when minimal RBAC is enabled.
+	GetRBACCommit(ctx context.Context) (string, status.Error)
 }
@@ -175,22 +175,26 @@ func (u *Updater) apply(ctx context.Context, commit string) status.MultiError {
 	// Collect errors into a MultiError
 	var err status.MultiError
 	eventHandler := func(event applier.Event) {
-		if errEvent, ok := event.(applier.ErrorEvent); ok {
+		switch e := event.(type) {
+		case applier.ErrorEvent:
 			if err == nil {
-				err = errEvent.Error
+				err = e.Error
 			} else {
-				err = status.Append(err, errEvent.Error)
+				err = status.Append(err, e.Error)
 			}
-			if conflictErr, ok := errEvent.Error.(status.ManagementConflictError); ok {
+			if conflictErr, ok := e.Error.(status.ManagementConflictError); ok {
 				conflict.Record(ctx, u.SyncErrorCache.ConflictHandler(), conflictErr, commit)
 			} else {
-				u.SyncErrorCache.AddApplyError(errEvent.Error)
+				u.SyncErrorCache.AddApplyError(e.Error)
 			}
+		case applier.OwnershipTransferEvent:
+			u.SyncErrorCache.ConflictHandler().AddOwnershipTransfer(e.ID, e.Manager)
 		}
 	}
 	klog.Info("Applier starting...")
 	start := time.Now()
 	u.SyncErrorCache.ResetApplyErrors()
+	u.SyncErrorCache.ConflictHandler().ClearOwnershipTransfers()
 	objStatusMap, syncStats := u.Applier.Apply(ctx, eventHandler, u.Resources)
 	if !syncStats.Empty() {
 		klog.Infof("Applier made new progress: %s", syncStats.String())
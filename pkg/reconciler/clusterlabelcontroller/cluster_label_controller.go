@@ -0,0 +1,147 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package clusterlabelcontroller watches the labels of the cluster matched by
+// ClusterSelectors, and the other ConfigMaps read by the parser, and schedules
+// a new sync when they change.
+package clusterlabelcontroller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/klog/v2"
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/importer/analyzer/transform/selectors"
+	"kpt.dev/configsync/pkg/status"
+	"kpt.dev/configsync/pkg/validate/policy"
+	"kpt.dev/configsync/pkg/validate/variables"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// ConfigMapKey is the key of the ConfigMap which declares the cluster labels.
+var ConfigMapKey = client.ObjectKey{
+	Namespace: configsync.ControllerNamespace,
+	Name:      selectors.ClusterLabelsConfigMapName,
+}
+
+// ParsedConfigMapKeys are the keys of the other ConfigMaps which the parser
+// reads on each parse. A change to any of them schedules a sync, so that it
+// doesn't wait for the next commit or full sync.
+var ParsedConfigMapKeys = []client.ObjectKey{
+	{Namespace: configsync.ControllerNamespace, Name: policy.ConfigMapName},
+	{Namespace: configsync.ControllerNamespace, Name: variables.ConfigMapName},
+}
+
+// ClusterLabelController reads the cluster labels from the ConfigMap
+// config-management-system/config-sync-cluster-labels, and the versions of the
+// ParsedConfigMapKeys.
+type ClusterLabelController struct {
+	client client.Client
+	state  *State
+}
+
+// New instantiates the cluster label controller.
+func New(cl client.Client, state *State) *ClusterLabelController {
+	return &ClusterLabelController{
+		client: cl,
+		state:  state,
+	}
+}
+
+// Refresh reads the cluster labels and the versions of the other ConfigMaps,
+// and updates the state. It is invoked at startup, before the first sync, and
+// on each event of the ConfigMaps.
+func (c *ClusterLabelController) Refresh(ctx context.Context) error {
+	cm, err := c.getConfigMap(ctx, ConfigMapKey)
+	if err != nil {
+		return err
+	}
+	labels, labelsErr := selectors.ClusterLabelsFromConfigMap(cm)
+	if c.state.update(labels, labelsErr) {
+		klog.Infof("The cluster labels in ConfigMap %s changed: %v", ConfigMapKey, labels)
+	}
+	for _, key := range ParsedConfigMapKeys {
+		cm, err := c.getConfigMap(ctx, key)
+		if err != nil {
+			return err
+		}
+		if c.state.updateVersion(key, cm.ResourceVersion) {
+			klog.Infof("The ConfigMap %s changed", key)
+		}
+	}
+	return nil
+}
+
+// getConfigMap returns the ConfigMap, or an empty ConfigMap if it doesn't
+// exist.
+func (c *ClusterLabelController) getConfigMap(ctx context.Context, key client.ObjectKey) (*corev1.ConfigMap, error) {
+	cm := &corev1.ConfigMap{}
+	if err := c.client.Get(ctx, key, cm); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, status.APIServerError(err, "failed to get ConfigMap "+key.String())
+		}
+		return &corev1.ConfigMap{}, nil
+	}
+	return cm, nil
+}
+
+// Reconcile implements reconcile.Reconciler.
+func (c *ClusterLabelController) Reconcile(ctx context.Context, _ ctrl.Request) (ctrl.Result, error) {
+	return ctrl.Result{}, c.Refresh(ctx)
+}
+
+// SetupWithManager registers the cluster label controller with the manager.
+// Each ConfigMap is watched with a dedicated cache, which is started by the
+// manager, so that the ConfigMaps cached by the manager for the other
+// controllers are not restricted to them.
+func (c *ClusterLabelController) SetupWithManager(mgr ctrl.Manager) error {
+	blder := ctrl.NewControllerManagedBy(mgr).
+		Named("ClusterLabelController")
+	for _, key := range append([]client.ObjectKey{ConfigMapKey}, ParsedConfigMapKeys...) {
+		configMapCache, err := newCache(mgr, key)
+		if err != nil {
+			return fmt.Errorf("failed to create the cache of ConfigMap %s: %w", key, err)
+		}
+		if err := mgr.Add(configMapCache); err != nil {
+			return fmt.Errorf("failed to add the cache of ConfigMap %s: %w", key, err)
+		}
+		blder = blder.WatchesRawSource(source.Kind(configMapCache, &corev1.ConfigMap{},
+			&handler.TypedEnqueueRequestForObject[*corev1.ConfigMap]{}))
+	}
+	return blder.Complete(c)
+}
+
+// newCache returns a cache of the ConfigMap, so that the reconcilers only need
+// permission to list and watch that ConfigMap.
+func newCache(mgr ctrl.Manager, key client.ObjectKey) (cache.Cache, error) {
+	return cache.New(mgr.GetConfig(), cache.Options{
+		HTTPClient: mgr.GetHTTPClient(),
+		Scheme:     mgr.GetScheme(),
+		Mapper:     mgr.GetRESTMapper(),
+		ByObject: map[client.Object]cache.ByObject{
+			&corev1.ConfigMap{}: {
+				Namespaces: map[string]cache.Config{key.Namespace: {}},
+				Field:      fields.OneTermEqualSelector("metadata.name", key.Name),
+			},
+		},
+	})
+}
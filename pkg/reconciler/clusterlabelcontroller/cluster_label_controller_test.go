@@ -0,0 +1,113 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clusterlabelcontroller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/labels"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/core/k8sobjects"
+	"kpt.dev/configsync/pkg/importer/analyzer/transform/selectors"
+	"kpt.dev/configsync/pkg/syncer/syncertest/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestRefresh(t *testing.T) {
+	ctx := context.Background()
+	cm := k8sobjects.ConfigMapObject(core.Name(ConfigMapKey.Name), core.Namespace(ConfigMapKey.Namespace))
+	cm.Data = map[string]string{"region": "us-east1", "env": "prod"}
+	fakeClient := fake.NewClient(t, core.Scheme, cm)
+	state := NewState()
+	controller := New(fakeClient, state)
+
+	// The first read doesn't schedule a sync.
+	require.NoError(t, controller.Refresh(ctx))
+	assert.False(t, state.ScheduleSync())
+	got, err := state.Labels()
+	require.NoError(t, err)
+	assert.Equal(t, labels.Set{"region": "us-east1", "env": "prod"}, got)
+
+	// Unchanged labels don't schedule a sync.
+	require.NoError(t, controller.Refresh(ctx))
+	assert.False(t, state.ScheduleSync())
+
+	// Changed labels schedule a single sync.
+	cm.Data["env"] = "dev"
+	require.NoError(t, fakeClient.Update(ctx, cm, client.FieldOwner(fake.FieldManager)))
+	require.NoError(t, controller.Refresh(ctx))
+	assert.True(t, state.ScheduleSync())
+	assert.False(t, state.ScheduleSync())
+	got, err = state.Labels()
+	require.NoError(t, err)
+	assert.Equal(t, labels.Set{"region": "us-east1", "env": "dev"}, got)
+
+	// Invalid labels are reported by the parser.
+	cm.Data["env"] = "not a label value"
+	require.NoError(t, fakeClient.Update(ctx, cm, client.FieldOwner(fake.FieldManager)))
+	require.NoError(t, controller.Refresh(ctx))
+	assert.True(t, state.ScheduleSync())
+	_, err = state.Labels()
+	require.Error(t, err)
+	assert.Equal(t, selectors.InvalidClusterLabelsErrorCode, err.Code())
+
+	// Deleting the ConfigMap removes the labels.
+	require.NoError(t, fakeClient.Delete(ctx, cm))
+	require.NoError(t, controller.Refresh(ctx))
+	assert.True(t, state.ScheduleSync())
+	got, err = state.Labels()
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
+
+func TestRefresh_ParsedConfigMaps(t *testing.T) {
+	ctx := context.Background()
+	fakeClient := fake.NewClient(t, core.Scheme)
+	state := NewState()
+	controller := New(fakeClient, state)
+
+	// The first read doesn't schedule a sync, even if the ConfigMaps don't
+	// exist.
+	require.NoError(t, controller.Refresh(ctx))
+	assert.False(t, state.ScheduleSync())
+
+	for _, key := range ParsedConfigMapKeys {
+		// Creating the ConfigMap schedules a single sync.
+		cm := k8sobjects.ConfigMapObject(core.Name(key.Name), core.Namespace(key.Namespace))
+		cm.Data = map[string]string{"key": "value"}
+		require.NoError(t, fakeClient.Create(ctx, cm, client.FieldOwner(fake.FieldManager)))
+		require.NoError(t, controller.Refresh(ctx))
+		assert.True(t, state.ScheduleSync(), key.String())
+		assert.False(t, state.ScheduleSync(), key.String())
+
+		// An unchanged ConfigMap doesn't schedule a sync.
+		require.NoError(t, controller.Refresh(ctx))
+		assert.False(t, state.ScheduleSync(), key.String())
+
+		// Updating the ConfigMap schedules a sync.
+		cm.Data["key"] = "other"
+		require.NoError(t, fakeClient.Update(ctx, cm, client.FieldOwner(fake.FieldManager)))
+		require.NoError(t, controller.Refresh(ctx))
+		assert.True(t, state.ScheduleSync(), key.String())
+
+		// Deleting the ConfigMap schedules a sync.
+		require.NoError(t, fakeClient.Delete(ctx, cm))
+		require.NoError(t, controller.Refresh(ctx))
+		assert.True(t, state.ScheduleSync(), key.String())
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clusterlabelcontroller
+
+import (
+	"sync"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"kpt.dev/configsync/pkg/status"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// State caches the cluster labels read by the cluster label controller, and
+// whether they changed since the last sync.
+type State struct {
+	// mux is the mutex to protect read/write to all the fields.
+	mux sync.Mutex
+
+	// initialized indicates whether the cluster labels have been read at
+	// least once. The first read doesn't require a new sync.
+	initialized bool
+
+	// labels are the cluster labels matched by ClusterSelectors.
+	labels labels.Set
+
+	// err is the error of the invalid cluster labels, reported by the parser.
+	err status.Error
+
+	// versions are the resource versions of the other ConfigMaps read by the
+	// parser, or empty if they don't exist.
+	versions map[client.ObjectKey]string
+
+	// isSyncPending indicates whether the cluster labels or the other
+	// ConfigMaps changed, which requires a new sync.
+	isSyncPending bool
+}
+
+// NewState instantiates the cluster label controller state.
+func NewState() *State {
+	return &State{
+		versions: make(map[client.ObjectKey]string),
+	}
+}
+
+// Labels returns the cluster labels, or the error if they are invalid.
+func (s *State) Labels() (labels.Set, status.Error) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	if s.err != nil {
+		return nil, s.err
+	}
+	return labels.Merge(nil, s.labels), nil
+}
+
+// update records the cluster labels, or the error if they are invalid, and
+// schedules a sync if they changed.
+func (s *State) update(newLabels labels.Set, err status.Error) bool {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	changed := !labels.Equals(s.labels, newLabels) || errorMessage(s.err) != errorMessage(err)
+	s.labels = newLabels
+	s.err = err
+	if changed && s.initialized {
+		s.isSyncPending = true
+	}
+	s.initialized = true
+	return changed
+}
+
+// updateVersion records the resource version of the ConfigMap, and schedules
+// a sync if it changed. The first read doesn't require a new sync.
+func (s *State) updateVersion(key client.ObjectKey, version string) bool {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	oldVersion, found := s.versions[key]
+	s.versions[key] = version
+	changed := found && oldVersion != version
+	if changed {
+		s.isSyncPending = true
+	}
+	return changed
+}
+
+// ScheduleSync checks whether the isSyncPending flag is true.
+// If it is true, a sync should be scheduled by the reconciler thread, and it
+// flips the internal flag to false.
+// If it is false, no sync will be scheduled.
+func (s *State) ScheduleSync() bool {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	isSyncPending := s.isSyncPending
+	s.isSyncPending = false
+	return isSyncPending
+}
+
+func errorMessage(err status.Error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
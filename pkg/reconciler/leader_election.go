@@ -0,0 +1,216 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconciler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+	"k8s.io/klog/v2"
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/client/restconfig"
+)
+
+// leaseTiming configures how quickly a standby reconciler takes over when the
+// leader stops renewing its Lease.
+type leaseTiming struct {
+	// LeaseDuration is how long the standby waits after the last renewal,
+	// before acquiring the Lease.
+	LeaseDuration time.Duration
+	// RenewDeadline is how long the leader retries renewing the Lease, before
+	// giving up leadership.
+	RenewDeadline time.Duration
+	// RetryPeriod is how long to wait between attempts to acquire or renew the
+	// Lease.
+	RetryPeriod time.Duration
+}
+
+var defaultLeaseTiming = leaseTiming{
+	LeaseDuration: 15 * time.Second,
+	RenewDeadline: 10 * time.Second,
+	RetryPeriod:   2 * time.Second,
+}
+
+// runWithLeaderElection runs the pipeline while holding the reconciler's Lease,
+// until the context is done.
+//
+// Every replica of the reconciler Deployment keeps pulling the source with its
+// sidecars, but only the holder of the Lease parses, applies and remediates.
+// When the leader is evicted, it releases the Lease once its pipeline stopped,
+// so a standby takes over without waiting for the Lease to expire.
+//
+// Returns an error if the pipeline failed, or if the Lease was lost before the
+// context was done, so the reconciler restarts as a standby.
+func runWithLeaderElection(ctx context.Context, opts Options) error {
+	cfg, err := restconfig.NewRestConfig(restconfig.DefaultTimeout)
+	if err != nil {
+		return fmt.Errorf("creating rest config: %w", err)
+	}
+	clientSet, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return fmt.Errorf("creating clientset: %w", err)
+	}
+	// The hostname is the name of the reconciler Pod.
+	identity, err := os.Hostname()
+	if err != nil {
+		return fmt.Errorf("getting hostname: %w", err)
+	}
+	// The Lease is created by the reconciler-manager.
+	lock := &resourcelock.LeaseLock{
+		LeaseMeta: metav1.ObjectMeta{
+			Name:      opts.ReconcilerName,
+			Namespace: configsync.ControllerNamespace,
+		},
+		Client:     clientSet.CoordinationV1(),
+		LockConfig: resourcelock.ResourceLockConfig{Identity: identity},
+	}
+	return runElected(ctx, lock, defaultLeaseTiming, func(leaderCtx context.Context) error {
+		return RunPipeline(leaderCtx, opts)
+	})
+}
+
+// runElected runs runFn while holding the lock, until the context is done.
+//
+// The context passed to runFn is done when the context is done or the lock is
+// lost. The lock is only released after runFn returns, even if renewing it
+// failed, so a standby never runs the pipeline while runFn is still running.
+func runElected(ctx context.Context, lock resourcelock.Interface, timing leaseTiming, runFn func(context.Context) error) error {
+	// The elector is stopped explicitly, after the pipeline exits.
+	electorCtx, stopElector := context.WithCancel(context.WithoutCancel(ctx))
+	defer stopElector()
+
+	var mux sync.Mutex
+	var stopped bool
+	var pipelineDone chan struct{}
+	var runErr error
+	// waitForPipeline prevents the pipeline from starting, if not yet started,
+	// and waits for it to exit otherwise.
+	waitForPipeline := func() {
+		mux.Lock()
+		stopped = true
+		done := pipelineDone
+		mux.Unlock()
+		if done != nil {
+			<-done
+		}
+	}
+
+	elector, err := leaderelection.NewLeaderElector(leaderelection.LeaderElectionConfig{
+		Lock:          lock,
+		LeaseDuration: timing.LeaseDuration,
+		RenewDeadline: timing.RenewDeadline,
+		RetryPeriod:   timing.RetryPeriod,
+		// The elector would release the lock as soon as renewing it fails,
+		// while the pipeline may still be running. Instead, the lock is
+		// released below, after the pipeline exited.
+		ReleaseOnCancel: false,
+		Name:            lock.Describe(),
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: func(leaderCtx context.Context) {
+				mux.Lock()
+				if stopped {
+					mux.Unlock()
+					return
+				}
+				done := make(chan struct{})
+				pipelineDone = done
+				mux.Unlock()
+				defer close(done)
+
+				klog.Infof("Acquired leader election lease %s", lock.Describe())
+				pipelineCtx, cancel := context.WithCancel(leaderCtx)
+				defer cancel()
+				stop := context.AfterFunc(ctx, cancel)
+				defer stop()
+				if err := runFn(pipelineCtx); err != nil {
+					mux.Lock()
+					runErr = err
+					mux.Unlock()
+					stopElector()
+				}
+			},
+			OnStoppedLeading: func() {
+				klog.Infof("Stopped leading with leader election lease %s", lock.Describe())
+			},
+			OnNewLeader: func(identity string) {
+				klog.Infof("Leader election lease %s held by %s", lock.Describe(), identity)
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("creating leader elector: %w", err)
+	}
+
+	// Stop the elector when the context is done, once the pipeline exited.
+	go func() {
+		select {
+		case <-ctx.Done():
+			waitForPipeline()
+			stopElector()
+		case <-electorCtx.Done():
+		}
+	}()
+
+	elector.Run(electorCtx)
+	waitForPipeline()
+	releaseLock(lock, timing)
+
+	mux.Lock()
+	defer mux.Unlock()
+	switch {
+	case runErr != nil:
+		return runErr
+	case ctx.Err() != nil:
+		return nil
+	default:
+		return errors.New("lost leader election lease")
+	}
+}
+
+// releaseLock releases the lock, if still held, so a standby takes over without
+// waiting for it to expire. The update fails if another holder acquired the
+// lock since it was read.
+func releaseLock(lock resourcelock.Interface, timing leaseTiming) {
+	ctx, cancel := context.WithTimeout(context.Background(), timing.RenewDeadline)
+	defer cancel()
+	record, _, err := lock.Get(ctx)
+	if err != nil {
+		klog.Errorf("Failed to get leader election lease %s: %v", lock.Describe(), err)
+		return
+	}
+	if record.HolderIdentity != lock.Identity() {
+		return
+	}
+	now := metav1.NewTime(time.Now())
+	err = lock.Update(ctx, resourcelock.LeaderElectionRecord{
+		LeaderTransitions:    record.LeaderTransitions,
+		LeaseDurationSeconds: 1,
+		RenewTime:            now,
+		AcquireTime:          now,
+	})
+	if err != nil {
+		klog.Errorf("Failed to release leader election lease %s: %v", lock.Describe(), err)
+		return
+	}
+	klog.Infof("Released leader election lease %s", lock.Describe())
+}
@@ -0,0 +1,172 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconciler
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+	"k8s.io/utils/ptr"
+	"kpt.dev/configsync/pkg/api/configsync"
+)
+
+var testLeaseTiming = leaseTiming{
+	LeaseDuration: 2 * time.Second,
+	RenewDeadline: time.Second,
+	RetryPeriod:   100 * time.Millisecond,
+}
+
+func testLeaseLock(clientSet kubernetes.Interface, identity string) resourcelock.Interface {
+	return &resourcelock.LeaseLock{
+		LeaseMeta: metav1.ObjectMeta{
+			Name:      "root-reconciler",
+			Namespace: configsync.ControllerNamespace,
+		},
+		Client:     clientSet.CoordinationV1(),
+		LockConfig: resourcelock.ResourceLockConfig{Identity: identity},
+	}
+}
+
+func leaseHolder(t *testing.T, clientSet kubernetes.Interface) string {
+	t.Helper()
+	lease, err := clientSet.CoordinationV1().Leases(configsync.ControllerNamespace).
+		Get(context.Background(), "root-reconciler", metav1.GetOptions{})
+	require.NoError(t, err)
+	if lease.Spec.HolderIdentity == nil {
+		return ""
+	}
+	return *lease.Spec.HolderIdentity
+}
+
+func TestRunElectedFailover(t *testing.T) {
+	clientSet := fake.NewClientset()
+	started := make(chan string, 2)
+	runFn := func(identity string) func(context.Context) error {
+		return func(ctx context.Context) error {
+			started <- identity
+			<-ctx.Done()
+			return nil
+		}
+	}
+
+	ctxA, cancelA := context.WithCancel(context.Background())
+	doneA := make(chan error)
+	go func() {
+		doneA <- runElected(ctxA, testLeaseLock(clientSet, "pod-a"), testLeaseTiming, runFn("pod-a"))
+	}()
+	require.Equal(t, "pod-a", <-started)
+
+	ctxB, cancelB := context.WithCancel(context.Background())
+	defer cancelB()
+	doneB := make(chan error)
+	go func() {
+		doneB <- runElected(ctxB, testLeaseLock(clientSet, "pod-b"), testLeaseTiming, runFn("pod-b"))
+	}()
+
+	// The standby doesn't run the pipeline while the leader holds the Lease.
+	select {
+	case identity := <-started:
+		t.Fatalf("unexpected pipeline started by %s", identity)
+	case <-time.After(3 * testLeaseTiming.RetryPeriod):
+	}
+	assert.Equal(t, "pod-a", leaseHolder(t, clientSet))
+
+	// The leader releases the Lease when stopped, so the standby takes over
+	// before the Lease expires.
+	start := time.Now()
+	cancelA()
+	require.NoError(t, <-doneA)
+	require.Equal(t, "pod-b", <-started)
+	assert.Less(t, time.Since(start), testLeaseTiming.LeaseDuration)
+	assert.Equal(t, "pod-b", leaseHolder(t, clientSet))
+
+	cancelB()
+	require.NoError(t, <-doneB)
+}
+
+func TestRunElectedPipelineError(t *testing.T) {
+	clientSet := fake.NewClientset()
+	err := runElected(context.Background(), testLeaseLock(clientSet, "pod-a"), testLeaseTiming,
+		func(context.Context) error {
+			return errors.New("pipeline failed")
+		})
+	assert.EqualError(t, err, "pipeline failed")
+	// The Lease is released, so a standby can take over.
+	assert.Equal(t, "", leaseHolder(t, clientSet))
+}
+
+func TestRunElectedRenewFailure(t *testing.T) {
+	clientSet := fake.NewClientset()
+	// Fail renewing the Lease, but not releasing it.
+	var failRenewals atomic.Bool
+	clientSet.PrependReactor("update", "leases", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		lease := action.(k8stesting.UpdateAction).GetObject().(*coordinationv1.Lease)
+		if failRenewals.Load() && ptr.Deref(lease.Spec.HolderIdentity, "") != "" {
+			return true, nil, errors.New("renewal failed")
+		}
+		return false, nil, nil
+	})
+
+	started := make(chan struct{})
+	cancelled := make(chan struct{})
+	exit := make(chan struct{})
+	done := make(chan error)
+	go func() {
+		done <- runElected(context.Background(), testLeaseLock(clientSet, "pod-a"), testLeaseTiming,
+			func(ctx context.Context) error {
+				close(started)
+				<-ctx.Done()
+				close(cancelled)
+				// Keep running after the Lease is lost, like a pipeline
+				// finishing its current apply.
+				<-exit
+				return nil
+			})
+	}()
+	<-started
+
+	// Renewing fails, so the pipeline is told to stop.
+	failRenewals.Store(true)
+	select {
+	case <-cancelled:
+	case <-time.After(testLeaseTiming.LeaseDuration + testLeaseTiming.RenewDeadline):
+		t.Fatal("pipeline not cancelled after the Lease renewal failed")
+	}
+	// The Lease is not released while the pipeline is still running.
+	time.Sleep(3 * testLeaseTiming.RetryPeriod)
+	assert.Equal(t, "pod-a", leaseHolder(t, clientSet))
+	select {
+	case err := <-done:
+		t.Fatalf("runElected returned while the pipeline was running: %v", err)
+	default:
+	}
+
+	// Once the pipeline exited, the Lease is released.
+	close(exit)
+	assert.EqualError(t, <-done, "lost leader election lease")
+	assert.Equal(t, "", leaseHolder(t, clientSet))
+}
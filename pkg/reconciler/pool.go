@@ -0,0 +1,288 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconciler
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/hydrate"
+	"kpt.dev/configsync/pkg/importer/filesystem/cmpath"
+	"kpt.dev/configsync/pkg/oci"
+	"kpt.dev/configsync/pkg/reconciler/pool"
+	"kpt.dev/configsync/pkg/util"
+	utillog "kpt.dev/configsync/pkg/util/log"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// sourceLink is the name of the symlink to the pulled source, under the
+	// source root of each member.
+	sourceLink = "rev"
+	// fetchTimeout is the max time allowed for a single pull of the source.
+	fetchTimeout = 2 * time.Minute
+)
+
+// PoolOptions configure a pooled reconciler.
+type PoolOptions struct {
+	// Name is the name of the pool, which is also the name of the ConfigMap
+	// listing its members.
+	Name string
+	// Pipeline is the template for the options of each member's pipeline.
+	// The fields specific to the RepoSync are set from the Member.
+	Pipeline Options
+	// RepoRoot is the directory under which the source of each member is
+	// pulled.
+	RepoRoot cmpath.Absolute
+	// ResyncPeriod is the period between reads of the pool ConfigMap.
+	ResyncPeriod time.Duration
+}
+
+// poolPipeline is a running pool member pipeline.
+type poolPipeline struct {
+	member pool.Member
+	cancel context.CancelFunc
+	// done is closed when the pipeline has exited.
+	done chan struct{}
+	// err is the error the pipeline exited with. Only read after done is closed.
+	err error
+}
+
+func (p *poolPipeline) exited() bool {
+	select {
+	case <-p.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// poolRunner starts and stops the member pipelines to match the pool ConfigMap.
+type poolRunner struct {
+	opts   PoolOptions
+	reader client.Reader
+	// runFn runs the pipeline of one member, until the context is done.
+	runFn     func(ctx context.Context, m pool.Member) error
+	pipelines map[types.NamespacedName]*poolPipeline
+}
+
+// RunPool runs the pipelines of the members of the pool, until the context is
+// done and all the pipelines have exited.
+//
+// The members are read from the pool ConfigMap every ResyncPeriod. Pipelines
+// are started for new members, restarted when the member changes or the
+// pipeline failed, and stopped when the member is removed.
+func RunPool(ctx context.Context, reader client.Reader, opts PoolOptions) {
+	r := &poolRunner{
+		opts:      opts,
+		reader:    reader,
+		pipelines: make(map[types.NamespacedName]*poolPipeline),
+	}
+	r.runFn = r.runMember
+	wait.UntilWithContext(ctx, r.sync, opts.ResyncPeriod)
+	r.stopAll()
+	klog.Info("All pool pipelines exited")
+}
+
+// sync reads the pool members and reconciles the running pipelines.
+func (r *poolRunner) sync(ctx context.Context) {
+	members, err := r.members(ctx)
+	if err != nil {
+		// Keep the current pipelines running until the members can be read.
+		klog.Errorf("Failed to read reconciler pool members: %v", err)
+		return
+	}
+	desired := make(map[types.NamespacedName]pool.Member, len(members))
+	for _, m := range members {
+		desired[m.SyncRef()] = m
+	}
+	for ref, p := range r.pipelines {
+		m, found := desired[ref]
+		switch {
+		case !found:
+			klog.Infof("Stopping pipeline for RepoSync %s: removed from pool", ref)
+			r.stop(ref, p)
+		case m != p.member:
+			klog.Infof("Restarting pipeline for RepoSync %s: settings changed", ref)
+			r.stop(ref, p)
+		case p.exited() && p.err != nil:
+			klog.Infof("Restarting pipeline for RepoSync %s: previous run failed: %v", ref, p.err)
+			r.stop(ref, p)
+		}
+		// Pipelines that exited without error have finalized the RepoSync, and
+		// are not restarted until the member is removed or changed.
+	}
+	for ref, m := range desired {
+		if _, running := r.pipelines[ref]; !running {
+			r.start(ctx, m)
+		}
+	}
+}
+
+// members returns the members listed in the pool ConfigMap.
+// Returns no members if the ConfigMap does not exist.
+func (r *poolRunner) members(ctx context.Context) ([]pool.Member, error) {
+	cm := &corev1.ConfigMap{}
+	key := types.NamespacedName{Namespace: configsync.ControllerNamespace, Name: r.opts.Name}
+	if err := r.reader.Get(ctx, key, cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get ConfigMap %s: %w", key, err)
+	}
+	return pool.DecodeMembers(cm)
+}
+
+func (r *poolRunner) start(ctx context.Context, m pool.Member) {
+	ref := m.SyncRef()
+	klog.Infof("Starting pipeline for RepoSync %s", ref)
+	pipelineCtx, cancel := context.WithCancel(ctx)
+	p := &poolPipeline{
+		member: m,
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+	r.pipelines[ref] = p
+	go func() {
+		defer close(p.done)
+		p.err = r.runFn(pipelineCtx, m)
+		if p.err != nil {
+			klog.Errorf("Pipeline for RepoSync %s failed: %v", ref, p.err)
+		} else {
+			klog.Infof("Pipeline for RepoSync %s exited", ref)
+		}
+	}()
+}
+
+func (r *poolRunner) stop(ref types.NamespacedName, p *poolPipeline) {
+	p.cancel()
+	<-p.done
+	delete(r.pipelines, ref)
+}
+
+func (r *poolRunner) stopAll() {
+	for ref, p := range r.pipelines {
+		r.stop(ref, p)
+	}
+}
+
+// runMember pulls the source of the member and runs its pipeline, with the
+// permissions of the member's reconciler ServiceAccount.
+func (r *poolRunner) runMember(ctx context.Context, m pool.Member) error {
+	memberRoot := r.opts.RepoRoot.Join(cmpath.RelativeSlash(m.SyncNamespace)).Join(cmpath.RelativeSlash(m.SyncName))
+	sourceRoot := memberRoot.Join(cmpath.RelativeSlash("source"))
+	signalsDir := memberRoot.Join(cmpath.RelativeSlash("signals"))
+	for _, dir := range []cmpath.Absolute{sourceRoot, signalsDir} {
+		if err := os.MkdirAll(dir.OSPath(), os.FileMode(0755)); err != nil {
+			return fmt.Errorf("failed to create directory %q: %w", dir.OSPath(), err)
+		}
+	}
+
+	fetchCtx, stopFetch := context.WithCancel(ctx)
+	defer stopFetch()
+	go fetchLoop(fetchCtx, m, sourceRoot)
+
+	opts := r.opts.Pipeline
+	opts.Logger = opts.Logger.WithValues("syncNamespace", m.SyncNamespace, "syncName", m.SyncName)
+	opts.ReconcilerScope = m.Scope()
+	opts.SyncName = m.SyncName
+	opts.ReconcilerName = m.ReconcilerName
+	opts.SourceType = m.SourceType
+	opts.SourceRepo = m.SourceRepo
+	// Some users specify the directory as if the root of the repository is "/".
+	opts.SyncDir = cmpath.RelativeOS(strings.TrimPrefix(m.SyncDir, "/"))
+	opts.StatusMode = m.StatusMode
+	opts.ReconcileTimeout = m.ReconcileTimeout
+	opts.APIServerTimeout = m.APIServerTimeout
+	if m.MinWorkers > 0 {
+		opts.MinWorkers = m.MinWorkers
+	}
+	if m.MaxWorkers > 0 {
+		opts.MaxWorkers = m.MaxWorkers
+	}
+	opts.WebhookEnabled = m.WebhookEnabled
+	opts.ClientQPS = m.ClientQPS
+	opts.ClientBurst = m.ClientBurst
+	opts.ApplierMaxInflightRequests = m.ApplierMaxInflightRequests
+	opts.RepoRoot = memberRoot
+	opts.SourceRoot = sourceRoot.Join(cmpath.RelativeSlash(sourceLink))
+	opts.HydratedRoot = memberRoot.Join(cmpath.RelativeSlash("hydrated")).OSPath()
+	opts.ReconcilerSignalsDir = signalsDir
+	// Pooled RepoSyncs never require rendering. The reconciler-manager moves
+	// RepoSyncs which require rendering back to a dedicated reconciler.
+	opts.RenderingEnabled = false
+	opts.RootOptions = nil
+	opts.ImpersonateUsername = m.Username()
+	opts.Pooled = true
+	return RunPipeline(ctx, opts)
+}
+
+// fetchLoop pulls the OCI image of the member into the source root, like the
+// oci-sync container of a dedicated reconciler, until the context is done.
+// Errors are written to the error file read by the pipeline.
+func fetchLoop(ctx context.Context, m pool.Member, sourceRoot cmpath.Absolute) {
+	log := utillog.NewLogger(klog.NewKlogr().WithValues("image", m.SourceRepo), sourceRoot.OSPath(), hydrate.ErrorFile)
+	period := configsync.DefaultReconcilerPollingPeriod
+	if m.PollingPeriod != "" {
+		if d, err := time.ParseDuration(m.PollingPeriod); err == nil && d > 0 {
+			period = d
+		}
+	}
+	newBackoff := func() wait.Backoff {
+		return util.BackoffWithDurationAndStepLimit(max(period, util.MinimumSyncContainerBackoffCap), math.MaxInt32)
+	}
+	backoff := newBackoff()
+	fetcher := &oci.Fetcher{
+		// Only RepoSyncs without authentication are pooled.
+		Authenticator: authn.Anonymous,
+	}
+	for {
+		attemptCtx, cancel := context.WithTimeout(ctx, fetchTimeout)
+		err := fetcher.FetchPackage(attemptCtx, m.SourceRepo, sourceRoot.OSPath(), sourceLink)
+		cancel()
+		waitTime := period
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			log.Error(err, "unexpected error fetching package, will retry")
+			waitTime = backoff.Step()
+		} else {
+			backoff = newBackoff()
+			log.DeleteErrorFile()
+			// If the image contains a digest, it never changes.
+			if oci.HasDigest(m.SourceRepo) {
+				log.Info(oci.NoFurtherSyncsLog, "reason", "image was provided with digest")
+				return
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(waitTime):
+		}
+	}
+}
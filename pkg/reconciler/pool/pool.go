@@ -0,0 +1,155 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package pool describes the shared reconcilers which run the sync pipelines
+// of many RepoSyncs in one process.
+//
+// Only RepoSyncs which pull an unauthenticated OCI image are pooled, since the
+// pooled reconciler pulls the images itself, without credentials. The
+// reconciler-manager assigns each of them to one of a bounded number of pools,
+// and publishes the members of each pool in a ConfigMap with the same name as
+// the pool's Deployment. The pooled reconciler watches the ConfigMap and runs
+// one pipeline per member, impersonating the member's reconciler
+// ServiceAccount, so that each pipeline has the same permissions as a
+// dedicated namespace reconciler.
+package pool
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/declared"
+	"kpt.dev/configsync/pkg/metadata"
+)
+
+const (
+	// NamePrefix is the prefix of the names of the pooled reconciler
+	// Deployments, ServiceAccounts, Roles, RoleBindings and ConfigMaps.
+	// Unlike the "ns-reconciler-" prefix of dedicated namespace reconcilers,
+	// it can't collide with the reconciler name of a RepoSync.
+	NamePrefix = "reconciler-pool-"
+	// MembersKey is the key of the ConfigMap data which lists the members of
+	// the pool.
+	MembersKey = "members.json"
+)
+
+// Name returns the name of the pool with the specified index.
+func Name(index int) string {
+	return fmt.Sprintf("%s%d", NamePrefix, index)
+}
+
+// Index returns the index of the pool to assign the RepoSync to.
+// The assignment only depends on the RepoSync's namespace and name, so it is
+// stable as long as the number of pools does not change.
+func Index(rsRef types.NamespacedName, size int) int {
+	if size <= 0 {
+		return 0
+	}
+	h := fnv.New32a()
+	// Hash writes never return an error.
+	_, _ = h.Write([]byte(rsRef.String()))
+	return int(h.Sum32() % uint32(size))
+}
+
+// Member is a RepoSync assigned to a pool, with the settings the pooled
+// reconciler needs to run its pipeline.
+type Member struct {
+	// SyncName is the name of the RepoSync.
+	SyncName string `json:"syncName"`
+	// SyncNamespace is the namespace of the RepoSync.
+	SyncNamespace string `json:"syncNamespace"`
+	// ReconcilerName is the name of the RepoSync's reconciler ServiceAccount,
+	// which the pipeline impersonates.
+	ReconcilerName string `json:"reconcilerName"`
+	// SourceType is the type of the source. Only OCI is supported.
+	SourceType configsync.SourceType `json:"sourceType"`
+	// SourceRepo is the OCI image to sync.
+	SourceRepo string `json:"sourceRepo"`
+	// SyncDir is the path to the configs in the image.
+	SyncDir string `json:"syncDir,omitempty"`
+	// PollingPeriod is how often to pull the image.
+	PollingPeriod string `json:"pollingPeriod,omitempty"`
+	// StatusMode controls whether the applier injects the actuation status
+	// into the ResourceGroup.
+	StatusMode metadata.StatusMode `json:"statusMode,omitempty"`
+	// ReconcileTimeout is the applier reconcile and prune timeout.
+	ReconcileTimeout string `json:"reconcileTimeout,omitempty"`
+	// APIServerTimeout is the client-side timeout for API server requests.
+	APIServerTimeout string `json:"apiServerTimeout,omitempty"`
+	// MinWorkers is the minimum number of remediator workers.
+	MinWorkers int `json:"minWorkers,omitempty"`
+	// MaxWorkers is the maximum number of remediator workers.
+	MaxWorkers int `json:"maxWorkers,omitempty"`
+	// WebhookEnabled is whether the admission webhook is installed.
+	WebhookEnabled bool `json:"webhookEnabled,omitempty"`
+	// ClientQPS is the maximum sustained QPS of the pipeline's requests to the
+	// API server.
+	ClientQPS int `json:"clientQPS,omitempty"`
+	// ClientBurst is the maximum burst of the pipeline's requests to the API
+	// server.
+	ClientBurst int `json:"clientBurst,omitempty"`
+	// ApplierMaxInflightRequests is the maximum number of concurrent requests
+	// from the pipeline's applier.
+	ApplierMaxInflightRequests int `json:"applierMaxInflightRequests,omitempty"`
+}
+
+// SyncRef returns the namespace and name of the RepoSync.
+func (m Member) SyncRef() types.NamespacedName {
+	return types.NamespacedName{Namespace: m.SyncNamespace, Name: m.SyncName}
+}
+
+// Scope returns the scope of the RepoSync's reconciler.
+func (m Member) Scope() declared.Scope {
+	return declared.Scope(m.SyncNamespace)
+}
+
+// Username returns the username of the RepoSync's reconciler
+// ServiceAccount, which the pipeline impersonates.
+func (m Member) Username() string {
+	return fmt.Sprintf("system:serviceaccount:%s:%s", configsync.ControllerNamespace, m.ReconcilerName)
+}
+
+// EncodeMembers encodes the members as the ConfigMap data value, sorted by
+// RepoSync namespace and name, so that the value only changes when the
+// members change.
+func EncodeMembers(members []Member) (string, error) {
+	sorted := make([]Member, len(members))
+	copy(sorted, members)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].SyncRef().String() < sorted[j].SyncRef().String()
+	})
+	data, err := json.Marshal(sorted)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode reconciler pool members: %w", err)
+	}
+	return string(data), nil
+}
+
+// DecodeMembers decodes the members of the pool from the ConfigMap.
+func DecodeMembers(cm *corev1.ConfigMap) ([]Member, error) {
+	data, found := cm.Data[MembersKey]
+	if !found || data == "" {
+		return nil, nil
+	}
+	var members []Member
+	if err := json.Unmarshal([]byte(data), &members); err != nil {
+		return nil, fmt.Errorf("failed to decode reconciler pool members from ConfigMap %s/%s: %w", cm.Namespace, cm.Name, err)
+	}
+	return members, nil
+}
@@ -0,0 +1,98 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pool
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/metadata"
+)
+
+func TestIndex(t *testing.T) {
+	const size = 4
+	counts := make([]int, size)
+	for i := 0; i < 100; i++ {
+		rsRef := types.NamespacedName{Namespace: fmt.Sprintf("tenant-%d", i), Name: configsync.RepoSyncName}
+		index := Index(rsRef, size)
+		require.GreaterOrEqual(t, index, 0)
+		require.Less(t, index, size)
+		// The assignment is stable.
+		assert.Equal(t, index, Index(rsRef, size))
+		counts[index]++
+	}
+	for index, count := range counts {
+		assert.NotZerof(t, count, "no RepoSync assigned to pool %d", index)
+	}
+	assert.Equal(t, 0, Index(types.NamespacedName{Namespace: "ns", Name: "rs"}, 0))
+}
+
+func TestName(t *testing.T) {
+	assert.Equal(t, "reconciler-pool-3", Name(3))
+}
+
+func TestMemberUsername(t *testing.T) {
+	m := Member{SyncNamespace: "tenant", SyncName: "repo-sync", ReconcilerName: "ns-reconciler-tenant"}
+	assert.Equal(t, "system:serviceaccount:config-management-system:ns-reconciler-tenant", m.Username())
+	assert.Equal(t, types.NamespacedName{Namespace: "tenant", Name: "repo-sync"}, m.SyncRef())
+}
+
+func TestEncodeDecodeMembers(t *testing.T) {
+	b := Member{
+		SyncName:         "repo-sync",
+		SyncNamespace:    "tenant-b",
+		ReconcilerName:   "ns-reconciler-tenant-b",
+		SourceType:       configsync.OciSource,
+		SourceRepo:       "us-docker.pkg.dev/project/repo/b",
+		StatusMode:       metadata.StatusEnabled,
+		ReconcileTimeout: "5m0s",
+		APIServerTimeout: "15s",
+	}
+	a := Member{
+		SyncName:       "repo-sync",
+		SyncNamespace:  "tenant-a",
+		ReconcilerName: "ns-reconciler-tenant-a",
+		SourceType:     configsync.OciSource,
+		SourceRepo:     "us-docker.pkg.dev/project/repo/a",
+		SyncDir:        "configs",
+		MinWorkers:     2,
+		MaxWorkers:     8,
+		WebhookEnabled: true,
+	}
+
+	data, err := EncodeMembers([]Member{b, a})
+	require.NoError(t, err)
+	// Encoding is independent of the input order.
+	data2, err := EncodeMembers([]Member{a, b})
+	require.NoError(t, err)
+	assert.Equal(t, data, data2)
+
+	cm := &corev1.ConfigMap{Data: map[string]string{MembersKey: data}}
+	got, err := DecodeMembers(cm)
+	require.NoError(t, err)
+	assert.Equal(t, []Member{a, b}, got)
+
+	got, err = DecodeMembers(&corev1.ConfigMap{})
+	require.NoError(t, err)
+	assert.Empty(t, got)
+
+	_, err = DecodeMembers(&corev1.ConfigMap{Data: map[string]string{MembersKey: "{"}})
+	assert.Error(t, err)
+}
@@ -0,0 +1,139 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconciler
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/core/k8sobjects"
+	"kpt.dev/configsync/pkg/reconciler/pool"
+	"kpt.dev/configsync/pkg/reconcilermanager"
+	syncertestfake "kpt.dev/configsync/pkg/syncer/syncertest/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// fakePipelines records the pipelines started by the pool runner.
+type fakePipelines struct {
+	mux     sync.Mutex
+	started map[types.NamespacedName]int
+	// fail makes the pipelines exit immediately with an error.
+	fail bool
+}
+
+func (f *fakePipelines) run(ctx context.Context, m pool.Member) error {
+	f.mux.Lock()
+	f.started[m.SyncRef()]++
+	fail := f.fail
+	f.mux.Unlock()
+	if fail {
+		return errors.New("pipeline failed")
+	}
+	<-ctx.Done()
+	return nil
+}
+
+func (f *fakePipelines) startCount(ref types.NamespacedName) int {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+	return f.started[ref]
+}
+
+func poolConfigMap(t *testing.T, members ...pool.Member) *corev1.ConfigMap {
+	t.Helper()
+	data, err := pool.EncodeMembers(members)
+	require.NoError(t, err)
+	cm := k8sobjects.ConfigMapObject(core.Name(pool.Name(0)), core.Namespace(configsync.ControllerNamespace))
+	cm.Data = map[string]string{pool.MembersKey: data}
+	return cm
+}
+
+func TestPoolRunnerSync(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	memberA := pool.Member{SyncNamespace: "tenant-a", SyncName: "repo-sync", SourceRepo: "example.com/a"}
+	memberB := pool.Member{SyncNamespace: "tenant-b", SyncName: "repo-sync", SourceRepo: "example.com/b"}
+	cm := poolConfigMap(t, memberA, memberB)
+	fakeClient := syncertestfake.NewClient(t, core.Scheme, cm)
+	pipelines := &fakePipelines{started: make(map[types.NamespacedName]int)}
+	r := &poolRunner{
+		opts:      PoolOptions{Name: pool.Name(0)},
+		reader:    fakeClient,
+		runFn:     pipelines.run,
+		pipelines: make(map[types.NamespacedName]*poolPipeline),
+	}
+
+	// New members are started.
+	r.sync(ctx)
+	require.Len(t, r.pipelines, 2)
+	r.sync(ctx)
+	require.Len(t, r.pipelines, 2)
+	assert.False(t, r.pipelines[memberA.SyncRef()].exited())
+
+	// Changed members are restarted, and removed members are stopped.
+	memberA.SourceRepo = "example.com/a2"
+	updated := poolConfigMap(t, memberA)
+	updated.ResourceVersion = cm.ResourceVersion
+	require.NoError(t, fakeClient.Update(ctx, updated, client.FieldOwner(reconcilermanager.FieldManager)))
+	r.sync(ctx)
+	require.Len(t, r.pipelines, 1)
+	assert.Equal(t, memberA, r.pipelines[memberA.SyncRef()].member)
+
+	// All pipelines are stopped when the ConfigMap is deleted.
+	require.NoError(t, fakeClient.Delete(ctx, updated))
+	r.sync(ctx)
+	assert.Empty(t, r.pipelines)
+}
+
+func TestPoolRunnerRestartsFailedPipelines(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	member := pool.Member{SyncNamespace: "tenant-a", SyncName: "repo-sync"}
+	fakeClient := syncertestfake.NewClient(t, core.Scheme, poolConfigMap(t, member))
+	pipelines := &fakePipelines{started: make(map[types.NamespacedName]int), fail: true}
+	r := &poolRunner{
+		opts:      PoolOptions{Name: pool.Name(0)},
+		reader:    fakeClient,
+		runFn:     pipelines.run,
+		pipelines: make(map[types.NamespacedName]*poolPipeline),
+	}
+
+	r.sync(ctx)
+	<-r.pipelines[member.SyncRef()].done
+	r.sync(ctx)
+	<-r.pipelines[member.SyncRef()].done
+	assert.Equal(t, 2, pipelines.startCount(member.SyncRef()))
+
+	// Pipelines which exit without error are not restarted.
+	pipelines.mux.Lock()
+	pipelines.fail = false
+	pipelines.mux.Unlock()
+	r.sync(ctx)
+	r.pipelines[member.SyncRef()].cancel()
+	<-r.pipelines[member.SyncRef()].done
+	r.sync(ctx)
+	assert.Equal(t, 3, pipelines.startCount(member.SyncRef()))
+	r.stopAll()
+}
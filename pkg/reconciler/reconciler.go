@@ -16,6 +16,7 @@ package reconciler
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"time"
 
@@ -25,6 +26,7 @@ import (
 	"k8s.io/client-go/rest"
 	"k8s.io/klog/v2"
 	"k8s.io/utils/clock"
+	"k8s.io/utils/ptr"
 	"kpt.dev/configsync/pkg/api/configsync"
 	"kpt.dev/configsync/pkg/applier"
 	"kpt.dev/configsync/pkg/applyset"
@@ -37,6 +39,7 @@ import (
 	"kpt.dev/configsync/pkg/metadata"
 	"kpt.dev/configsync/pkg/parse"
 	"kpt.dev/configsync/pkg/parse/events"
+	"kpt.dev/configsync/pkg/reconciler/clusterlabelcontroller"
 	"kpt.dev/configsync/pkg/reconciler/finalizer"
 	"kpt.dev/configsync/pkg/reconciler/namespacecontroller"
 	"kpt.dev/configsync/pkg/reconcilermanager/controllers"
@@ -49,6 +52,7 @@ import (
 	"kpt.dev/configsync/pkg/syncer/reconcile/fight"
 	"kpt.dev/configsync/pkg/util"
 	utilwatch "kpt.dev/configsync/pkg/util/watch"
+	"kpt.dev/configsync/pkg/validate/openapi"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -65,10 +69,14 @@ type Options struct {
 	// Resource at which the reconciler will log warnings about too many updates
 	// to the resource.
 	FightDetectionThreshold float64
-	// NumWorkers is the number of concurrent remediator workers to run at once.
-	// Each worker pulls resources off of the work queue and remediates them one
-	// at a time.
-	NumWorkers int
+	// MinWorkers is the minimum number of concurrent remediator workers to run
+	// at once. Each worker pulls resources off of the work queue and remediates
+	// them one at a time.
+	MinWorkers int
+	// MaxWorkers is the maximum number of concurrent remediator workers to run
+	// at once. The remediator adds workers while the work queue is backed up,
+	// and removes them when idle or throttled by the API server.
+	MaxWorkers int
 	// ReconcilerScope is the scope of resources which the reconciler will manage.
 	// Currently this can either be a namespace or the root scope which allows a
 	// cluster admin to manage the entire cluster.
@@ -119,9 +127,21 @@ type Options struct {
 	ReconcileTimeout string
 	// APIServerTimeout is the client-side timeout used for talking to the API server
 	APIServerTimeout string
+	// ClientQPS overrides the client-side throttling QPS of the requests to the
+	// API server. Zero keeps the default.
+	ClientQPS int
+	// ClientBurst overrides the client-side throttling burst of the requests to
+	// the API server. Zero defaults to twice ClientQPS.
+	ClientBurst int
+	// ApplierMaxInflightRequests limits the number of concurrent requests from
+	// the applier to the API server. Zero is unlimited.
+	ApplierMaxInflightRequests int
 	// RenderingEnabled indicates whether the reconciler Pod is currently running
 	// with the hydration-controller.
 	RenderingEnabled bool
+	// RenderingEngine is the engine used to render the source configs. If
+	// empty, the engine is detected from the source files.
+	RenderingEngine configsync.RenderingEngine
 	// RootOptions is the set of options to fill in if this is configuring the
 	// Root reconciler.
 	// Unset for Namespace repositories.
@@ -135,6 +155,26 @@ type Options struct {
 	WebhookEnabled bool
 	// ReconcilerSignalsDir is the absolute path to the directory of ready-to-render file shared with hydration-controller
 	ReconcilerSignalsDir cmpath.Absolute
+	// ImpersonateUsername is the username to impersonate when talking to the
+	// API server. Used by pooled reconcilers to run each pipeline with the
+	// permissions of the RepoSync's reconciler ServiceAccount.
+	// Unset for dedicated reconcilers.
+	ImpersonateUsername string
+	// Pooled indicates whether the pipeline shares the process with the
+	// pipelines of other RepoSyncs, in which case it must not bind the metrics
+	// port or require unique controller names.
+	Pooled bool
+	// LeaderElection indicates whether the reconciler Deployment has more than
+	// one replica, in which case only the holder of the leader election Lease
+	// runs the pipeline.
+	LeaderElection bool
+	// ResourceUsageReporting indicates whether to report the resource usage of
+	// the reconciler in the RSync status, to recommend the resources of the
+	// reconciler container.
+	ResourceUsageReporting bool
+	// MinimalRBAC indicates whether the permissions of the reconciler are
+	// generated from the declared kinds by the reconciler-manager.
+	MinimalRBAC bool
 }
 
 // RootOptions are the options specific to parsing Root repositories.
@@ -151,32 +191,54 @@ func Run(opts Options) {
 	signalCtx := signals.SetupSignalHandler()
 	fight.SetFightThreshold(opts.FightDetectionThreshold)
 
+	if opts.LeaderElection {
+		if err := runWithLeaderElection(signalCtx, opts); err != nil {
+			klog.Fatal(err)
+		}
+	} else if err := RunPipeline(signalCtx, opts); err != nil {
+		klog.Fatal(err)
+	}
+
+	// Wait for exit signal, if not already received.
+	// This avoids unnecessary restarts after the finalizer has completed.
+	<-signalCtx.Done()
+	klog.Info("All controllers exited")
+}
+
+// RunPipeline configures and runs the parser, remediator, finalizer and
+// controllers which sync one RootSync or RepoSync, until the parent context is
+// done and they have all exited.
+//
+// Returns an error if the pipeline could not be configured.
+func RunPipeline(parentCtx context.Context, opts Options) error {
 	// Get a config to talk to the apiserver.
 	apiServerTimeout, err := time.ParseDuration(opts.APIServerTimeout)
 	if err != nil {
-		klog.Fatalf("Error parsing applier reconcile/prune task timeout: %v", err)
+		return fmt.Errorf("parsing API server timeout: %w", err)
 	}
 	if apiServerTimeout <= 0 {
-		klog.Fatalf("Invalid apiServerTimeout: %v, timeout should be positive", apiServerTimeout)
+		return fmt.Errorf("invalid apiServerTimeout: %v, timeout should be positive", apiServerTimeout)
 	}
 	cfg, err := restconfig.NewRestConfig(apiServerTimeout)
 	if err != nil {
-		klog.Fatalf("Error creating rest config: %v", err)
+		return fmt.Errorf("creating rest config: %w", err)
 	}
+	cfg.Impersonate.UserName = opts.ImpersonateUsername
+	restconfig.SetRateLimits(cfg, opts.ClientQPS, opts.ClientBurst)
 
 	configFlags, err := restconfig.NewConfigFlags(cfg)
 	if err != nil {
-		klog.Fatalf("Error creating config flags from rest config: %v", err)
+		return fmt.Errorf("creating config flags from rest config: %w", err)
 	}
 
 	discoveryClient, err := configFlags.ToDiscoveryClient()
 	if err != nil {
-		klog.Fatalf("Error creating discovery client: %v", err)
+		return fmt.Errorf("creating discovery client: %w", err)
 	}
 
 	mapper, err := utilwatch.ReplaceOnResetRESTMapperFromConfig(cfg)
 	if err != nil {
-		klog.Fatalf("Error creating resettable rest mapper: %v", err)
+		return fmt.Errorf("creating resettable rest mapper: %w", err)
 	}
 
 	cl, err := client.New(cfg, client.Options{
@@ -184,7 +246,7 @@ func Run(opts Options) {
 		Mapper: mapper,
 	})
 	if err != nil {
-		klog.Fatalf("failed to create client: %v", err)
+		return fmt.Errorf("failed to create client: %w", err)
 	}
 
 	// Configure the Applier.
@@ -192,38 +254,49 @@ func Run(opts Options) {
 	genericClient := syncerclient.New(cl, metrics.APICallDuration)
 	baseApplier, err := reconcile.NewApplierForMultiRepo(cfg, genericClient, applySetID)
 	if err != nil {
-		klog.Fatalf("Instantiating Applier: %v", err)
+		return fmt.Errorf("instantiating Applier: %w", err)
 	}
 
 	reconcileTimeout, err := time.ParseDuration(opts.ReconcileTimeout)
 	if err != nil {
-		klog.Fatalf("Error parsing applier reconcile/prune task timeout: %v", err)
+		return fmt.Errorf("parsing applier reconcile/prune task timeout: %w", err)
 	}
 	if reconcileTimeout < 0 {
-		klog.Fatalf("Invalid reconcileTimeout: %v, timeout should not be negative", reconcileTimeout)
+		return fmt.Errorf("invalid reconcileTimeout: %v, timeout should not be negative", reconcileTimeout)
 	}
-	clientSet, err := applier.NewClientSet(cl, configFlags, opts.ReconcilerScope, opts.SyncName, opts.StatusMode, applySetID)
+	// The applier may send many concurrent requests, so it gets a separate
+	// config with the in-flight requests limit.
+	applierCfg := rest.CopyConfig(cfg)
+	restconfig.LimitInflightRequests(applierCfg, opts.ApplierMaxInflightRequests)
+	applierConfigFlags, err := restconfig.NewConfigFlags(applierCfg)
 	if err != nil {
-		klog.Fatalf("Error creating clients: %v", err)
+		return fmt.Errorf("creating config flags from applier rest config: %w", err)
+	}
+	// The declared resources are shared by the Applier, which reports their
+	// sources in the ResourceGroup status, and the Remediator.
+	decls := &declared.Resources{}
+	clientSet, err := applier.NewClientSet(cl, applierConfigFlags, opts.ReconcilerScope, opts.SyncName, opts.StatusMode, applySetID, decls)
+	if err != nil {
+		return fmt.Errorf("creating clients: %w", err)
 	}
 	supervisor := applier.NewSupervisor(clientSet, opts.ReconcilerScope, opts.SyncName, reconcileTimeout)
-	if err := supervisor.UpdateStatusMode(signalCtx); err != nil {
-		klog.Fatalf("Error setting status mode on ResourceGroup: %v", err)
+	if err := supervisor.UpdateStatusMode(parentCtx); err != nil {
+		return fmt.Errorf("setting status mode on ResourceGroup: %w", err)
 	}
 
 	// Configure the Remediator.
-	decls := &declared.Resources{}
-
 	// Get a separate config for the remediator to talk to the apiserver since
 	// we want a longer REST config timeout for the remediator to avoid restarting
 	// idle watches too frequently.
 	cfgForWatch, err := restconfig.NewRestConfig(watch.RESTConfigTimeout)
 	if err != nil {
-		klog.Fatalf("Error creating rest config for the remediator: %v", err)
+		return fmt.Errorf("creating rest config for the remediator: %w", err)
 	}
+	cfgForWatch.Impersonate.UserName = opts.ImpersonateUsername
+	restconfig.SetRateLimits(cfgForWatch, opts.ClientQPS, opts.ClientBurst)
 	dynamicClient, err := dynamic.NewForConfig(cfgForWatch)
 	if err != nil {
-		klog.Fatalf("Error creating DynamicClient for the remediator: %v", err)
+		return fmt.Errorf("creating DynamicClient for the remediator: %w", err)
 	}
 	lwFactory := &watch.DynamicListerWatcherFactory{
 		DynamicClient: dynamicClient,
@@ -234,9 +307,13 @@ func Run(opts Options) {
 	conflictHandler := conflict.NewHandler()
 	fightHandler := fight.NewHandler()
 
-	rem, err := remediator.New(opts.ReconcilerScope, opts.SyncName, watcherFactory, mapper, baseApplier, conflictHandler, fightHandler, crdController, decls, opts.NumWorkers)
+	rem, err := remediator.New(opts.ReconcilerScope, opts.SyncName, watcherFactory, mapper, baseApplier, conflictHandler, fightHandler, crdController, decls, remediator.WorkerPoolOptions{
+		MinWorkers: opts.MinWorkers,
+		MaxWorkers: opts.MaxWorkers,
+		Throttled:  genericClient.Throttled,
+	})
 	if err != nil {
-		klog.Fatalf("Instantiating Remediator: %v", err)
+		return fmt.Errorf("instantiating Remediator: %w", err)
 	}
 
 	// Configure the Parser.
@@ -267,13 +344,28 @@ func Run(opts Options) {
 		WebhookEnabled:    opts.WebhookEnabled,
 		DeclaredResources: decls,
 	}
+	// The cluster labels are read before the first sync, so that the first
+	// sync already selects the objects with them.
+	clusterLabelsState := clusterlabelcontroller.NewState()
+	clusterLabelController := clusterlabelcontroller.New(cl, clusterLabelsState)
+	if err := clusterLabelController.Refresh(parentCtx); err != nil {
+		return fmt.Errorf("reading the cluster labels: %w", err)
+	}
+	parseOpts.ClusterLabelsState = clusterLabelsState
 	// Only instantiate the converter when the webhook is enabled because the
 	// instantiation pulls fresh schemas from the openapi discovery endpoint.
 	if opts.WebhookEnabled {
 		parseOpts.Converter, err = declared.NewValueConverter(discoveryClient)
 		if err != nil {
-			klog.Fatalf("Instantiating converter: %v", err)
+			return fmt.Errorf("instantiating converter: %w", err)
 		}
+		// The declared objects are validated against the schemas pulled by
+		// the converter, rather than pulling and holding them twice.
+		parseOpts.Schemas = openapi.NewSourceResources(parseOpts.Converter)
+	} else {
+		// The declared objects are validated against the OpenAPI schemas
+		// served by the cluster, which are pulled on the first validation.
+		parseOpts.Schemas = openapi.NewClusterResources(discoveryClient)
 	}
 
 	// Use the builder to build a set of event publishers for parser.Run.
@@ -288,6 +380,8 @@ func Run(opts Options) {
 		// TODO: Shouldn't this use opts.RetryPeriod as the initial duration?
 		// Limit to 12 retries, with no max retry duration.
 		RetryBackoff: util.BackoffWithDurationAndStepLimit(0, 12),
+		// Check whether the cluster labels changed every second.
+		ClusterLabelControllerPeriod: time.Second,
 	}
 
 	reconcilerOpts := &parse.ReconcilerOptions{
@@ -299,9 +393,12 @@ func Run(opts Options) {
 			Remediator:     rem,
 			SyncErrorCache: parse.NewSyncErrorCache(conflictHandler, fightHandler),
 		},
-		FullSyncPeriod:     opts.FullSyncPeriod,
-		StatusUpdatePeriod: opts.StatusUpdatePeriod,
-		RenderingEnabled:   opts.RenderingEnabled,
+		FullSyncPeriod:         opts.FullSyncPeriod,
+		StatusUpdatePeriod:     opts.StatusUpdatePeriod,
+		RenderingEnabled:       opts.RenderingEnabled,
+		RenderingEngine:        opts.RenderingEngine,
+		ResourceUsageReporting: opts.ResourceUsageReporting,
+		MinimalRBAC:            opts.MinimalRBAC,
 	}
 
 	var nsControllerState *namespacecontroller.State
@@ -335,7 +432,7 @@ func Run(opts Options) {
 			return mapper, nil
 		},
 		BaseContext: func() context.Context {
-			return signalCtx
+			return parentCtx
 		},
 	}
 	// For Namespaced Reconcilers, set the default namespace to watch.
@@ -347,21 +444,27 @@ func Run(opts Options) {
 			string(opts.ReconcilerScope): {},
 		}
 	}
+	// Pooled pipelines share the process, so they can't all bind the metrics
+	// port, and they register controllers with the same names.
+	if opts.Pooled {
+		mgrOptions.Metrics.BindAddress = "0"
+		mgrOptions.Controller.SkipNameValidation = ptr.To(true)
+	}
 	mgr, err := ctrl.NewManager(cfgForWatch, mgrOptions)
 	if err != nil {
-		klog.Fatalf("Instantiating Controller Manager: %v", err)
+		return fmt.Errorf("instantiating Controller Manager: %w", err)
 	}
 
 	crdControllerLogger := opts.Logger.WithName("controllers").WithName("CRD")
 	crdMetaController := controllers.NewCRDMetaController(crdController,
 		mgr.GetCache(), mapper, crdControllerLogger)
 	if err := crdMetaController.Register(mgr); err != nil {
-		klog.Fatalf("Instantiating CRD Controller: %v", err)
+		return fmt.Errorf("instantiating CRD Controller: %w", err)
 	}
 
 	// This cancelFunc will be used by the Finalizer to stop all the other
 	// controllers (Parser & Remediator).
-	ctx, stopControllers := context.WithCancel(signalCtx)
+	ctx, stopControllers := context.WithCancel(parentCtx)
 	// This channel will be closed when all the other controllers have exited,
 	// signalling for the finalizer to continue.
 	continueChanForFinalizer := make(chan struct{})
@@ -385,7 +488,7 @@ func Run(opts Options) {
 
 	// Register the Finalizer Controller
 	if err := finalizerController.SetupWithManager(mgr); err != nil {
-		klog.Fatalf("Instantiating Finalizer: %v", err)
+		return fmt.Errorf("instantiating Finalizer: %w", err)
 	}
 
 	// Only create and register the Namespace Controller when the flag is enabled.
@@ -397,10 +500,16 @@ func Run(opts Options) {
 		// Register the Namespace Controller
 		// The controller will stop when the controller-manager shuts down.
 		if err := nsController.SetupWithManager(mgr); err != nil {
-			klog.Fatalf("Instantiating Namespace Controller: %v", err)
+			return fmt.Errorf("instantiating Namespace Controller: %w", err)
 		}
 	}
 
+	// Register the Cluster Label Controller
+	// The controller will stop when the controller-manager shuts down.
+	if err := clusterLabelController.SetupWithManager(mgr); err != nil {
+		return fmt.Errorf("instantiating Cluster Label Controller: %w", err)
+	}
+
 	klog.Info("Starting ControllerManager")
 	// TODO: Once everything is using the controller-manager, move mgr.Start to the top level.
 	doneChanForManager := make(chan struct{})
@@ -411,7 +520,7 @@ func Run(opts Options) {
 			stopControllers()
 			close(doneChanForManager) // Signal thread completion
 		}()
-		err := mgr.Start(signalCtx) // blocks on signalCtx.Done()
+		err := mgr.Start(parentCtx) // blocks on parentCtx.Done()
 		if err != nil {
 			klog.Errorf("Starting ControllerManager: %v", err)
 			// klog.Fatalf calls os.Exit, which doesn't trigger defer funcs.
@@ -430,7 +539,7 @@ func Run(opts Options) {
 	funnel := &events.Funnel{
 		Publishers: pgBuilder.Build(),
 		// Wrap the parser with an event handler that triggers the RunFunc, as needed.
-		Subscriber: parse.NewEventHandler(ctx, reconciler, nsControllerState),
+		Subscriber: parse.NewEventHandler(ctx, reconciler, nsControllerState, clusterLabelsState),
 	}
 	doneChForParser := funnel.Start(ctx)
 
@@ -447,9 +556,5 @@ func Run(opts Options) {
 	// Wait for ControllerManager to exit
 	<-doneChanForManager
 	klog.Info("Finalizer exited")
-
-	// Wait for exit signal, if not already received.
-	// This avoids unnecessary restarts after the finalizer has completed.
-	<-signalCtx.Done()
-	klog.Info("All controllers exited")
+	return nil
 }
@@ -35,6 +35,10 @@ const (
 	// of the cluster.
 	ClusterNameKey = "CLUSTER_NAME"
 
+	// ImageRegistryMirrorKey is the OS env variable key for the registry
+	// prefix which replaces the registry of the reconciler container images.
+	ImageRegistryMirrorKey = "IMAGE_REGISTRY_MIRROR"
+
 	// ScopeKey is the OS env variable key for the scope of the
 	// reconciler and hydration controller.
 	ScopeKey = "SCOPE"
@@ -107,6 +111,46 @@ const (
 	// WebhookEnabled tells the reconciler container whether the Admission Webhook
 	// is installed and running on the cluster.
 	WebhookEnabled = "WEBHOOK_ENABLED"
+
+	// RemediatorMinWorkers is to control the minimum number of concurrent
+	// remediator workers.
+	RemediatorMinWorkers = "REMEDIATOR_MIN_WORKERS"
+
+	// RemediatorMaxWorkers is to control the maximum number of concurrent
+	// remediator workers.
+	RemediatorMaxWorkers = "REMEDIATOR_MAX_WORKERS"
+
+	// ReconcilerPool is the OS env variable key for the name of the reconciler
+	// pool. When set, the reconciler runs the pipelines of the RepoSyncs
+	// assigned to the pool, instead of a single RootSync or RepoSync.
+	ReconcilerPool = "RECONCILER_POOL"
+
+	// LeaderElectionEnabled tells the reconciler container whether to acquire
+	// the leader election Lease before syncing, which is required when the
+	// reconciler Deployment has more than one replica.
+	LeaderElectionEnabled = "LEADER_ELECTION_ENABLED"
+
+	// ResourceUsageReportingEnabled tells the reconciler container whether to
+	// report its resource usage in the RSync status, which is required to
+	// recommend the resources of the reconciler container.
+	ResourceUsageReportingEnabled = "RESOURCE_USAGE_REPORTING_ENABLED"
+
+	// APIClientQPS is to control the maximum sustained QPS of the reconciler's
+	// requests to the API server.
+	APIClientQPS = "API_CLIENT_QPS"
+
+	// APIClientBurst is to control the maximum burst of the reconciler's
+	// requests to the API server.
+	APIClientBurst = "API_CLIENT_BURST"
+
+	// ApplierMaxInflightRequests is to control the maximum number of
+	// concurrent requests from the applier to the API server.
+	ApplierMaxInflightRequests = "APPLIER_MAX_INFLIGHT_REQUESTS"
+
+	// MinimalRBACEnabled tells the reconciler container whether its
+	// permissions are generated from the declared kinds, in which case it
+	// reports the declared kinds and waits for the permissions before applying.
+	MinimalRBACEnabled = "MINIMAL_RBAC_ENABLED"
 )
 
 const (
@@ -131,6 +175,15 @@ const (
 	// HydrationPollingPeriod defines how often the hydration controller should
 	// poll the filesystem for rendering the DRY configs.
 	HydrationPollingPeriod = "HYDRATION_POLLING_PERIOD"
+
+	// RenderingEngine is the engine used by the hydration controller to render
+	// the DRY configs, which the reconciler also uses to check if rendering is
+	// required. If unset, the engine is detected from the source files.
+	RenderingEngine = "RENDERING_ENGINE"
+
+	// EnableShellInRendering indicates whether the shell is enabled in the
+	// rendering process of the hydration controller.
+	EnableShellInRendering = "ENABLE_SHELL_IN_RENDERING"
 )
 
 const (
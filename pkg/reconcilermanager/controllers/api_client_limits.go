@@ -0,0 +1,93 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
+)
+
+// APIClientLimits are the client-side rate limits of a reconciler's requests
+// to the API server. Zero means the reconciler default.
+type APIClientLimits struct {
+	// QPS is the maximum sustained number of requests per second.
+	QPS int
+	// Burst is the maximum number of requests in a burst.
+	Burst int
+	// ApplierMaxInflightRequests is the maximum number of concurrent requests
+	// from the applier, excluding watches.
+	ApplierMaxInflightRequests int
+}
+
+// apiClientLimits returns the limits specified by the override. If only qps is
+// specified, burst defaults to twice qps.
+func apiClientLimits(o *v1beta1.APIClientOverride) APIClientLimits {
+	var limits APIClientLimits
+	if o == nil {
+		return limits
+	}
+	if o.QPS != nil && *o.QPS > 0 {
+		limits.QPS = int(*o.QPS)
+	}
+	if o.Burst != nil && *o.Burst > 0 {
+		limits.Burst = int(*o.Burst)
+	}
+	if o.ApplierMaxInflightRequests != nil && *o.ApplierMaxInflightRequests > 0 {
+		limits.ApplierMaxInflightRequests = int(*o.ApplierMaxInflightRequests)
+	}
+	if limits.QPS > 0 && limits.Burst == 0 {
+		limits.Burst = 2 * limits.QPS
+	}
+	return limits
+}
+
+// capped returns the limits, lowered to the caps. Limits which are unset
+// default to the caps, since the reconciler defaults may be unlimited.
+// Caps which are zero are ignored.
+func (l APIClientLimits) capped(caps APIClientLimits) APIClientLimits {
+	result := APIClientLimits{
+		QPS:                        capLimit(l.QPS, caps.QPS),
+		Burst:                      l.Burst,
+		ApplierMaxInflightRequests: capLimit(l.ApplierMaxInflightRequests, caps.ApplierMaxInflightRequests),
+	}
+	if result.QPS > 0 && result.Burst == 0 {
+		result.Burst = 2 * result.QPS
+	}
+	result.Burst = capLimit(result.Burst, caps.Burst)
+	return result
+}
+
+func capLimit(limit, maxLimit int) int {
+	if maxLimit <= 0 {
+		return limit
+	}
+	if limit <= 0 || limit > maxLimit {
+		return maxLimit
+	}
+	return limit
+}
+
+// SetAPIClientCaps sets the cluster-level caps of the API client limits of the
+// namespace reconcilers, so that RepoSync overrides can't exceed the budget
+// set by the cluster admin. Zero values are not capped.
+// Must be called before the controller is registered.
+func (r *RepoSyncReconciler) SetAPIClientCaps(caps APIClientLimits) {
+	r.apiClientCaps = caps
+}
+
+// apiClientLimits returns the API client limits of the RepoSync's reconciler,
+// capped by the cluster-level caps.
+func (r *RepoSyncReconciler) apiClientLimits(rs *v1beta1.RepoSync) APIClientLimits {
+	return apiClientLimits(rs.Spec.SafeOverride().APIClient).capped(r.apiClientCaps)
+}
@@ -0,0 +1,82 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/ptr"
+	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
+)
+
+func TestAPIClientLimits(t *testing.T) {
+	testCases := []struct {
+		name     string
+		override *v1beta1.APIClientOverride
+		caps     APIClientLimits
+		want     APIClientLimits
+	}{
+		{
+			name: "no override, no caps",
+			want: APIClientLimits{},
+		},
+		{
+			name:     "qps defaults burst",
+			override: &v1beta1.APIClientOverride{QPS: ptr.To[int32](10)},
+			want:     APIClientLimits{QPS: 10, Burst: 20},
+		},
+		{
+			name: "all overridden",
+			override: &v1beta1.APIClientOverride{
+				QPS:                        ptr.To[int32](10),
+				Burst:                      ptr.To[int32](15),
+				ApplierMaxInflightRequests: ptr.To[int32](4),
+			},
+			want: APIClientLimits{QPS: 10, Burst: 15, ApplierMaxInflightRequests: 4},
+		},
+		{
+			name: "no override defaults to caps",
+			caps: APIClientLimits{QPS: 5, Burst: 8, ApplierMaxInflightRequests: 2},
+			want: APIClientLimits{QPS: 5, Burst: 8, ApplierMaxInflightRequests: 2},
+		},
+		{
+			name: "override above caps",
+			override: &v1beta1.APIClientOverride{
+				QPS:                        ptr.To[int32](100),
+				ApplierMaxInflightRequests: ptr.To[int32](50),
+			},
+			caps: APIClientLimits{QPS: 20, Burst: 30, ApplierMaxInflightRequests: 10},
+			want: APIClientLimits{QPS: 20, Burst: 30, ApplierMaxInflightRequests: 10},
+		},
+		{
+			name:     "override below caps",
+			override: &v1beta1.APIClientOverride{QPS: ptr.To[int32](5)},
+			caps:     APIClientLimits{QPS: 20, Burst: 30},
+			want:     APIClientLimits{QPS: 5, Burst: 10},
+		},
+		{
+			name:     "qps cap only",
+			override: &v1beta1.APIClientOverride{ApplierMaxInflightRequests: ptr.To[int32](3)},
+			caps:     APIClientLimits{QPS: 20},
+			want:     APIClientLimits{QPS: 20, Burst: 40, ApplierMaxInflightRequests: 3},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, apiClientLimits(tc.override).capped(tc.caps))
+		})
+	}
+}
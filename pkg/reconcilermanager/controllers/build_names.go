@@ -49,6 +49,15 @@ const (
 	// for RootSync objects. This contains basic permissions for RootSync reconcilers
 	// (e.g. RootSync status update).
 	RootSyncBaseClusterRoleBindingName = RootSyncBaseClusterRoleName + "-base"
+	// ReconcilerConfigReaderRoleName is the name of the Role which grants the
+	// reconcilers read access to the cluster-level configuration ConfigMaps in
+	// the config-management-system namespace.
+	// e.g. configsync.gke.io:reconciler:config-reader
+	ReconcilerConfigReaderRoleName = configsync.GroupName + ":reconciler:config-reader"
+	// ReconcilerConfigReaderRoleBindingName is the name of the RoleBinding
+	// created for both RootSync and RepoSync objects, which binds the
+	// reconcilers to the config reader Role.
+	ReconcilerConfigReaderRoleBindingName = ReconcilerConfigReaderRoleName
 )
 
 // ReconcilerResourceName returns resource name in the format <reconciler-name>-<resource-name>.
@@ -0,0 +1,141 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/reconcilermanager"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// ReconcilerDeploymentPatchName is the name of the ConfigMap in the
+	// config-management-system namespace which contains a strategic merge
+	// patch applied to the Deployments of all the reconcilers.
+	ReconcilerDeploymentPatchName = "reconciler-deployment-patch"
+
+	// DeploymentPatchKey is the ConfigMap data key of a reconciler Deployment
+	// patch.
+	DeploymentPatchKey = "patch.yaml"
+
+	// deploymentPatchSuffix is the suffix of the name of the ConfigMap which
+	// contains the patch for the Deployment of a single reconciler.
+	deploymentPatchSuffix = "deployment-patch"
+)
+
+// DeploymentPatchName returns the name of the ConfigMap in the
+// config-management-system namespace which contains a strategic merge patch
+// for the Deployment of the specified reconciler. It is applied after the
+// patch for all the reconcilers.
+func DeploymentPatchName(reconcilerName string) string {
+	return ReconcilerResourceName(reconcilerName, deploymentPatchSuffix)
+}
+
+// applyDeploymentPatches applies the patch for all the reconcilers, followed by
+// the patch for this reconciler, to the reconciler Deployment, if the patch
+// ConfigMaps exist.
+func (r *reconcilerBase) applyDeploymentPatches(ctx context.Context, reconcilerRef types.NamespacedName, d *appsv1.Deployment) error {
+	cmRefs := []types.NamespacedName{
+		{Namespace: configsync.ControllerNamespace, Name: ReconcilerDeploymentPatchName},
+		{Namespace: configsync.ControllerNamespace, Name: DeploymentPatchName(reconcilerRef.Name)},
+	}
+	for _, cmRef := range cmRefs {
+		cm := &corev1.ConfigMap{}
+		if err := r.client.Get(ctx, cmRef, cm); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return NewObjectOperationErrorWithKey(err, cm, OperationGet, cmRef)
+		}
+		patch, found := cm.Data[DeploymentPatchKey]
+		if !found {
+			return NewDeploymentPatchError(fmt.Errorf("missing data key %q", DeploymentPatchKey), cmRef)
+		}
+		patched, err := patchDeployment(d, patch)
+		if err != nil {
+			return NewDeploymentPatchError(err, cmRef)
+		}
+		*d = *patched
+	}
+	return nil
+}
+
+// patchDeployment returns a copy of the Deployment with the YAML strategic
+// merge patch applied.
+func patchDeployment(d *appsv1.Deployment, patch string) (*appsv1.Deployment, error) {
+	if strings.TrimSpace(patch) == "" {
+		return d, nil
+	}
+	patchJSON, err := yaml.YAMLToJSONStrict([]byte(patch))
+	if err != nil {
+		return nil, fmt.Errorf("parsing patch: %w", err)
+	}
+	originalJSON, err := json.Marshal(d)
+	if err != nil {
+		return nil, fmt.Errorf("encoding Deployment: %w", err)
+	}
+	patchedJSON, err := strategicpatch.StrategicMergePatch(originalJSON, patchJSON, appsv1.Deployment{})
+	if err != nil {
+		return nil, fmt.Errorf("applying patch: %w", err)
+	}
+	patched := &appsv1.Deployment{}
+	decoder := json.NewDecoder(bytes.NewReader(patchedJSON))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(patched); err != nil {
+		return nil, fmt.Errorf("decoding patched Deployment: %w", err)
+	}
+	if err := validatePatchedDeployment(d, patched); err != nil {
+		return nil, err
+	}
+	return patched, nil
+}
+
+// validatePatchedDeployment returns an error if the patch modified the fields
+// which the reconciler-manager depends on to manage the reconciler.
+func validatePatchedDeployment(original, patched *appsv1.Deployment) error {
+	if patched.Name != original.Name || patched.Namespace != original.Namespace {
+		return errors.New("must not change the name or namespace")
+	}
+	if !equality.Semantic.DeepEqual(patched.Spec.Selector, original.Spec.Selector) {
+		return errors.New("must not change spec.selector")
+	}
+	for key, value := range original.Spec.Template.Labels {
+		if patched.Spec.Template.Labels[key] != value {
+			return fmt.Errorf("must not change the pod template label %q", key)
+		}
+	}
+	if patched.Spec.Template.Spec.ServiceAccountName != original.Spec.Template.Spec.ServiceAccountName {
+		return errors.New("must not change spec.template.spec.serviceAccountName")
+	}
+	for _, container := range patched.Spec.Template.Spec.Containers {
+		if container.Name == reconcilermanager.Reconciler {
+			return nil
+		}
+	}
+	return fmt.Errorf("must not remove the %s container", reconcilermanager.Reconciler)
+}
@@ -0,0 +1,240 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
+	"kpt.dev/configsync/pkg/metadata"
+	"kpt.dev/configsync/pkg/reconcilermanager"
+	"kpt.dev/configsync/pkg/rootsync"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func testPatchDeployment() *appsv1.Deployment {
+	labels := map[string]string{metadata.DeploymentNameLabel: rootReconcilerName}
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      rootReconcilerName,
+			Namespace: configsync.ControllerNamespace,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					ServiceAccountName: rootReconcilerName,
+					Containers: []corev1.Container{
+						{Name: reconcilermanager.Reconciler, Image: "reconciler:v1"},
+						{Name: reconcilermanager.GitSync, Image: "git-sync:v1"},
+					},
+				},
+			},
+		},
+	}
+}
+
+func deploymentPatchConfigMap(name, patch string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: configsync.ControllerNamespace,
+		},
+		Data: map[string]string{DeploymentPatchKey: patch},
+	}
+}
+
+func TestPatchDeployment(t *testing.T) {
+	testCases := []struct {
+		name    string
+		patch   string
+		wantErr string
+		check   func(*testing.T, *appsv1.Deployment)
+	}{
+		{
+			name:  "empty patch",
+			patch: " \n",
+			check: func(t *testing.T, d *appsv1.Deployment) {
+				assert.Equal(t, testPatchDeployment(), d)
+			},
+		},
+		{
+			name: "add sidecar and seccomp profile",
+			patch: `
+spec:
+  template:
+    spec:
+      securityContext:
+        seccompProfile:
+          type: RuntimeDefault
+      containers:
+      - name: proxy
+        image: proxy:v1
+      - name: reconciler
+        image: mirror.example.com/reconciler:v1
+`,
+			check: func(t *testing.T, d *appsv1.Deployment) {
+				containers := d.Spec.Template.Spec.Containers
+				require.Len(t, containers, 3)
+				images := map[string]string{}
+				for _, c := range containers {
+					images[c.Name] = c.Image
+				}
+				assert.Equal(t, map[string]string{
+					reconcilermanager.Reconciler: "mirror.example.com/reconciler:v1",
+					reconcilermanager.GitSync:    "git-sync:v1",
+					"proxy":                      "proxy:v1",
+				}, images)
+				assert.Equal(t, corev1.SeccompProfileTypeRuntimeDefault,
+					d.Spec.Template.Spec.SecurityContext.SeccompProfile.Type)
+			},
+		},
+		{
+			name:    "invalid YAML",
+			patch:   "spec: [",
+			wantErr: "parsing patch",
+		},
+		{
+			name:    "unknown field",
+			patch:   "spec:\n  template:\n    spec:\n      bogus: true\n",
+			wantErr: "decoding patched Deployment",
+		},
+		{
+			name:    "change service account",
+			patch:   "spec:\n  template:\n    spec:\n      serviceAccountName: other\n",
+			wantErr: "must not change spec.template.spec.serviceAccountName",
+		},
+		{
+			name:    "change pod template label",
+			patch:   "spec:\n  template:\n    metadata:\n      labels:\n        " + metadata.DeploymentNameLabel + ": other\n",
+			wantErr: "must not change the pod template label",
+		},
+		{
+			name:    "remove reconciler container",
+			patch:   "spec:\n  template:\n    spec:\n      containers:\n      - name: reconciler\n        $patch: delete\n",
+			wantErr: "must not remove the reconciler container",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			original := testPatchDeployment()
+			patched, err := patchDeployment(original, tc.patch)
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			tc.check(t, patched)
+			// The original Deployment must not be modified.
+			assert.Equal(t, testPatchDeployment(), original)
+		})
+	}
+}
+
+func TestRootSyncDeploymentPatch(t *testing.T) {
+	// Mock out parseDeployment for testing.
+	parseDeployment = parsedDeployment
+
+	ctx := context.Background()
+	rs := rootSyncWithGit(rootsyncName, rootsyncRef(gitRevision), rootsyncBranch(branch),
+		rootsyncSecretType(configsync.AuthNone))
+	reqNamespacedName := namespacedName(rs.Name, rs.Namespace)
+	clusterPatch := deploymentPatchConfigMap(ReconcilerDeploymentPatchName, `
+spec:
+  template:
+    spec:
+      containers:
+      - name: proxy
+        image: proxy:v1
+`)
+	fakeClient, fakeDynamicClient, testReconciler := setupRootReconciler(t, rs, clusterPatch)
+	reconcilerRef := types.NamespacedName{Namespace: configsync.ControllerNamespace, Name: rootReconcilerName}
+
+	_, err := testReconciler.Reconcile(ctx, reqNamespacedName)
+	require.NoError(t, err)
+
+	d, err := getDeployment(t, fakeDynamicClient, reconcilerRef)
+	require.NoError(t, err)
+	var names []string
+	for _, c := range d.Spec.Template.Spec.Containers {
+		names = append(names, c.Name)
+	}
+	assert.Contains(t, names, "proxy")
+	assert.Contains(t, names, reconcilermanager.Reconciler)
+
+	// An invalid per-sync patch stalls the RootSync, without updating the
+	// Deployment.
+	syncPatch := deploymentPatchConfigMap(DeploymentPatchName(rootReconcilerName),
+		"spec:\n  template:\n    spec:\n      serviceAccountName: other\n")
+	require.NoError(t, fakeClient.Create(ctx, syncPatch, client.FieldOwner(reconcilermanager.FieldManager)))
+	_, err = testReconciler.Reconcile(ctx, reqNamespacedName)
+	require.NoError(t, err)
+
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(rs), rs))
+	stalled := rootsync.GetCondition(rs.Status.Conditions, v1beta1.RootSyncStalled)
+	require.NotNil(t, stalled)
+	assert.Equal(t, metav1.ConditionTrue, stalled.Status)
+	assert.Equal(t, "DeploymentPatch", stalled.Reason)
+	assert.Contains(t, stalled.Message, DeploymentPatchName(rootReconcilerName))
+
+	d, err = getDeployment(t, fakeDynamicClient, reconcilerRef)
+	require.NoError(t, err)
+	assert.Equal(t, rootReconcilerName, d.Spec.Template.Spec.ServiceAccountName)
+
+	// Fixing the patch clears the Stalled condition.
+	syncPatch.Data[DeploymentPatchKey] = "spec:\n  template:\n    spec:\n      priorityClassName: high\n"
+	require.NoError(t, fakeClient.Update(ctx, syncPatch, client.FieldOwner(reconcilermanager.FieldManager)))
+	_, err = testReconciler.Reconcile(ctx, reqNamespacedName)
+	require.NoError(t, err)
+
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(rs), rs))
+	stalled = rootsync.GetCondition(rs.Status.Conditions, v1beta1.RootSyncStalled)
+	require.NotNil(t, stalled)
+	assert.Equal(t, metav1.ConditionFalse, stalled.Status)
+	d, err = getDeployment(t, fakeDynamicClient, reconcilerRef)
+	require.NoError(t, err)
+	assert.Equal(t, "high", d.Spec.Template.Spec.PriorityClassName)
+}
+
+func TestMapDeploymentPatchToRepoSyncs(t *testing.T) {
+	ctx := context.Background()
+	rs1 := repoSyncWithGit(reposyncNs, reposyncName, reposyncRef(gitRevision), reposyncBranch(branch),
+		reposyncSecretType(configsync.AuthNone))
+	rs2 := repoSyncWithGit("other-ns", reposyncName, reposyncRef(gitRevision), reposyncBranch(branch),
+		reposyncSecretType(configsync.AuthNone))
+	_, _, testReconciler := setupNSReconciler(t, rs1, rs2)
+
+	reqs := testReconciler.mapDeploymentPatchToRepoSyncs(ctx,
+		deploymentPatchConfigMap(ReconcilerDeploymentPatchName, ""))
+	assert.Len(t, reqs, 2)
+
+	reqs = testReconciler.mapDeploymentPatchToRepoSyncs(ctx,
+		deploymentPatchConfigMap(DeploymentPatchName(nsReconcilerName), ""))
+	require.Len(t, reqs, 1)
+	assert.Equal(t, client.ObjectKeyFromObject(rs1), reqs[0].NamespacedName)
+
+	reqs = testReconciler.mapDeploymentPatchToRepoSyncs(ctx,
+		deploymentPatchConfigMap("unrelated", ""))
+	assert.Empty(t, reqs)
+}
@@ -205,3 +205,28 @@ func (n *NoRetryError) Error() string {
 func (n *NoRetryError) Unwrap() error {
 	return n.Cause
 }
+
+// DeploymentPatchError is an error from the reconciler-manager regarding an
+// invalid patch of the reconciler Deployment.
+type DeploymentPatchError struct {
+	// ConfigMap is the key of the ConfigMap which contains the patch.
+	ConfigMap client.ObjectKey
+	// Cause of the patch failure
+	Cause error
+}
+
+// NewDeploymentPatchError constructs a new DeploymentPatchError
+func NewDeploymentPatchError(err error, cmKey client.ObjectKey) *DeploymentPatchError {
+	return &DeploymentPatchError{ConfigMap: cmKey, Cause: err}
+}
+
+// Error returns the error message
+func (dpe *DeploymentPatchError) Error() string {
+	return fmt.Sprintf("invalid reconciler Deployment patch in ConfigMap (%s): %v",
+		dpe.ConfigMap, dpe.Cause)
+}
+
+// Unwrap returns the cause of this DeploymentPatchError
+func (dpe *DeploymentPatchError) Unwrap() error {
+	return dpe.Cause
+}
@@ -286,3 +286,41 @@ func (r *reconcilerBase) deleteSharedClusterRoleBinding(ctx context.Context, nam
 		logFieldObjectKind, "ClusterRoleBinding")
 	return nil
 }
+
+// deleteConfigReaderRoleBinding removes the reconciler from the subjects of
+// the shared RoleBinding to the config reader Role, and deletes the
+// RoleBinding once it has no subject left.
+func (r *reconcilerBase) deleteConfigReaderRoleBinding(ctx context.Context, reconcilerRef types.NamespacedName) error {
+	rbKey := client.ObjectKey{Namespace: configsync.ControllerNamespace, Name: ReconcilerConfigReaderRoleBindingName}
+	rb := &rbacv1.RoleBinding{}
+	if err := r.client.Get(ctx, rbKey, rb); err != nil {
+		if apierrors.IsNotFound(err) {
+			// already deleted
+			r.Logger(ctx).V(3).Info("Managed object already deleted",
+				logFieldObjectRef, rbKey.String(),
+				logFieldObjectKind, "RoleBinding")
+			return nil
+		}
+		return NewObjectOperationErrorWithKey(err, rb, OperationGet, rbKey)
+	}
+	count := len(rb.Subjects)
+	rb.Subjects = removeSubject(rb.Subjects, r.serviceAccountSubject(reconcilerRef))
+	if len(rb.Subjects) == 0 {
+		// Delete the whole RoleBinding
+		return r.cleanup(ctx, rb)
+	}
+	if count == len(rb.Subjects) {
+		// No change
+		return nil
+	}
+	r.Logger(ctx).V(3).Info("Updating managed object",
+		logFieldObjectRef, rbKey.String(),
+		logFieldObjectKind, "RoleBinding")
+	if err := r.client.Update(ctx, rb, client.FieldOwner(reconcilermanager.FieldManager)); err != nil {
+		return NewObjectOperationError(err, rb, OperationUpdate)
+	}
+	r.Logger(ctx).Info("Updating managed object successful",
+		logFieldObjectRef, rbKey.String(),
+		logFieldObjectKind, "RoleBinding")
+	return nil
+}
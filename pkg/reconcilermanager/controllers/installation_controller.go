@@ -0,0 +1,488 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"kpt.dev/configsync/pkg/api/configmanagement"
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
+	"kpt.dev/configsync/pkg/kinds"
+	"kpt.dev/configsync/pkg/metadata"
+	"kpt.dev/configsync/pkg/metrics"
+	"kpt.dev/configsync/pkg/reconcilermanager"
+	"kpt.dev/configsync/pkg/reposync"
+	"kpt.dev/configsync/pkg/rootsync"
+	webhookconfiguration "kpt.dev/configsync/pkg/webhook/configuration"
+	kstatus "sigs.k8s.io/cli-utils/pkg/kstatus/status"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// InstallationHealthyCondition is the type of the ConfigSyncInstallation
+	// condition which reports whether the installation is healthy.
+	InstallationHealthyCondition = "Healthy"
+
+	// installationResyncPeriod is the period between recomputing the
+	// ConfigSyncInstallation status without any change event, to catch
+	// changes which are not watched, like the otel-collector rollout.
+	installationResyncPeriod = time.Minute
+
+	// maxInstallationListSize is the maximum number of stalled syncs and not
+	// ready reconcilers listed in the ConfigSyncInstallation status.
+	maxInstallationListSize = 20
+)
+
+// installationComponents are the Deployments of the Config Sync components
+// reported in the ConfigSyncInstallation status.
+var installationComponents = []types.NamespacedName{
+	{Namespace: configsync.ControllerNamespace, Name: reconcilermanager.ManagerName},
+	{Namespace: configsync.ControllerNamespace, Name: webhookconfiguration.ShortName},
+	{Namespace: configmanagement.RGControllerNamespace, Name: configmanagement.RGControllerName},
+	{Namespace: configmanagement.MonitoringNamespace, Name: metrics.OtelCollectorName},
+}
+
+var _ reconcile.Reconciler = &InstallationReconciler{}
+
+// InstallationReconciler maintains the status of the ConfigSyncInstallation,
+// which aggregates the health of all RootSyncs, RepoSyncs, reconcilers and
+// Config Sync components.
+type InstallationReconciler struct {
+	*LoggingController
+
+	client client.Client
+
+	lock       sync.Mutex
+	controller controller.Controller
+}
+
+// NewInstallationReconciler returns a new InstallationReconciler.
+func NewInstallationReconciler(client client.Client, log logr.Logger) *InstallationReconciler {
+	return &InstallationReconciler{
+		LoggingController: NewLoggingController(log),
+		client:            client,
+	}
+}
+
+// Reconcile the ConfigSyncInstallation status.
+func (r *InstallationReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	ctx = r.SetLoggerValues(ctx, "installation", req.Name)
+	if req.Name != configsync.ConfigSyncInstallationName {
+		return controllerruntime.Result{}, nil
+	}
+
+	installationStatus, err := r.computeStatus(ctx)
+	if err != nil {
+		return controllerruntime.Result{}, err
+	}
+	if err := r.updateStatus(ctx, installationStatus); err != nil {
+		return controllerruntime.Result{}, err
+	}
+	metrics.RecordInstallationHealthy(ctx,
+		meta.IsStatusConditionTrue(installationStatus.Conditions, InstallationHealthyCondition))
+	return controllerruntime.Result{RequeueAfter: installationResyncPeriod}, nil
+}
+
+// syncHealth is the health of a RootSync or RepoSync.
+type syncHealth struct {
+	ref         v1beta1.SyncReference
+	reconciling bool
+	stalled     bool
+	syncing     bool
+	withErrors  bool
+}
+
+func rootSyncHealth(rs *v1beta1.RootSync) syncHealth {
+	h := syncHealth{
+		ref: v1beta1.SyncReference{
+			Kind:      configsync.RootSyncKind,
+			Namespace: rs.Namespace,
+			Name:      rs.Name,
+		},
+		withErrors: hasSyncErrors(rs.Status.Status),
+	}
+	if c := rootsync.GetCondition(rs.Status.Conditions, v1beta1.RootSyncReconciling); c != nil {
+		h.reconciling = c.Status == metav1.ConditionTrue
+	}
+	if c := rootsync.GetCondition(rs.Status.Conditions, v1beta1.RootSyncStalled); c != nil && c.Status == metav1.ConditionTrue {
+		h.stalled = true
+		h.ref.Reason = c.Reason
+		h.ref.Message = c.Message
+	}
+	if c := rootsync.GetCondition(rs.Status.Conditions, v1beta1.RootSyncSyncing); c != nil {
+		h.syncing = c.Status == metav1.ConditionTrue
+	}
+	return h
+}
+
+func repoSyncHealth(rs *v1beta1.RepoSync) syncHealth {
+	h := syncHealth{
+		ref: v1beta1.SyncReference{
+			Kind:      configsync.RepoSyncKind,
+			Namespace: rs.Namespace,
+			Name:      rs.Name,
+		},
+		withErrors: hasSyncErrors(rs.Status.Status),
+	}
+	if c := reposync.GetCondition(rs.Status.Conditions, v1beta1.RepoSyncReconciling); c != nil {
+		h.reconciling = c.Status == metav1.ConditionTrue
+	}
+	if c := reposync.GetCondition(rs.Status.Conditions, v1beta1.RepoSyncStalled); c != nil && c.Status == metav1.ConditionTrue {
+		h.stalled = true
+		h.ref.Reason = c.Reason
+		h.ref.Message = c.Message
+	}
+	if c := reposync.GetCondition(rs.Status.Conditions, v1beta1.RepoSyncSyncing); c != nil {
+		h.syncing = c.Status == metav1.ConditionTrue
+	}
+	return h
+}
+
+// hasSyncErrors returns whether the sync status reports any source, rendering
+// or sync error.
+func hasSyncErrors(s v1beta1.Status) bool {
+	for _, summary := range []*v1beta1.ErrorSummary{
+		s.Source.ErrorSummary, s.Rendering.ErrorSummary, s.Sync.ErrorSummary,
+	} {
+		if summary != nil && summary.TotalCount > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// computeStatus computes the ConfigSyncInstallation status from the RootSyncs,
+// RepoSyncs, reconciler Deployments and component Deployments.
+func (r *InstallationReconciler) computeStatus(ctx context.Context) (v1beta1.ConfigSyncInstallationStatus, error) {
+	var s v1beta1.ConfigSyncInstallationStatus
+
+	// Reconciler Deployments which are not ready, by sync.
+	notReadyReconcilers, notReadySyncs, err := r.notReadyReconcilers(ctx)
+	if err != nil {
+		return s, err
+	}
+	s.ReconcilersNotReady = notReadyReconcilers
+	if len(s.ReconcilersNotReady) > maxInstallationListSize {
+		s.ReconcilersNotReady = s.ReconcilersNotReady[:maxInstallationListSize]
+	}
+
+	rootSyncList := &v1beta1.RootSyncList{}
+	if err := r.client.List(ctx, rootSyncList); err != nil {
+		return s, fmt.Errorf("listing %s objects: %w", configsync.RootSyncKind, err)
+	}
+	var stalled []v1beta1.SyncReference
+	for i := range rootSyncList.Items {
+		h := rootSyncHealth(&rootSyncList.Items[i])
+		addSyncHealth(&s.RootSyncs, h, notReadySyncs)
+		if h.stalled {
+			stalled = append(stalled, h.ref)
+		}
+	}
+	repoSyncList := &v1beta1.RepoSyncList{}
+	if err := r.client.List(ctx, repoSyncList); err != nil {
+		return s, fmt.Errorf("listing %s objects: %w", configsync.RepoSyncKind, err)
+	}
+	for i := range repoSyncList.Items {
+		h := repoSyncHealth(&repoSyncList.Items[i])
+		addSyncHealth(&s.RepoSyncs, h, notReadySyncs)
+		if h.stalled {
+			stalled = append(stalled, h.ref)
+		}
+	}
+	sort.Slice(stalled, func(i, j int) bool {
+		a, b := stalled[i], stalled[j]
+		if a.Kind != b.Kind {
+			return a.Kind > b.Kind // RootSyncs first
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+	if len(stalled) > maxInstallationListSize {
+		stalled = stalled[:maxInstallationListSize]
+	}
+	s.StalledSyncs = stalled
+
+	for _, key := range installationComponents {
+		component, err := r.componentStatus(ctx, key)
+		if err != nil {
+			return s, err
+		}
+		s.Components = append(s.Components, component)
+	}
+
+	s.Conditions = []metav1.Condition{installationHealthyCondition(s)}
+	return s, nil
+}
+
+func addSyncHealth(summary *v1beta1.SyncSummary, h syncHealth, notReadySyncs map[v1beta1.SyncReference]bool) {
+	summary.Total++
+	if h.reconciling {
+		summary.Reconciling++
+	}
+	if h.stalled {
+		summary.Stalled++
+	}
+	if h.syncing {
+		summary.Syncing++
+	}
+	if h.withErrors {
+		summary.WithErrors++
+	}
+	key := v1beta1.SyncReference{Kind: h.ref.Kind, Namespace: h.ref.Namespace, Name: h.ref.Name}
+	if notReadySyncs[key] {
+		summary.ReconcilersNotReady++
+	}
+}
+
+// notReadyReconcilers returns the sorted names of the reconciler Deployments
+// which are not ready, and the syncs of the dedicated reconcilers which are not
+// ready.
+func (r *InstallationReconciler) notReadyReconcilers(ctx context.Context) ([]string, map[v1beta1.SyncReference]bool, error) {
+	deploymentList := &appsv1.DeploymentList{}
+	if err := r.client.List(ctx, deploymentList,
+		client.InNamespace(configsync.ControllerNamespace),
+		client.HasLabels{metadata.SyncKindLabel}); err != nil {
+		return nil, nil, fmt.Errorf("listing reconciler Deployments: %w", err)
+	}
+	var names []string
+	syncs := make(map[v1beta1.SyncReference]bool)
+	for i := range deploymentList.Items {
+		d := &deploymentList.Items[i]
+		ready, _, err := r.deploymentReady(d)
+		if err != nil {
+			return nil, nil, err
+		}
+		if ready {
+			continue
+		}
+		names = append(names, d.Name)
+		labels := d.GetLabels()
+		if name, found := labels[metadata.SyncNameLabel]; found {
+			syncs[v1beta1.SyncReference{
+				Kind:      labels[metadata.SyncKindLabel],
+				Namespace: labels[metadata.SyncNamespaceLabel],
+				Name:      name,
+			}] = true
+		}
+	}
+	sort.Strings(names)
+	return names, syncs, nil
+}
+
+// deploymentReady returns whether the Deployment is available and up to date,
+// and the reason if not.
+func (r *InstallationReconciler) deploymentReady(d *appsv1.Deployment) (bool, string, error) {
+	u, err := kinds.ToUnstructured(d, r.client.Scheme())
+	if err != nil {
+		return false, "", err
+	}
+	result, err := kstatus.Compute(u)
+	if err != nil {
+		return false, "", fmt.Errorf("computing Deployment status: %s: %w",
+			client.ObjectKeyFromObject(d), err)
+	}
+	return result.Status == kstatus.CurrentStatus, result.Message, nil
+}
+
+// componentStatus returns the status of the component Deployment.
+func (r *InstallationReconciler) componentStatus(ctx context.Context, key types.NamespacedName) (v1beta1.ComponentStatus, error) {
+	component := v1beta1.ComponentStatus{
+		Name:      key.Name,
+		Namespace: key.Namespace,
+	}
+	d := &appsv1.Deployment{}
+	if err := r.client.Get(ctx, key, d); err != nil {
+		if apierrors.IsNotFound(err) {
+			component.State = v1beta1.ComponentNotInstalled
+			return component, nil
+		}
+		return component, NewObjectOperationErrorWithKey(err, d, OperationGet, key)
+	}
+	if containers := d.Spec.Template.Spec.Containers; len(containers) > 0 {
+		component.Version = imageTag(containers[0].Image)
+	}
+	ready, message, err := r.deploymentReady(d)
+	if err != nil {
+		return component, err
+	}
+	if ready {
+		component.State = v1beta1.ComponentReady
+	} else {
+		component.State = v1beta1.ComponentNotReady
+		component.Message = message
+	}
+	return component, nil
+}
+
+// imageTag returns the tag of the image, or the digest if the image has no tag.
+func imageTag(image string) string {
+	if i := strings.Index(image, "@"); i >= 0 {
+		if tag := imageTag(image[:i]); tag != "" {
+			return tag
+		}
+		return image[i+1:]
+	}
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		return image[i+1:]
+	}
+	return ""
+}
+
+// installationHealthyCondition returns the Healthy condition of the
+// installation.
+func installationHealthyCondition(s v1beta1.ConfigSyncInstallationStatus) metav1.Condition {
+	var notReadyComponents []string
+	for _, component := range s.Components {
+		if component.State == v1beta1.ComponentNotReady {
+			notReadyComponents = append(notReadyComponents, component.Name)
+		}
+	}
+	stalled := s.RootSyncs.Stalled + s.RepoSyncs.Stalled
+	condition := metav1.Condition{
+		Type:    InstallationHealthyCondition,
+		Status:  metav1.ConditionFalse,
+		Reason:  "Healthy",
+		Message: "All syncs, reconcilers and components are healthy",
+	}
+	switch {
+	case len(notReadyComponents) > 0:
+		condition.Reason = "ComponentsNotReady"
+		condition.Message = fmt.Sprintf("Components not ready: %s", strings.Join(notReadyComponents, ", "))
+	case stalled > 0:
+		condition.Reason = "SyncsStalled"
+		condition.Message = fmt.Sprintf("%d RootSyncs and %d RepoSyncs are stalled",
+			s.RootSyncs.Stalled, s.RepoSyncs.Stalled)
+	case len(s.ReconcilersNotReady) > 0:
+		condition.Reason = "ReconcilersNotReady"
+		condition.Message = fmt.Sprintf("Reconcilers not ready: %s", strings.Join(s.ReconcilersNotReady, ", "))
+	default:
+		condition.Status = metav1.ConditionTrue
+	}
+	return condition
+}
+
+// updateStatus creates the ConfigSyncInstallation, if it doesn't exist, and
+// updates its status, if changed.
+func (r *InstallationReconciler) updateStatus(ctx context.Context, newStatus v1beta1.ConfigSyncInstallationStatus) error {
+	installation := &v1beta1.ConfigSyncInstallation{}
+	installation.Name = configsync.ConfigSyncInstallationName
+	key := client.ObjectKeyFromObject(installation)
+	if err := r.client.Get(ctx, key, installation); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return NewObjectOperationErrorWithKey(err, installation, OperationGet, key)
+		}
+		installation.Labels = ManagedByLabel()
+		if err := r.client.Create(ctx, installation, client.FieldOwner(reconcilermanager.FieldManager)); err != nil {
+			return NewObjectOperationError(err, installation, OperationCreate)
+		}
+		r.Logger(ctx).Info("Managed object create successful",
+			logFieldObjectRef, key.String(),
+			logFieldObjectKind, configsync.ConfigSyncInstallationKind)
+	}
+
+	// Keep the transition time of unchanged conditions.
+	conditions := installation.Status.Conditions
+	for _, condition := range newStatus.Conditions {
+		meta.SetStatusCondition(&conditions, condition)
+	}
+	newStatus.Conditions = conditions
+	newStatus.LastUpdate = installation.Status.LastUpdate
+	if equality.Semantic.DeepEqual(installation.Status, newStatus) {
+		return nil
+	}
+	newStatus.LastUpdate = metav1.Now()
+	installation.Status = newStatus
+	if err := r.client.Status().Update(ctx, installation, client.FieldOwner(reconcilermanager.FieldManager)); err != nil {
+		return NewObjectOperationError(err, installation, OperationUpdate)
+	}
+	r.Logger(ctx).V(3).Info("Managed object status update successful",
+		logFieldObjectRef, key.String(),
+		logFieldObjectKind, configsync.ConfigSyncInstallationKind)
+	return nil
+}
+
+// Register the ConfigSyncInstallation controller with reconciler-manager.
+func (r *InstallationReconciler) Register(mgr controllerruntime.Manager) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	// Avoid re-registering the controller
+	if r.controller != nil {
+		return nil
+	}
+
+	ctrlr, err := controllerruntime.NewControllerManagedBy(mgr).
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: 1,
+		}).
+		For(&v1beta1.ConfigSyncInstallation{}).
+		Watches(&v1beta1.RootSync{},
+			handler.EnqueueRequestsFromMapFunc(mapToInstallation),
+			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{})).
+		Watches(&v1beta1.RepoSync{},
+			handler.EnqueueRequestsFromMapFunc(mapToInstallation),
+			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{})).
+		Watches(&appsv1.Deployment{},
+			handler.EnqueueRequestsFromMapFunc(mapDeploymentToInstallation),
+			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{})).
+		Build(r)
+	r.controller = ctrlr
+	return err
+}
+
+// mapToInstallation maps any object to the ConfigSyncInstallation.
+func mapToInstallation(_ context.Context, _ client.Object) []reconcile.Request {
+	return []reconcile.Request{{
+		NamespacedName: types.NamespacedName{Name: configsync.ConfigSyncInstallationName},
+	}}
+}
+
+// mapDeploymentToInstallation maps reconciler and component Deployments to
+// the ConfigSyncInstallation.
+func mapDeploymentToInstallation(ctx context.Context, obj client.Object) []reconcile.Request {
+	key := client.ObjectKeyFromObject(obj)
+	if key.Namespace == configsync.ControllerNamespace {
+		if _, found := obj.GetLabels()[metadata.SyncKindLabel]; found {
+			return mapToInstallation(ctx, obj)
+		}
+	}
+	for _, component := range installationComponents {
+		if key == component {
+			return mapToInstallation(ctx, obj)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
+	"kpt.dev/configsync/pkg/core"
+	syncerFake "kpt.dev/configsync/pkg/syncer/syncertest/fake"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestImageTag(t *testing.T) {
+	testCases := map[string]string{
+		"gcr.io/config-management-release/reconciler-manager:v1.19.0":          "v1.19.0",
+		"localhost:5000/reconciler-manager:v1.19.0":                            "v1.19.0",
+		"localhost:5000/reconciler-manager":                                    "",
+		"gcr.io/reconciler-manager@sha256:abc":                                 "sha256:abc",
+		"gcr.io/config-management-release/reconciler-manager:v1.19.0@sha256:a": "v1.19.0",
+	}
+	for image, want := range testCases {
+		t.Run(image, func(t *testing.T) {
+			assert.Equal(t, want, imageTag(image))
+		})
+	}
+}
+
+func TestInstallationHealthyCondition(t *testing.T) {
+	testCases := []struct {
+		name        string
+		status      v1beta1.ConfigSyncInstallationStatus
+		wantStatus  metav1.ConditionStatus
+		wantReason  string
+		wantMessage string
+	}{
+		{
+			name: "healthy",
+			status: v1beta1.ConfigSyncInstallationStatus{
+				Components: []v1beta1.ComponentStatus{
+					{Name: "reconciler-manager", State: v1beta1.ComponentReady},
+					{Name: "admission-webhook", State: v1beta1.ComponentNotInstalled},
+				},
+			},
+			wantStatus:  metav1.ConditionTrue,
+			wantReason:  "Healthy",
+			wantMessage: "All syncs, reconcilers and components are healthy",
+		},
+		{
+			name: "component not ready",
+			status: v1beta1.ConfigSyncInstallationStatus{
+				RootSyncs: v1beta1.SyncSummary{Total: 1, Stalled: 1},
+				Components: []v1beta1.ComponentStatus{
+					{Name: "otel-collector", State: v1beta1.ComponentNotReady},
+				},
+			},
+			wantStatus:  metav1.ConditionFalse,
+			wantReason:  "ComponentsNotReady",
+			wantMessage: "Components not ready: otel-collector",
+		},
+		{
+			name: "syncs stalled",
+			status: v1beta1.ConfigSyncInstallationStatus{
+				RootSyncs:           v1beta1.SyncSummary{Total: 2, Stalled: 1},
+				RepoSyncs:           v1beta1.SyncSummary{Total: 3, Stalled: 2},
+				ReconcilersNotReady: []string{"root-reconciler"},
+			},
+			wantStatus:  metav1.ConditionFalse,
+			wantReason:  "SyncsStalled",
+			wantMessage: "1 RootSyncs and 2 RepoSyncs are stalled",
+		},
+		{
+			name: "reconcilers not ready",
+			status: v1beta1.ConfigSyncInstallationStatus{
+				ReconcilersNotReady: []string{"ns-reconciler-bookstore", "root-reconciler"},
+			},
+			wantStatus:  metav1.ConditionFalse,
+			wantReason:  "ReconcilersNotReady",
+			wantMessage: "Reconcilers not ready: ns-reconciler-bookstore, root-reconciler",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			condition := installationHealthyCondition(tc.status)
+			assert.Equal(t, InstallationHealthyCondition, condition.Type)
+			assert.Equal(t, tc.wantStatus, condition.Status)
+			assert.Equal(t, tc.wantReason, condition.Reason)
+			assert.Equal(t, tc.wantMessage, condition.Message)
+		})
+	}
+}
+
+func TestInstallationReconciler(t *testing.T) {
+	rootSync := &v1beta1.RootSync{}
+	rootSync.Name = configsync.RootSyncName
+	rootSync.Namespace = configsync.ControllerNamespace
+	rootSync.Status.Conditions = []v1beta1.RootSyncCondition{
+		{Type: v1beta1.RootSyncStalled, Status: metav1.ConditionTrue, Reason: "Validation", Message: "invalid spec"},
+	}
+	repoSync := &v1beta1.RepoSync{}
+	repoSync.Name = configsync.RepoSyncName
+	repoSync.Namespace = "bookstore"
+	repoSync.Status.Conditions = []v1beta1.RepoSyncCondition{
+		{Type: v1beta1.RepoSyncSyncing, Status: metav1.ConditionTrue},
+	}
+	repoSync.Status.Sync.ErrorSummary = &v1beta1.ErrorSummary{TotalCount: 1}
+
+	fakeClient := syncerFake.NewClient(t, core.Scheme, rootSync, repoSync)
+	testReconciler := NewInstallationReconciler(fakeClient,
+		controllerruntime.Log.WithName("controllers").WithName(configsync.ConfigSyncInstallationKind))
+
+	ctx := context.Background()
+	req := reconcile.Request{
+		NamespacedName: types.NamespacedName{Name: configsync.ConfigSyncInstallationName},
+	}
+	_, err := testReconciler.Reconcile(ctx, req)
+	require.NoError(t, err)
+
+	installation := &v1beta1.ConfigSyncInstallation{}
+	key := client.ObjectKey{Name: configsync.ConfigSyncInstallationName}
+	require.NoError(t, fakeClient.Get(ctx, key, installation))
+
+	assert.Equal(t, v1beta1.SyncSummary{Total: 1, Stalled: 1}, installation.Status.RootSyncs)
+	assert.Equal(t, v1beta1.SyncSummary{Total: 1, Syncing: 1, WithErrors: 1}, installation.Status.RepoSyncs)
+	assert.Equal(t, []v1beta1.SyncReference{{
+		Kind:      configsync.RootSyncKind,
+		Namespace: configsync.ControllerNamespace,
+		Name:      configsync.RootSyncName,
+		Reason:    "Validation",
+		Message:   "invalid spec",
+	}}, installation.Status.StalledSyncs)
+	require.Len(t, installation.Status.Components, len(installationComponents))
+	for _, component := range installation.Status.Components {
+		assert.Equal(t, v1beta1.ComponentNotInstalled, component.State)
+	}
+	condition := meta.FindStatusCondition(installation.Status.Conditions, InstallationHealthyCondition)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, "SyncsStalled", condition.Reason)
+}
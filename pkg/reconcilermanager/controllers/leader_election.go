@@ -0,0 +1,242 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/kinds"
+	"kpt.dev/configsync/pkg/metadata"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// leaderElectionResourceName is the suffix of the name of the Role and
+// RoleBinding which allow the reconciler to hold its leader election Lease.
+const leaderElectionResourceName = "leader-election"
+
+// applyReplicasOverride sets the number of replicas of the reconciler
+// Deployment. With more than one replica, the Deployment is rolled out one Pod
+// at a time, so that a standby reconciler is available during updates.
+func applyReplicasOverride(d *appsv1.Deployment, overrides v1beta1.OverrideSpec) {
+	replicas := v1beta1.GetReplicas(overrides.Replicas)
+	if replicas <= 1 {
+		return
+	}
+	d.Spec.Replicas = ptr.To(replicas)
+	maxUnavailable := intstr.FromInt32(1)
+	maxSurge := intstr.FromInt32(0)
+	d.Spec.Strategy = appsv1.DeploymentStrategy{
+		Type: appsv1.RollingUpdateDeploymentStrategyType,
+		RollingUpdate: &appsv1.RollingUpdateDeployment{
+			MaxUnavailable: &maxUnavailable,
+			MaxSurge:       &maxSurge,
+		},
+	}
+}
+
+// manageLeaderElection creates or updates the Lease, RBAC and
+// PodDisruptionBudget of a reconciler with more than one replica, and deletes
+// them otherwise.
+//
+// The Lease is created by the reconciler-manager, so the reconciler only needs
+// permission to read and update its own Lease.
+func (r *reconcilerBase) manageLeaderElection(ctx context.Context, reconcilerRef types.NamespacedName, labelMap map[string]string, overrides v1beta1.OverrideSpec) error {
+	if v1beta1.GetReplicas(overrides.Replicas) <= 1 {
+		return r.deleteLeaderElectionObjects(ctx, reconcilerRef)
+	}
+	if err := r.upsertLease(ctx, reconcilerRef, labelMap); err != nil {
+		return fmt.Errorf("upserting leader election lease: %w", err)
+	}
+	if err := r.upsertLeaderElectionRBAC(ctx, reconcilerRef); err != nil {
+		return fmt.Errorf("upserting leader election RBAC: %w", err)
+	}
+	if err := r.upsertPodDisruptionBudget(ctx, reconcilerRef, labelMap); err != nil {
+		return fmt.Errorf("upserting pod disruption budget: %w", err)
+	}
+	return nil
+}
+
+func (r *reconcilerBase) upsertLease(ctx context.Context, reconcilerRef types.NamespacedName, labelMap map[string]string) error {
+	lease := &coordinationv1.Lease{}
+	lease.Name = reconcilerRef.Name
+	lease.Namespace = reconcilerRef.Namespace
+	// The Lease spec is managed by the reconciler.
+	return r.upsertManagedObject(ctx, lease, func() error {
+		core.AddLabels(lease, labelMap)
+		return nil
+	})
+}
+
+// upsertLeaderElectionRBAC creates or updates the Role and RoleBinding which
+// allow the reconciler to hold its Lease.
+//
+// The RoleBinding doesn't have the RootSync or RepoSync labels, to avoid being
+// garbage collected as one of the bindings declared by the RootSync roleRefs.
+func (r *reconcilerBase) upsertLeaderElectionRBAC(ctx context.Context, reconcilerRef types.NamespacedName) error {
+	name := ReconcilerResourceName(reconcilerRef.Name, leaderElectionResourceName)
+	role := &rbacv1.Role{}
+	role.Name = name
+	role.Namespace = reconcilerRef.Namespace
+	if err := r.upsertManagedObject(ctx, role, func() error {
+		core.AddLabels(role, ManagedByLabel())
+		role.Rules = []rbacv1.PolicyRule{
+			{
+				APIGroups:     []string{coordinationv1.GroupName},
+				Resources:     []string{"leases"},
+				ResourceNames: []string{reconcilerRef.Name},
+				Verbs:         []string{"get", "update"},
+			},
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	rb := &rbacv1.RoleBinding{}
+	rb.Name = name
+	rb.Namespace = reconcilerRef.Namespace
+	return r.upsertManagedObject(ctx, rb, func() error {
+		core.AddLabels(rb, ManagedByLabel())
+		rb.RoleRef = rolereference(name, "Role")
+		rb.Subjects = []rbacv1.Subject{r.serviceAccountSubject(reconcilerRef)}
+		return nil
+	})
+}
+
+// upsertPodDisruptionBudget creates or updates the PodDisruptionBudget which
+// keeps at least one reconciler Pod available during voluntary disruptions,
+// like node drains.
+func (r *reconcilerBase) upsertPodDisruptionBudget(ctx context.Context, reconcilerRef types.NamespacedName, labelMap map[string]string) error {
+	pdb := &policyv1.PodDisruptionBudget{}
+	pdb.Name = reconcilerRef.Name
+	pdb.Namespace = reconcilerRef.Namespace
+	return r.upsertManagedObject(ctx, pdb, func() error {
+		core.AddLabels(pdb, labelMap)
+		minAvailable := intstr.FromInt32(1)
+		pdb.Spec.MinAvailable = &minAvailable
+		pdb.Spec.Selector = &metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				metadata.DeploymentNameLabel: reconcilerRef.Name,
+			},
+		}
+		return nil
+	})
+}
+
+// upsertManagedObject creates or updates an object in the config-management-system
+// namespace, and logs the operation.
+func (r *reconcilerBase) upsertManagedObject(ctx context.Context, obj client.Object, mutateFn controllerutil.MutateFn) error {
+	gvk, err := kinds.Lookup(obj, r.scheme)
+	if err != nil {
+		return err
+	}
+	kind := gvk.Kind
+	objRef := client.ObjectKeyFromObject(obj)
+	r.Logger(ctx).V(3).Info("Upserting managed object",
+		logFieldObjectRef, objRef.String(),
+		logFieldObjectKind, kind)
+	op, err := CreateOrUpdate(ctx, r.client, obj, mutateFn)
+	if err != nil {
+		return err
+	}
+	if op != controllerutil.OperationResultNone {
+		r.Logger(ctx).Info("Upserting managed object successful",
+			logFieldObjectRef, objRef.String(),
+			logFieldObjectKind, kind,
+			logFieldOperation, op)
+	}
+	return nil
+}
+
+// deleteLeaderElectionObjects deletes the Lease, RBAC and PodDisruptionBudget
+// of the reconciler, if they exist.
+func (r *reconcilerBase) deleteLeaderElectionObjects(ctx context.Context, reconcilerRef types.NamespacedName) error {
+	rbacName := ReconcilerResourceName(reconcilerRef.Name, leaderElectionResourceName)
+	objs := []client.Object{
+		&policyv1.PodDisruptionBudget{ObjectMeta: metav1.ObjectMeta{Name: reconcilerRef.Name, Namespace: reconcilerRef.Namespace}},
+		&rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{Name: rbacName, Namespace: reconcilerRef.Namespace}},
+		&rbacv1.Role{ObjectMeta: metav1.ObjectMeta{Name: rbacName, Namespace: reconcilerRef.Namespace}},
+		&coordinationv1.Lease{ObjectMeta: metav1.ObjectMeta{Name: reconcilerRef.Name, Namespace: reconcilerRef.Namespace}},
+	}
+	for _, obj := range objs {
+		if err := r.cleanupIfExists(ctx, obj); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// cleanupIfExists deletes the object and waits for it to be fully deleted, if
+// it exists. Checking the cache first avoids watching for the deletion of an
+// object which was already deleted.
+func (r *reconcilerBase) cleanupIfExists(ctx context.Context, obj client.Object) error {
+	if err := r.client.Get(ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return NewObjectOperationErrorWithKey(err, obj, OperationGet, client.ObjectKeyFromObject(obj))
+	}
+	return r.cleanup(ctx, obj)
+}
+
+// leaseHolder returns the identity of the reconciler Pod which holds the
+// leader election Lease, or an empty string if the reconciler has a single
+// replica or the Lease is not held.
+func (r *reconcilerBase) leaseHolder(ctx context.Context, reconcilerRef types.NamespacedName, overrides v1beta1.OverrideSpec) (string, error) {
+	if v1beta1.GetReplicas(overrides.Replicas) <= 1 {
+		return "", nil
+	}
+	lease := &coordinationv1.Lease{}
+	if err := r.client.Get(ctx, reconcilerRef, lease); err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", NewObjectOperationErrorWithKey(err, lease, OperationGet, reconcilerRef)
+	}
+	return ptr.Deref(lease.Spec.HolderIdentity, ""), nil
+}
+
+// leaseHolderChangedPredicate filters Lease update events to only those which
+// change the holder, ignoring the periodic renewals.
+type leaseHolderChangedPredicate struct {
+	predicate.Funcs
+}
+
+// Update implements predicate.Predicate.
+func (leaseHolderChangedPredicate) Update(e event.UpdateEvent) bool {
+	oldLease, ok := e.ObjectOld.(*coordinationv1.Lease)
+	if !ok {
+		return false
+	}
+	newLease, ok := e.ObjectNew.(*coordinationv1.Lease)
+	if !ok {
+		return false
+	}
+	return ptr.Deref(oldLease.Spec.HolderIdentity, "") != ptr.Deref(newLease.Spec.HolderIdentity, "")
+}
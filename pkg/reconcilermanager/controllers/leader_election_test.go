@@ -0,0 +1,125 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
+	"kpt.dev/configsync/pkg/metadata"
+	"kpt.dev/configsync/pkg/reconcilermanager"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+func rootsyncOverrideReplicas(replicas *int32) func(*v1beta1.RootSync) {
+	return func(rs *v1beta1.RootSync) {
+		rs.Spec.SafeOverride().Replicas = replicas
+	}
+}
+
+func TestRootSyncLeaderElection(t *testing.T) {
+	// Mock out parseDeployment for testing.
+	parseDeployment = parsedDeployment
+
+	ctx := context.Background()
+	rs := rootSyncWithGit(rootsyncName, rootsyncRef(gitRevision), rootsyncBranch(branch),
+		rootsyncSecretType(configsync.AuthNone), rootsyncOverrideReplicas(ptr.To[int32](2)))
+	reqNamespacedName := namespacedName(rs.Name, rs.Namespace)
+	fakeClient, fakeDynamicClient, testReconciler := setupRootReconciler(t, rs)
+
+	reconcilerRef := types.NamespacedName{Namespace: configsync.ControllerNamespace, Name: rootReconcilerName}
+	rbacRef := types.NamespacedName{Namespace: configsync.ControllerNamespace, Name: rootReconcilerName + "-leader-election"}
+
+	_, err := testReconciler.Reconcile(ctx, reqNamespacedName)
+	require.NoError(t, err)
+
+	// The Deployment runs two replicas, which acquire the Lease before syncing.
+	d, err := getDeployment(t, fakeDynamicClient, reconcilerRef)
+	require.NoError(t, err)
+	assert.Equal(t, ptr.To[int32](2), d.Spec.Replicas)
+	assert.Equal(t, appsv1.RollingUpdateDeploymentStrategyType, d.Spec.Strategy.Type)
+	for _, container := range d.Spec.Template.Spec.Containers {
+		if container.Name == reconcilermanager.Reconciler {
+			assert.Contains(t, container.Env, corev1.EnvVar{Name: reconcilermanager.LeaderElectionEnabled, Value: "true"})
+		}
+	}
+
+	lease := &coordinationv1.Lease{}
+	require.NoError(t, fakeClient.Get(ctx, reconcilerRef, lease))
+	role := &rbacv1.Role{}
+	require.NoError(t, fakeClient.Get(ctx, rbacRef, role))
+	assert.Equal(t, []string{rootReconcilerName}, role.Rules[0].ResourceNames)
+	rb := &rbacv1.RoleBinding{}
+	require.NoError(t, fakeClient.Get(ctx, rbacRef, rb))
+	assert.Equal(t, []rbacv1.Subject{testReconciler.serviceAccountSubject(reconcilerRef)}, rb.Subjects)
+	pdb := &policyv1.PodDisruptionBudget{}
+	require.NoError(t, fakeClient.Get(ctx, reconcilerRef, pdb))
+	assert.Equal(t, 1, pdb.Spec.MinAvailable.IntValue())
+	assert.Equal(t, map[string]string{metadata.DeploymentNameLabel: rootReconcilerName}, pdb.Spec.Selector.MatchLabels)
+
+	// The Lease holder is reported in the RootSync status.
+	lease.Spec.HolderIdentity = ptr.To(rootReconcilerName + "-abc")
+	require.NoError(t, fakeClient.Update(ctx, lease, client.FieldOwner(reconcilermanager.FieldManager)))
+	_, err = testReconciler.Reconcile(ctx, reqNamespacedName)
+	require.NoError(t, err)
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(rs), rs))
+	assert.Equal(t, rootReconcilerName+"-abc", rs.Status.LeaseHolder)
+
+	// Removing the replicas override deletes the leader election objects.
+	existing := rs.DeepCopy()
+	rs.Spec.Override.Replicas = nil
+	require.NoError(t, fakeClient.Patch(ctx, rs, client.MergeFrom(existing), client.FieldOwner(reconcilermanager.FieldManager)))
+	_, err = testReconciler.Reconcile(ctx, reqNamespacedName)
+	require.NoError(t, err)
+
+	d, err = getDeployment(t, fakeDynamicClient, reconcilerRef)
+	require.NoError(t, err)
+	assert.Equal(t, ptr.To[int32](1), d.Spec.Replicas)
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(rs), rs))
+	assert.Empty(t, rs.Status.LeaseHolder)
+	for _, obj := range []client.Object{&coordinationv1.Lease{}, &policyv1.PodDisruptionBudget{}} {
+		err := fakeClient.Get(ctx, reconcilerRef, obj)
+		assert.True(t, apierrors.IsNotFound(err), "expected %T to be NotFound, got: %v", obj, err)
+	}
+	for _, obj := range []client.Object{&rbacv1.Role{}, &rbacv1.RoleBinding{}} {
+		err := fakeClient.Get(ctx, rbacRef, obj)
+		assert.True(t, apierrors.IsNotFound(err), "expected %T to be NotFound, got: %v", obj, err)
+	}
+}
+
+func TestLeaseHolderChangedPredicate(t *testing.T) {
+	lease := func(holder string) *coordinationv1.Lease {
+		l := &coordinationv1.Lease{}
+		l.Spec.HolderIdentity = ptr.To(holder)
+		return l
+	}
+	p := leaseHolderChangedPredicate{}
+	assert.False(t, p.Update(event.UpdateEvent{ObjectOld: lease("pod-a"), ObjectNew: lease("pod-a")}))
+	assert.True(t, p.Update(event.UpdateEvent{ObjectOld: lease("pod-a"), ObjectNew: lease("pod-b")}))
+	assert.True(t, p.Update(event.UpdateEvent{ObjectOld: &coordinationv1.Lease{}, ObjectNew: lease("pod-a")}))
+}
@@ -0,0 +1,314 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/record"
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
+	"kpt.dev/configsync/pkg/api/kpt.dev/v1alpha1"
+	"kpt.dev/configsync/pkg/applier"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/kinds"
+	"kpt.dev/configsync/pkg/metadata"
+	"kpt.dev/configsync/pkg/metrics"
+	"sigs.k8s.io/cli-utils/pkg/common"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+const (
+	// OrphanDetectedReason is the reason of the event recorded on an object
+	// when the orphan sweep first finds it orphaned.
+	OrphanDetectedReason = "OrphanDetected"
+	// OrphanDeletedReason is the reason of the event recorded on an object
+	// when the orphan sweep deletes it.
+	OrphanDeletedReason = "OrphanDeleted"
+)
+
+// orphanSweepLists are the lists of the kinds of objects which the
+// reconciler-manager creates for a RootSync or RepoSync, labelled with
+// ManagedObjectLabelMap.
+//
+// Shared RoleBindings and ClusterRoleBindings don't have the sync name label,
+// and pooled reconciler objects aren't owned by a single RepoSync, so neither
+// are considered by the orphan sweep.
+func orphanSweepLists() []client.ObjectList {
+	return []client.ObjectList{
+		&appsv1.DeploymentList{},
+		&corev1.ServiceAccountList{},
+		&corev1.ConfigMapList{},
+		&corev1.SecretList{},
+		&coordinationv1.LeaseList{},
+		&policyv1.PodDisruptionBudgetList{},
+		&rbacv1.RoleBindingList{},
+		&rbacv1.ClusterRoleBindingList{},
+		&rbacv1.RoleList{},
+		&rbacv1.ClusterRoleList{},
+	}
+}
+
+var _ manager.Runnable = &OrphanSweeper{}
+var _ manager.LeaderElectionRunnable = &OrphanSweeper{}
+
+// OrphanSweeper periodically finds the objects created by the
+// reconciler-manager whose RootSync or RepoSync no longer exists, for example
+// because the reconciler-manager crashed while cleaning up, or the finalizer
+// was removed by hand. Orphaned objects are reported with events and metrics,
+// and deleted once they have been orphaned for longer than the grace period,
+// unless annotated with `configsync.gke.io/keep-orphan: "true"`.
+type OrphanSweeper struct {
+	*LoggingController
+
+	// client is used to list RootSyncs and RepoSyncs from the cache.
+	client client.Client
+	// watcher is used to list and delete the managed objects without caching,
+	// to avoid caching all Secrets and ConfigMaps in the cluster.
+	watcher  client.Client
+	recorder record.EventRecorder
+
+	period      time.Duration
+	gracePeriod time.Duration
+
+	// lock protects orphanedSince
+	lock sync.Mutex
+	// orphanedSince records when each orphaned object was first found.
+	// It's reset when the reconciler-manager restarts, which restarts the grace
+	// period.
+	orphanedSince map[core.ID]time.Time
+}
+
+// NewOrphanSweeper returns a new OrphanSweeper which sweeps every period, and
+// deletes objects which have been orphaned for longer than gracePeriod.
+func NewOrphanSweeper(client, watcher client.Client, recorder record.EventRecorder, log logr.Logger, period, gracePeriod time.Duration) *OrphanSweeper {
+	return &OrphanSweeper{
+		LoggingController: NewLoggingController(log),
+		client:            client,
+		watcher:           watcher,
+		recorder:          recorder,
+		period:            period,
+		gracePeriod:       gracePeriod,
+		orphanedSince:     make(map[core.ID]time.Time),
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable, so that only
+// the leader sweeps when leader election is enabled.
+func (s *OrphanSweeper) NeedLeaderElection() bool {
+	return true
+}
+
+// Start sweeps periodically until the context is done.
+func (s *OrphanSweeper) Start(ctx context.Context) error {
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		if err := s.Sweep(ctx, time.Now()); err != nil {
+			s.Logger(ctx).Error(err, "Orphan sweep failed")
+		}
+	}, s.period)
+	return nil
+}
+
+// orphan is a managed object whose RootSync or RepoSync no longer exists.
+type orphan struct {
+	id  core.ID
+	obj client.Object
+}
+
+// Sweep finds the orphaned objects, records the number found, and deletes
+// those orphaned for longer than the grace period.
+func (s *OrphanSweeper) Sweep(ctx context.Context, now time.Time) error {
+	syncs, err := s.existingSyncs(ctx)
+	if err != nil {
+		return err
+	}
+	orphans, err := s.findOrphans(ctx, syncs)
+	if err != nil {
+		return err
+	}
+	metrics.RecordOrphanedObjects(ctx, len(orphans))
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	found := make(map[core.ID]time.Time, len(orphans))
+	var errs []error
+	for _, o := range orphans {
+		since, known := s.orphanedSince[o.id]
+		if !known {
+			since = now
+			s.Logger(ctx).Info("Orphaned managed object detected",
+				logFieldObjectRef, o.id.ObjectKey.String(),
+				logFieldObjectKind, o.id.Kind)
+			s.recorder.Eventf(o.obj, corev1.EventTypeWarning, OrphanDetectedReason,
+				"The %s %s/%s that created this object no longer exists",
+				o.obj.GetLabels()[metadata.SyncKindLabel],
+				o.obj.GetLabels()[metadata.SyncNamespaceLabel],
+				o.obj.GetLabels()[metadata.SyncNameLabel])
+		}
+		found[o.id] = since
+		if core.GetAnnotation(o.obj, metadata.KeepOrphanAnnotationKey) == "true" {
+			continue
+		}
+		if now.Sub(since) < s.gracePeriod {
+			continue
+		}
+		if err := s.watcher.Delete(ctx, o.obj); err != nil && !apierrors.IsNotFound(err) {
+			errs = append(errs, NewObjectOperationErrorWithID(err, o.id, OperationDelete))
+			continue
+		}
+		s.Logger(ctx).Info("Orphaned managed object delete successful",
+			logFieldObjectRef, o.id.ObjectKey.String(),
+			logFieldObjectKind, o.id.Kind)
+		s.recorder.Eventf(o.obj, corev1.EventTypeNormal, OrphanDeletedReason,
+			"Deleted after being orphaned for %s", now.Sub(since).Round(time.Second))
+	}
+	// Forget objects which are no longer orphaned or were deleted.
+	s.orphanedSince = found
+	return errors.Join(errs...)
+}
+
+// existingSyncs returns the references of all the RootSyncs and RepoSyncs.
+func (s *OrphanSweeper) existingSyncs(ctx context.Context) (map[v1beta1.SyncReference]bool, error) {
+	syncs := make(map[v1beta1.SyncReference]bool)
+	rootSyncList := &v1beta1.RootSyncList{}
+	if err := s.client.List(ctx, rootSyncList); err != nil {
+		return nil, fmt.Errorf("listing %s objects: %w", configsync.RootSyncKind, err)
+	}
+	for _, rs := range rootSyncList.Items {
+		syncs[syncRef(configsync.RootSyncKind, client.ObjectKeyFromObject(&rs))] = true
+	}
+	repoSyncList := &v1beta1.RepoSyncList{}
+	if err := s.client.List(ctx, repoSyncList); err != nil {
+		return nil, fmt.Errorf("listing %s objects: %w", configsync.RepoSyncKind, err)
+	}
+	for _, rs := range repoSyncList.Items {
+		syncs[syncRef(configsync.RepoSyncKind, client.ObjectKeyFromObject(&rs))] = true
+	}
+	return syncs, nil
+}
+
+func syncRef(kind string, key types.NamespacedName) v1beta1.SyncReference {
+	return v1beta1.SyncReference{Kind: kind, Namespace: key.Namespace, Name: key.Name}
+}
+
+// findOrphans returns the managed objects and the Config Sync ResourceGroups
+// whose RootSync or RepoSync doesn't exist. Objects which are already being
+// deleted are skipped.
+func (s *OrphanSweeper) findOrphans(ctx context.Context, syncs map[v1beta1.SyncReference]bool) ([]orphan, error) {
+	var orphans []orphan
+	for _, list := range orphanSweepLists() {
+		if err := s.watcher.List(ctx, list,
+			client.MatchingLabels(ManagedByLabel()),
+			client.HasLabels{metadata.SyncKindLabel, metadata.SyncNamespaceLabel, metadata.SyncNameLabel}); err != nil {
+			return nil, NewObjectOperationErrorForList(err, list, OperationList)
+		}
+		objs, err := s.extractObjects(list)
+		if err != nil {
+			return nil, err
+		}
+		for _, obj := range objs {
+			labels := obj.GetLabels()
+			ref := syncRef(labels[metadata.SyncKindLabel], types.NamespacedName{
+				Namespace: labels[metadata.SyncNamespaceLabel],
+				Name:      labels[metadata.SyncNameLabel],
+			})
+			if syncs[ref] {
+				continue
+			}
+			o, err := s.newOrphan(obj)
+			if err != nil {
+				return nil, err
+			}
+			orphans = append(orphans, o)
+		}
+	}
+
+	// ResourceGroups are created by the reconcilers, without the managed
+	// object labels, so they're identified by their inventory ID.
+	rgList := &v1alpha1.ResourceGroupList{}
+	if err := s.watcher.List(ctx, rgList, client.HasLabels{common.InventoryLabel}); err != nil {
+		if meta.IsNoMatchError(err) {
+			return orphans, nil
+		}
+		return nil, NewObjectOperationErrorForList(err, rgList, OperationList)
+	}
+	for i := range rgList.Items {
+		rg := &rgList.Items[i]
+		key := client.ObjectKeyFromObject(rg)
+		if rg.GetLabels()[common.InventoryLabel] != applier.InventoryID(key.Name, key.Namespace) {
+			// Not created by Config Sync
+			continue
+		}
+		if syncs[syncRef(configsync.RootSyncKind, key)] || syncs[syncRef(configsync.RepoSyncKind, key)] {
+			continue
+		}
+		o, err := s.newOrphan(rg)
+		if err != nil {
+			return nil, err
+		}
+		orphans = append(orphans, o)
+	}
+	return orphans, nil
+}
+
+// extractObjects returns the objects in the list which aren't being deleted.
+func (s *OrphanSweeper) extractObjects(list client.ObjectList) ([]client.Object, error) {
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return nil, err
+	}
+	objs := make([]client.Object, 0, len(items))
+	for _, item := range items {
+		obj, ok := item.(client.Object)
+		if !ok {
+			return nil, fmt.Errorf("unexpected list item type: %T", item)
+		}
+		if obj.GetDeletionTimestamp() != nil {
+			continue
+		}
+		objs = append(objs, obj)
+	}
+	return objs, nil
+}
+
+func (s *OrphanSweeper) newOrphan(obj client.Object) (orphan, error) {
+	gvk, err := kinds.Lookup(obj, s.watcher.Scheme())
+	if err != nil {
+		return orphan{}, err
+	}
+	return orphan{
+		id: core.ID{
+			GroupKind: gvk.GroupKind(),
+			ObjectKey: client.ObjectKeyFromObject(obj),
+		},
+		obj: obj,
+	}, nil
+}
@@ -0,0 +1,133 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
+	"kpt.dev/configsync/pkg/api/kpt.dev/v1alpha1"
+	"kpt.dev/configsync/pkg/applier"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/metadata"
+	syncerFake "kpt.dev/configsync/pkg/syncer/syncertest/fake"
+	"sigs.k8s.io/cli-utils/pkg/common"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestOrphanSweeper(t *testing.T) {
+	existingRef := types.NamespacedName{Namespace: "bookstore", Name: configsync.RepoSyncName}
+	deletedRef := types.NamespacedName{Namespace: configsync.ControllerNamespace, Name: "deleted"}
+	keptRef := types.NamespacedName{Namespace: configsync.ControllerNamespace, Name: "kept"}
+
+	repoSync := &v1beta1.RepoSync{}
+	repoSync.Name = existingRef.Name
+	repoSync.Namespace = existingRef.Namespace
+
+	managedDeployment := func(name string, syncKind string, rsRef types.NamespacedName) *appsv1.Deployment {
+		d := &appsv1.Deployment{}
+		d.Name = name
+		d.Namespace = configsync.ControllerNamespace
+		d.Labels = ManagedObjectLabelMap(syncKind, rsRef)
+		return d
+	}
+	inUse := managedDeployment("ns-reconciler-bookstore", configsync.RepoSyncKind, existingRef)
+	orphaned := managedDeployment("root-reconciler-deleted", configsync.RootSyncKind, deletedRef)
+	kept := managedDeployment("root-reconciler-kept", configsync.RootSyncKind, keptRef)
+	kept.Annotations = map[string]string{metadata.KeepOrphanAnnotationKey: "true"}
+
+	orphanedSA := &corev1.ServiceAccount{}
+	orphanedSA.Name = "root-reconciler-deleted"
+	orphanedSA.Namespace = configsync.ControllerNamespace
+	orphanedSA.Labels = ManagedObjectLabelMap(configsync.RootSyncKind, deletedRef)
+
+	unmanagedSA := &corev1.ServiceAccount{}
+	unmanagedSA.Name = "unmanaged"
+	unmanagedSA.Namespace = configsync.ControllerNamespace
+	unmanagedSA.Labels = map[string]string{metadata.SyncNameLabel: "deleted"}
+
+	orphanedRG := &v1alpha1.ResourceGroup{}
+	orphanedRG.Name = deletedRef.Name
+	orphanedRG.Namespace = deletedRef.Namespace
+	orphanedRG.Labels = map[string]string{common.InventoryLabel: applier.InventoryID(deletedRef.Name, deletedRef.Namespace)}
+
+	userRG := &v1alpha1.ResourceGroup{}
+	userRG.Name = "user"
+	userRG.Namespace = "bookstore"
+	userRG.Labels = map[string]string{common.InventoryLabel: "user-inventory"}
+
+	fakeClient := syncerFake.NewClient(t, core.Scheme,
+		repoSync, inUse, orphaned, kept, orphanedSA, unmanagedSA, orphanedRG, userRG)
+	recorder := record.NewFakeRecorder(10)
+	gracePeriod := time.Hour
+	sweeper := NewOrphanSweeper(fakeClient, fakeClient, recorder,
+		controllerruntime.Log.WithName("controllers").WithName("OrphanSweeper"),
+		time.Minute, gracePeriod)
+
+	ctx := context.Background()
+	start := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	// The first sweep detects the orphans, without deleting them.
+	require.NoError(t, sweeper.Sweep(ctx, start))
+	assert.Len(t, recorder.Events, 4)
+	for len(recorder.Events) > 0 {
+		assert.Contains(t, <-recorder.Events, OrphanDetectedReason)
+	}
+	for _, obj := range []client.Object{inUse, orphaned, kept, orphanedSA, unmanagedSA, orphanedRG, userRG} {
+		assertObjectExists(t, fakeClient, obj)
+	}
+
+	// Within the grace period, the orphans are kept.
+	require.NoError(t, sweeper.Sweep(ctx, start.Add(gracePeriod/2)))
+	assert.Empty(t, recorder.Events)
+	assertObjectExists(t, fakeClient, orphaned)
+
+	// After the grace period, the orphans are deleted, unless annotated to keep.
+	require.NoError(t, sweeper.Sweep(ctx, start.Add(gracePeriod)))
+	assert.Len(t, recorder.Events, 3)
+	for len(recorder.Events) > 0 {
+		assert.Contains(t, <-recorder.Events, OrphanDeletedReason)
+	}
+	for _, obj := range []client.Object{inUse, kept, unmanagedSA, userRG} {
+		assertObjectExists(t, fakeClient, obj)
+	}
+	for _, obj := range []client.Object{orphaned, orphanedSA, orphanedRG} {
+		assertObjectNotFound(t, fakeClient, obj)
+	}
+}
+
+func assertObjectExists(t *testing.T, c client.Client, obj client.Object) {
+	t.Helper()
+	key := client.ObjectKeyFromObject(obj)
+	assert.NoError(t, c.Get(context.Background(), key, obj.DeepCopyObject().(client.Object)), key.String())
+}
+
+func assertObjectNotFound(t *testing.T, c client.Client, obj client.Object) {
+	t.Helper()
+	key := client.ObjectKeyFromObject(obj)
+	err := c.Get(context.Background(), key, obj.DeepCopyObject().(client.Object))
+	assert.True(t, apierrors.IsNotFound(err), "expected %s to be deleted, got: %v", key, err)
+}
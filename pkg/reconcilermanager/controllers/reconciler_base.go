@@ -15,6 +15,7 @@
 package controllers
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -48,11 +49,13 @@ import (
 	"kpt.dev/configsync/pkg/reconcilermanager"
 	"kpt.dev/configsync/pkg/status"
 	"kpt.dev/configsync/pkg/util"
+	"kpt.dev/configsync/pkg/util/imagemirror"
 	"kpt.dev/configsync/pkg/validate/rsync/validate"
 	webhookconfiguration "kpt.dev/configsync/pkg/webhook/configuration"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/structured-merge-diff/v4/fieldpath"
 )
 
 const (
@@ -81,7 +84,7 @@ var reconcilerManagerAllowList = []string{
 	"$.spec.template.spec.containers[*].*.failureThreshold",
 	"$.spec.template.spec.tolerations",
 	"$.spec.template.spec.restartPolicy",
-	"$.spec.template.spec.nodeSelector", // remove this after Config Sync support user-defined nodeSelector through API
+	"$.spec.template.spec.nodeSelector",
 	"$.spec.template.spec.terminationGracePeriodSeconds",
 	"$.spec.template.spec.dnsPolicy",
 	"$.spec.template.spec.schedulerName",
@@ -89,6 +92,13 @@ var reconcilerManagerAllowList = []string{
 	"$.spec.progressDeadlineSeconds",
 }
 
+// overridableAllowList maps the paths in reconcilerManagerAllowList, which can
+// also be set by the RootSync or RepoSync overrides, to their field paths.
+var overridableAllowList = map[string][]string{
+	"$.spec.template.spec.tolerations":  {"spec", "template", "spec", "tolerations"},
+	"$.spec.template.spec.nodeSelector": {"spec", "template", "spec", "nodeSelector"},
+}
+
 // reconcilerBase provides common data and methods for the RepoSync and RootSync reconcilers
 type reconcilerBase struct {
 	*LoggingController
@@ -107,6 +117,7 @@ type reconcilerBase struct {
 	knownHostExist          bool
 	githubApp               githubAppSpec
 	webhookEnabled          bool
+	imageRegistryMirror     string
 
 	// syncGVK is the GroupVersionKind of the sync object: RootSync or RepoSync.
 	syncGVK schema.GroupVersionKind
@@ -115,6 +126,13 @@ type reconcilerBase struct {
 	controllerName string
 }
 
+// SetImageRegistryMirror sets the registry prefix which replaces the registry
+// of the container images of the reconciler Deployments. Empty disables
+// mirroring. Must be called before the controller is registered.
+func (r *reconcilerBase) SetImageRegistryMirror(mirror string) {
+	r.imageRegistryMirror = mirror
+}
+
 func (r *reconcilerBase) serviceAccountSubject(reconcilerRef types.NamespacedName) rbacv1.Subject {
 	return newSubject(reconcilerRef.Name, reconcilerRef.Namespace, kinds.ServiceAccount().Kind)
 }
@@ -159,7 +177,7 @@ func (r *reconcilerBase) upsertServiceAccount(
 
 type mutateFn func(client.Object) error
 
-func (r *reconcilerBase) upsertDeployment(ctx context.Context, reconcilerRef types.NamespacedName, labelMap map[string]string, mutateObject mutateFn) (*unstructured.Unstructured, controllerutil.OperationResult, error) {
+func (r *reconcilerBase) upsertDeployment(ctx context.Context, reconcilerRef types.NamespacedName, labelMap map[string]string, overrides v1beta1.OverrideSpec, mutateObject mutateFn) (*unstructured.Unstructured, controllerutil.OperationResult, error) {
 	reconcilerDeployment := &appsv1.Deployment{}
 	if err := parseDeployment(reconcilerDeployment); err != nil {
 		return nil, controllerutil.OperationResultNone, fmt.Errorf("failed to parse reconciler Deployment manifest from ConfigMap: %w", err)
@@ -191,6 +209,19 @@ func (r *reconcilerBase) upsertDeployment(ctx context.Context, reconcilerRef typ
 	if err := mutateObject(reconcilerDeployment); err != nil {
 		return nil, controllerutil.OperationResultNone, err
 	}
+
+	// Apply the pod overrides last, so the labels and annotations set by
+	// Config Sync take precedence.
+	applyPodOverrides(reconcilerDeployment, overrides)
+	applyReplicasOverride(reconcilerDeployment, overrides)
+	applyImageRegistryMirror(reconcilerDeployment, r.imageRegistryMirror)
+
+	// Apply the patches from the ConfigMaps last, so they can customize
+	// everything except the fields required by Config Sync.
+	if err := r.applyDeploymentPatches(ctx, reconcilerRef, reconcilerDeployment); err != nil {
+		return nil, controllerutil.OperationResultNone, err
+	}
+
 	appliedObj, op, err := r.applyDeployment(ctx, reconcilerDeployment)
 
 	if op != controllerutil.OperationResultNone {
@@ -333,6 +364,14 @@ func (r *reconcilerBase) compareDeploymentsToCreatePatchData(declared *appsv1.De
 	if err != nil {
 		return nil, err
 	}
+	uObjDeclared, err := kinds.ToUnstructured(declared, r.scheme)
+	if err != nil {
+		return nil, err
+	}
+	allowList, err = filterOverriddenFields(allowList, uObjDeclared, currentDeploymentUnstructured)
+	if err != nil {
+		return nil, err
+	}
 	processedCurrent, err := deleteDeploymentFields(allowList, currentDeploymentUnstructured)
 	if err != nil {
 		return nil, err
@@ -341,7 +380,8 @@ func (r *reconcilerBase) compareDeploymentsToCreatePatchData(declared *appsv1.De
 	if err != nil {
 		return nil, err
 	}
-	uObjDeclared, err := kinds.ToUnstructured(declared, r.scheme)
+	// Re-convert the declared Deployment, in case the resources were adjusted.
+	uObjDeclared, err = kinds.ToUnstructured(declared, r.scheme)
 	if err != nil {
 		return nil, err
 	}
@@ -359,6 +399,113 @@ func (r *reconcilerBase) compareDeploymentsToCreatePatchData(declared *appsv1.De
 	return &deploymentProcessResult{false, adjusted, data}, nil
 }
 
+// filterOverriddenFields returns the allowList without the overridable fields
+// which are either declared, or were previously applied by the
+// reconciler-manager. This ensures that adding, changing and removing pod
+// overrides is not ignored, while other controllers are still allowed to
+// modify those fields when they are not overridden.
+func filterOverriddenFields(allowList []string, declared, current *unstructured.Unstructured) ([]string, error) {
+	applied, err := reconcilerManagerAppliedFields(current)
+	if err != nil {
+		return nil, err
+	}
+	var result []string
+	for _, path := range allowList {
+		fields, overridable := overridableAllowList[path]
+		if overridable {
+			if _, found, _ := unstructured.NestedFieldNoCopy(declared.Object, fields...); found {
+				continue
+			}
+			if hasFieldsWithPrefix(applied, fields...) {
+				continue
+			}
+		}
+		result = append(result, path)
+	}
+	return result, nil
+}
+
+// reconcilerManagerAppliedFields returns the fields of the object owned by the
+// reconciler-manager's server-side apply field manager.
+func reconcilerManagerAppliedFields(obj *unstructured.Unstructured) (*fieldpath.Set, error) {
+	applied := &fieldpath.Set{}
+	for _, entry := range obj.GetManagedFields() {
+		if entry.Manager != reconcilermanager.ManagerName || entry.Operation != metav1.ManagedFieldsOperationApply || entry.FieldsV1 == nil {
+			continue
+		}
+		set := &fieldpath.Set{}
+		if err := set.FromJSON(bytes.NewReader(entry.FieldsV1.Raw)); err != nil {
+			return nil, fmt.Errorf("failed to decode managed fields of %s: %w", core.IDOf(obj), err)
+		}
+		applied = applied.Union(set)
+	}
+	return applied, nil
+}
+
+// hasFieldsWithPrefix returns true if the set contains the nested field, or
+// any of its children.
+func hasFieldsWithPrefix(set *fieldpath.Set, fields ...string) bool {
+	path := make(fieldpath.Path, len(fields))
+	for i := range fields {
+		path[i] = fieldpath.PathElement{FieldName: &fields[i]}
+	}
+	if set.Has(path) {
+		return true
+	}
+	for _, pe := range path {
+		set = set.WithPrefix(pe)
+	}
+	return !set.Empty()
+}
+
+// applyPodOverrides applies the pod scheduling, label and annotation overrides
+// to the pod template of the reconciler Deployment. The labels and annotations
+// already on the pod template take precedence over the overrides.
+func applyPodOverrides(d *appsv1.Deployment, overrides v1beta1.OverrideSpec) {
+	overrides = *overrides.DeepCopy()
+	templateSpec := &d.Spec.Template.Spec
+	if len(overrides.NodeSelector) > 0 {
+		templateSpec.NodeSelector = overrides.NodeSelector
+	}
+	if len(overrides.Tolerations) > 0 {
+		templateSpec.Tolerations = overrides.Tolerations
+	}
+	if overrides.Affinity != nil {
+		templateSpec.Affinity = overrides.Affinity
+	}
+	if len(overrides.TopologySpreadConstraints) > 0 {
+		templateSpec.TopologySpreadConstraints = overrides.TopologySpreadConstraints
+	}
+	if overrides.PriorityClassName != "" {
+		templateSpec.PriorityClassName = overrides.PriorityClassName
+	}
+	for key, value := range overrides.PodLabels {
+		if _, found := d.Spec.Template.Labels[key]; !found {
+			core.SetLabel(&d.Spec.Template, key, value)
+		}
+	}
+	for key, value := range overrides.PodAnnotations {
+		if _, found := d.Spec.Template.Annotations[key]; !found {
+			core.SetAnnotation(&d.Spec.Template, key, value)
+		}
+	}
+}
+
+// applyImageRegistryMirror replaces the registry of the images of all the
+// containers of the reconciler Deployment with the mirror, if not empty.
+func applyImageRegistryMirror(d *appsv1.Deployment, mirror string) {
+	if mirror == "" {
+		return
+	}
+	templateSpec := &d.Spec.Template.Spec
+	for i := range templateSpec.InitContainers {
+		templateSpec.InitContainers[i].Image = imagemirror.Rewrite(templateSpec.InitContainers[i].Image, mirror)
+	}
+	for i := range templateSpec.Containers {
+		templateSpec.Containers[i].Image = imagemirror.Rewrite(templateSpec.Containers[i].Image, mirror)
+	}
+}
+
 // adjustContainerResources adjusts the resources of all containers in the declared Deployment.
 // It returns a boolean to indicate if the declared Deployment is updated or not.
 // This function aims to address the fight among Autopilot and the reconciler since they all update the resources.
@@ -756,6 +903,39 @@ func (r *reconcilerBase) upsertSharedClusterRoleBinding(ctx context.Context, nam
 	return nil
 }
 
+// upsertConfigReaderRoleBinding adds the reconciler to the subjects of the
+// shared RoleBinding to the config reader Role.
+func (r *reconcilerBase) upsertConfigReaderRoleBinding(ctx context.Context, reconcilerRef types.NamespacedName) error {
+	rbRef := client.ObjectKey{Namespace: configsync.ControllerNamespace, Name: ReconcilerConfigReaderRoleBindingName}
+	childRB := &rbacv1.RoleBinding{}
+	childRB.Name = rbRef.Name
+	childRB.Namespace = rbRef.Namespace
+
+	// Only the managed-by label is set, since the RoleBinding is shared by
+	// RootSync and RepoSync reconcilers.
+	labelMap := ManagedByLabel()
+
+	r.Logger(ctx).V(3).Info("Upserting managed object",
+		logFieldObjectRef, rbRef.String(),
+		logFieldObjectKind, "RoleBinding")
+	op, err := CreateOrUpdate(ctx, r.client, childRB, func() error {
+		core.AddLabels(childRB, labelMap)
+		childRB.RoleRef = rolereference(ReconcilerConfigReaderRoleName, "Role")
+		childRB.Subjects = addSubject(childRB.Subjects, r.serviceAccountSubject(reconcilerRef))
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if op != controllerutil.OperationResultNone {
+		r.Logger(ctx).Info("Upserting managed object successful",
+			logFieldObjectRef, rbRef.String(),
+			logFieldObjectKind, "RoleBinding",
+			logFieldOperation, op)
+	}
+	return nil
+}
+
 func (r *reconcilerBase) isKnownHostsEnabled(auth configsync.AuthType) bool {
 	if auth == configsync.AuthSSH && r.knownHostExist {
 		return true
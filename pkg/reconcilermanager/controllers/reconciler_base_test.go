@@ -28,9 +28,12 @@ import (
 	"github.com/stretchr/testify/require"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/ptr"
 	"kpt.dev/configsync/pkg/api/configsync"
 	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
@@ -38,6 +41,7 @@ import (
 	"kpt.dev/configsync/pkg/core/k8sobjects"
 	"kpt.dev/configsync/pkg/kinds"
 	"kpt.dev/configsync/pkg/metadata"
+	"kpt.dev/configsync/pkg/metrics"
 	"kpt.dev/configsync/pkg/reconcilermanager"
 	syncerFake "kpt.dev/configsync/pkg/syncer/syncertest/fake"
 	"kpt.dev/configsync/pkg/testing/testcontroller"
@@ -716,6 +720,129 @@ func TestCompareDeploymentsToCreatePatchDataResourceLimits(t *testing.T) {
 	}
 }
 
+func TestCompareDeploymentsToCreatePatchDataPodOverrides(t *testing.T) {
+	declared := yamlToDeployment(t, declaredDeployment)
+	currentDeploymentUnstructured := yamlToUnstructured(t, currentDeployment)
+	nodeSelector := map[string]string{"cloud.google.com/gke-nodepool": "system"}
+	withNodeSelector := func(u *unstructured.Unstructured) *unstructured.Unstructured {
+		u = u.DeepCopy()
+		require.NoError(t, unstructured.SetNestedStringMap(u.Object, nodeSelector, "spec", "template", "spec", "nodeSelector"))
+		return u
+	}
+	withManagedFields := func(u *unstructured.Unstructured, manager string) *unstructured.Unstructured {
+		u = u.DeepCopy()
+		u.SetManagedFields([]metav1.ManagedFieldsEntry{{
+			Manager:    manager,
+			Operation:  metav1.ManagedFieldsOperationApply,
+			FieldsType: "FieldsV1",
+			FieldsV1: &metav1.FieldsV1{
+				Raw: []byte(`{"f:spec":{"f:template":{"f:spec":{"f:nodeSelector":{"f:cloud.google.com/gke-nodepool":{}}}}}}`),
+			},
+		}})
+		return u
+	}
+	testCases := map[string]struct {
+		overrides    v1beta1.OverrideSpec
+		current      *unstructured.Unstructured
+		expectedSame bool
+	}{
+		"nodeSelector override added": {
+			overrides:    v1beta1.OverrideSpec{NodeSelector: nodeSelector},
+			current:      currentDeploymentUnstructured,
+			expectedSame: false,
+		},
+		"nodeSelector override unchanged": {
+			overrides:    v1beta1.OverrideSpec{NodeSelector: nodeSelector},
+			current:      withManagedFields(withNodeSelector(currentDeploymentUnstructured), reconcilermanager.ManagerName),
+			expectedSame: true,
+		},
+		"nodeSelector override removed": {
+			current:      withManagedFields(withNodeSelector(currentDeploymentUnstructured), reconcilermanager.ManagerName),
+			expectedSame: false,
+		},
+		"nodeSelector set by another controller": {
+			current:      withManagedFields(withNodeSelector(currentDeploymentUnstructured), "kubectl"),
+			expectedSame: true,
+		},
+		"priorityClassName override added": {
+			overrides:    v1beta1.OverrideSpec{PriorityClassName: "system-cluster-critical"},
+			current:      currentDeploymentUnstructured,
+			expectedSame: false,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			testDeclared := declared.DeepCopy()
+			applyPodOverrides(testDeclared, tc.overrides)
+			fakeClient := syncerFake.NewClient(t, core.Scheme)
+			logger := testcontroller.NewTestLogger(t)
+			r := &reconcilerBase{
+				LoggingController: NewLoggingController(logger),
+				scheme:            fakeClient.Scheme(),
+				client:            fakeClient,
+			}
+			dep, err := r.compareDeploymentsToCreatePatchData(testDeclared, tc.current.DeepCopy(), reconcilerManagerAllowList)
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedSame, dep.same)
+		})
+	}
+}
+
+func TestApplyPodOverrides(t *testing.T) {
+	d := &appsv1.Deployment{}
+	core.SetLabel(&d.Spec.Template, metadata.ReconcilerLabel, "root-reconciler")
+	overrides := v1beta1.OverrideSpec{
+		NodeSelector:      map[string]string{"cloud.google.com/gke-nodepool": "system"},
+		Tolerations:       []corev1.Toleration{{Key: "dedicated", Operator: corev1.TolerationOpEqual, Value: "system", Effect: corev1.TaintEffectNoSchedule}},
+		PriorityClassName: "system-cluster-critical",
+		PodLabels: map[string]string{
+			"team":                   "platform",
+			metadata.ReconcilerLabel: "other",
+		},
+		PodAnnotations: map[string]string{"example.com/owner": "platform"},
+	}
+	applyPodOverrides(d, overrides)
+
+	assert.Equal(t, overrides.NodeSelector, d.Spec.Template.Spec.NodeSelector)
+	assert.Equal(t, overrides.Tolerations, d.Spec.Template.Spec.Tolerations)
+	assert.Equal(t, "system-cluster-critical", d.Spec.Template.Spec.PriorityClassName)
+	assert.Nil(t, d.Spec.Template.Spec.Affinity)
+	assert.Equal(t, map[string]string{
+		"team":                   "platform",
+		metadata.ReconcilerLabel: "root-reconciler",
+	}, d.Spec.Template.Labels)
+	assert.Equal(t, map[string]string{"example.com/owner": "platform"}, d.Spec.Template.Annotations)
+
+	// The overrides must not be modified via the Deployment.
+	d.Spec.Template.Spec.NodeSelector["foo"] = "bar"
+	assert.NotContains(t, overrides.NodeSelector, "foo")
+}
+
+func TestApplyImageRegistryMirror(t *testing.T) {
+	d := &appsv1.Deployment{}
+	d.Spec.Template.Spec.Containers = []corev1.Container{
+		{Name: reconcilermanager.Reconciler, Image: "gcr.io/config-management-release/reconciler:v1.20.0"},
+		{Name: reconcilermanager.GitSync, Image: "gcr.io/config-management-release/git-sync:v4.2.3-gke.5__linux_amd64"},
+		{Name: metrics.OtelAgentName, Image: "gcr.io/config-management-release/otelcontribcol:v0.103.0-gke.7"},
+	}
+
+	// An empty mirror doesn't modify the images.
+	applyImageRegistryMirror(d, "")
+	assert.Equal(t, "gcr.io/config-management-release/reconciler:v1.20.0", d.Spec.Template.Spec.Containers[0].Image)
+
+	applyImageRegistryMirror(d, "registry.example.com/config-sync")
+	var images []string
+	for _, container := range d.Spec.Template.Spec.Containers {
+		images = append(images, container.Image)
+	}
+	assert.Equal(t, []string{
+		"registry.example.com/config-sync/reconciler:v1.20.0",
+		"registry.example.com/config-sync/git-sync:v4.2.3-gke.5__linux_amd64",
+		"registry.example.com/config-sync/otelcontribcol:v0.103.0-gke.7",
+	}, images)
+}
+
 func TestMountConfigMapValuesFiles(t *testing.T) {
 	testCases := map[string]struct {
 		input    []v1beta1.ValuesFileRef
@@ -1131,3 +1258,31 @@ func TestSetupAndTeardown(t *testing.T) {
 		})
 	}
 }
+
+func TestConfigReaderRoleBinding(t *testing.T) {
+	ctx := context.Background()
+	fakeClient, _, testReconciler := setupNSReconciler(t)
+	rbKey := client.ObjectKey{Namespace: configsync.ControllerNamespace, Name: ReconcilerConfigReaderRoleBindingName}
+	reconciler1 := types.NamespacedName{Namespace: configsync.ControllerNamespace, Name: "ns-reconciler-foo"}
+	reconciler2 := types.NamespacedName{Namespace: configsync.ControllerNamespace, Name: "ns-reconciler-bar"}
+
+	// Each reconciler is bound, rather than every ServiceAccount in the
+	// namespace.
+	require.NoError(t, testReconciler.upsertConfigReaderRoleBinding(ctx, reconciler1))
+	require.NoError(t, testReconciler.upsertConfigReaderRoleBinding(ctx, reconciler2))
+	rb := &rbacv1.RoleBinding{}
+	require.NoError(t, fakeClient.Get(ctx, rbKey, rb))
+	assert.Equal(t, rolereference(ReconcilerConfigReaderRoleName, "Role"), rb.RoleRef)
+	assert.ElementsMatch(t, []rbacv1.Subject{
+		testReconciler.serviceAccountSubject(reconciler1),
+		testReconciler.serviceAccountSubject(reconciler2),
+	}, rb.Subjects)
+
+	// The RoleBinding is deleted with its last subject.
+	require.NoError(t, testReconciler.deleteConfigReaderRoleBinding(ctx, reconciler1))
+	require.NoError(t, fakeClient.Get(ctx, rbKey, rb))
+	assert.Equal(t, []rbacv1.Subject{testReconciler.serviceAccountSubject(reconciler2)}, rb.Subjects)
+	require.NoError(t, testReconciler.deleteConfigReaderRoleBinding(ctx, reconciler2))
+	err := fakeClient.Get(ctx, rbKey, rb)
+	assert.True(t, apierrors.IsNotFound(err), "expected RoleBinding to be NotFound, got: %v", err)
+}
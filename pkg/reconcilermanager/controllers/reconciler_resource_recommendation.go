@@ -0,0 +1,255 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
+	"kpt.dev/configsync/pkg/api/kpt.dev/v1alpha1"
+	"kpt.dev/configsync/pkg/reconcilermanager"
+)
+
+const (
+	// recommendationBaseMilliCPU is the CPU request of a reconciler without
+	// any objects.
+	recommendationBaseMilliCPU = 20
+	// recommendationMilliCPUPerThousandObjects is the additional CPU request
+	// for every thousand objects synced by the reconciler.
+	recommendationMilliCPUPerThousandObjects = 30
+	// recommendationBaseMemory is the memory request of a reconciler without
+	// any objects.
+	recommendationBaseMemory = 50 * mebibyte
+	// recommendationMemoryPerObject is the additional memory request for every
+	// object synced by the reconciler.
+	recommendationMemoryPerObject = 150 * kibibyte
+	// recommendationMemoryHeadroomPercent is the headroom added on top of the
+	// memory high-water mark.
+	recommendationMemoryHeadroomPercent = 25
+	// recommendationMemoryHighWaterMarkWindow is how long the memory
+	// high-water mark of previous reconciler pods is kept after it was
+	// reported, so that the recommendation eventually decreases when the
+	// memory usage drops.
+	recommendationMemoryHighWaterMarkWindow = 7 * 24 * time.Hour
+	// recommendationTolerancePercent is the change below which the previous
+	// recommendation is kept, to avoid restarting the reconciler for small
+	// changes.
+	recommendationTolerancePercent = 10
+
+	kibibyte = 1024
+	mebibyte = 1024 * kibibyte
+)
+
+// resourceUsageObservation is the resource usage of a reconciler, used to
+// recommend the resources of the reconciler container.
+type resourceUsageObservation struct {
+	inventoryObjects        int
+	declaredResources       int
+	memoryHighWaterMark     int64
+	memoryHighWaterMarkTime metav1.Time
+}
+
+// recommendResources returns the recommended resources of the reconciler
+// container, or nil if recommendations are disabled.
+//
+// The inventory object count is read from the ResourceGroup of the RSync. The
+// declared resource count and memory high-water mark are reported by the
+// reconciler in the RSync status. The memory high-water mark is kept across
+// reconciler restarts for up to recommendationMemoryHighWaterMarkWindow, so the
+// recommendation doesn't decrease when the reconciler restarts with the applied
+// recommendation, but still follows a lasting drop in memory usage.
+func (r *reconcilerBase) recommendResources(ctx context.Context, rsRef types.NamespacedName, overrides v1beta1.OverrideSpec, syncStatus v1beta1.Status) (*v1beta1.ResourceRecommendation, error) {
+	spec := overrides.ResourceRecommendations
+	if spec == nil {
+		return nil, nil
+	}
+	now := metav1.Now()
+	obs := resourceUsageObservation{}
+	rg := &v1alpha1.ResourceGroup{}
+	if err := r.watcher.Get(ctx, rsRef, rg); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, NewObjectOperationErrorWithKey(err, rg, OperationGet, rsRef)
+		}
+		// The reconciler hasn't created its inventory yet.
+	} else {
+		obs.inventoryObjects = len(rg.Spec.Resources)
+	}
+	var reported int64
+	if usage := syncStatus.Sync.ResourceUsage; usage != nil {
+		obs.declaredResources = usage.DeclaredResources
+		reported = usage.MemoryHighWaterMark.Value()
+	}
+	previous := syncStatus.ResourceRecommendation
+	obs.memoryHighWaterMark, obs.memoryHighWaterMarkTime = carryMemoryHighWaterMark(reported, previous, now)
+	return computeResourceRecommendation(spec, obs, previous, now), nil
+}
+
+// carryMemoryHighWaterMark returns the memory high-water mark and when it was
+// reported. The high-water mark of the previous recommendation is kept if it's
+// not lower than the reported one, and it was reported within
+// recommendationMemoryHighWaterMarkWindow. Keeping its timestamp when it's equal
+// avoids updating the status on every reconcile.
+func carryMemoryHighWaterMark(reported int64, previous *v1beta1.ResourceRecommendation, now metav1.Time) (int64, metav1.Time) {
+	if previous == nil || previous.MemoryHighWaterMark.Value() < reported {
+		return reported, now
+	}
+	reportedAt := previous.MemoryHighWaterMarkTime
+	if reportedAt.IsZero() {
+		// Recommendations computed before the timestamp was recorded start
+		// the window now.
+		reportedAt = now
+	}
+	if now.Sub(reportedAt.Time) >= recommendationMemoryHighWaterMarkWindow {
+		return reported, now
+	}
+	return previous.MemoryHighWaterMark.Value(), reportedAt
+}
+
+// computeResourceRecommendation computes the CPU and memory requests of the
+// reconciler container from the observed resource usage, within the bounds of
+// the spec.
+//
+// The CPU request is estimated from the number of objects. The memory request
+// is the larger of the estimate from the number of objects and the memory
+// high-water mark plus headroom. The previous recommendation is kept if the new
+// one is within tolerance and bounds.
+func computeResourceRecommendation(spec *v1beta1.ResourceRecommendationsSpec, obs resourceUsageObservation, previous *v1beta1.ResourceRecommendation, now metav1.Time) *v1beta1.ResourceRecommendation {
+	objects := max(obs.inventoryObjects, obs.declaredResources)
+	var reasons []string
+
+	milliCPU := int64(recommendationBaseMilliCPU + (recommendationMilliCPUPerThousandObjects*objects+999)/1000)
+	cpuRequest := *resource.NewMilliQuantity(milliCPU, resource.DecimalSI)
+	reasons = append(reasons, fmt.Sprintf("CPU estimated for %d objects", objects))
+
+	memory := int64(recommendationBaseMemory + recommendationMemoryPerObject*objects)
+	memoryReason := fmt.Sprintf("memory estimated for %d objects", objects)
+	if observed := obs.memoryHighWaterMark * (100 + recommendationMemoryHeadroomPercent) / 100; observed > memory {
+		memory = observed
+		memoryReason = fmt.Sprintf("memory high-water mark of %s plus %d%% headroom",
+			resource.NewQuantity(obs.memoryHighWaterMark, resource.BinarySI), recommendationMemoryHeadroomPercent)
+	}
+	// Round up to the nearest MiB.
+	memory = (memory + mebibyte - 1) / mebibyte * mebibyte
+	memoryRequest := *resource.NewQuantity(memory, resource.BinarySI)
+	reasons = append(reasons, memoryReason)
+
+	if reason, clamped := clampQuantity(&cpuRequest, spec.MinCPU, spec.MaxCPU, "CPU"); clamped {
+		reasons = append(reasons, reason)
+	}
+	if reason, clamped := clampQuantity(&memoryRequest, spec.MinMemory, spec.MaxMemory, "memory"); clamped {
+		reasons = append(reasons, reason)
+	}
+
+	recommendation := &v1beta1.ResourceRecommendation{
+		CPURequest:              cpuRequest,
+		MemoryRequest:           memoryRequest,
+		Applied:                 spec.AutoApply != nil && *spec.AutoApply,
+		Reason:                  strings.Join(reasons, "; "),
+		InventoryObjects:        obs.inventoryObjects,
+		DeclaredResources:       obs.declaredResources,
+		MemoryHighWaterMark:     *resource.NewQuantity(obs.memoryHighWaterMark, resource.BinarySI),
+		MemoryHighWaterMarkTime: obs.memoryHighWaterMarkTime,
+		LastUpdate:              now,
+	}
+	if previous != nil &&
+		withinTolerance(previous.CPURequest, cpuRequest) &&
+		withinTolerance(previous.MemoryRequest, memoryRequest) &&
+		withinBounds(previous.CPURequest, spec.MinCPU, spec.MaxCPU) &&
+		withinBounds(previous.MemoryRequest, spec.MinMemory, spec.MaxMemory) {
+		recommendation.CPURequest = previous.CPURequest
+		recommendation.MemoryRequest = previous.MemoryRequest
+		recommendation.Reason = previous.Reason
+		recommendation.LastUpdate = previous.LastUpdate
+	}
+	return recommendation
+}
+
+// clampQuantity clamps the quantity within the bounds, if not zero, and
+// returns the reason if clamped.
+func clampQuantity(q *resource.Quantity, minimum, maximum resource.Quantity, name string) (string, bool) {
+	switch {
+	case !minimum.IsZero() && q.Cmp(minimum) < 0:
+		*q = minimum.DeepCopy()
+		return fmt.Sprintf("%s raised to the minimum of %s", name, minimum.String()), true
+	case !maximum.IsZero() && q.Cmp(maximum) > 0:
+		*q = maximum.DeepCopy()
+		return fmt.Sprintf("%s capped at the maximum of %s", name, maximum.String()), true
+	default:
+		return "", false
+	}
+}
+
+func withinBounds(q, minimum, maximum resource.Quantity) bool {
+	return (minimum.IsZero() || q.Cmp(minimum) >= 0) &&
+		(maximum.IsZero() || q.Cmp(maximum) <= 0)
+}
+
+func withinTolerance(previous, current resource.Quantity) bool {
+	diff := current.MilliValue() - previous.MilliValue()
+	if diff < 0 {
+		diff = -diff
+	}
+	return diff*100 <= previous.MilliValue()*recommendationTolerancePercent
+}
+
+// applyResourceRecommendation returns the container resource overrides with
+// the recommended requests of the reconciler container, if applied. The
+// resources specified in the overrides take precedence, and the recommended
+// requests are capped at the limits specified in the overrides, since requests
+// may not exceed limits. On Autopilot clusters, where limits must equal
+// requests, the limits are set to the recommended requests too.
+func applyResourceRecommendation(overrides []v1beta1.ContainerResourcesSpec, recommendation *v1beta1.ResourceRecommendation, isAutopilot bool) []v1beta1.ContainerResourcesSpec {
+	if recommendation == nil || !recommendation.Applied {
+		return overrides
+	}
+	result := make([]v1beta1.ContainerResourcesSpec, 0, len(overrides)+1)
+	reconcilerResources := v1beta1.ContainerResourcesSpec{ContainerName: reconcilermanager.Reconciler}
+	for _, override := range overrides {
+		if override.ContainerName == reconcilermanager.Reconciler {
+			reconcilerResources = override
+			continue
+		}
+		result = append(result, override)
+	}
+	if reconcilerResources.CPURequest.IsZero() {
+		reconcilerResources.CPURequest = cappedRequest(recommendation.CPURequest, reconcilerResources.CPULimit)
+		if isAutopilot && reconcilerResources.CPULimit.IsZero() {
+			reconcilerResources.CPULimit = recommendation.CPURequest
+		}
+	}
+	if reconcilerResources.MemoryRequest.IsZero() {
+		reconcilerResources.MemoryRequest = cappedRequest(recommendation.MemoryRequest, reconcilerResources.MemoryLimit)
+		if isAutopilot && reconcilerResources.MemoryLimit.IsZero() {
+			reconcilerResources.MemoryLimit = recommendation.MemoryRequest
+		}
+	}
+	return append(result, reconcilerResources)
+}
+
+// cappedRequest returns the recommended request, capped at the limit, if not
+// zero.
+func cappedRequest(recommended, limit resource.Quantity) resource.Quantity {
+	if !limit.IsZero() && recommended.Cmp(limit) > 0 {
+		return limit.DeepCopy()
+	}
+	return recommended
+}
@@ -0,0 +1,347 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
+	"kpt.dev/configsync/pkg/api/kpt.dev/v1alpha1"
+	"kpt.dev/configsync/pkg/reconcilermanager"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestComputeResourceRecommendation(t *testing.T) {
+	now := metav1.NewTime(time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC))
+	earlier := metav1.NewTime(now.Add(-time.Hour))
+	testCases := []struct {
+		name       string
+		spec       v1beta1.ResourceRecommendationsSpec
+		obs        resourceUsageObservation
+		previous   *v1beta1.ResourceRecommendation
+		wantCPU    string
+		wantMemory string
+		wantReason string
+		wantUpdate metav1.Time
+	}{
+		{
+			name:       "no objects",
+			wantCPU:    "20m",
+			wantMemory: "50Mi",
+			wantReason: "CPU estimated for 0 objects; memory estimated for 0 objects",
+			wantUpdate: now,
+		},
+		{
+			name: "estimated from the larger object count",
+			obs: resourceUsageObservation{
+				inventoryObjects:    2000,
+				declaredResources:   1500,
+				memoryHighWaterMark: 200 * mebibyte,
+			},
+			wantCPU:    "80m",
+			wantMemory: "343Mi",
+			wantReason: "CPU estimated for 2000 objects; memory estimated for 2000 objects",
+			wantUpdate: now,
+		},
+		{
+			name: "memory from high-water mark",
+			obs: resourceUsageObservation{
+				declaredResources:   100,
+				memoryHighWaterMark: 400 * mebibyte,
+			},
+			wantCPU:    "23m",
+			wantMemory: "500Mi",
+			wantReason: "CPU estimated for 100 objects; memory high-water mark of 400Mi plus 25% headroom",
+			wantUpdate: now,
+		},
+		{
+			name: "clamped to bounds",
+			spec: v1beta1.ResourceRecommendationsSpec{
+				MinCPU:    resource.MustParse("100m"),
+				MaxMemory: resource.MustParse("256Mi"),
+			},
+			obs:        resourceUsageObservation{inventoryObjects: 2000},
+			wantCPU:    "100m",
+			wantMemory: "256Mi",
+			wantReason: "CPU estimated for 2000 objects; memory estimated for 2000 objects; " +
+				"CPU raised to the minimum of 100m; memory capped at the maximum of 256Mi",
+			wantUpdate: now,
+		},
+		{
+			name: "previous recommendation within tolerance",
+			obs:  resourceUsageObservation{inventoryObjects: 2000},
+			previous: &v1beta1.ResourceRecommendation{
+				CPURequest:    resource.MustParse("78m"),
+				MemoryRequest: resource.MustParse("330Mi"),
+				Reason:        "previous",
+				LastUpdate:    earlier,
+			},
+			wantCPU:    "78m",
+			wantMemory: "330Mi",
+			wantReason: "previous",
+			wantUpdate: earlier,
+		},
+		{
+			name: "previous recommendation outside tolerance",
+			obs:  resourceUsageObservation{inventoryObjects: 2000},
+			previous: &v1beta1.ResourceRecommendation{
+				CPURequest:    resource.MustParse("78m"),
+				MemoryRequest: resource.MustParse("200Mi"),
+				Reason:        "previous",
+				LastUpdate:    earlier,
+			},
+			wantCPU:    "80m",
+			wantMemory: "343Mi",
+			wantReason: "CPU estimated for 2000 objects; memory estimated for 2000 objects",
+			wantUpdate: now,
+		},
+		{
+			name: "previous recommendation out of bounds",
+			spec: v1beta1.ResourceRecommendationsSpec{
+				MaxMemory: resource.MustParse("300Mi"),
+			},
+			obs: resourceUsageObservation{inventoryObjects: 2000},
+			previous: &v1beta1.ResourceRecommendation{
+				CPURequest:    resource.MustParse("80m"),
+				MemoryRequest: resource.MustParse("320Mi"),
+				Reason:        "previous",
+				LastUpdate:    earlier,
+			},
+			wantCPU:    "80m",
+			wantMemory: "300Mi",
+			wantReason: "CPU estimated for 2000 objects; memory estimated for 2000 objects; " +
+				"memory capped at the maximum of 300Mi",
+			wantUpdate: now,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := computeResourceRecommendation(&tc.spec, tc.obs, tc.previous, now)
+			assert.Equal(t, tc.wantCPU, got.CPURequest.String())
+			assert.Equal(t, tc.wantMemory, got.MemoryRequest.String())
+			assert.Equal(t, tc.wantReason, got.Reason)
+			assert.Equal(t, tc.wantUpdate, got.LastUpdate)
+			assert.Equal(t, tc.obs.inventoryObjects, got.InventoryObjects)
+			assert.Equal(t, tc.obs.declaredResources, got.DeclaredResources)
+			assert.False(t, got.Applied)
+		})
+	}
+}
+
+func TestCarryMemoryHighWaterMark(t *testing.T) {
+	now := metav1.NewTime(time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC))
+	earlier := metav1.NewTime(now.Add(-time.Hour))
+	expired := metav1.NewTime(now.Add(-recommendationMemoryHighWaterMarkWindow))
+	testCases := []struct {
+		name     string
+		reported int64
+		previous *v1beta1.ResourceRecommendation
+		want     int64
+		wantTime metav1.Time
+	}{
+		{
+			name:     "no previous recommendation",
+			reported: 200 * mebibyte,
+			want:     200 * mebibyte,
+			wantTime: now,
+		},
+		{
+			name:     "reported higher than previous",
+			reported: 300 * mebibyte,
+			previous: &v1beta1.ResourceRecommendation{
+				MemoryHighWaterMark:     resource.MustParse("200Mi"),
+				MemoryHighWaterMarkTime: earlier,
+			},
+			want:     300 * mebibyte,
+			wantTime: now,
+		},
+		{
+			name:     "previous kept within window",
+			reported: 100 * mebibyte,
+			previous: &v1beta1.ResourceRecommendation{
+				MemoryHighWaterMark:     resource.MustParse("200Mi"),
+				MemoryHighWaterMarkTime: earlier,
+			},
+			want:     200 * mebibyte,
+			wantTime: earlier,
+		},
+		{
+			name:     "previous equal to reported keeps its time",
+			reported: 200 * mebibyte,
+			previous: &v1beta1.ResourceRecommendation{
+				MemoryHighWaterMark:     resource.MustParse("200Mi"),
+				MemoryHighWaterMarkTime: earlier,
+			},
+			want:     200 * mebibyte,
+			wantTime: earlier,
+		},
+		{
+			name:     "previous dropped after window",
+			reported: 100 * mebibyte,
+			previous: &v1beta1.ResourceRecommendation{
+				MemoryHighWaterMark:     resource.MustParse("200Mi"),
+				MemoryHighWaterMarkTime: expired,
+			},
+			want:     100 * mebibyte,
+			wantTime: now,
+		},
+		{
+			name:     "previous without time starts the window",
+			reported: 100 * mebibyte,
+			previous: &v1beta1.ResourceRecommendation{
+				MemoryHighWaterMark: resource.MustParse("200Mi"),
+			},
+			want:     200 * mebibyte,
+			wantTime: now,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, gotTime := carryMemoryHighWaterMark(tc.reported, tc.previous, now)
+			assert.Equal(t, tc.want, got)
+			assert.Equal(t, tc.wantTime, gotTime)
+		})
+	}
+}
+
+func TestApplyResourceRecommendation(t *testing.T) {
+	recommendation := &v1beta1.ResourceRecommendation{
+		CPURequest:    resource.MustParse("80m"),
+		MemoryRequest: resource.MustParse("343Mi"),
+		Applied:       true,
+	}
+	overrides := []v1beta1.ContainerResourcesSpec{
+		{ContainerName: reconcilermanager.GitSync, CPURequest: resource.MustParse("10m")},
+		{ContainerName: reconcilermanager.Reconciler, CPURequest: resource.MustParse("500m")},
+	}
+
+	notApplied := recommendation.DeepCopy()
+	notApplied.Applied = false
+	assert.Equal(t, overrides, applyResourceRecommendation(overrides, notApplied, false))
+	assert.Equal(t, overrides, applyResourceRecommendation(overrides, nil, false))
+
+	assert.Equal(t, []v1beta1.ContainerResourcesSpec{
+		overrides[0],
+		{
+			ContainerName: reconcilermanager.Reconciler,
+			CPURequest:    resource.MustParse("500m"),
+			MemoryRequest: resource.MustParse("343Mi"),
+		},
+	}, applyResourceRecommendation(overrides, recommendation, false))
+
+	assert.Equal(t, []v1beta1.ContainerResourcesSpec{
+		{
+			ContainerName: reconcilermanager.Reconciler,
+			CPURequest:    resource.MustParse("80m"),
+			CPULimit:      resource.MustParse("80m"),
+			MemoryRequest: resource.MustParse("343Mi"),
+			MemoryLimit:   resource.MustParse("343Mi"),
+		},
+	}, applyResourceRecommendation(nil, recommendation, true))
+
+	// The recommended requests don't exceed the limits of the overrides.
+	limitOnly := []v1beta1.ContainerResourcesSpec{
+		{ContainerName: reconcilermanager.Reconciler, CPULimit: resource.MustParse("1"), MemoryLimit: resource.MustParse("256Mi")},
+	}
+	assert.Equal(t, []v1beta1.ContainerResourcesSpec{
+		{
+			ContainerName: reconcilermanager.Reconciler,
+			CPURequest:    resource.MustParse("80m"),
+			CPULimit:      resource.MustParse("1"),
+			MemoryRequest: resource.MustParse("256Mi"),
+			MemoryLimit:   resource.MustParse("256Mi"),
+		},
+	}, applyResourceRecommendation(limitOnly, recommendation, false))
+}
+
+func TestRootSyncResourceRecommendation(t *testing.T) {
+	// Mock out parseDeployment for testing.
+	parseDeployment = parsedDeployment
+
+	ctx := context.Background()
+	rs := rootSyncWithGit(rootsyncName, rootsyncRef(gitRevision), rootsyncBranch(branch),
+		rootsyncSecretType(configsync.AuthNone), func(rs *v1beta1.RootSync) {
+			rs.Spec.Override = &v1beta1.RootSyncOverrideSpec{
+				OverrideSpec: v1beta1.OverrideSpec{
+					ResourceRecommendations: &v1beta1.ResourceRecommendationsSpec{
+						AutoApply: ptr.To(true),
+					},
+				},
+			}
+		})
+	reqNamespacedName := namespacedName(rs.Name, rs.Namespace)
+	rg := resourceGroup(rs)
+	for i := 0; i < 2000; i++ {
+		rg.Spec.Resources = append(rg.Spec.Resources, v1alpha1.ObjMetadata{
+			Name:      fmt.Sprintf("cm-%d", i),
+			Namespace: "default",
+			GroupKind: v1alpha1.GroupKind{Kind: "ConfigMap"},
+		})
+	}
+	fakeClient, fakeDynamicClient, testReconciler := setupRootReconciler(t, rs, rg)
+	reconcilerRef := types.NamespacedName{Namespace: configsync.ControllerNamespace, Name: rootReconcilerName}
+
+	// Usage reported by the reconciler.
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(rs), rs))
+	rs.Status.Sync.ResourceUsage = &v1beta1.ReconcilerResourceUsage{
+		DeclaredResources:   1500,
+		MemoryHighWaterMark: resource.MustParse("200Mi"),
+	}
+	require.NoError(t, fakeClient.Status().Update(ctx, rs, client.FieldOwner(reconcilermanager.FieldManager)))
+
+	_, err := testReconciler.Reconcile(ctx, reqNamespacedName)
+	require.NoError(t, err)
+
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(rs), rs))
+	recommendation := rs.Status.ResourceRecommendation
+	require.NotNil(t, recommendation)
+	assert.True(t, recommendation.Applied)
+	assert.Equal(t, 2000, recommendation.InventoryObjects)
+	assert.Equal(t, 1500, recommendation.DeclaredResources)
+	assert.Equal(t, "80m", recommendation.CPURequest.String())
+	assert.Equal(t, "343Mi", recommendation.MemoryRequest.String())
+	assert.Equal(t, "200Mi", recommendation.MemoryHighWaterMark.String())
+
+	d, err := getDeployment(t, fakeDynamicClient, reconcilerRef)
+	require.NoError(t, err)
+	var found bool
+	for _, c := range d.Spec.Template.Spec.Containers {
+		if c.Name != reconcilermanager.Reconciler {
+			continue
+		}
+		found = true
+		assert.Equal(t, "80m", c.Resources.Requests.Cpu().String())
+		assert.Equal(t, "343Mi", c.Resources.Requests.Memory().String())
+	}
+	assert.True(t, found)
+
+	// Disabling recommendations removes the status.
+	rs.Spec.Override = nil
+	require.NoError(t, fakeClient.Update(ctx, rs, client.FieldOwner(reconcilermanager.FieldManager)))
+	_, err = testReconciler.Reconcile(ctx, reqNamespacedName)
+	require.NoError(t, err)
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(rs), rs))
+	assert.Nil(t, rs.Status.ResourceRecommendation)
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	admissionv1 "k8s.io/api/admissionregistration/v1"
 	appsv1 "k8s.io/api/apps/v1"
+	coordinationv1 "k8s.io/api/coordination/v1"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
@@ -36,6 +37,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
 	"kpt.dev/configsync/pkg/api/configsync"
 	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
 	hubv1 "kpt.dev/configsync/pkg/api/hub/v1"
@@ -44,6 +46,7 @@ import (
 	"kpt.dev/configsync/pkg/kinds"
 	"kpt.dev/configsync/pkg/metadata"
 	"kpt.dev/configsync/pkg/metrics"
+	"kpt.dev/configsync/pkg/reconciler/pool"
 	"kpt.dev/configsync/pkg/reconcilermanager"
 	"kpt.dev/configsync/pkg/reposync"
 	"kpt.dev/configsync/pkg/status"
@@ -77,6 +80,14 @@ type RepoSyncReconciler struct {
 	controller controller.Controller
 
 	cache cache.Cache
+
+	// poolSize is the maximum number of pooled reconcilers.
+	// Zero disables pooling. Immutable after the controller is registered.
+	poolSize int
+
+	// apiClientCaps are the cluster-level caps of the API client limits of
+	// the namespace reconcilers. Zero values are not capped.
+	apiClientCaps APIClientLimits
 }
 
 const (
@@ -216,10 +227,19 @@ func (r *RepoSyncReconciler) Reconcile(ctx context.Context, req controllerruntim
 	return controllerruntime.Result{}, nil
 }
 
-func (r *RepoSyncReconciler) upsertManagedObjects(ctx context.Context, reconcilerRef types.NamespacedName, rs *v1beta1.RepoSync) error {
+func (r *RepoSyncReconciler) upsertManagedObjects(ctx context.Context, reconcilerRef types.NamespacedName, rs *v1beta1.RepoSync, recommendation *v1beta1.ResourceRecommendation) error {
 	rsRef := client.ObjectKeyFromObject(rs)
 	r.Logger(ctx).V(3).Info("Reconciling managed objects")
 
+	if r.isPooled(ctx, rs) {
+		return r.upsertPooledObjects(ctx, reconcilerRef, rs)
+	}
+	// Remove the RepoSync from its pool, if it was pooled before, so that it
+	// is not synced by two reconcilers.
+	if _, err := r.reconcilePools(ctx, rsRef, nil); err != nil {
+		return fmt.Errorf("reconciling reconciler pools: %w", err)
+	}
+
 	labelMap := ManagedObjectLabelMap(r.syncGVK.Kind, rsRef)
 
 	// Create secret in config-management-system namespace using the
@@ -271,6 +291,11 @@ func (r *RepoSyncReconciler) upsertManagedObjects(ctx context.Context, reconcile
 		return fmt.Errorf("upserting role binding: %w", err)
 	}
 
+	// Read access to the cluster-level configuration
+	if err := r.upsertConfigReaderRoleBinding(ctx, reconcilerRef); err != nil {
+		return fmt.Errorf("upserting config reader role binding: %w", err)
+	}
+
 	if err := r.upsertHelmConfigMaps(ctx, rs, labelMap); err != nil {
 		return fmt.Errorf("upserting helm config maps: %w", err)
 	}
@@ -279,10 +304,16 @@ func (r *RepoSyncReconciler) upsertManagedObjects(ctx context.Context, reconcile
 	if err != nil {
 		return fmt.Errorf("populating container environment variables: %w", err)
 	}
-	mut := r.mutationsFor(ctx, rs, containerEnvs)
+	mut := r.mutationsFor(ctx, rs, containerEnvs, recommendation)
+
+	// Upsert the leader election Lease, RBAC and PodDisruptionBudget, if the
+	// reconciler has more than one replica.
+	if err := r.manageLeaderElection(ctx, reconcilerRef, labelMap, rs.Spec.SafeOverride().OverrideSpec); err != nil {
+		return fmt.Errorf("configuring leader election: %w", err)
+	}
 
 	// Upsert Namespace reconciler deployment.
-	deployObj, op, err := r.upsertDeployment(ctx, reconcilerRef, labelMap, mut)
+	deployObj, op, err := r.upsertDeployment(ctx, reconcilerRef, labelMap, rs.Spec.SafeOverride().OverrideSpec, mut)
 	if err != nil {
 		return fmt.Errorf("upserting reconciler deployment: %w", err)
 	}
@@ -297,35 +328,7 @@ func (r *RepoSyncReconciler) upsertManagedObjects(ctx context.Context, reconcile
 		}
 	}
 
-	gvk, err := kinds.Lookup(deployObj, r.scheme)
-	if err != nil {
-		return err
-	}
-	deployID := core.ID{
-		ObjectKey: reconcilerRef,
-		GroupKind: gvk.GroupKind(),
-	}
-
-	result, err := kstatus.Compute(deployObj)
-	if err != nil {
-		return fmt.Errorf("computing reconciler deployment status: %w", err)
-	}
-
-	r.Logger(ctx).V(3).Info("Reconciler status",
-		logFieldObjectRef, deployID.ObjectKey.String(),
-		logFieldObjectKind, deployID.Kind,
-		logFieldResourceVersion, deployObj.GetResourceVersion(),
-		"status", result.Status,
-		"message", result.Message)
-
-	if result.Status != kstatus.CurrentStatus {
-		// reconciler deployment failed or not yet available
-		err := errors.New(result.Message)
-		return NewObjectReconcileErrorWithID(err, deployID, result.Status)
-	}
-
-	// success - reconciler deployment is available
-	return nil
+	return r.checkDeploymentStatus(ctx, deployObj)
 }
 
 // setup performs the following steps:
@@ -335,13 +338,27 @@ func (r *RepoSyncReconciler) upsertManagedObjects(ctx context.Context, reconcile
 // - Update the RepoSync status
 func (r *RepoSyncReconciler) setup(ctx context.Context, reconcilerRef types.NamespacedName, rs *v1beta1.RepoSync) error {
 	_, err := r.patchSyncMetadata(ctx, rs)
+	recommendation, recommendationErr := r.recommendResources(ctx, client.ObjectKeyFromObject(rs), rs.Spec.SafeOverride().OverrideSpec, rs.Status.Status)
+	if err == nil {
+		err = recommendationErr
+	}
+	if err == nil {
+		err = r.upsertManagedObjects(ctx, reconcilerRef, rs, recommendation)
+	}
+	leaseHolder, leaseErr := r.leaseHolder(ctx, reconcilerRef, rs.Spec.SafeOverride().OverrideSpec)
 	if err == nil {
-		err = r.upsertManagedObjects(ctx, reconcilerRef, rs)
+		err = leaseErr
 	}
 	updated, updateErr := r.updateSyncStatus(ctx, rs, reconcilerRef, func(syncObj *v1beta1.RepoSync) error {
 		// Modify the sync status,
 		// but keep the upsert error separate from the status update error.
 		err = r.handleReconcileError(ctx, err, syncObj, "Setup")
+		if leaseErr == nil {
+			syncObj.Status.LeaseHolder = leaseHolder
+		}
+		if recommendationErr == nil {
+			syncObj.Status.ResourceRecommendation = recommendation
+		}
 		return nil
 	})
 	switch {
@@ -373,6 +390,7 @@ func (r *RepoSyncReconciler) teardown(ctx context.Context, reconcilerRef types.N
 		// Modify the sync status,
 		// but keep the upsert error separate from the status update error.
 		err = r.handleReconcileError(ctx, err, syncObj, "Teardown")
+		syncObj.Status.LeaseHolder = ""
 		return nil
 	})
 	switch {
@@ -406,6 +424,7 @@ func (r *RepoSyncReconciler) handleReconcileError(ctx context.Context, err error
 	// The type of error indicates whether setup/teardown is stalled or still making progress (waiting for next event).
 	var opErr *ObjectOperationError
 	var statusErr *ObjectReconcileError
+	var patchErr *DeploymentPatchError
 	if errors.As(err, &opErr) {
 		// Metadata from ManagedObjectOperationError used for log context
 		r.Logger(ctx).Error(err, fmt.Sprintf("%s failed", stage),
@@ -431,6 +450,15 @@ func (r *RepoSyncReconciler) handleReconcileError(ctx context.Context, err error
 			reposync.SetReconciling(rs, stage, fmt.Sprintf("%s stalled", stage))
 			reposync.SetStalled(rs, statusErr.ID.Kind, err)
 		}
+	} else if errors.As(err, &patchErr) {
+		r.Logger(ctx).Error(err, fmt.Sprintf("%s failed", stage),
+			logFieldObjectRef, patchErr.ConfigMap.String(),
+			logFieldObjectKind, "ConfigMap")
+		reposync.SetReconciling(rs, stage, fmt.Sprintf("%s stalled", stage))
+		reposync.SetStalled(rs, "DeploymentPatch", err)
+		// The patch is not valid without user input, so wait for the
+		// ConfigMap to change.
+		return NewNoRetryError(err)
 	} else {
 		r.Logger(ctx).Error(err, fmt.Sprintf("%s failed", stage))
 		reposync.SetReconciling(rs, stage, fmt.Sprintf("%s stalled", stage))
@@ -452,6 +480,11 @@ func (r *RepoSyncReconciler) deleteManagedObjects(ctx context.Context, reconcile
 		return fmt.Errorf("deleting reconciler deployment: %w", err)
 	}
 
+	// Remove the RepoSync from its pool, if pooled.
+	if _, err := r.reconcilePools(ctx, rsRef, nil); err != nil {
+		return fmt.Errorf("reconciling reconciler pools: %w", err)
+	}
+
 	// Note: ConfigMaps have been replaced by Deployment env vars.
 	// Using env vars auto-updates the Deployment when they change.
 	// This deletion remains to clean up after users upgrade.
@@ -472,10 +505,18 @@ func (r *RepoSyncReconciler) deleteManagedObjects(ctx context.Context, reconcile
 		return fmt.Errorf("deleting cluster role binding: %w", err)
 	}
 
+	if err := r.deleteConfigReaderRoleBinding(ctx, reconcilerRef); err != nil {
+		return fmt.Errorf("deleting config reader role binding: %w", err)
+	}
+
 	if err := r.deleteHelmConfigMapCopies(ctx, rsRef, nil); err != nil {
 		return fmt.Errorf("deleting helm config maps: %w", err)
 	}
 
+	if err := r.deleteLeaderElectionObjects(ctx, reconcilerRef); err != nil {
+		return fmt.Errorf("deleting leader election objects: %w", err)
+	}
+
 	if err := r.deleteServiceAccount(ctx, reconcilerRef); err != nil {
 		return fmt.Errorf("deleting service account: %w", err)
 	}
@@ -512,6 +553,13 @@ func (r *RepoSyncReconciler) Register(mgr controllerruntime.Manager, watchFleetM
 		Watches(withNamespace(&corev1.ServiceAccount{}, configsync.ControllerNamespace),
 			handler.EnqueueRequestsFromMapFunc(r.mapObjectToRepoSync),
 			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{})).
+		Watches(withNamespace(&corev1.ConfigMap{}, configsync.ControllerNamespace),
+			handler.EnqueueRequestsFromMapFunc(r.mapDeploymentPatchToRepoSyncs),
+			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{})).
+		// Only watch changes to the Lease holder, not its renewals.
+		Watches(withNamespace(&coordinationv1.Lease{}, configsync.ControllerNamespace),
+			handler.EnqueueRequestsFromMapFunc(r.mapObjectToRepoSync),
+			builder.WithPredicates(leaseHolderChangedPredicate{})).
 		// Watch RoleBindings in all namespaces, because RoleBindings are created
 		// in the namespace of the RepoSync. Only maps to existing RepoSyncs.
 		Watches(&rbacv1.RoleBinding{},
@@ -832,6 +880,11 @@ func repoSyncHelmValuesFileNames(rs *v1beta1.RepoSync) []string {
 func (r *RepoSyncReconciler) mapObjectToRepoSync(ctx context.Context, obj client.Object) []reconcile.Request {
 	objRef := client.ObjectKeyFromObject(obj)
 
+	// Changes to the Deployment of a pooled reconciler requeue its members.
+	if _, ok := obj.(*appsv1.Deployment); ok && strings.HasPrefix(objRef.Name, pool.NamePrefix) {
+		return r.mapPoolToRepoSyncs(ctx, objRef)
+	}
+
 	// Ignore changes from resources without the ns-reconciler prefix or configsync.gke.io:ns-reconciler
 	// because all the generated resources have the prefix.
 	nsRoleBindingName := RepoSyncBaseRoleBindingName
@@ -888,15 +941,50 @@ func (r *RepoSyncReconciler) mapObjectToRepoSync(ctx context.Context, obj client
 	return requests
 }
 
+// mapDeploymentPatchToRepoSyncs defines a mapping from the ConfigMaps with
+// reconciler Deployment patches to the RepoSyncs whose reconcilers they patch.
+func (r *RepoSyncReconciler) mapDeploymentPatchToRepoSyncs(ctx context.Context, obj client.Object) []reconcile.Request {
+	objRef := client.ObjectKeyFromObject(obj)
+	if objRef.Namespace != configsync.ControllerNamespace {
+		return nil
+	}
+
+	// The Deployment patch for all reconcilers affects all RepoSyncs.
+	if objRef.Name == ReconcilerDeploymentPatchName {
+		return r.requeueAllRSyncs(ctx, obj)
+	}
+
+	if !strings.HasPrefix(objRef.Name, core.NsReconcilerPrefix+"-") ||
+		!strings.HasSuffix(objRef.Name, "-"+deploymentPatchSuffix) {
+		return nil
+	}
+
+	syncMetaList, err := r.listSyncMetadata(ctx)
+	if err != nil {
+		r.Logger(ctx).Error(err, "Failed to list objects",
+			logFieldSyncKind, r.syncGVK.Kind)
+		return nil
+	}
+	for _, syncMeta := range syncMetaList.Items {
+		reconcilerName := core.NsReconcilerName(syncMeta.GetNamespace(), syncMeta.GetName())
+		if objRef.Name == DeploymentPatchName(reconcilerName) {
+			return r.requeueRSync(ctx, obj, client.ObjectKeyFromObject(&syncMeta))
+		}
+	}
+	return nil
+}
+
 func (r *RepoSyncReconciler) populateContainerEnvs(ctx context.Context, rs *v1beta1.RepoSync, reconcilerName string) (map[string][]corev1.EnvVar, error) {
 	result := map[string][]corev1.EnvVar{
 		reconcilermanager.HydrationController: hydrationEnvs(hydrationOptions{
-			sourceType:     rs.Spec.SourceType,
-			gitConfig:      rs.Spec.Git,
-			ociConfig:      rs.Spec.Oci,
-			scope:          declared.Scope(rs.Namespace),
-			reconcilerName: reconcilerName,
-			pollPeriod:     r.hydrationPollingPeriod.String(),
+			sourceType:      rs.Spec.SourceType,
+			gitConfig:       rs.Spec.Git,
+			ociConfig:       rs.Spec.Oci,
+			scope:           declared.Scope(rs.Namespace),
+			reconcilerName:  reconcilerName,
+			pollPeriod:      r.hydrationPollingPeriod.String(),
+			renderingEngine: v1beta1.GetRenderingEngine(rs.Spec.Rendering),
+			enableShell:     ptr.Deref(rs.Spec.SafeOverride().EnableShellInRendering, false),
 		}),
 		reconcilermanager.Reconciler: reconcilerEnvs(reconcilerOptions{
 			clusterName:       r.clusterName,
@@ -913,9 +1001,14 @@ func (r *RepoSyncReconciler) populateContainerEnvs(ctx context.Context, rs *v1be
 			reconcileTimeout:  v1beta1.GetReconcileTimeout(rs.Spec.SafeOverride().ReconcileTimeout),
 			apiServerTimeout:  v1beta1.GetAPIServerTimeout(rs.Spec.SafeOverride().APIServerTimeout),
 			requiresRendering: r.isAnnotationValueTrue(ctx, rs, metadata.RequiresRenderingAnnotationKey),
+			renderingEngine:   v1beta1.GetRenderingEngine(rs.Spec.Rendering),
 			// Namespace reconciler doesn't support NamespaceSelector at all.
 			dynamicNSSelectorEnabled: false,
 			webhookEnabled:           r.webhookEnabled,
+			remediatorWorkers:        rs.Spec.SafeOverride().RemediatorWorkers,
+			replicas:                 rs.Spec.SafeOverride().Replicas,
+			resourceUsageReporting:   rs.Spec.SafeOverride().ResourceRecommendations != nil,
+			apiClient:                r.apiClientLimits(rs),
 		}),
 	}
 
@@ -1122,7 +1215,7 @@ func (r *RepoSyncReconciler) updateSyncStatus(ctx context.Context, rs *v1beta1.R
 	return updated, nil
 }
 
-func (r *RepoSyncReconciler) mutationsFor(ctx context.Context, rs *v1beta1.RepoSync, containerEnvs map[string][]corev1.EnvVar) mutateFn {
+func (r *RepoSyncReconciler) mutationsFor(ctx context.Context, rs *v1beta1.RepoSync, containerEnvs map[string][]corev1.EnvVar, recommendation *v1beta1.ResourceRecommendation) mutateFn {
 	return func(obj client.Object) error {
 		d, ok := obj.(*appsv1.Deployment)
 		if !ok {
@@ -1196,7 +1289,8 @@ func (r *RepoSyncReconciler) mutationsFor(ctx context.Context, rs *v1beta1.RepoS
 		var containerLogLevelDefaults = ReconcilerContainerLogLevelDefaults()
 
 		overrides := rs.Spec.SafeOverride()
-		containerResources := setContainerResourceDefaults(overrides.Resources,
+		containerResources := setContainerResourceDefaults(
+			applyResourceRecommendation(overrides.Resources, recommendation, autopilot),
 			containerResourceDefaults)
 		containerLogLevels := setContainerLogLevelDefaults(overrides.LogLevels, containerLogLevelDefaults)
 
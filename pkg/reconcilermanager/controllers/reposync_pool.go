@@ -0,0 +1,568 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/kinds"
+	"kpt.dev/configsync/pkg/metadata"
+	"kpt.dev/configsync/pkg/metrics"
+	"kpt.dev/configsync/pkg/reconciler/pool"
+	"kpt.dev/configsync/pkg/reconcilermanager"
+	kstatus "sigs.k8s.io/cli-utils/pkg/kstatus/status"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// SetReconcilerPoolSize enables pooled reconcilers with at most the specified
+// number of pools. RepoSyncs which pull an unauthenticated OCI image are
+// assigned to a pool instead of getting a dedicated reconciler Deployment.
+// Zero disables pooling.
+// Must be called before the controller is registered.
+func (r *RepoSyncReconciler) SetReconcilerPoolSize(size int) {
+	r.poolSize = size
+}
+
+// isPooled returns whether the RepoSync is synced by a pooled reconciler.
+//
+// Only OCI sources without authentication or CA certificate, which don't
+// require rendering or per-Pod overrides, are pooled, because the pooled
+// reconciler pulls the source itself, anonymously, and shares the Pod with
+// other tenants. Git, Helm and authenticated OCI RepoSyncs always use a
+// dedicated reconciler.
+func (r *RepoSyncReconciler) isPooled(ctx context.Context, rs *v1beta1.RepoSync) bool {
+	if r.poolSize <= 0 || rs.Spec.SourceType != configsync.OciSource || rs.Spec.Oci == nil {
+		return false
+	}
+	if rs.Spec.Oci.Auth != configsync.AuthNone || useCACert(v1beta1.GetSecretName(rs.Spec.Oci.CACertSecretRef)) {
+		return false
+	}
+	if hasPodOverrides(rs.Spec.SafeOverride().OverrideSpec) || v1beta1.GetRenderingEngine(rs.Spec.Rendering) != "" {
+		return false
+	}
+	return !r.isAnnotationValueTrue(ctx, rs, metadata.RequiresRenderingAnnotationKey)
+}
+
+// hasPodOverrides returns whether the overrides change the reconciler Pod,
+// which can't be honored by a pooled reconciler.
+func hasPodOverrides(overrides v1beta1.OverrideSpec) bool {
+	return len(overrides.Resources) > 0 ||
+		len(overrides.LogLevels) > 0 ||
+		len(overrides.NodeSelector) > 0 ||
+		len(overrides.Tolerations) > 0 ||
+		overrides.Affinity != nil ||
+		len(overrides.TopologySpreadConstraints) > 0 ||
+		overrides.PriorityClassName != "" ||
+		len(overrides.PodLabels) > 0 ||
+		len(overrides.PodAnnotations) > 0 ||
+		overrides.ResourceRecommendations != nil ||
+		v1beta1.GetReplicas(overrides.Replicas) > 1
+}
+
+// isFinalized returns whether the RepoSync is being deleted and its reconciler
+// finalizer has completed, so it no longer needs a pipeline.
+func isFinalized(rs *v1beta1.RepoSync) bool {
+	return !rs.DeletionTimestamp.IsZero() &&
+		!controllerutil.ContainsFinalizer(rs, metadata.ReconcilerFinalizer)
+}
+
+// poolMember returns the pool member for the RepoSync.
+func (r *RepoSyncReconciler) poolMember(rs *v1beta1.RepoSync) pool.Member {
+	overrides := rs.Spec.SafeOverride()
+	statusMode := metadata.StatusMode(overrides.StatusMode)
+	if statusMode == "" {
+		statusMode = metadata.StatusEnabled
+	}
+	m := pool.Member{
+		SyncName:         rs.Name,
+		SyncNamespace:    rs.Namespace,
+		ReconcilerName:   core.NsReconcilerName(rs.Namespace, rs.Name),
+		SourceType:       rs.Spec.SourceType,
+		SourceRepo:       rs.Spec.Oci.Image,
+		SyncDir:          rs.Spec.Oci.Dir,
+		PollingPeriod:    v1beta1.GetPeriod(rs.Spec.Oci.Period, configsync.DefaultReconcilerPollingPeriod).String(),
+		StatusMode:       statusMode,
+		ReconcileTimeout: v1beta1.GetReconcileTimeout(overrides.ReconcileTimeout),
+		APIServerTimeout: v1beta1.GetAPIServerTimeout(overrides.APIServerTimeout),
+		WebhookEnabled:   r.webhookEnabled,
+	}
+	if overrides.RemediatorWorkers != nil {
+		m.MinWorkers, m.MaxWorkers = v1beta1.GetRemediatorWorkers(overrides.RemediatorWorkers)
+	}
+	limits := r.apiClientLimits(rs)
+	m.ClientQPS = limits.QPS
+	m.ClientBurst = limits.Burst
+	m.ApplierMaxInflightRequests = limits.ApplierMaxInflightRequests
+	return m
+}
+
+// poolLabelMap returns the labels of the objects of a pool.
+func poolLabelMap(poolName string) map[string]string {
+	labelMap := ManagedByLabel()
+	labelMap[metadata.SyncKindLabel] = configsync.RepoSyncKind
+	labelMap[metadata.ReconcilerPoolLabel] = poolName
+	return labelMap
+}
+
+// upsertPooledObjects creates or updates the objects needed to sync the
+// RepoSync with a pooled reconciler, and deletes its dedicated reconciler
+// Deployment and Secrets, if any.
+//
+// The RepoSync keeps its own reconciler ServiceAccount and RoleBindings, which
+// the pooled reconciler impersonates, so the RBAC boundaries are the same as
+// with a dedicated reconciler.
+func (r *RepoSyncReconciler) upsertPooledObjects(ctx context.Context, reconcilerRef types.NamespacedName, rs *v1beta1.RepoSync) error {
+	rsRef := client.ObjectKeyFromObject(rs)
+	labelMap := ManagedObjectLabelMap(r.syncGVK.Kind, rsRef)
+
+	if err := r.deleteDeploymentIfExists(ctx, reconcilerRef); err != nil {
+		return fmt.Errorf("deleting reconciler deployment: %w", err)
+	}
+
+	if err := r.deleteSecrets(ctx, reconcilerRef); err != nil {
+		return fmt.Errorf("garbage collecting secrets: %w", err)
+	}
+
+	if err := r.deleteLeaderElectionObjects(ctx, reconcilerRef); err != nil {
+		return fmt.Errorf("deleting leader election objects: %w", err)
+	}
+
+	if _, err := r.upsertServiceAccount(ctx, reconcilerRef, configsync.AuthNone, "", labelMap); err != nil {
+		return fmt.Errorf("upserting service account: %w", err)
+	}
+
+	// Namespace-scoped read/write permissions
+	if _, err := r.upsertSharedRoleBinding(ctx, reconcilerRef, rsRef); err != nil {
+		return fmt.Errorf("upserting role binding: %w", err)
+	}
+
+	// Cluster-scoped read permissions
+	if err := r.upsertSharedClusterRoleBinding(ctx, RepoSyncClusterScopeClusterRoleBindingName, RepoSyncClusterScopeClusterRoleName, reconcilerRef, rsRef); err != nil {
+		return fmt.Errorf("upserting role binding: %w", err)
+	}
+
+	// Read access to the cluster-level configuration
+	if err := r.upsertConfigReaderRoleBinding(ctx, reconcilerRef); err != nil {
+		return fmt.Errorf("upserting config reader role binding: %w", err)
+	}
+
+	var member *pool.Member
+	if !isFinalized(rs) {
+		m := r.poolMember(rs)
+		member = &m
+	}
+	deployObj, err := r.reconcilePools(ctx, rsRef, member)
+	if err != nil {
+		return fmt.Errorf("reconciling reconciler pools: %w", err)
+	}
+	if deployObj == nil {
+		// The RepoSync was excluded from the members, e.g. while finalizing.
+		return nil
+	}
+	return r.checkDeploymentStatus(ctx, deployObj)
+}
+
+// reconcilePools adds the RepoSync to the pool it is assigned to, or removes it
+// from every pool if the member is nil, and upserts or deletes the objects of
+// the pools whose members changed. Only the pool ConfigMaps are read, so the
+// cost of a reconcile does not grow with the number of RepoSyncs.
+//
+// The RepoSync is also removed from the other pools, and pools beyond the pool
+// size are deleted, so that no RepoSync is synced by two reconcilers after the
+// pool size changes.
+//
+// Returns the Deployment of the pool of the RepoSync, or nil if the RepoSync
+// is not pooled.
+func (r *RepoSyncReconciler) reconcilePools(ctx context.Context, rsRef types.NamespacedName, member *pool.Member) (*unstructured.Unstructured, error) {
+	cmList := &corev1.ConfigMapList{}
+	if err := r.client.List(ctx, cmList,
+		client.InNamespace(configsync.ControllerNamespace),
+		client.HasLabels{metadata.ReconcilerPoolLabel}); err != nil {
+		return nil, NewObjectOperationErrorForListWithNamespace(err, cmList, OperationList, configsync.ControllerNamespace)
+	}
+	rsPoolName := ""
+	if member != nil && r.poolSize > 0 {
+		rsPoolName = pool.Name(pool.Index(rsRef, r.poolSize))
+	}
+	valid := make(map[string]bool, r.poolSize)
+	for index := 0; index < r.poolSize; index++ {
+		valid[pool.Name(index)] = true
+	}
+
+	var rsDeployObj *unstructured.Unstructured
+	rsPoolFound := false
+	for i := range cmList.Items {
+		cm := &cmList.Items[i]
+		poolRef := client.ObjectKeyFromObject(cm)
+		if !valid[cm.Name] {
+			if err := r.deletePoolObjects(ctx, poolRef); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		members, err := pool.DecodeMembers(cm)
+		if err != nil {
+			return nil, err
+		}
+		members = removeMember(members, rsRef)
+		if cm.Name == rsPoolName {
+			rsPoolFound = true
+			members = append(members, *member)
+		} else if len(members) == 0 {
+			if err := r.deletePoolObjects(ctx, poolRef); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		deployObj, err := r.updatePool(ctx, poolRef, members, cm.Name == rsPoolName)
+		if err != nil {
+			return nil, err
+		}
+		if cm.Name == rsPoolName {
+			rsDeployObj = deployObj
+		}
+	}
+	if rsPoolName != "" && !rsPoolFound {
+		poolRef := types.NamespacedName{Namespace: configsync.ControllerNamespace, Name: rsPoolName}
+		return r.updatePool(ctx, poolRef, []pool.Member{*member}, true)
+	}
+	return rsDeployObj, nil
+}
+
+// updatePool updates the ConfigMap of the pool with the members, and upserts
+// the other objects of the pool if the members changed or if the caller needs
+// the pool Deployment.
+//
+// Returns the Deployment of the pool, or nil if it was not upserted.
+func (r *RepoSyncReconciler) updatePool(ctx context.Context, poolRef types.NamespacedName, members []pool.Member, needDeployment bool) (*unstructured.Unstructured, error) {
+	op, err := r.upsertPoolConfigMap(ctx, poolRef, members)
+	if err != nil {
+		return nil, err
+	}
+	if op == controllerutil.OperationResultNone && !needDeployment {
+		return nil, nil
+	}
+	return r.upsertPoolObjects(ctx, poolRef, members)
+}
+
+// mapPoolToRepoSyncs returns requests for the RepoSyncs assigned to the pool.
+func (r *RepoSyncReconciler) mapPoolToRepoSyncs(ctx context.Context, poolRef types.NamespacedName) []reconcile.Request {
+	if r.poolSize <= 0 || poolRef.Namespace != configsync.ControllerNamespace {
+		return nil
+	}
+	syncMetaList, err := r.listSyncMetadata(ctx)
+	if err != nil {
+		r.Logger(ctx).Error(err, "Failed to list objects",
+			logFieldSyncKind, r.syncGVK.Kind)
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, syncMeta := range syncMetaList.Items {
+		rsRef := client.ObjectKeyFromObject(&syncMeta)
+		if pool.Name(pool.Index(rsRef, r.poolSize)) == poolRef.Name {
+			requests = append(requests, reconcile.Request{NamespacedName: rsRef})
+		}
+	}
+	return requests
+}
+
+// removeMember returns the members without the RepoSync.
+func removeMember(members []pool.Member, rsRef types.NamespacedName) []pool.Member {
+	var result []pool.Member
+	for _, m := range members {
+		if m.SyncRef() != rsRef {
+			result = append(result, m)
+		}
+	}
+	return result
+}
+
+// upsertPoolConfigMap creates or updates the ConfigMap which lists the members
+// of the pool.
+func (r *RepoSyncReconciler) upsertPoolConfigMap(ctx context.Context, poolRef types.NamespacedName, members []pool.Member) (controllerutil.OperationResult, error) {
+	data, err := pool.EncodeMembers(members)
+	if err != nil {
+		return controllerutil.OperationResultNone, err
+	}
+	cm := &corev1.ConfigMap{}
+	cm.Name = poolRef.Name
+	cm.Namespace = poolRef.Namespace
+	r.Logger(ctx).V(3).Info("Upserting managed object",
+		logFieldObjectRef, poolRef.String(),
+		logFieldObjectKind, "ConfigMap")
+	op, err := CreateOrUpdate(ctx, r.client, cm, func() error {
+		core.AddLabels(cm, poolLabelMap(poolRef.Name))
+		cm.Data = map[string]string{pool.MembersKey: data}
+		return nil
+	})
+	if err != nil {
+		return op, err
+	}
+	if op != controllerutil.OperationResultNone {
+		r.Logger(ctx).Info("Upserting managed object successful",
+			logFieldObjectRef, poolRef.String(),
+			logFieldObjectKind, "ConfigMap",
+			logFieldOperation, op)
+	}
+	return op, nil
+}
+
+// upsertPoolObjects creates or updates the ServiceAccount, RBAC and Deployment
+// of the pooled reconciler. The pool ServiceAccount may only read the pool
+// ConfigMap and impersonate the reconciler ServiceAccounts of its members.
+func (r *RepoSyncReconciler) upsertPoolObjects(ctx context.Context, poolRef types.NamespacedName, members []pool.Member) (*unstructured.Unstructured, error) {
+	labelMap := poolLabelMap(poolRef.Name)
+
+	if _, err := r.upsertServiceAccount(ctx, poolRef, configsync.AuthNone, "", labelMap); err != nil {
+		return nil, fmt.Errorf("upserting pool service account: %w", err)
+	}
+
+	var reconcilerNames []string
+	for _, m := range members {
+		reconcilerNames = append(reconcilerNames, m.ReconcilerName)
+	}
+	sort.Strings(reconcilerNames)
+	role := &rbacv1.Role{}
+	role.Name = poolRef.Name
+	role.Namespace = poolRef.Namespace
+	op, err := CreateOrUpdate(ctx, r.client, role, func() error {
+		core.AddLabels(role, labelMap)
+		role.Rules = []rbacv1.PolicyRule{
+			{
+				APIGroups:     []string{""},
+				Resources:     []string{"serviceaccounts"},
+				Verbs:         []string{"impersonate"},
+				ResourceNames: reconcilerNames,
+			},
+			{
+				APIGroups:     []string{""},
+				Resources:     []string{"configmaps"},
+				Verbs:         []string{"get"},
+				ResourceNames: []string{poolRef.Name},
+			},
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("upserting pool role: %w", err)
+	}
+	if op != controllerutil.OperationResultNone {
+		r.Logger(ctx).Info("Upserting managed object successful",
+			logFieldObjectRef, poolRef.String(),
+			logFieldObjectKind, "Role",
+			logFieldOperation, op)
+	}
+
+	rb := &rbacv1.RoleBinding{}
+	rb.Name = poolRef.Name
+	rb.Namespace = poolRef.Namespace
+	op, err = CreateOrUpdate(ctx, r.client, rb, func() error {
+		core.AddLabels(rb, labelMap)
+		rb.RoleRef = rolereference(poolRef.Name, "Role")
+		rb.Subjects = []rbacv1.Subject{r.serviceAccountSubject(poolRef)}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("upserting pool role binding: %w", err)
+	}
+	if op != controllerutil.OperationResultNone {
+		r.Logger(ctx).Info("Upserting managed object successful",
+			logFieldObjectRef, poolRef.String(),
+			logFieldObjectKind, "RoleBinding",
+			logFieldOperation, op)
+	}
+
+	deployObj, op, err := r.upsertDeployment(ctx, poolRef, labelMap, v1beta1.OverrideSpec{}, r.poolMutations(poolRef))
+	if err != nil {
+		return nil, fmt.Errorf("upserting pool deployment: %w", err)
+	}
+	// Get the latest deployment to check the status.
+	// For other operations, upsertDeployment will have returned the latest already.
+	if op == controllerutil.OperationResultNone {
+		deployObj, err = r.deployment(ctx, poolRef)
+		if err != nil {
+			return nil, fmt.Errorf("getting pool deployment: %w", err)
+		}
+	}
+	return deployObj, nil
+}
+
+// poolMutations returns the mutations of the reconciler Deployment template
+// for a pooled reconciler. The pooled reconciler pulls the sources itself, so
+// only the reconciler and otel-agent containers are kept.
+func (r *RepoSyncReconciler) poolMutations(poolRef types.NamespacedName) mutateFn {
+	return func(obj client.Object) error {
+		d, ok := obj.(*appsv1.Deployment)
+		if !ok {
+			return fmt.Errorf("expected appsv1 Deployment, got: %T", obj)
+		}
+
+		// Add unique reconciler label
+		core.SetLabel(&d.Spec.Template, metadata.ReconcilerLabel, poolRef.Name)
+
+		templateSpec := &d.Spec.Template.Spec
+		templateSpec.ServiceAccountName = poolRef.Name
+		// The Deployment object fetched from the API server has the field defined.
+		// Update DeprecatedServiceAccount to avoid discrepancy in equality check.
+		templateSpec.DeprecatedServiceAccount = poolRef.Name
+		templateSpec.Volumes = filterVolumes(templateSpec.Volumes, configsync.AuthNone, "", "", configsync.OciSource, nil)
+
+		autopilot, err := r.isAutopilot()
+		if err != nil {
+			return err
+		}
+		var containerResourceDefaults map[string]v1beta1.ContainerResourcesSpec
+		if autopilot {
+			containerResourceDefaults = ReconcilerContainerResourceDefaultsForAutopilot()
+		} else {
+			containerResourceDefaults = ReconcilerContainerResourceDefaults()
+		}
+		containerResources := setContainerResourceDefaults(nil, containerResourceDefaults)
+		containerLogLevels := setContainerLogLevelDefaults(nil, ReconcilerContainerLogLevelDefaults())
+
+		var updatedContainers []corev1.Container
+		for _, container := range templateSpec.Containers {
+			switch container.Name {
+			case reconcilermanager.Reconciler:
+				container.Env = append(container.Env,
+					corev1.EnvVar{
+						Name:  reconcilermanager.ClusterNameKey,
+						Value: r.clusterName,
+					},
+					corev1.EnvVar{
+						Name:  reconcilermanager.ReconcilerPollingPeriod,
+						Value: r.reconcilerPollingPeriod.String(),
+					},
+					corev1.EnvVar{
+						Name:  reconcilermanager.ReconcilerPool,
+						Value: poolRef.Name,
+					})
+				// The pooled reconciler pulls the sources into the repo volume.
+				for i := range container.VolumeMounts {
+					if container.VolumeMounts[i].Name == "repo" {
+						container.VolumeMounts[i].ReadOnly = false
+					}
+				}
+			case metrics.OtelAgentName:
+			default:
+				continue
+			}
+			mutateContainerResource(&container, containerResources)
+			if err := mutateContainerLogLevel(&container, containerLogLevels); err != nil {
+				return err
+			}
+			updatedContainers = append(updatedContainers, container)
+		}
+		templateSpec.Containers = updatedContainers
+		return nil
+	}
+}
+
+// checkDeploymentStatus returns an ObjectReconcileError if the reconciler
+// Deployment is not yet available.
+func (r *RepoSyncReconciler) checkDeploymentStatus(ctx context.Context, deployObj *unstructured.Unstructured) error {
+	gvk, err := kinds.Lookup(deployObj, r.scheme)
+	if err != nil {
+		return err
+	}
+	deployID := core.ID{
+		ObjectKey: client.ObjectKeyFromObject(deployObj),
+		GroupKind: gvk.GroupKind(),
+	}
+
+	result, err := kstatus.Compute(deployObj)
+	if err != nil {
+		return fmt.Errorf("computing reconciler deployment status: %w", err)
+	}
+
+	r.Logger(ctx).V(3).Info("Reconciler status",
+		logFieldObjectRef, deployID.ObjectKey.String(),
+		logFieldObjectKind, deployID.Kind,
+		logFieldResourceVersion, deployObj.GetResourceVersion(),
+		"status", result.Status,
+		"message", result.Message)
+
+	if result.Status != kstatus.CurrentStatus {
+		// reconciler deployment failed or not yet available
+		err := errors.New(result.Message)
+		return NewObjectReconcileErrorWithID(err, deployID, result.Status)
+	}
+
+	// success - reconciler deployment is available
+	return nil
+}
+
+// deleteDeploymentIfExists deletes the Deployment, if it exists.
+// Checking the cache first avoids watching for the deletion of a Deployment
+// which was already deleted.
+func (r *RepoSyncReconciler) deleteDeploymentIfExists(ctx context.Context, ref types.NamespacedName) error {
+	d := &appsv1.Deployment{}
+	if err := r.client.Get(ctx, ref, d); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return NewObjectOperationErrorWithKey(err, d, OperationGet, ref)
+	}
+	return r.cleanup(ctx, d)
+}
+
+// deletePoolObjects deletes the objects of the pool, if its ConfigMap exists.
+// The ConfigMap is deleted last, so that a partial deletion is retried.
+func (r *RepoSyncReconciler) deletePoolObjects(ctx context.Context, poolRef types.NamespacedName) error {
+	cm := &corev1.ConfigMap{}
+	if err := r.client.Get(ctx, poolRef, cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return NewObjectOperationErrorWithKey(err, cm, OperationGet, poolRef)
+	}
+	r.Logger(ctx).Info("Deleting reconciler pool", logFieldObjectRef, poolRef.String())
+	if err := r.deleteDeployment(ctx, poolRef); err != nil {
+		return fmt.Errorf("deleting pool deployment: %w", err)
+	}
+	rb := &rbacv1.RoleBinding{}
+	rb.Name = poolRef.Name
+	rb.Namespace = poolRef.Namespace
+	if err := r.cleanup(ctx, rb); err != nil {
+		return fmt.Errorf("deleting pool role binding: %w", err)
+	}
+	role := &rbacv1.Role{}
+	role.Name = poolRef.Name
+	role.Namespace = poolRef.Namespace
+	if err := r.cleanup(ctx, role); err != nil {
+		return fmt.Errorf("deleting pool role: %w", err)
+	}
+	if err := r.deleteServiceAccount(ctx, poolRef); err != nil {
+		return fmt.Errorf("deleting pool service account: %w", err)
+	}
+	if err := r.cleanup(ctx, cm); err != nil {
+		return fmt.Errorf("deleting pool config map: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,249 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/kinds"
+	"kpt.dev/configsync/pkg/metadata"
+	"kpt.dev/configsync/pkg/reconciler/pool"
+	"kpt.dev/configsync/pkg/reconcilermanager"
+	syncerFake "kpt.dev/configsync/pkg/syncer/syncertest/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func noRendering(rs *v1beta1.RepoSync) {
+	core.RemoveAnnotations(rs, metadata.RequiresRenderingAnnotationKey)
+}
+
+func getDeployment(t *testing.T, fakeDynamicClient *syncerFake.DynamicClient, ref types.NamespacedName) (*appsv1.Deployment, error) {
+	t.Helper()
+	uObj, err := fakeDynamicClient.Resource(kinds.DeploymentResource()).
+		Namespace(ref.Namespace).
+		Get(context.Background(), ref.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	d := &appsv1.Deployment{}
+	require.NoError(t, runtime.DefaultUnstructuredConverter.FromUnstructured(uObj.Object, d))
+	return d, nil
+}
+
+func TestRepoSyncPooledReconciler(t *testing.T) {
+	// Mock out parseDeployment for testing.
+	parseDeployment = parsedDeployment
+
+	ctx := context.Background()
+	rs := repoSyncWithOCI(reposyncNs, reposyncName, reposyncOCIAuthType(configsync.AuthNone), noRendering)
+	gitRS := repoSyncWithGit("other", reposyncName, reposyncSecretType(configsync.AuthNone), noRendering)
+	reqNamespacedName := namespacedName(rs.Name, rs.Namespace)
+	fakeClient, fakeDynamicClient, testReconciler := setupNSReconciler(t, rs, gitRS)
+	testReconciler.SetReconcilerPoolSize(1)
+
+	poolRef := types.NamespacedName{Namespace: configsync.ControllerNamespace, Name: pool.Name(0)}
+	reconcilerRef := types.NamespacedName{Namespace: configsync.ControllerNamespace, Name: nsReconcilerName}
+
+	_, err := testReconciler.Reconcile(ctx, reqNamespacedName)
+	require.NoError(t, err)
+
+	// The pool lists the OCI RepoSync, but not the git RepoSync.
+	cm := &corev1.ConfigMap{}
+	require.NoError(t, fakeClient.Get(ctx, poolRef, cm))
+	members, err := pool.DecodeMembers(cm)
+	require.NoError(t, err)
+	require.Len(t, members, 1)
+	assert.Equal(t, pool.Member{
+		SyncName:         reposyncName,
+		SyncNamespace:    reposyncNs,
+		ReconcilerName:   nsReconcilerName,
+		SourceType:       configsync.OciSource,
+		SourceRepo:       ociImage,
+		SyncDir:          reposyncDir,
+		PollingPeriod:    configsync.DefaultReconcilerPollingPeriod.String(),
+		StatusMode:       metadata.StatusEnabled,
+		ReconcileTimeout: configsync.DefaultReconcileTimeout.String(),
+		APIServerTimeout: v1beta1.GetAPIServerTimeout(nil),
+	}, members[0])
+
+	// The pool may only impersonate the reconcilers of its members.
+	role := &rbacv1.Role{}
+	require.NoError(t, fakeClient.Get(ctx, poolRef, role))
+	require.Len(t, role.Rules, 2)
+	assert.Equal(t, []string{"impersonate"}, role.Rules[0].Verbs)
+	assert.Equal(t, []string{nsReconcilerName}, role.Rules[0].ResourceNames)
+	rb := &rbacv1.RoleBinding{}
+	require.NoError(t, fakeClient.Get(ctx, poolRef, rb))
+	assert.Equal(t, []rbacv1.Subject{testReconciler.serviceAccountSubject(poolRef)}, rb.Subjects)
+
+	// The RepoSync keeps its own ServiceAccount, to be impersonated.
+	require.NoError(t, fakeClient.Get(ctx, reconcilerRef, &corev1.ServiceAccount{}))
+	require.NoError(t, fakeClient.Get(ctx, poolRef, &corev1.ServiceAccount{}))
+
+	// The pool Deployment only runs the reconciler, and there's no dedicated
+	// reconciler Deployment.
+	poolDeployment, err := getDeployment(t, fakeDynamicClient, poolRef)
+	require.NoError(t, err)
+	assert.Equal(t, poolRef.Name, poolDeployment.Spec.Template.Spec.ServiceAccountName)
+	require.Len(t, poolDeployment.Spec.Template.Spec.Containers, 1)
+	reconcilerContainer := poolDeployment.Spec.Template.Spec.Containers[0]
+	assert.Equal(t, reconcilermanager.Reconciler, reconcilerContainer.Name)
+	assert.Contains(t, reconcilerContainer.Env, corev1.EnvVar{Name: reconcilermanager.ReconcilerPool, Value: poolRef.Name})
+	_, err = getDeployment(t, fakeDynamicClient, reconcilerRef)
+	assert.True(t, apierrors.IsNotFound(err), "expected dedicated Deployment to be NotFound, got: %v", err)
+
+	// Once the RepoSync requires rendering, it moves to a dedicated reconciler,
+	// and the empty pool is deleted.
+	existing := rs.DeepCopy()
+	core.SetAnnotation(rs, metadata.RequiresRenderingAnnotationKey, "true")
+	require.NoError(t, fakeClient.Patch(ctx, rs, client.MergeFrom(existing), client.FieldOwner(reconcilermanager.FieldManager)))
+	_, err = testReconciler.Reconcile(ctx, reqNamespacedName)
+	require.NoError(t, err)
+
+	_, err = getDeployment(t, fakeDynamicClient, reconcilerRef)
+	require.NoError(t, err)
+	_, err = getDeployment(t, fakeDynamicClient, poolRef)
+	assert.True(t, apierrors.IsNotFound(err), "expected pool Deployment to be NotFound, got: %v", err)
+	err = fakeClient.Get(ctx, poolRef, &corev1.ConfigMap{})
+	assert.True(t, apierrors.IsNotFound(err), "expected pool ConfigMap to be NotFound, got: %v", err)
+	err = fakeClient.Get(ctx, poolRef, &rbacv1.Role{})
+	assert.True(t, apierrors.IsNotFound(err), "expected pool Role to be NotFound, got: %v", err)
+}
+
+func TestRepoSyncIsPooled(t *testing.T) {
+	testCases := []struct {
+		name     string
+		poolSize int
+		rs       *v1beta1.RepoSync
+		want     bool
+	}{
+		{
+			name:     "pooling disabled",
+			poolSize: 0,
+			rs:       repoSyncWithOCI(reposyncNs, reposyncName, reposyncOCIAuthType(configsync.AuthNone), noRendering),
+			want:     false,
+		},
+		{
+			name:     "public OCI image",
+			poolSize: 2,
+			rs:       repoSyncWithOCI(reposyncNs, reposyncName, reposyncOCIAuthType(configsync.AuthNone), noRendering),
+			want:     true,
+		},
+		{
+			name:     "OCI image with authentication",
+			poolSize: 2,
+			rs:       repoSyncWithOCI(reposyncNs, reposyncName, reposyncOCIAuthType(configsync.AuthGCENode), noRendering),
+			want:     false,
+		},
+		{
+			name:     "OCI image which requires rendering",
+			poolSize: 2,
+			rs:       repoSyncWithOCI(reposyncNs, reposyncName, reposyncOCIAuthType(configsync.AuthNone)),
+			want:     false,
+		},
+		{
+			name:     "OCI image with Pod overrides",
+			poolSize: 2,
+			rs: repoSyncWithOCI(reposyncNs, reposyncName, reposyncOCIAuthType(configsync.AuthNone), noRendering,
+				func(rs *v1beta1.RepoSync) {
+					rs.Spec.Override = &v1beta1.RepoSyncOverrideSpec{
+						OverrideSpec: v1beta1.OverrideSpec{
+							NodeSelector: map[string]string{"pool": "config-sync"},
+						},
+					}
+				}),
+			want: false,
+		},
+		{
+			name:     "git repository",
+			poolSize: 2,
+			rs:       repoSyncWithGit(reposyncNs, reposyncName, reposyncSecretType(configsync.AuthNone), noRendering),
+			want:     false,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, _, testReconciler := setupNSReconciler(t)
+			testReconciler.SetReconcilerPoolSize(tc.poolSize)
+			assert.Equal(t, tc.want, testReconciler.isPooled(context.Background(), tc.rs))
+		})
+	}
+}
+
+func TestRepoSyncPoolMembers(t *testing.T) {
+	// Mock out parseDeployment for testing.
+	parseDeployment = parsedDeployment
+
+	ctx := context.Background()
+	rs1 := repoSyncWithOCI("ns-1", reposyncName, reposyncOCIAuthType(configsync.AuthNone), noRendering)
+	rs2 := repoSyncWithOCI("ns-2", reposyncName, reposyncOCIAuthType(configsync.AuthNone), noRendering)
+	fakeClient, _, testReconciler := setupNSReconciler(t, rs1, rs2)
+	testReconciler.SetReconcilerPoolSize(1)
+
+	poolMembers := func(poolName string) []types.NamespacedName {
+		t.Helper()
+		cm := &corev1.ConfigMap{}
+		err := fakeClient.Get(ctx, types.NamespacedName{Namespace: configsync.ControllerNamespace, Name: poolName}, cm)
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		require.NoError(t, err)
+		members, err := pool.DecodeMembers(cm)
+		require.NoError(t, err)
+		var refs []types.NamespacedName
+		for _, m := range members {
+			refs = append(refs, m.SyncRef())
+		}
+		return refs
+	}
+
+	// Each reconcile only adds the reconciled RepoSync to its pool.
+	_, err := testReconciler.Reconcile(ctx, namespacedName(rs1.Name, rs1.Namespace))
+	require.NoError(t, err)
+	assert.Equal(t, []types.NamespacedName{client.ObjectKeyFromObject(rs1)}, poolMembers(pool.Name(0)))
+	_, err = testReconciler.Reconcile(ctx, namespacedName(rs2.Name, rs2.Namespace))
+	require.NoError(t, err)
+	assert.Equal(t, []types.NamespacedName{client.ObjectKeyFromObject(rs1), client.ObjectKeyFromObject(rs2)}, poolMembers(pool.Name(0)))
+
+	// After the pool size changes, the reconciled RepoSync moves to its new
+	// pool, and is removed from its previous pool.
+	testReconciler.SetReconcilerPoolSize(4)
+	require.Equal(t, 1, pool.Index(client.ObjectKeyFromObject(rs2), 4))
+	_, err = testReconciler.Reconcile(ctx, namespacedName(rs2.Name, rs2.Namespace))
+	require.NoError(t, err)
+	assert.Equal(t, []types.NamespacedName{client.ObjectKeyFromObject(rs2)}, poolMembers(pool.Name(1)))
+	assert.Equal(t, []types.NamespacedName{client.ObjectKeyFromObject(rs1)}, poolMembers(pool.Name(0)))
+
+	// Pools beyond the pool size are deleted.
+	testReconciler.SetReconcilerPoolSize(1)
+	_, err = testReconciler.Reconcile(ctx, namespacedName(rs1.Name, rs1.Namespace))
+	require.NoError(t, err)
+	for i := 1; i < 4; i++ {
+		assert.Empty(t, poolMembers(pool.Name(i)))
+	}
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	admissionv1 "k8s.io/api/admissionregistration/v1"
 	appsv1 "k8s.io/api/apps/v1"
+	coordinationv1 "k8s.io/api/coordination/v1"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
@@ -36,6 +37,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
 	"kpt.dev/configsync/pkg/api/configsync"
 	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
 	hubv1 "kpt.dev/configsync/pkg/api/hub/v1"
@@ -57,6 +59,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -182,7 +185,7 @@ func (r *RootSyncReconciler) Reconcile(ctx context.Context, req controllerruntim
 	return controllerruntime.Result{}, nil
 }
 
-func (r *RootSyncReconciler) upsertManagedObjects(ctx context.Context, reconcilerRef types.NamespacedName, rs *v1beta1.RootSync) error {
+func (r *RootSyncReconciler) upsertManagedObjects(ctx context.Context, reconcilerRef types.NamespacedName, rs *v1beta1.RootSync, recommendation *v1beta1.ResourceRecommendation, roleRefs []v1beta1.RootSyncRoleRef) error {
 	r.Logger(ctx).V(3).Info("Reconciling managed objects")
 
 	// Note: RootSync Secret is managed by the user, not the ReconcilerManager.
@@ -214,7 +217,7 @@ func (r *RootSyncReconciler) upsertManagedObjects(ctx context.Context, reconcile
 	}
 
 	// Reconcile reconciler RBAC bindings.
-	if err := r.manageRBACBindings(ctx, reconcilerRef, rsRef, rs.Spec.SafeOverride().RoleRefs); err != nil {
+	if err := r.manageRBACBindings(ctx, reconcilerRef, rsRef, roleRefs); err != nil {
 		return fmt.Errorf("configuring RBAC bindings: %w", err)
 	}
 
@@ -222,10 +225,16 @@ func (r *RootSyncReconciler) upsertManagedObjects(ctx context.Context, reconcile
 	if err != nil {
 		return fmt.Errorf("populating container environment variables: %w", err)
 	}
-	mut := r.mutationsFor(ctx, rs, containerEnvs)
+	mut := r.mutationsFor(ctx, rs, containerEnvs, recommendation)
+
+	// Upsert the leader election Lease, RBAC and PodDisruptionBudget, if the
+	// reconciler has more than one replica.
+	if err := r.manageLeaderElection(ctx, reconcilerRef, labelMap, rs.Spec.SafeOverride().OverrideSpec); err != nil {
+		return fmt.Errorf("configuring leader election: %w", err)
+	}
 
 	// Upsert Root reconciler deployment.
-	deployObj, op, err := r.upsertDeployment(ctx, reconcilerRef, labelMap, mut)
+	deployObj, op, err := r.upsertDeployment(ctx, reconcilerRef, labelMap, rs.Spec.SafeOverride().OverrideSpec, mut)
 	if err != nil {
 		return fmt.Errorf("upserting reconciler deployment: %w", err)
 	}
@@ -277,13 +286,32 @@ func (r *RootSyncReconciler) upsertManagedObjects(ctx context.Context, reconcile
 // - Update the RootSync status
 func (r *RootSyncReconciler) setup(ctx context.Context, reconcilerRef types.NamespacedName, rs *v1beta1.RootSync) error {
 	_, err := r.patchSyncMetadata(ctx, rs)
+	recommendation, recommendationErr := r.recommendResources(ctx, client.ObjectKeyFromObject(rs), rs.Spec.SafeOverride().OverrideSpec, rs.Status.Status)
+	if err == nil {
+		err = recommendationErr
+	}
+	roleRefs, rbacStatus, rbacErr := r.reconcilerRoleRefs(ctx, reconcilerRef, rs)
+	if err == nil {
+		err = rbacErr
+	}
 	if err == nil {
-		err = r.upsertManagedObjects(ctx, reconcilerRef, rs)
+		err = r.upsertManagedObjects(ctx, reconcilerRef, rs, recommendation, roleRefs)
+	}
+	leaseHolder, leaseErr := r.leaseHolder(ctx, reconcilerRef, rs.Spec.SafeOverride().OverrideSpec)
+	if err == nil {
+		err = leaseErr
 	}
 	updated, updateErr := r.updateSyncStatus(ctx, rs, reconcilerRef, func(syncObj *v1beta1.RootSync) error {
 		// Modify the sync status,
 		// but keep the upsert error separate from the status update error.
 		err = r.handleReconcileError(ctx, err, syncObj, "Setup")
+		if leaseErr == nil {
+			syncObj.Status.LeaseHolder = leaseHolder
+		}
+		if recommendationErr == nil {
+			syncObj.Status.ResourceRecommendation = recommendation
+		}
+		syncObj.Status.RBAC = rbacStatus
 		return nil
 	})
 	switch {
@@ -315,6 +343,7 @@ func (r *RootSyncReconciler) teardown(ctx context.Context, reconcilerRef types.N
 		// Modify the sync status,
 		// but keep the upsert error separate from the status update error.
 		err = r.handleReconcileError(ctx, err, syncObj, "Teardown")
+		syncObj.Status.LeaseHolder = ""
 		return nil
 	})
 	switch {
@@ -348,6 +377,7 @@ func (r *RootSyncReconciler) handleReconcileError(ctx context.Context, err error
 	// The type of error indicates whether setup/teardown is stalled or still making progress (waiting for next event).
 	var opErr *ObjectOperationError
 	var statusErr *ObjectReconcileError
+	var patchErr *DeploymentPatchError
 	if errors.As(err, &opErr) {
 		// Metadata from ManagedObjectOperationError used for log context
 		r.Logger(ctx).Error(err, fmt.Sprintf("%s failed", stage),
@@ -373,6 +403,15 @@ func (r *RootSyncReconciler) handleReconcileError(ctx context.Context, err error
 			rootsync.SetReconciling(rs, stage, fmt.Sprintf("%s stalled", stage))
 			rootsync.SetStalled(rs, statusErr.ID.Kind, err)
 		}
+	} else if errors.As(err, &patchErr) {
+		r.Logger(ctx).Error(err, fmt.Sprintf("%s failed", stage),
+			logFieldObjectRef, patchErr.ConfigMap.String(),
+			logFieldObjectKind, "ConfigMap")
+		rootsync.SetReconciling(rs, stage, fmt.Sprintf("%s stalled", stage))
+		rootsync.SetStalled(rs, "DeploymentPatch", err)
+		// The patch is not valid without user input, so wait for the
+		// ConfigMap to change.
+		return NewNoRetryError(err)
 	} else {
 		r.Logger(ctx).Error(err, fmt.Sprintf("%s failed", stage))
 		rootsync.SetReconciling(rs, stage, fmt.Sprintf("%s stalled", stage))
@@ -409,6 +448,14 @@ func (r *RootSyncReconciler) deleteManagedObjects(ctx context.Context, reconcile
 		return fmt.Errorf("deleting RBAC bindings: %w", err)
 	}
 
+	if err := r.deleteMinimalRoles(ctx, reconcilerRef, rsRef); err != nil {
+		return fmt.Errorf("deleting minimal RBAC: %w", err)
+	}
+
+	if err := r.deleteLeaderElectionObjects(ctx, reconcilerRef); err != nil {
+		return fmt.Errorf("deleting leader election objects: %w", err)
+	}
+
 	if err := r.deleteServiceAccount(ctx, reconcilerRef); err != nil {
 		return fmt.Errorf("deleting service account: %w", err)
 	}
@@ -448,6 +495,10 @@ func (r *RootSyncReconciler) Register(mgr controllerruntime.Manager, watchFleetM
 		Watches(withNamespace(&corev1.ServiceAccount{}, configsync.ControllerNamespace),
 			handler.EnqueueRequestsFromMapFunc(r.mapObjectToRootSync),
 			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{})).
+		// Only watch changes to the Lease holder, not its renewals.
+		Watches(withNamespace(&coordinationv1.Lease{}, configsync.ControllerNamespace),
+			handler.EnqueueRequestsFromMapFunc(r.mapObjectToRootSync),
+			builder.WithPredicates(leaseHolderChangedPredicate{})).
 		Watches(&rbacv1.ClusterRoleBinding{},
 			handler.EnqueueRequestsFromMapFunc(r.mapObjectToRootSync),
 			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{})).
@@ -457,7 +508,16 @@ func (r *RootSyncReconciler) Register(mgr controllerruntime.Manager, watchFleetM
 			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{})).
 		Watches(&admissionv1.ValidatingWebhookConfiguration{},
 			handler.EnqueueRequestsFromMapFunc(r.mapAdmissionWebhookToRootSync),
-			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}))
+			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{})).
+		// Only watch new Namespaces, to create the minimal Roles deferred
+		// until the namespace exists.
+		Watches(&corev1.Namespace{},
+			handler.EnqueueRequestsFromMapFunc(r.mapNamespaceToRootSyncs),
+			builder.WithPredicates(predicate.Funcs{
+				UpdateFunc:  func(event.UpdateEvent) bool { return false },
+				DeleteFunc:  func(event.DeleteEvent) bool { return false },
+				GenericFunc: func(event.GenericEvent) bool { return false },
+			}))
 
 	if watchFleetMembership {
 		// Custom Watch for membership to trigger reconciliation.
@@ -515,6 +575,11 @@ func (r *RootSyncReconciler) mapConfigMapsToRootSyncs(ctx context.Context, obj c
 		return nil
 	}
 
+	// The Deployment patch for all reconcilers affects all RootSyncs.
+	if objRef.Name == ReconcilerDeploymentPatchName {
+		return r.requeueAllRSyncs(ctx, obj)
+	}
+
 	// Look up RootSyncs and see if any of them reference this ConfigMap.
 	rootSyncList := &v1beta1.RootSyncList{}
 	if err := r.client.List(ctx, rootSyncList, client.InNamespace(objRef.Namespace)); err != nil {
@@ -527,7 +592,8 @@ func (r *RootSyncReconciler) mapConfigMapsToRootSyncs(ctx context.Context, obj c
 	var attachedRSNames []string
 	for _, rs := range rootSyncList.Items {
 		// Only enqueue a request for the RSync if it references the ConfigMap that triggered the event
-		if slices.Contains(rootSyncHelmValuesFileNames(&rs), objRef.Name) {
+		if slices.Contains(rootSyncHelmValuesFileNames(&rs), objRef.Name) ||
+			objRef.Name == DeploymentPatchName(core.RootReconcilerName(rs.Name)) {
 			requests = append(requests, reconcile.Request{
 				NamespacedName: client.ObjectKeyFromObject(&rs),
 			})
@@ -751,12 +817,14 @@ func rootSyncHelmSecretName(rs *v1beta1.RootSync) string {
 func (r *RootSyncReconciler) populateContainerEnvs(ctx context.Context, rs *v1beta1.RootSync, reconcilerName string) (map[string][]corev1.EnvVar, error) {
 	result := map[string][]corev1.EnvVar{
 		reconcilermanager.HydrationController: hydrationEnvs(hydrationOptions{
-			sourceType:     rs.Spec.SourceType,
-			gitConfig:      rs.Spec.Git,
-			ociConfig:      rs.Spec.Oci,
-			scope:          declared.RootScope,
-			reconcilerName: reconcilerName,
-			pollPeriod:     r.hydrationPollingPeriod.String(),
+			sourceType:      rs.Spec.SourceType,
+			gitConfig:       rs.Spec.Git,
+			ociConfig:       rs.Spec.Oci,
+			scope:           declared.RootScope,
+			reconcilerName:  reconcilerName,
+			pollPeriod:      r.hydrationPollingPeriod.String(),
+			renderingEngine: v1beta1.GetRenderingEngine(rs.Spec.Rendering),
+			enableShell:     ptr.Deref(rs.Spec.SafeOverride().EnableShellInRendering, false),
 		}),
 		reconcilermanager.Reconciler: append(
 			reconcilerEnvs(reconcilerOptions{
@@ -774,8 +842,14 @@ func (r *RootSyncReconciler) populateContainerEnvs(ctx context.Context, rs *v1be
 				reconcileTimeout:         v1beta1.GetReconcileTimeout(rs.Spec.SafeOverride().ReconcileTimeout),
 				apiServerTimeout:         v1beta1.GetAPIServerTimeout(rs.Spec.SafeOverride().APIServerTimeout),
 				requiresRendering:        r.isAnnotationValueTrue(ctx, rs, metadata.RequiresRenderingAnnotationKey),
+				renderingEngine:          v1beta1.GetRenderingEngine(rs.Spec.Rendering),
 				dynamicNSSelectorEnabled: r.isAnnotationValueTrue(ctx, rs, metadata.DynamicNSSelectorEnabledAnnotationKey),
 				webhookEnabled:           r.webhookEnabled,
+				remediatorWorkers:        rs.Spec.SafeOverride().RemediatorWorkers,
+				replicas:                 rs.Spec.SafeOverride().Replicas,
+				resourceUsageReporting:   rs.Spec.SafeOverride().ResourceRecommendations != nil,
+				apiClient:                apiClientLimits(rs.Spec.SafeOverride().APIClient),
+				minimalRBAC:              rs.Spec.SafeOverride().RBACMode == configsync.RBACModeMinimal,
 			}),
 			sourceFormatEnv(rs.Spec.SourceFormat),
 			namespaceStrategyEnv(rs.Spec.SafeOverride().NamespaceStrategy),
@@ -945,6 +1019,9 @@ func (r *RootSyncReconciler) cleanRBACBindings(ctx context.Context, reconcilerRe
 	if err := r.deleteSharedClusterRoleBinding(ctx, RootSyncBaseClusterRoleBindingName, reconcilerRef); err != nil && !apierrors.IsNotFound(err) {
 		return fmt.Errorf("deleting base binding: %w", err)
 	}
+	if err := r.deleteConfigReaderRoleBinding(ctx, reconcilerRef); err != nil {
+		return fmt.Errorf("deleting config reader binding: %w", err)
+	}
 	return nil
 }
 
@@ -955,6 +1032,10 @@ func (r *RootSyncReconciler) manageRBACBindings(ctx context.Context, reconcilerR
 	if err != nil {
 		return err
 	}
+	// Read access to the cluster-level configuration, regardless of roleRefs
+	if err := r.upsertConfigReaderRoleBinding(ctx, reconcilerRef); err != nil {
+		return err
+	}
 	if len(roleRefs) == 0 {
 		// Backwards compatible behavior to default to cluster-admin
 		if err := r.upsertSharedClusterRoleBinding(ctx, RootSyncLegacyClusterRoleBindingName, "cluster-admin", reconcilerRef, rsRef); err != nil {
@@ -1091,7 +1172,7 @@ func (r *RootSyncReconciler) updateSyncStatus(ctx context.Context, rs *v1beta1.R
 	return updated, nil
 }
 
-func (r *RootSyncReconciler) mutationsFor(ctx context.Context, rs *v1beta1.RootSync, containerEnvs map[string][]corev1.EnvVar) mutateFn {
+func (r *RootSyncReconciler) mutationsFor(ctx context.Context, rs *v1beta1.RootSync, containerEnvs map[string][]corev1.EnvVar, recommendation *v1beta1.ResourceRecommendation) mutateFn {
 	return func(obj client.Object) error {
 		d, ok := obj.(*appsv1.Deployment)
 		if !ok {
@@ -1165,7 +1246,8 @@ func (r *RootSyncReconciler) mutationsFor(ctx context.Context, rs *v1beta1.RootS
 		var containerLogLevelDefaults = ReconcilerContainerLogLevelDefaults()
 
 		overrides := rs.Spec.SafeOverride()
-		containerResources := setContainerResourceDefaults(overrides.Resources,
+		containerResources := setContainerResourceDefaults(
+			applyResourceRecommendation(overrides.Resources, recommendation, autopilot),
 			containerResourceDefaults)
 		containerLogLevels := setContainerLogLevelDefaults(overrides.LogLevels, containerLogLevelDefaults)
 
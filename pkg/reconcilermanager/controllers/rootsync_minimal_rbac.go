@@ -0,0 +1,449 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
+	"kpt.dev/configsync/pkg/kinds"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// minimalRBACResourceName is the suffix of the name of the generated
+// ClusterRole and Roles of a RootSync reconciler with minimal RBAC.
+const minimalRBACResourceName = "minimal"
+
+// readVerbs are the verbs granted cluster-wide for the declared namespaced
+// resources, so the remediator can watch them in all namespaces.
+var readVerbs = []string{"get", "list", "watch"}
+
+// declaredResource is a declared kind, resolved to its resource.
+type declaredResource struct {
+	kind       v1beta1.DeclaredKind
+	resource   schema.GroupResource
+	namespaced bool
+}
+
+// minimalPermissions are the permissions generated for the declared
+// resources.
+type minimalPermissions struct {
+	// cluster is the set of cluster-scoped resources, granted all verbs.
+	cluster map[schema.GroupResource]bool
+	// namespaced maps the namespaced resources, granted read verbs in all
+	// namespaces, to the namespaces they're granted all verbs in.
+	namespaced map[schema.GroupResource]map[string]bool
+}
+
+func newMinimalPermissions() minimalPermissions {
+	return minimalPermissions{
+		cluster:    make(map[schema.GroupResource]bool),
+		namespaced: make(map[schema.GroupResource]map[string]bool),
+	}
+}
+
+func (p minimalPermissions) addNamespaced(gr schema.GroupResource, namespaces ...string) {
+	if p.namespaced[gr] == nil {
+		p.namespaced[gr] = make(map[string]bool)
+	}
+	for _, ns := range namespaces {
+		p.namespaced[gr][ns] = true
+	}
+}
+
+// add grants the permissions required by the declared resources.
+func (p minimalPermissions) add(resources []declaredResource) {
+	for _, r := range resources {
+		if r.namespaced {
+			p.addNamespaced(r.resource, r.kind.Namespaces...)
+		} else {
+			p.cluster[r.resource] = true
+		}
+	}
+}
+
+// merge grants the other permissions.
+func (p minimalPermissions) merge(other minimalPermissions) {
+	for gr := range other.cluster {
+		p.cluster[gr] = true
+	}
+	for gr, namespaces := range other.namespaced {
+		p.addNamespaced(gr)
+		for ns := range namespaces {
+			p.namespaced[gr][ns] = true
+		}
+	}
+}
+
+// missing returns the declared kinds and namespaces which these permissions
+// don't grant access to.
+func (p minimalPermissions) missing(resources []declaredResource) []v1beta1.DeclaredKind {
+	var missing []v1beta1.DeclaredKind
+	for _, r := range resources {
+		if !r.namespaced {
+			if !p.cluster[r.resource] {
+				missing = append(missing, *r.kind.DeepCopy())
+			}
+			continue
+		}
+		granted, found := p.namespaced[r.resource]
+		var missingNamespaces []string
+		for _, ns := range r.kind.Namespaces {
+			if !granted[ns] {
+				missingNamespaces = append(missingNamespaces, ns)
+			}
+		}
+		if !found || len(missingNamespaces) > 0 {
+			missing = append(missing, v1beta1.DeclaredKind{
+				Group:      r.kind.Group,
+				Kind:       r.kind.Kind,
+				Namespaces: missingNamespaces,
+			})
+		}
+	}
+	return missing
+}
+
+// namespaces returns the sorted namespaces which need a Role.
+func (p minimalPermissions) namespaces() []string {
+	set := make(map[string]bool)
+	for _, namespaces := range p.namespaced {
+		for ns := range namespaces {
+			set[ns] = true
+		}
+	}
+	return sortedKeys(set)
+}
+
+// clusterRules returns the rules of the generated ClusterRole.
+func (p minimalPermissions) clusterRules() []rbacv1.PolicyRule {
+	readable := make(map[schema.GroupResource]bool, len(p.namespaced))
+	for gr := range p.namespaced {
+		readable[gr] = true
+	}
+	rules := policyRules(p.cluster, []string{rbacv1.VerbAll})
+	return append(rules, policyRules(readable, readVerbs)...)
+}
+
+// namespaceRules returns the rules of the generated Role in the namespace.
+func (p minimalPermissions) namespaceRules(namespace string) []rbacv1.PolicyRule {
+	writable := make(map[schema.GroupResource]bool)
+	for gr, namespaces := range p.namespaced {
+		if namespaces[namespace] {
+			writable[gr] = true
+		}
+	}
+	return policyRules(writable, []string{rbacv1.VerbAll})
+}
+
+// policyRules returns one rule per API group, granting the verbs on the
+// resources in the group, sorted by group.
+func policyRules(resources map[schema.GroupResource]bool, verbs []string) []rbacv1.PolicyRule {
+	groups := make(map[string]map[string]bool)
+	for gr := range resources {
+		if groups[gr.Group] == nil {
+			groups[gr.Group] = make(map[string]bool)
+		}
+		groups[gr.Group][gr.Resource] = true
+	}
+	var rules []rbacv1.PolicyRule
+	for _, group := range sortedKeys(groups) {
+		rules = append(rules, rbacv1.PolicyRule{
+			APIGroups: []string{group},
+			Resources: sortedKeys(groups[group]),
+			Verbs:     verbs,
+		})
+	}
+	return rules
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// permissionsFromRoles returns the permissions granted by the generated
+// ClusterRole and Roles.
+func permissionsFromRoles(clusterRole *rbacv1.ClusterRole, roles []rbacv1.Role) minimalPermissions {
+	p := newMinimalPermissions()
+	if clusterRole != nil {
+		for _, rule := range clusterRole.Rules {
+			all := len(rule.Verbs) == 1 && rule.Verbs[0] == rbacv1.VerbAll
+			for _, group := range rule.APIGroups {
+				for _, resource := range rule.Resources {
+					gr := schema.GroupResource{Group: group, Resource: resource}
+					if all {
+						p.cluster[gr] = true
+					} else {
+						p.addNamespaced(gr)
+					}
+				}
+			}
+		}
+	}
+	for _, role := range roles {
+		for _, rule := range role.Rules {
+			for _, group := range rule.APIGroups {
+				for _, resource := range rule.Resources {
+					p.addNamespaced(schema.GroupResource{Group: group, Resource: resource}, role.Namespace)
+				}
+			}
+		}
+	}
+	return p
+}
+
+// resolveDeclaredKinds resolves the declared kinds to their resources. Kinds
+// which aren't served yet, for example custom resources declared with their
+// CustomResourceDefinition, are resolved by guessing the resource from the
+// kind, and are namespaced if declared in a namespace.
+func (r *RootSyncReconciler) resolveDeclaredKinds(declaredKinds []v1beta1.DeclaredKind) []declaredResource {
+	resources := make([]declaredResource, 0, len(declaredKinds))
+	for _, kind := range declaredKinds {
+		gk := schema.GroupKind{Group: kind.Group, Kind: kind.Kind}
+		resource := declaredResource{kind: kind}
+		mapping, err := r.client.RESTMapper().RESTMapping(gk)
+		if err == nil {
+			resource.resource = mapping.Resource.GroupResource()
+			resource.namespaced = mapping.Scope.Name() == meta.RESTScopeNameNamespace
+		} else {
+			plural, _ := meta.UnsafeGuessKindToResource(gk.WithVersion(""))
+			resource.resource = plural.GroupResource()
+			resource.namespaced = len(kind.Namespaces) > 0
+		}
+		resources = append(resources, resource)
+	}
+	return resources
+}
+
+// reconcilerRoleRefs returns the references of the roles to bind to the
+// reconciler, along with the RBAC status. With minimal RBAC, the generated
+// roles are bound in addition to spec.override.roleRefs. Otherwise, the
+// generated roles are deleted, if any.
+func (r *RootSyncReconciler) reconcilerRoleRefs(ctx context.Context, reconcilerRef types.NamespacedName, rs *v1beta1.RootSync) ([]v1beta1.RootSyncRoleRef, *v1beta1.RBACStatus, error) {
+	if rs.Spec.SafeOverride().RBACMode == configsync.RBACModeMinimal {
+		return r.manageMinimalRBAC(ctx, reconcilerRef, rs)
+	}
+	if err := r.deleteMinimalRoles(ctx, reconcilerRef, client.ObjectKeyFromObject(rs)); err != nil {
+		return nil, nil, fmt.Errorf("deleting minimal RBAC: %w", err)
+	}
+	return rs.Spec.SafeOverride().RoleRefs, nil, nil
+}
+
+// manageMinimalRBAC generates the ClusterRole and Roles which grant the
+// reconciler access to the kinds declared in the last successfully parsed
+// commit, and returns the references of the roles to bind, along with the
+// RBAC status.
+//
+// The permissions of the previously granted commit are kept until the
+// declared commit is synced, so the reconciler can still prune the objects
+// which were removed from the source. Likewise, the permissions newly granted
+// for the declared commit are reported as pending until it is synced.
+//
+// The Roles in namespaces which don't exist yet, for example namespaces
+// declared in the same commit, are deferred until the namespace is created, so
+// the commit is still granted and the reconciler can create the namespace.
+func (r *RootSyncReconciler) manageMinimalRBAC(ctx context.Context, reconcilerRef types.NamespacedName, rs *v1beta1.RootSync) ([]v1beta1.RootSyncRoleRef, *v1beta1.RBACStatus, error) {
+	rsRef := client.ObjectKeyFromObject(rs)
+	name := ReconcilerResourceName(reconcilerRef.Name, minimalRBACResourceName)
+	labelMap := ManagedObjectLabelMap(r.syncGVK.Kind, rsRef)
+
+	rbacStatus := rs.Status.RBAC.DeepCopy()
+	if rbacStatus == nil {
+		rbacStatus = &v1beta1.RBACStatus{}
+	}
+
+	clusterRole := &rbacv1.ClusterRole{}
+	if err := r.client.Get(ctx, client.ObjectKey{Name: name}, clusterRole); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, rbacStatus, NewObjectOperationErrorWithKey(err, clusterRole, OperationGet, client.ObjectKey{Name: name})
+		}
+		clusterRole = nil
+	}
+	roleList := &rbacv1.RoleList{}
+	if err := r.client.List(ctx, roleList, client.MatchingLabels(labelMap)); err != nil {
+		return nil, rbacStatus, NewObjectOperationErrorForList(err, roleList, OperationList)
+	}
+	granted := permissionsFromRoles(clusterRole, roleList.Items)
+
+	var commit string
+	var resources []declaredResource
+	if declared := rs.Status.Source.DeclaredKinds; declared != nil {
+		commit = declared.Commit
+		resources = r.resolveDeclaredKinds(declared.Kinds)
+	}
+	desired := newMinimalPermissions()
+	desired.add(resources)
+	if rs.Status.LastSyncedCommit != commit {
+		desired.merge(granted)
+	}
+
+	pending := granted.missing(resources)
+	if len(pending) > 0 {
+		r.Logger(ctx).Info("Granting new permissions to the reconciler",
+			"commit", commit, "permissions", pending)
+	}
+	roleNamespaces, err := r.upsertMinimalRoles(ctx, name, labelMap, desired, roleList.Items)
+	if err != nil {
+		return nil, rbacStatus, err
+	}
+	if rbacStatus.Commit != commit || len(pending) > 0 {
+		rbacStatus.LastUpdate = metav1.Now()
+	}
+	switch {
+	case rs.Status.LastSyncedCommit == commit:
+		rbacStatus.PendingPermissions = nil
+	case len(pending) > 0:
+		rbacStatus.PendingPermissions = pending
+	case rbacStatus.Commit != commit:
+		rbacStatus.PendingPermissions = nil
+	}
+	rbacStatus.Commit = commit
+
+	// The declared roleRefs are bound in addition to the generated roles.
+	roleRefs := append([]v1beta1.RootSyncRoleRef{}, rs.Spec.SafeOverride().RoleRefs...)
+	roleRefs = append(roleRefs, v1beta1.RootSyncRoleRef{Kind: kinds.ClusterRole().Kind, Name: name})
+	for _, ns := range roleNamespaces {
+		roleRefs = append(roleRefs, v1beta1.RootSyncRoleRef{Kind: kinds.Role().Kind, Name: name, Namespace: ns})
+	}
+	return roleRefs, rbacStatus, nil
+}
+
+// upsertMinimalRoles creates or updates the generated ClusterRole and Roles,
+// and deletes the Roles in namespaces which are no longer needed. It returns
+// the sorted namespaces of the Roles, skipping the namespaces which don't
+// exist yet.
+func (r *RootSyncReconciler) upsertMinimalRoles(ctx context.Context, name string, labelMap map[string]string, desired minimalPermissions, currentRoles []rbacv1.Role) ([]string, error) {
+	clusterRole := &rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	if err := r.upsertManagedObject(ctx, clusterRole, func() error {
+		clusterRole.Labels = labelMap
+		clusterRole.Rules = desired.clusterRules()
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("upserting minimal ClusterRole: %w", err)
+	}
+	var roleNamespaces []string
+	namespaces := make(map[string]bool)
+	for _, ns := range desired.namespaces() {
+		namespaces[ns] = true
+		exists, err := r.namespaceExists(ctx, ns)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			r.Logger(ctx).Info("Deferring the minimal Role until the namespace exists",
+				logFieldObjectNamespace, ns)
+			continue
+		}
+		role := &rbacv1.Role{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns}}
+		if err := r.upsertManagedObject(ctx, role, func() error {
+			role.Labels = labelMap
+			role.Rules = desired.namespaceRules(ns)
+			return nil
+		}); err != nil {
+			return nil, fmt.Errorf("upserting minimal Role: %w", err)
+		}
+		roleNamespaces = append(roleNamespaces, ns)
+	}
+	for i := range currentRoles {
+		role := &currentRoles[i]
+		if role.Name == name && !namespaces[role.Namespace] {
+			if err := r.cleanup(ctx, role); err != nil {
+				return nil, fmt.Errorf("deleting minimal Role: %w", err)
+			}
+		}
+	}
+	return roleNamespaces, nil
+}
+
+// namespaceExists returns whether the namespace exists.
+func (r *RootSyncReconciler) namespaceExists(ctx context.Context, name string) (bool, error) {
+	namespace := &corev1.Namespace{}
+	if err := r.client.Get(ctx, client.ObjectKey{Name: name}, namespace); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, NewObjectOperationErrorWithKey(err, namespace, OperationGet, client.ObjectKey{Name: name})
+	}
+	return true, nil
+}
+
+// mapNamespaceToRootSyncs requeues the RootSyncs with minimal RBAC which
+// declared objects in the namespace, so the Roles deferred until the namespace
+// exists are created.
+func (r *RootSyncReconciler) mapNamespaceToRootSyncs(ctx context.Context, obj client.Object) []reconcile.Request {
+	rsList := &v1beta1.RootSyncList{}
+	if err := r.client.List(ctx, rsList); err != nil {
+		r.Logger(ctx).Error(err, "Failed to list objects",
+			logFieldSyncKind, r.syncGVK.Kind)
+		return nil
+	}
+	var requests []reconcile.Request
+	for i := range rsList.Items {
+		rs := &rsList.Items[i]
+		if rs.Spec.SafeOverride().RBACMode != configsync.RBACModeMinimal || rs.Status.Source.DeclaredKinds == nil {
+			continue
+		}
+		if declaresNamespace(rs.Status.Source.DeclaredKinds.Kinds, obj.GetName()) {
+			requests = append(requests, r.requeueRSync(ctx, obj, client.ObjectKeyFromObject(rs))...)
+		}
+	}
+	return requests
+}
+
+func declaresNamespace(declaredKinds []v1beta1.DeclaredKind, namespace string) bool {
+	for _, kind := range declaredKinds {
+		for _, ns := range kind.Namespaces {
+			if ns == namespace {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// deleteMinimalRoles deletes the generated ClusterRole and Roles, if any.
+func (r *RootSyncReconciler) deleteMinimalRoles(ctx context.Context, reconcilerRef, rsRef types.NamespacedName) error {
+	name := ReconcilerResourceName(reconcilerRef.Name, minimalRBACResourceName)
+	roleList := &rbacv1.RoleList{}
+	if err := r.client.List(ctx, roleList, client.MatchingLabels(ManagedObjectLabelMap(r.syncGVK.Kind, rsRef))); err != nil {
+		return NewObjectOperationErrorForList(err, roleList, OperationList)
+	}
+	for i := range roleList.Items {
+		role := &roleList.Items[i]
+		if role.Name != name {
+			continue
+		}
+		if err := r.cleanup(ctx, role); err != nil {
+			return fmt.Errorf("deleting minimal Role: %w", err)
+		}
+	}
+	return r.cleanupIfExists(ctx, &rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: name}})
+}
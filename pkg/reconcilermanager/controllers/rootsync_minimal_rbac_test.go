@@ -0,0 +1,257 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/core/k8sobjects"
+	"kpt.dev/configsync/pkg/reconcilermanager"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var (
+	namespacesGR = schema.GroupResource{Resource: "namespaces"}
+	configMapsGR = schema.GroupResource{Resource: "configmaps"}
+	widgetsGR    = schema.GroupResource{Group: "example.com", Resource: "widgets"}
+)
+
+func TestMinimalPermissions(t *testing.T) {
+	resources := []declaredResource{
+		{kind: v1beta1.DeclaredKind{Kind: "Namespace"}, resource: namespacesGR},
+		{kind: v1beta1.DeclaredKind{Kind: "ConfigMap", Namespaces: []string{"bar", "foo"}}, resource: configMapsGR, namespaced: true},
+		{kind: v1beta1.DeclaredKind{Group: "example.com", Kind: "Widget", Namespaces: []string{"foo"}}, resource: widgetsGR, namespaced: true},
+	}
+	p := newMinimalPermissions()
+	p.add(resources)
+
+	assert.Equal(t, []rbacv1.PolicyRule{
+		{APIGroups: []string{""}, Resources: []string{"namespaces"}, Verbs: []string{rbacv1.VerbAll}},
+		{APIGroups: []string{""}, Resources: []string{"configmaps"}, Verbs: readVerbs},
+		{APIGroups: []string{"example.com"}, Resources: []string{"widgets"}, Verbs: readVerbs},
+	}, p.clusterRules())
+	assert.Equal(t, []string{"bar", "foo"}, p.namespaces())
+	assert.Equal(t, []rbacv1.PolicyRule{
+		{APIGroups: []string{""}, Resources: []string{"configmaps"}, Verbs: []string{rbacv1.VerbAll}},
+		{APIGroups: []string{"example.com"}, Resources: []string{"widgets"}, Verbs: []string{rbacv1.VerbAll}},
+	}, p.namespaceRules("foo"))
+	assert.Empty(t, p.missing(resources))
+
+	// Parsing the generated roles yields the same permissions.
+	clusterRole := &rbacv1.ClusterRole{Rules: p.clusterRules()}
+	var roles []rbacv1.Role
+	for _, ns := range p.namespaces() {
+		roles = append(roles, rbacv1.Role{
+			ObjectMeta: metav1.ObjectMeta{Namespace: ns},
+			Rules:      p.namespaceRules(ns),
+		})
+	}
+	assert.Equal(t, p, permissionsFromRoles(clusterRole, roles))
+
+	// Only the namespaces which aren't granted are missing.
+	granted := permissionsFromRoles(clusterRole, roles[1:])
+	assert.Equal(t, []v1beta1.DeclaredKind{
+		{Kind: "ConfigMap", Namespaces: []string{"bar"}},
+	}, granted.missing(resources))
+	assert.Equal(t, []v1beta1.DeclaredKind{
+		{Kind: "Namespace"},
+		{Kind: "ConfigMap", Namespaces: []string{"bar", "foo"}},
+		{Group: "example.com", Kind: "Widget", Namespaces: []string{"foo"}},
+	}, newMinimalPermissions().missing(resources))
+
+	// Merging keeps the permissions of both.
+	other := newMinimalPermissions()
+	other.addNamespaced(configMapsGR, "baz")
+	other.merge(p)
+	assert.Equal(t, []string{"bar", "baz", "foo"}, other.namespaces())
+}
+
+func TestRootSyncMinimalRBAC(t *testing.T) {
+	// Mock out parseDeployment for testing.
+	parseDeployment = parsedDeployment
+
+	rs := rootSyncWithOCI(rootsyncName, rootsyncOCIAuthType(configsync.AuthGCENode),
+		func(rs *v1beta1.RootSync) {
+			rs.Spec.SafeOverride().RBACMode = configsync.RBACModeMinimal
+		})
+	reqNamespacedName := namespacedName(rs.Name, rs.Namespace)
+	fakeClient, _, testReconciler := setupRootReconciler(t, rs,
+		k8sobjects.NamespaceObject("foo"), k8sobjects.NamespaceObject("bar"))
+	ctx := context.Background()
+	name := ReconcilerResourceName(rootReconcilerName, minimalRBACResourceName)
+
+	// Nothing is declared yet, so the generated ClusterRole is empty.
+	_, err := testReconciler.Reconcile(ctx, reqNamespacedName)
+	require.NoError(t, err)
+	clusterRole := &rbacv1.ClusterRole{}
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Name: name}, clusterRole))
+	assert.Empty(t, clusterRole.Rules)
+	require.NoError(t, validateReconcilerClusterRoleBindings(fakeClient, rootsyncName,
+		map[v1beta1.RootSyncRoleRef]rbacv1.ClusterRoleBinding{
+			{Kind: "ClusterRole", Name: name}: *rootReconcilerClusterRoleBinding(rootReconcilerName, name),
+		}))
+
+	// The reconciler reports the kinds declared in the first commit.
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(rs), rs))
+	rs.Status.Source.DeclaredKinds = &v1beta1.DeclaredKinds{
+		Commit: "abc",
+		Kinds: []v1beta1.DeclaredKind{
+			{Kind: "ConfigMap", Namespaces: []string{"foo"}},
+			// Not served yet, so the resource is guessed from the kind.
+			{Group: "example.com", Kind: "Widget"},
+		},
+	}
+	require.NoError(t, fakeClient.Status().Update(ctx, rs, client.FieldOwner(reconcilermanager.FieldManager)))
+	_, err = testReconciler.Reconcile(ctx, reqNamespacedName)
+	require.NoError(t, err)
+
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Name: name}, clusterRole))
+	assert.Equal(t, []rbacv1.PolicyRule{
+		{APIGroups: []string{"example.com"}, Resources: []string{"widgets"}, Verbs: []string{rbacv1.VerbAll}},
+		{APIGroups: []string{""}, Resources: []string{"configmaps"}, Verbs: readVerbs},
+	}, clusterRole.Rules)
+	role := &rbacv1.Role{}
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Namespace: "foo", Name: name}, role))
+	assert.Equal(t, []rbacv1.PolicyRule{
+		{APIGroups: []string{""}, Resources: []string{"configmaps"}, Verbs: []string{rbacv1.VerbAll}},
+	}, role.Rules)
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(rs), rs))
+	require.NotNil(t, rs.Status.RBAC)
+	assert.Equal(t, "abc", rs.Status.RBAC.Commit)
+	wantPending := []v1beta1.DeclaredKind{
+		{Kind: "ConfigMap", Namespaces: []string{"foo"}},
+		{Group: "example.com", Kind: "Widget"},
+	}
+	assert.Equal(t, wantPending, rs.Status.RBAC.PendingPermissions)
+
+	// The new permissions stay pending until the commit is synced.
+	_, err = testReconciler.Reconcile(ctx, reqNamespacedName)
+	require.NoError(t, err)
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(rs), rs))
+	assert.Equal(t, wantPending, rs.Status.RBAC.PendingPermissions)
+
+	// The permissions of the synced commit are kept until the next commit,
+	// which moves the ConfigMap to another namespace, is synced.
+	rs.Status.LastSyncedCommit = "abc"
+	rs.Status.Source.DeclaredKinds = &v1beta1.DeclaredKinds{
+		Commit: "def",
+		Kinds:  []v1beta1.DeclaredKind{{Kind: "ConfigMap", Namespaces: []string{"bar"}}},
+	}
+	require.NoError(t, fakeClient.Status().Update(ctx, rs, client.FieldOwner(reconcilermanager.FieldManager)))
+	_, err = testReconciler.Reconcile(ctx, reqNamespacedName)
+	require.NoError(t, err)
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Namespace: "foo", Name: name}, role))
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Namespace: "bar", Name: name}, role))
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(rs), rs))
+	assert.Equal(t, "def", rs.Status.RBAC.Commit)
+	assert.Equal(t, []v1beta1.DeclaredKind{{Kind: "ConfigMap", Namespaces: []string{"bar"}}},
+		rs.Status.RBAC.PendingPermissions)
+
+	rs.Status.LastSyncedCommit = "def"
+	require.NoError(t, fakeClient.Status().Update(ctx, rs, client.FieldOwner(reconcilermanager.FieldManager)))
+	_, err = testReconciler.Reconcile(ctx, reqNamespacedName)
+	require.NoError(t, err)
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(rs), rs))
+	assert.Empty(t, rs.Status.RBAC.PendingPermissions)
+	require.NoError(t, validateResourceDeleted(core.IDOf(&rbacv1.Role{
+		TypeMeta:   metav1.TypeMeta{APIVersion: rbacv1.SchemeGroupVersion.String(), Kind: "Role"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: "foo", Name: name},
+	}), fakeClient))
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Name: name}, clusterRole))
+	assert.Equal(t, []rbacv1.PolicyRule{
+		{APIGroups: []string{""}, Resources: []string{"configmaps"}, Verbs: readVerbs},
+	}, clusterRole.Rules)
+
+	// Switching back to roleRefs deletes the generated roles.
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(rs), rs))
+	rs.Spec.SafeOverride().RBACMode = configsync.RBACModeRoleRefs
+	require.NoError(t, fakeClient.Update(ctx, rs, client.FieldOwner(reconcilermanager.FieldManager)))
+	_, err = testReconciler.Reconcile(ctx, reqNamespacedName)
+	require.NoError(t, err)
+	require.NoError(t, validateResourceDeleted(core.IDOf(clusterRole), fakeClient))
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(rs), rs))
+	assert.Nil(t, rs.Status.RBAC)
+}
+
+func TestRootSyncMinimalRBACMissingNamespace(t *testing.T) {
+	// Mock out parseDeployment for testing.
+	parseDeployment = parsedDeployment
+
+	rs := rootSyncWithOCI(rootsyncName, rootsyncOCIAuthType(configsync.AuthGCENode),
+		func(rs *v1beta1.RootSync) {
+			rs.Spec.SafeOverride().RBACMode = configsync.RBACModeMinimal
+		})
+	reqNamespacedName := namespacedName(rs.Name, rs.Namespace)
+	fakeClient, _, testReconciler := setupRootReconciler(t, rs)
+	ctx := context.Background()
+	name := ReconcilerResourceName(rootReconcilerName, minimalRBACResourceName)
+
+	// The commit declares the namespace along with a ConfigMap in it.
+	rs.Status.Source.DeclaredKinds = &v1beta1.DeclaredKinds{
+		Commit: "abc",
+		Kinds: []v1beta1.DeclaredKind{
+			{Kind: "Namespace"},
+			{Kind: "ConfigMap", Namespaces: []string{"foo"}},
+		},
+	}
+	require.NoError(t, fakeClient.Status().Update(ctx, rs, client.FieldOwner(reconcilermanager.FieldManager)))
+	_, err := testReconciler.Reconcile(ctx, reqNamespacedName)
+	require.NoError(t, err)
+
+	// The Role is deferred, but the commit is granted, so the reconciler can
+	// create the namespace.
+	roleKey := client.ObjectKey{Namespace: "foo", Name: name}
+	require.NoError(t, validateResourceDeleted(core.IDOf(&rbacv1.Role{
+		TypeMeta:   metav1.TypeMeta{APIVersion: rbacv1.SchemeGroupVersion.String(), Kind: "Role"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: roleKey.Namespace, Name: roleKey.Name},
+	}), fakeClient))
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(rs), rs))
+	require.NotNil(t, rs.Status.RBAC)
+	assert.Equal(t, "abc", rs.Status.RBAC.Commit)
+	assert.Equal(t, []v1beta1.DeclaredKind{
+		{Kind: "Namespace"},
+		{Kind: "ConfigMap", Namespaces: []string{"foo"}},
+	}, rs.Status.RBAC.PendingPermissions)
+	require.NoError(t, validateReconcilerClusterRoleBindings(fakeClient, rootsyncName,
+		map[v1beta1.RootSyncRoleRef]rbacv1.ClusterRoleBinding{
+			{Kind: "ClusterRole", Name: name}: *rootReconcilerClusterRoleBinding(rootReconcilerName, name),
+		}))
+
+	// Creating the namespace requeues the RootSync, which creates the Role.
+	namespace := k8sobjects.NamespaceObject("foo")
+	require.NoError(t, fakeClient.Create(ctx, namespace, client.FieldOwner(reconcilermanager.FieldManager)))
+	assert.Equal(t, []reconcile.Request{reqNamespacedName},
+		testReconciler.mapNamespaceToRootSyncs(ctx, namespace))
+	assert.Empty(t, testReconciler.mapNamespaceToRootSyncs(ctx, k8sobjects.NamespaceObject("bar")))
+	_, err = testReconciler.Reconcile(ctx, reqNamespacedName)
+	require.NoError(t, err)
+	role := &rbacv1.Role{}
+	require.NoError(t, fakeClient.Get(ctx, roleKey, role))
+	assert.Equal(t, []rbacv1.PolicyRule{
+		{APIGroups: []string{""}, Resources: []string{"configmaps"}, Verbs: []string{rbacv1.VerbAll}},
+	}, role.Rules)
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(rs), rs))
+	assert.Equal(t, "abc", rs.Status.RBAC.Commit)
+}
@@ -42,12 +42,14 @@ func updateHydrationControllerImage(image string, overrides v1beta1.OverrideSpec
 }
 
 type hydrationOptions struct {
-	sourceType     configsync.SourceType
-	gitConfig      *v1beta1.Git
-	ociConfig      *v1beta1.Oci
-	scope          declared.Scope
-	reconcilerName string
-	pollPeriod     string
+	sourceType      configsync.SourceType
+	gitConfig       *v1beta1.Git
+	ociConfig       *v1beta1.Oci
+	scope           declared.Scope
+	reconcilerName  string
+	pollPeriod      string
+	renderingEngine configsync.RenderingEngine
+	enableShell     bool
 }
 
 // hydrationEnvs returns environment variables for the hydration controller.
@@ -89,6 +91,18 @@ func hydrationEnvs(opts hydrationOptions) []corev1.EnvVar {
 			Name:  reconcilermanager.HydrationPollingPeriod,
 			Value: opts.pollPeriod,
 		})
+	if opts.renderingEngine != "" {
+		result = append(result, corev1.EnvVar{
+			Name:  reconcilermanager.RenderingEngine,
+			Value: string(opts.renderingEngine),
+		})
+	}
+	if opts.enableShell {
+		result = append(result, corev1.EnvVar{
+			Name:  reconcilermanager.EnableShellInRendering,
+			Value: "true",
+		})
+	}
 	return result
 }
 
@@ -107,8 +121,14 @@ type reconcilerOptions struct {
 	reconcileTimeout         string
 	apiServerTimeout         string
 	requiresRendering        bool
+	renderingEngine          configsync.RenderingEngine
 	dynamicNSSelectorEnabled bool
 	webhookEnabled           bool
+	remediatorWorkers        *v1beta1.RemediatorWorkersOverride
+	replicas                 *int32
+	resourceUsageReporting   bool
+	apiClient                APIClientLimits
+	minimalRBAC              bool
 }
 
 // reconcilerEnvs returns environment variables for namespace reconciler.
@@ -209,6 +229,13 @@ func reconcilerEnvs(opts reconcilerOptions) []corev1.EnvVar {
 		},
 	)
 
+	if opts.renderingEngine != "" {
+		result = append(result, corev1.EnvVar{
+			Name:  reconcilermanager.RenderingEngine,
+			Value: string(opts.renderingEngine),
+		})
+	}
+
 	if opts.webhookEnabled {
 		result = append(result,
 			corev1.EnvVar{
@@ -218,6 +245,74 @@ func reconcilerEnvs(opts reconcilerOptions) []corev1.EnvVar {
 		)
 	}
 
+	if opts.remediatorWorkers != nil {
+		minWorkers, maxWorkers := v1beta1.GetRemediatorWorkers(opts.remediatorWorkers)
+		result = append(result,
+			corev1.EnvVar{
+				Name:  reconcilermanager.RemediatorMinWorkers,
+				Value: strconv.Itoa(minWorkers),
+			},
+			corev1.EnvVar{
+				Name:  reconcilermanager.RemediatorMaxWorkers,
+				Value: strconv.Itoa(maxWorkers),
+			},
+		)
+	}
+
+	if v1beta1.GetReplicas(opts.replicas) > 1 {
+		result = append(result,
+			corev1.EnvVar{
+				Name:  reconcilermanager.LeaderElectionEnabled,
+				Value: "true",
+			},
+		)
+	}
+
+	if opts.resourceUsageReporting {
+		result = append(result,
+			corev1.EnvVar{
+				Name:  reconcilermanager.ResourceUsageReportingEnabled,
+				Value: "true",
+			},
+		)
+	}
+
+	if opts.apiClient.QPS > 0 {
+		result = append(result,
+			corev1.EnvVar{
+				Name:  reconcilermanager.APIClientQPS,
+				Value: strconv.Itoa(opts.apiClient.QPS),
+			},
+		)
+	}
+
+	if opts.apiClient.Burst > 0 {
+		result = append(result,
+			corev1.EnvVar{
+				Name:  reconcilermanager.APIClientBurst,
+				Value: strconv.Itoa(opts.apiClient.Burst),
+			},
+		)
+	}
+
+	if opts.apiClient.ApplierMaxInflightRequests > 0 {
+		result = append(result,
+			corev1.EnvVar{
+				Name:  reconcilermanager.ApplierMaxInflightRequests,
+				Value: strconv.Itoa(opts.apiClient.ApplierMaxInflightRequests),
+			},
+		)
+	}
+
+	if opts.minimalRBAC {
+		result = append(result,
+			corev1.EnvVar{
+				Name:  reconcilermanager.MinimalRBACEnabled,
+				Value: "true",
+			},
+		)
+	}
+
 	if opts.dynamicNSSelectorEnabled {
 		result = append(result,
 			corev1.EnvVar{
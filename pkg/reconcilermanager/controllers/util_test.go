@@ -22,6 +22,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 	"kpt.dev/configsync/pkg/api/configsync"
 	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
 	"kpt.dev/configsync/pkg/reconcilermanager"
@@ -221,3 +222,50 @@ func TestOCISyncEnvs(t *testing.T) {
 		})
 	}
 }
+
+func TestReconcilerEnvsRemediatorWorkers(t *testing.T) {
+	testCases := map[string]struct {
+		override *v1beta1.RemediatorWorkersOverride
+		expected []corev1.EnvVar
+	}{
+		"without override": {},
+		"with min and max": {
+			override: &v1beta1.RemediatorWorkersOverride{Min: ptr.To[int64](2), Max: ptr.To[int64](8)},
+			expected: []corev1.EnvVar{
+				{Name: reconcilermanager.RemediatorMinWorkers, Value: "2"},
+				{Name: reconcilermanager.RemediatorMaxWorkers, Value: "8"},
+			},
+		},
+		"with min only": {
+			override: &v1beta1.RemediatorWorkersOverride{Min: ptr.To[int64](6)},
+			expected: []corev1.EnvVar{
+				{Name: reconcilermanager.RemediatorMinWorkers, Value: "6"},
+				{Name: reconcilermanager.RemediatorMaxWorkers, Value: "6"},
+			},
+		},
+		"with max only": {
+			override: &v1beta1.RemediatorWorkersOverride{Max: ptr.To[int64](10)},
+			expected: []corev1.EnvVar{
+				{Name: reconcilermanager.RemediatorMinWorkers, Value: "1"},
+				{Name: reconcilermanager.RemediatorMaxWorkers, Value: "10"},
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			envs := reconcilerEnvs(reconcilerOptions{
+				sourceType:        configsync.GitSource,
+				gitConfig:         &v1beta1.Git{},
+				remediatorWorkers: tc.override,
+			})
+			var got []corev1.EnvVar
+			for _, env := range envs {
+				if env.Name == reconcilermanager.RemediatorMinWorkers || env.Name == reconcilermanager.RemediatorMaxWorkers {
+					got = append(got, env)
+				}
+			}
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
@@ -41,6 +41,25 @@ type Handler interface {
 	HasConflictErrors() bool
 	// HasConflictError returns true when there is a conflict for the specified object ID.
 	HasConflictError(core.ID) bool
+
+	// AddOwnershipTransfer records that the object with the specified ID was
+	// claimed by the specified manager, and resolves any conflict for it.
+	AddOwnershipTransfer(id core.ID, manager string)
+	// ClearOwnershipTransfers removes all the recorded ownership transfers.
+	ClearOwnershipTransfers()
+	// OwnershipTransfers returns the ownership transfers recorded since the
+	// last call to ClearOwnershipTransfers.
+	OwnershipTransfers() []OwnershipTransfer
+}
+
+// OwnershipTransfer records that management of a declared object was handed
+// off to a reconciler with higher precedence.
+type OwnershipTransfer struct {
+	// ID of the transferred object.
+	ID core.ID
+	// Manager is the value of the `configsync.gke.io/manager` annotation of
+	// the reconciler that claimed the object.
+	Manager string
 }
 
 // Record a management conflict error, including log and metric.
@@ -62,6 +81,9 @@ type handler struct {
 	// conflictErrs tracks all the conflict errors (KNV1060) the remediator encounters,
 	// and report to RootSync|RepoSync status.
 	conflictErrs *orderedmap.OrderedMap[core.ID, status.ManagementConflictError]
+	// transfers tracks the objects claimed by a reconciler with higher
+	// precedence, and reports them to RootSync|RepoSync status.
+	transfers *orderedmap.OrderedMap[core.ID, string]
 }
 
 var _ Handler = &handler{}
@@ -70,6 +92,7 @@ var _ Handler = &handler{}
 func NewHandler() Handler {
 	return &handler{
 		conflictErrs: orderedmap.NewOrderedMap[core.ID, status.ManagementConflictError](),
+		transfers:    orderedmap.NewOrderedMap[core.ID, string](),
 	}
 }
 
@@ -138,3 +161,32 @@ func (h *handler) HasConflictErrors() bool {
 
 	return h.conflictErrs.Len() > 0
 }
+
+func (h *handler) AddOwnershipTransfer(id core.ID, manager string) {
+	h.mux.Lock()
+	defer h.mux.Unlock()
+
+	if h.conflictErrs.Delete(id) {
+		klog.Infof("Conflict error resolved for %s: ownership transferred to %q", id, manager)
+	}
+	h.transfers.Set(id, manager)
+}
+
+func (h *handler) ClearOwnershipTransfers() {
+	h.mux.Lock()
+	defer h.mux.Unlock()
+
+	h.transfers = orderedmap.NewOrderedMap[core.ID, string]()
+}
+
+func (h *handler) OwnershipTransfers() []OwnershipTransfer {
+	h.mux.RLock()
+	defer h.mux.RUnlock()
+
+	// Return a copy
+	var result []OwnershipTransfer
+	for pair := h.transfers.Front(); pair != nil; pair = pair.Next() {
+		result = append(result, OwnershipTransfer{ID: pair.Key, Manager: pair.Value})
+	}
+	return result
+}
@@ -0,0 +1,239 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package queue
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/klog/v2"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/kinds"
+	"kpt.dev/configsync/pkg/metadata"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Priority of an object in the ObjectQueue. Objects with higher priority are
+// dequeued more often than objects with lower priority.
+type Priority int
+
+const (
+	// PriorityLow is the priority of objects annotated with
+	// `configsync.gke.io/remediation-priority: low`.
+	PriorityLow Priority = iota
+	// PriorityNormal is the default priority.
+	PriorityNormal
+	// PriorityHigh is the priority of security-relevant objects, like RBAC
+	// and NetworkPolicies, and objects annotated with
+	// `configsync.gke.io/remediation-priority: high`.
+	PriorityHigh
+)
+
+// priorities lists all the priorities, from highest to lowest.
+var priorities = []Priority{PriorityHigh, PriorityNormal, PriorityLow}
+
+// DefaultStarvationThreshold is the default maximum duration an object may wait
+// in the ObjectQueue before it is dequeued regardless of its priority.
+const DefaultStarvationThreshold = 30 * time.Second
+
+// String returns the string value of the Priority.
+// Implements the Stringer interface.
+func (p Priority) String() string {
+	switch p {
+	case PriorityHigh:
+		return metadata.RemediationPriorityHigh.String()
+	case PriorityLow:
+		return metadata.RemediationPriorityLow.String()
+	default:
+		return metadata.RemediationPriorityNormal.String()
+	}
+}
+
+// weight returns the relative share of dequeues the priority receives when
+// objects of multiple priorities are waiting.
+func (p Priority) weight() int {
+	switch p {
+	case PriorityHigh:
+		return 8
+	case PriorityLow:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// highPriorityGroupKinds are the kinds of security-relevant objects which are
+// remediated with PriorityHigh by default.
+var highPriorityGroupKinds = map[schema.GroupKind]bool{
+	kinds.Role().GroupKind():                           true,
+	kinds.RoleBinding().GroupKind():                    true,
+	kinds.ClusterRole().GroupKind():                    true,
+	kinds.ClusterRoleBinding().GroupKind():             true,
+	kinds.NetworkPolicy().GroupKind():                  true,
+	kinds.ValidatingWebhookConfiguration().GroupKind(): true,
+	kinds.MutatingWebhookConfiguration().GroupKind():   true,
+}
+
+// PriorityOf returns the remediation priority of the object.
+//
+// The `configsync.gke.io/remediation-priority` annotation takes precedence.
+// Otherwise, security-relevant kinds have PriorityHigh and everything else has
+// PriorityNormal.
+func PriorityOf(obj client.Object) Priority {
+	if value, found := obj.GetAnnotations()[metadata.RemediationPriorityAnnotationKey]; found {
+		switch metadata.RemediationPriority(value) {
+		case metadata.RemediationPriorityHigh:
+			return PriorityHigh
+		case metadata.RemediationPriorityNormal:
+			return PriorityNormal
+		case metadata.RemediationPriorityLow:
+			return PriorityLow
+		default:
+			klog.Warningf("Invalid remediation priority annotation (object: %q, annotation: %s=%q)",
+				core.IDOf(obj), metadata.RemediationPriorityAnnotationKey, value)
+		}
+	}
+	if highPriorityGroupKinds[obj.GetObjectKind().GroupVersionKind().GroupKind()] {
+		return PriorityHigh
+	}
+	return PriorityNormal
+}
+
+// pendingItem is a key waiting in a priority queue.
+type pendingItem struct {
+	gvknn   GVKNN
+	addedAt time.Time
+}
+
+// priorityQueues holds a FIFO queue of keys for each Priority, and picks the
+// next key to dequeue using smooth weighted round-robin.
+//
+// priorityQueues is not thread-safe. The ObjectQueue guards it with its lock.
+type priorityQueues struct {
+	// pending is the FIFO queue of keys for each priority.
+	pending map[Priority][]pendingItem
+	// credit is the current round-robin credit of each non-empty priority.
+	credit map[Priority]int
+	// starvationThreshold is the maximum duration a key may wait before it is
+	// dequeued regardless of its priority.
+	starvationThreshold time.Duration
+}
+
+func newPriorityQueues(starvationThreshold time.Duration) *priorityQueues {
+	return &priorityQueues{
+		pending:             map[Priority][]pendingItem{},
+		credit:              map[Priority]int{},
+		starvationThreshold: starvationThreshold,
+	}
+}
+
+// push appends the key to the queue for the specified priority.
+func (pq *priorityQueues) push(gvknn GVKNN, p Priority, now time.Time) {
+	pq.pending[p] = append(pq.pending[p], pendingItem{gvknn: gvknn, addedAt: now})
+}
+
+// move changes the priority of a pending key, without changing the time it
+// was added. Returns false if the key is not pending with the old priority.
+func (pq *priorityQueues) move(gvknn GVKNN, oldP, newP Priority) bool {
+	items := pq.pending[oldP]
+	for i, item := range items {
+		if item.gvknn != gvknn {
+			continue
+		}
+		pq.pending[oldP] = append(items[:i:i], items[i+1:]...)
+		if len(pq.pending[oldP]) == 0 {
+			delete(pq.credit, oldP)
+		}
+		// Keep the new queue sorted by the time keys were added.
+		newItems := pq.pending[newP]
+		j := len(newItems)
+		for j > 0 && newItems[j-1].addedAt.After(item.addedAt) {
+			j--
+		}
+		newItems = append(newItems, pendingItem{})
+		copy(newItems[j+1:], newItems[j:])
+		newItems[j] = item
+		pq.pending[newP] = newItems
+		return true
+	}
+	return false
+}
+
+// pop removes and returns the next key to dequeue, along with its priority
+// and the time it was added. Returns false if all the queues are empty.
+//
+// If the oldest key has waited longer than the starvation threshold, it is
+// returned first. Otherwise, each non-empty priority receives a share of
+// dequeues proportional to its weight.
+func (pq *priorityQueues) pop(now time.Time) (pendingItem, Priority, bool) {
+	var selected Priority
+	found := false
+	// Starvation guard
+	var oldest time.Time
+	for _, p := range priorities {
+		items := pq.pending[p]
+		if len(items) == 0 {
+			continue
+		}
+		if now.Sub(items[0].addedAt) > pq.starvationThreshold &&
+			(!found || items[0].addedAt.Before(oldest)) {
+			selected = p
+			oldest = items[0].addedAt
+			found = true
+		}
+	}
+	if found {
+		klog.V(3).Infof("ObjectQueue: dequeuing starved %s priority object: %v", selected, pq.pending[selected][0].gvknn)
+	} else {
+		// Smooth weighted round-robin
+		total := 0
+		for _, p := range priorities {
+			if len(pq.pending[p]) == 0 {
+				continue
+			}
+			pq.credit[p] += p.weight()
+			total += p.weight()
+			if !found || pq.credit[p] > pq.credit[selected] {
+				selected = p
+				found = true
+			}
+		}
+		if !found {
+			return pendingItem{}, PriorityNormal, false
+		}
+		pq.credit[selected] -= total
+	}
+	item := pq.pending[selected][0]
+	pq.pending[selected] = pq.pending[selected][1:]
+	if len(pq.pending[selected]) == 0 {
+		// Reset the credit, so an idle priority doesn't accumulate credit.
+		delete(pq.credit, selected)
+	}
+	return item, selected, true
+}
+
+// len returns the number of pending keys with the specified priority.
+func (pq *priorityQueues) len(p Priority) int {
+	return len(pq.pending[p])
+}
+
+// totalLen returns the number of pending keys across all priorities.
+func (pq *priorityQueues) totalLen() int {
+	total := 0
+	for _, p := range priorities {
+		total += len(pq.pending[p])
+	}
+	return total
+}
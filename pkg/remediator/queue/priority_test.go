@@ -0,0 +1,148 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package queue
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	testingclock "k8s.io/utils/clock/testing"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/core/k8sobjects"
+	"kpt.dev/configsync/pkg/kinds"
+	"kpt.dev/configsync/pkg/metadata"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestPriorityOf(t *testing.T) {
+	testCases := []struct {
+		name string
+		obj  client.Object
+		want Priority
+	}{
+		{
+			name: "ConfigMap",
+			obj:  k8sobjects.ConfigMapObject(),
+			want: PriorityNormal,
+		},
+		{
+			name: "Role",
+			obj:  k8sobjects.RoleObject(),
+			want: PriorityHigh,
+		},
+		{
+			name: "ClusterRoleBinding",
+			obj:  k8sobjects.ClusterRoleBindingObject(),
+			want: PriorityHigh,
+		},
+		{
+			name: "NetworkPolicy",
+			obj:  k8sobjects.UnstructuredObject(kinds.NetworkPolicy()),
+			want: PriorityHigh,
+		},
+		{
+			name: "ConfigMap with high annotation",
+			obj:  k8sobjects.ConfigMapObject(metadata.WithRemediationPriority(metadata.RemediationPriorityHigh)),
+			want: PriorityHigh,
+		},
+		{
+			name: "Role with low annotation",
+			obj:  k8sobjects.RoleObject(metadata.WithRemediationPriority(metadata.RemediationPriorityLow)),
+			want: PriorityLow,
+		},
+		{
+			name: "Role with normal annotation",
+			obj:  k8sobjects.RoleObject(metadata.WithRemediationPriority(metadata.RemediationPriorityNormal)),
+			want: PriorityNormal,
+		},
+		{
+			name: "Role with invalid annotation",
+			obj:  k8sobjects.RoleObject(metadata.WithRemediationPriority("urgent")),
+			want: PriorityHigh,
+		},
+		{
+			name: "deleted Role",
+			obj:  MarkDeleted(context.Background(), k8sobjects.RoleObject()),
+			want: PriorityHigh,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := PriorityOf(tc.obj); got != tc.want {
+				t.Errorf("PriorityOf() = %v; want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestObjectQueueWeightedFairness(t *testing.T) {
+	q := New("test")
+	defer q.ShutDown()
+
+	for i := 0; i < 20; i++ {
+		q.Add(k8sobjects.RoleObject(core.Namespace("foo-ns"), core.Name(fmt.Sprintf("role-%d", i))))
+		q.Add(k8sobjects.ConfigMapObject(core.Namespace("foo-ns"), core.Name(fmt.Sprintf("cm-%d", i))))
+		q.Add(k8sobjects.ConfigMapObject(core.Namespace("foo-ns"), core.Name(fmt.Sprintf("low-%d", i)),
+			metadata.WithRemediationPriority(metadata.RemediationPriorityLow)))
+	}
+
+	// One full round of weighted round-robin
+	got := map[Priority]int{}
+	for i := 0; i < PriorityHigh.weight()+PriorityNormal.weight()+PriorityLow.weight(); i++ {
+		obj, err := q.Get(context.Background())
+		if err != nil {
+			t.Fatalf("Object queue was shut down unexpectedly: %v", err)
+		}
+		got[PriorityOf(obj)]++
+	}
+	for _, p := range priorities {
+		if got[p] != p.weight() {
+			t.Errorf("got %d %s priority objects; want %d", got[p], p, p.weight())
+		}
+	}
+}
+
+func TestObjectQueueStarvationGuard(t *testing.T) {
+	q := New("test")
+	defer q.ShutDown()
+	fakeClock := testingclock.NewFakePassiveClock(time.Now())
+	q.clock = fakeClock
+
+	lowObj := k8sobjects.ConfigMapObject(core.Namespace("foo-ns"), core.Name("low"),
+		metadata.WithRemediationPriority(metadata.RemediationPriorityLow))
+	q.Add(lowObj)
+	fakeClock.SetTime(fakeClock.Now().Add(DefaultStarvationThreshold + time.Second))
+	for i := 0; i < 10; i++ {
+		q.Add(k8sobjects.RoleObject(core.Namespace("foo-ns"), core.Name(fmt.Sprintf("role-%d", i))))
+	}
+
+	obj, err := q.Get(context.Background())
+	if err != nil {
+		t.Fatalf("Object queue was shut down unexpectedly: %v", err)
+	}
+	if core.IDOf(obj) != core.IDOf(lowObj) {
+		t.Errorf("got object %q from queue; want starved object %q", core.IDOf(obj), core.IDOf(lowObj))
+	}
+	obj, err = q.Get(context.Background())
+	if err != nil {
+		t.Fatalf("Object queue was shut down unexpectedly: %v", err)
+	}
+	if PriorityOf(obj) != PriorityHigh {
+		t.Errorf("got %s priority object from queue; want %s", PriorityOf(obj), PriorityHigh)
+	}
+}
@@ -22,8 +22,10 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
 	"kpt.dev/configsync/pkg/core"
 	"kpt.dev/configsync/pkg/kinds"
+	"kpt.dev/configsync/pkg/metrics"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -62,12 +64,13 @@ type Interface interface {
 	ShutDown()
 }
 
-// ObjectQueue is a wrapper around a workqueue.Interface for use with declared
-// resources. It deduplicates work items by their GVKNN.
-// NOTE: This was originally designed to wrap a DelayingInterface, but we have
-// had to copy a lot of that logic here. At some point it may make sense to
-// remove the underlying workqueue.Interface and just consolidate copied logic
-// here.
+// ObjectQueue is a work queue for use with declared resources. It deduplicates
+// work items by their GVKNN, and dequeues them by Priority, so that drift on
+// security-relevant objects is remediated first. Objects that have waited
+// longer than the starvation threshold are dequeued regardless of priority.
+// NOTE: This was originally designed to wrap a workqueue.Interface, but the
+// logic to deduplicate and track items being processed has been consolidated
+// here to support priorities.
 type ObjectQueue struct {
 	// cond is a locking condition which allows us to lock all mutating calls but
 	// also allow any call to yield the lock safely (specifically for Get).
@@ -76,27 +79,37 @@ type ObjectQueue struct {
 	rateLimiter workqueue.TypedRateLimiter[GVKNN]
 	// delayer is a wrapper around the ObjectQueue which supports delayed Adds.
 	delayer workqueue.TypedDelayingInterface[client.Object]
-	// underlying is the workqueue that contains work item keys so that it can
-	// maintain the order in which those items should be worked on.
-	underlying workqueue.TypedInterface[GVKNN]
+	// pending contains the work item keys waiting to be processed, grouped by
+	// priority, so that it can maintain the order in which those items should
+	// be worked on.
+	pending *priorityQueues
+	// pendingPriority is a map of the priority of each key in pending.
+	pendingPriority map[GVKNN]Priority
 	// objects is a map of actual work items which need to be processed.
 	objects map[GVKNN]client.Object
 	// dirty is a map of object keys which will need to be reprocessed even if
 	// they are currently being processed. This is explained further in Add().
 	dirty map[GVKNN]bool
+	// processing is a map of object keys which are currently being processed.
+	processing map[GVKNN]bool
+	// shuttingDown is true after ShutDown is called.
+	shuttingDown bool
+	// clock is used to measure how long work items wait to be processed.
+	clock clock.PassiveClock
 }
 
 // New creates a new work queue for use in signalling objects that may need
 // remediation.
 func New(name string) *ObjectQueue {
 	oq := &ObjectQueue{
-		cond:        sync.NewCond(&sync.Mutex{}),
-		rateLimiter: workqueue.DefaultTypedControllerRateLimiter[GVKNN](),
-		underlying: workqueue.NewTypedWithConfig[GVKNN](workqueue.TypedQueueConfig[GVKNN]{
-			Name: name,
-		}),
-		objects: map[GVKNN]client.Object{},
-		dirty:   map[GVKNN]bool{},
+		cond:            sync.NewCond(&sync.Mutex{}),
+		rateLimiter:     workqueue.DefaultTypedControllerRateLimiter[GVKNN](),
+		pending:         newPriorityQueues(DefaultStarvationThreshold),
+		pendingPriority: map[GVKNN]Priority{},
+		objects:         map[GVKNN]client.Object{},
+		dirty:           map[GVKNN]bool{},
+		processing:      map[GVKNN]bool{},
+		clock:           clock.RealClock{},
 	}
 	oq.delayer = delayingWrap(oq, name)
 	return oq
@@ -137,15 +150,45 @@ func (q *ObjectQueue) Add(obj client.Object) {
 	klog.V(2).Infof("ObjectQueue.Add: %v (generation: %d)",
 		gvknn, obj.GetGeneration())
 	q.objects[gvknn] = obj
-	q.underlying.Add(gvknn)
 
+	if q.shuttingDown {
+		return
+	}
 	if !q.dirty[gvknn] {
 		q.dirty[gvknn] = true
+		// If the object is being processed, Done will re-add it.
+		if !q.processing[gvknn] {
+			q.push(gvknn)
+		}
 		// Signal the Get Wait to continue, to detect the new object
 		q.cond.Signal()
+	} else if oldPriority, found := q.pendingPriority[gvknn]; found {
+		// The object is already waiting. Update its priority, in case the
+		// priority annotation changed, without losing its place in line.
+		newPriority := PriorityOf(obj)
+		if newPriority != oldPriority && q.pending.move(gvknn, oldPriority, newPriority) {
+			q.pendingPriority[gvknn] = newPriority
+			q.recordDepth(oldPriority)
+			q.recordDepth(newPriority)
+		}
 	}
 }
 
+// push adds the key to the pending queue matching the priority of its object.
+// Must be called while holding the lock.
+func (q *ObjectQueue) push(gvknn GVKNN) {
+	p := PriorityOf(q.objects[gvknn])
+	q.pending.push(gvknn, p, q.clock.Now())
+	q.pendingPriority[gvknn] = p
+	q.recordDepth(p)
+}
+
+// recordDepth records the number of pending keys with the specified priority.
+// Must be called while holding the lock.
+func (q *ObjectQueue) recordDepth(p Priority) {
+	metrics.RecordRemediatorQueueDepth(context.Background(), p.String(), q.pending.len(p))
+}
+
 // Retry schedules the object to be requeued using the rate limiter.
 func (q *ObjectQueue) Retry(obj client.Object) {
 	gvknn := GVKNNOf(obj)
@@ -178,20 +221,28 @@ func (q *ObjectQueue) Get(ctx context.Context) (client.Object, error) {
 		case <-innerCtx.Done():
 			// Stop waiting for ctx to be cancelled
 		case <-ctx.Done():
-			// Signal the Get Wait to continue, to detect the context error
+			// Wake up all the Get Waits, because Signal might only wake up
+			// another Get, leaving this one blocked. The others will wait
+			// again, because their context is not done.
 			klog.V(3).Infof("ObjectQueue.Get interrupted: %v", ctx.Err())
-			q.cond.Signal()
+			q.cond.L.Lock()
+			q.cond.Broadcast()
+			q.cond.L.Unlock()
 		}
 	}()
 
 	// This is a yielding block that will allow Add() and Done() to be called
-	// while it blocks.
-	for q.underlying.Len() == 0 {
+	// while it blocks. The context is checked before popping, even if an item
+	// is ready, so that a stopped caller never takes an item it won't process.
+	for {
 		if err := ctx.Err(); err != nil {
 			klog.V(3).Infof("ObjectQueue.Get returning: %v", err)
 			return nil, err
 		}
-		if q.underlying.ShuttingDown() {
+		if q.pending.totalLen() > 0 {
+			break
+		}
+		if q.shuttingDown {
 			klog.V(3).Info("ObjectQueue.Get returning: Shutting Down")
 			return nil, ErrShutdown
 		}
@@ -203,16 +254,22 @@ func (q *ObjectQueue) Get(ctx context.Context) (client.Object, error) {
 	cancel()
 
 	// Because length > 0 and nothing else can remove from it while locked,
-	// Get should return immediately.
-	gvknn, shutdown := q.underlying.Get()
-	if shutdown {
+	// pop should always find an item.
+	now := q.clock.Now()
+	item, p, found := q.pending.pop(now)
+	if !found {
 		return nil, ErrShutdown
 	}
+	gvknn := item.gvknn
+	delete(q.pendingPriority, gvknn)
+	q.processing[gvknn] = true
+	q.recordDepth(p)
+	metrics.RecordRemediatorQueueLatency(ctx, p.String(), now.Sub(item.addedAt))
 
 	obj := q.objects[gvknn]
 	delete(q.dirty, gvknn)
-	klog.V(2).Infof("ObjectQueue.Get: returning object: %v (generation: %d)",
-		gvknn, obj.GetGeneration())
+	klog.V(2).Infof("ObjectQueue.Get: returning %s priority object: %v (generation: %d)",
+		p, gvknn, obj.GetGeneration())
 	return kinds.ObjectAsClientObject(obj.DeepCopyObject())
 }
 
@@ -224,11 +281,12 @@ func (q *ObjectQueue) Done(obj client.Object) {
 	defer q.cond.L.Unlock()
 
 	gvknn := GVKNNOf(obj)
-	q.underlying.Done(gvknn)
+	delete(q.processing, gvknn)
 
 	if q.dirty[gvknn] {
 		klog.V(3).Infof("ObjectQueue.Done: retaining object for retry: %v (generation: %d)",
 			gvknn, obj.GetGeneration())
+		q.push(gvknn)
 		// Signal the Get Wait to continue, to detect the new object
 		q.cond.Signal()
 	} else {
@@ -245,22 +303,31 @@ func (q *ObjectQueue) Forget(obj client.Object) {
 	q.rateLimiter.Forget(gvknn)
 }
 
-// Len returns the length of the underlying queue.
+// Len returns the number of objects waiting to be processed.
 func (q *ObjectQueue) Len() int {
-	return q.underlying.Len()
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+
+	return q.pending.totalLen()
 }
 
 // ShutDown shuts down the object queue.
 func (q *ObjectQueue) ShutDown() {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+
 	klog.V(1).Info("ObjectQueue.ShutDown()")
-	q.underlying.ShutDown()
-	// Signal the Get Wait to continue, to detect the shutdown
-	q.cond.Signal()
+	q.shuttingDown = true
+	// Wake up all the Get Waits, to detect the shutdown
+	q.cond.Broadcast()
 }
 
 // ShuttingDown returns true if the object queue is shutting down.
 func (q *ObjectQueue) ShuttingDown() bool {
-	return q.underlying.ShuttingDown()
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+
+	return q.shuttingDown
 }
 
 // delayingWrap returns the given ObjectQueue wrapped in a DelayingInterface to
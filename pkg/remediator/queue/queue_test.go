@@ -17,10 +17,12 @@ package queue
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"kpt.dev/configsync/pkg/core"
 	"kpt.dev/configsync/pkg/core/k8sobjects"
+	"kpt.dev/configsync/pkg/metadata"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -67,6 +69,11 @@ func TestObjectQueue(t *testing.T) {
 	cmHelloGen1 := k8sobjects.ConfigMapObject(core.Namespace("foo-ns"), core.Name("hello"), core.Generation(1))
 	cmGoodbyeGen0 := k8sobjects.ConfigMapObject(core.Namespace("foo-ns"), core.Name("goodbye"))
 	cmGoodbyeGen1 := k8sobjects.ConfigMapObject(core.Namespace("foo-ns"), core.Name("goodbye"), core.Generation(1))
+	roleGen0 := k8sobjects.RoleObject(core.Namespace("foo-ns"), core.Name("admin"))
+	cmLowGen0 := k8sobjects.ConfigMapObject(core.Namespace("foo-ns"), core.Name("low"),
+		metadata.WithRemediationPriority(metadata.RemediationPriorityLow))
+	cmHelloHighGen0 := k8sobjects.ConfigMapObject(core.Namespace("foo-ns"), core.Name("hello"),
+		metadata.WithRemediationPriority(metadata.RemediationPriorityHigh))
 
 	testCases := []struct {
 		name    string
@@ -160,6 +167,40 @@ func TestObjectQueue(t *testing.T) {
 				done(cmHelloGen1, 0),
 			},
 		},
+		{
+			name: "add cmHello, add role, get role first, get cmHello, done, done",
+			actions: []action{
+				add(cmHelloGen0, 1),
+				add(roleGen0, 2),
+				get(roleGen0, 1),
+				get(cmHelloGen0, 0),
+				done(roleGen0, 0),
+				done(cmHelloGen0, 0),
+			},
+		},
+		{
+			name: "add cmLow, add cmHello, get cmHello first, get cmLow, done, done",
+			actions: []action{
+				add(cmLowGen0, 1),
+				add(cmHelloGen0, 2),
+				get(cmHelloGen0, 1),
+				get(cmLowGen0, 0),
+				done(cmHelloGen0, 0),
+				done(cmLowGen0, 0),
+			},
+		},
+		{
+			name: "add cmHello, add cmGoodbye, reprioritize cmGoodbye, get cmHello, get cmGoodbye, done, done",
+			actions: []action{
+				add(cmGoodbyeGen0, 1),
+				add(cmHelloGen0, 2),
+				add(cmHelloHighGen0, 2),
+				get(cmHelloHighGen0, 1),
+				get(cmGoodbyeGen0, 0),
+				done(cmHelloHighGen0, 0),
+				done(cmGoodbyeGen0, 0),
+			},
+		},
 		{
 			name: "stress the logic",
 			actions: []action{
@@ -191,3 +232,72 @@ func TestObjectQueue(t *testing.T) {
 		})
 	}
 }
+
+func TestObjectQueue_GetCancelledWithOtherWaiters(t *testing.T) {
+	q := New("test")
+	defer q.ShutDown()
+
+	// Several Gets wait on the same condition. Cancelling one of them must
+	// return from that Get, whichever waiter gets woken up first.
+	otherCtx, otherCancel := context.WithCancel(context.Background())
+	defer otherCancel()
+	otherErrs := make(chan error, 3)
+	for i := 0; i < 3; i++ {
+		go func() {
+			_, err := q.Get(otherCtx)
+			otherErrs <- err
+		}()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errs := make(chan error, 1)
+	go func() {
+		_, err := q.Get(ctx)
+		errs <- err
+	}()
+	cancel()
+
+	select {
+	case err := <-errs:
+		if err != context.Canceled {
+			t.Errorf("got Get() error %v, want %v", err, context.Canceled)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Get() did not return after its context was cancelled")
+	}
+	select {
+	case err := <-otherErrs:
+		t.Errorf("got Get() error %v from a waiter with a live context", err)
+	default:
+	}
+
+	// Shutting down returns from all the remaining Gets.
+	q.ShutDown()
+	for i := 0; i < 3; i++ {
+		select {
+		case err := <-otherErrs:
+			if err != ErrShutdown {
+				t.Errorf("got Get() error %v, want %v", err, ErrShutdown)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("Get() did not return after the queue was shut down")
+		}
+	}
+}
+
+func TestObjectQueue_GetCancelledWithPendingObject(t *testing.T) {
+	q := New("test")
+	defer q.ShutDown()
+	cm := k8sobjects.ConfigMapObject(core.Name("hello"))
+	q.Add(cm)
+
+	// A stopped caller must not take an object it won't process.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := q.Get(ctx); err != context.Canceled {
+		t.Errorf("got Get() error %v, want %v", err, context.Canceled)
+	}
+	if q.Len() != 1 {
+		t.Errorf("got length %d after a cancelled Get; want length 1", q.Len())
+	}
+}
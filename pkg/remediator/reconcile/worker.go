@@ -53,12 +53,19 @@ func NewWorker(scope declared.Scope, syncName string, a syncerreconcile.Applier,
 // Run starts the Worker pulling objects from its queue for remediation. This
 // call blocks until the given context is cancelled.
 func (w *Worker) Run(ctx context.Context) {
+	w.RunUntil(ctx, ctx)
+}
+
+// RunUntil is like Run, but also returns when stopCtx is cancelled. Unlike
+// cancelling ctx, cancelling stopCtx lets the Worker finish remediating the
+// current object before it stops. stopCtx should be a child of ctx.
+func (w *Worker) RunUntil(ctx, stopCtx context.Context) {
 	klog.V(1).Info("Remediator worker starting...")
-	ctx, cancel := context.WithCancel(ctx)
-	wait.UntilWithContext(ctx, func(ctx context.Context) {
+	stopCtx, cancel := context.WithCancel(stopCtx)
+	wait.UntilWithContext(stopCtx, func(stopCtx context.Context) {
 		// Attempt to drain the queue
 		for {
-			if err := w.processNextObject(ctx); err != nil {
+			if err := w.processNextObject(ctx, stopCtx); err != nil {
 				if err == queue.ErrShutdown {
 					klog.Infof("Remediator worker stopping: %v", err)
 					cancel()
@@ -79,11 +86,12 @@ func (w *Worker) Run(ctx context.Context) {
 }
 
 // processNextObject remediates object received from the queue.
-// Returns an error if the context is cancelled, the queue is shut down, or
-// processing the item failed.
-func (w *Worker) processNextObject(ctx context.Context) error {
+// Returns an error if either context is cancelled, the queue is shut down, or
+// processing the item failed. Cancelling stopCtx only interrupts waiting for
+// the next object.
+func (w *Worker) processNextObject(ctx, stopCtx context.Context) error {
 	klog.V(3).Info("Remediator worker waiting for new object...")
-	obj, err := w.objectQueue.Get(ctx)
+	obj, err := w.objectQueue.Get(stopCtx)
 	if err != nil {
 		return err
 	}
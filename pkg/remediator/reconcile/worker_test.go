@@ -353,7 +353,7 @@ func TestWorker_ProcessNextObject(t *testing.T) {
 				syncertestfake.NewConflictHandler(), syncertestfake.NewFightHandler())
 
 			for _, obj := range tc.toProcess {
-				if err := w.processNextObject(context.Background()); err != nil {
+				if err := w.processNextObject(context.Background(), context.Background()); err != nil {
 					t.Errorf("unexpected error from processNextObject() for object %q: %v", core.IDOf(obj), err)
 				}
 			}
@@ -46,7 +46,7 @@ type Remediator struct {
 	// updates to queue, parentContext, doneCh, and stopFn.
 	lifecycleMux sync.RWMutex
 	// workers pull objects from the queue and remediate them
-	workers []*reconcile.Worker
+	workers *workerPool
 	// objectQueue is a queue of objects that have received watch events and
 	// need to be processed by the workers.
 	objectQueue *queue.ObjectQueue
@@ -105,13 +105,12 @@ func New(
 	fightHandler fight.Handler,
 	crdController *controllers.CRDController,
 	decls *declared.Resources,
-	numWorkers int,
+	workerOpts WorkerPoolOptions,
 ) (*Remediator, error) {
 	q := queue.New(scope.String())
-	workers := make([]*reconcile.Worker, numWorkers)
-	for i := 0; i < numWorkers; i++ {
-		workers[i] = reconcile.NewWorker(scope, syncName, applier, q, decls, conflictHandler, fightHandler)
-	}
+	workers := newWorkerPool(workerOpts, func() *reconcile.Worker {
+		return reconcile.NewWorker(scope, syncName, applier, q, decls, conflictHandler, fightHandler)
+	}, q.Len)
 
 	remediator := &Remediator{
 		workers:         workers,
@@ -167,18 +166,9 @@ func (r *Remediator) startWorkers() {
 	ctx, cancel := context.WithCancel(r.parentContext)
 
 	doneCh := make(chan struct{})
-	var wg sync.WaitGroup
-	for i := range r.workers {
-		worker := r.workers[i]
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-			worker.Run(ctx)
-		}()
-	}
 	go func() {
 		defer close(doneCh)
-		wg.Wait()
+		r.workers.Run(ctx)
 	}()
 
 	r.doneCh = doneCh
@@ -33,6 +33,7 @@ import (
 	"kpt.dev/configsync/pkg/core"
 	"kpt.dev/configsync/pkg/declared"
 	"kpt.dev/configsync/pkg/diff"
+	"kpt.dev/configsync/pkg/metadata"
 	"kpt.dev/configsync/pkg/metrics"
 	"kpt.dev/configsync/pkg/remediator/conflict"
 	"kpt.dev/configsync/pkg/remediator/queue"
@@ -486,6 +487,13 @@ func (w *filteredWatcher) shouldProcess(object client.Object) bool {
 		return true
 	}
 
+	if diff.IsOwnershipTransferred(w.scope, w.syncName, object) {
+		klog.V(3).Infof("Remediator ignoring event for object %q, which was claimed by reconciler %q",
+			id, core.GetAnnotation(object, metadata.ResourceManagerKey))
+		w.conflictHandler.RemoveConflictError(id)
+		return false
+	}
+
 	desiredManager := declared.ResourceManager(w.scope, w.syncName)
 	conflictErr := status.ManagementConflictErrorWrap(object, desiredManager)
 	conflict.Record(context.Background(), w.conflictHandler, conflictErr, commit)
@@ -0,0 +1,162 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remediator
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
+	"kpt.dev/configsync/pkg/metrics"
+	"kpt.dev/configsync/pkg/remediator/reconcile"
+)
+
+// scalePeriod is the time delay between resizing the worker pool.
+const scalePeriod = 5 * time.Second
+
+// WorkerPoolOptions configures the size of the remediator worker pool.
+type WorkerPoolOptions struct {
+	// MinWorkers is the minimum number of concurrent remediator workers.
+	// Must be at least 1.
+	MinWorkers int
+	// MaxWorkers is the maximum number of concurrent remediator workers.
+	// Defaults to MinWorkers, if less than MinWorkers.
+	MaxWorkers int
+	// Throttled returns the number of requests the API server has rejected
+	// with 429 Too Many Requests. Optional.
+	Throttled func() int64
+}
+
+// workerPool runs between a minimum and maximum number of workers, scaling
+// with the length of the work queue and backing off when the API server
+// throttles requests.
+type workerPool struct {
+	minWorkers int
+	maxWorkers int
+	// newWorker builds a new worker.
+	newWorker func() *reconcile.Worker
+	// queueLen returns the number of objects waiting to be remediated.
+	queueLen func() int
+	// throttled returns the number of throttled requests. Optional.
+	throttled func() int64
+
+	// stopFns stop the running workers, one per worker.
+	stopFns []context.CancelFunc
+	// wg waits for the running and stopping workers to exit.
+	wg sync.WaitGroup
+	// lastThrottled is the value of throttled at the last resize.
+	lastThrottled int64
+}
+
+func newWorkerPool(opts WorkerPoolOptions, newWorker func() *reconcile.Worker, queueLen func() int) *workerPool {
+	minWorkers := opts.MinWorkers
+	if minWorkers < 1 {
+		minWorkers = 1
+	}
+	maxWorkers := opts.MaxWorkers
+	if maxWorkers < minWorkers {
+		maxWorkers = minWorkers
+	}
+	return &workerPool{
+		minWorkers: minWorkers,
+		maxWorkers: maxWorkers,
+		newWorker:  newWorker,
+		queueLen:   queueLen,
+		throttled:  opts.Throttled,
+	}
+}
+
+// Run starts the minimum number of workers and resizes the pool periodically.
+// This call blocks until the given context is cancelled and all the workers
+// have exited.
+func (p *workerPool) Run(ctx context.Context) {
+	if p.throttled != nil {
+		p.lastThrottled = p.throttled()
+	}
+	p.resize(ctx, p.minWorkers)
+
+	ticker := time.NewTicker(scalePeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			p.stopFns = nil
+			p.wg.Wait()
+			return
+		case <-ticker.C:
+			throttled := false
+			if p.throttled != nil {
+				total := p.throttled()
+				throttled = total > p.lastThrottled
+				p.lastThrottled = total
+			}
+			p.resize(ctx, desiredWorkers(len(p.stopFns), p.minWorkers, p.maxWorkers, p.queueLen(), throttled))
+		}
+	}
+}
+
+// resize starts or stops workers until the specified number are running.
+// Stopped workers finish remediating their current object before exiting.
+func (p *workerPool) resize(ctx context.Context, size int) {
+	current := len(p.stopFns)
+	if size == current {
+		return
+	}
+	for len(p.stopFns) < size {
+		stopCtx, stopFn := context.WithCancel(ctx)
+		p.stopFns = append(p.stopFns, stopFn)
+		worker := p.newWorker()
+		p.wg.Add(1)
+		go func() {
+			defer p.wg.Done()
+			worker.RunUntil(ctx, stopCtx)
+		}()
+	}
+	for len(p.stopFns) > size {
+		last := len(p.stopFns) - 1
+		p.stopFns[last]()
+		p.stopFns = p.stopFns[:last]
+	}
+	klog.V(1).Infof("Remediator worker pool resized from %d to %d workers", current, size)
+	metrics.RecordRemediatorWorkers(ctx, size)
+}
+
+// desiredWorkers returns the number of workers to run, using additive increase
+// and multiplicative decrease:
+// - If the API server throttled any requests since the last resize, remove
+// half of the workers.
+// - Otherwise, if any objects are waiting to be remediated, add a worker.
+// - Otherwise, remove a worker.
+//
+// The result is always between minWorkers and maxWorkers.
+func desiredWorkers(current, minWorkers, maxWorkers, queueLen int, throttled bool) int {
+	desired := current
+	switch {
+	case throttled:
+		desired = current / 2
+	case queueLen > 0:
+		desired = current + 1
+	default:
+		desired = current - 1
+	}
+	if desired < minWorkers {
+		return minWorkers
+	}
+	if desired > maxWorkers {
+		return maxWorkers
+	}
+	return desired
+}
@@ -0,0 +1,87 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remediator
+
+import "testing"
+
+func TestDesiredWorkers(t *testing.T) {
+	testCases := []struct {
+		name       string
+		current    int
+		minWorkers int
+		maxWorkers int
+		queueLen   int
+		throttled  bool
+		want       int
+	}{
+		{
+			name:       "scale up when objects are waiting",
+			current:    1,
+			minWorkers: 1,
+			maxWorkers: 4,
+			queueLen:   10,
+			want:       2,
+		},
+		{
+			name:       "do not scale above max",
+			current:    4,
+			minWorkers: 1,
+			maxWorkers: 4,
+			queueLen:   10,
+			want:       4,
+		},
+		{
+			name:       "scale down when queue is empty",
+			current:    3,
+			minWorkers: 1,
+			maxWorkers: 4,
+			want:       2,
+		},
+		{
+			name:       "do not scale below min",
+			current:    2,
+			minWorkers: 2,
+			maxWorkers: 4,
+			want:       2,
+		},
+		{
+			name:       "halve when throttled",
+			current:    8,
+			minWorkers: 1,
+			maxWorkers: 8,
+			queueLen:   10,
+			throttled:  true,
+			want:       4,
+		},
+		{
+			name:       "do not halve below min when throttled",
+			current:    3,
+			minWorkers: 2,
+			maxWorkers: 8,
+			queueLen:   10,
+			throttled:  true,
+			want:       2,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := desiredWorkers(tc.current, tc.minWorkers, tc.maxWorkers, tc.queueLen, tc.throttled)
+			if got != tc.want {
+				t.Errorf("desiredWorkers() = %d; want %d", got, tc.want)
+			}
+		})
+	}
+}
@@ -333,6 +333,8 @@ func (r *reconciler) computeStatus(
 			resStatus.Strategy = aStatus.Strategy
 			resStatus.Actuation = aStatus.Actuation
 			resStatus.Reconcile = aStatus.Reconcile
+			resStatus.SourceLocation = aStatus.SourceLocation
+			resStatus.SourceChain = aStatus.SourceChain
 
 			// Update the reconcile status based on the Strategy & Actuation
 			// from the last apply attempt, and the newly computed kstatus.
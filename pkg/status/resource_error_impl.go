@@ -102,11 +102,23 @@ func GetSourceAnnotation(obj client.Object) string {
 	return as[metadata.SourcePathAnnotationKey]
 }
 
+// getSourceLocation returns the location of the Resource in the source,
+// falling back to the SourcePath Annotation if the location is unknown.
+func getSourceLocation(r client.Object) string {
+	if location := r.GetAnnotations()[metadata.SourceLocationAnnotationKey]; location != "" {
+		return location
+	}
+	return GetSourceAnnotation(r)
+}
+
 // PrintResource returns a human-readable output for the Resource.
 func PrintResource(r client.Object) string {
 	var sb strings.Builder
-	if sourcePath := GetSourceAnnotation(r); sourcePath != "" {
-		sb.WriteString(fmt.Sprintf("source: %s\n", sourcePath))
+	if source := getSourceLocation(r); source != "" {
+		sb.WriteString(fmt.Sprintf("source: %s\n", source))
+	}
+	if chain := r.GetAnnotations()[metadata.SourceChainAnnotationKey]; chain != "" {
+		sb.WriteString(fmt.Sprintf("rendered from: %s\n", chain))
 	}
 	if r.GetNamespace() != "" {
 		sb.WriteString(fmt.Sprintf("namespace: %s\n", r.GetNamespace()))
@@ -18,6 +18,7 @@ package client
 import (
 	"context"
 	"fmt"
+	"sync/atomic"
 	"time"
 
 	"github.com/davecgh/go-spew/spew"
@@ -39,6 +40,9 @@ type Client struct {
 	client.Client
 	latencyMetric *prometheus.HistogramVec
 	MaxTries      int
+	// throttled is the number of requests rejected by the API server with
+	// 429 Too Many Requests.
+	throttled atomic.Int64
 }
 
 // New returns a new Client.
@@ -67,6 +71,7 @@ func (c *Client) Create(ctx context.Context, obj client.Object, opts ...client.C
 
 	start := time.Now()
 	err := c.Client.Create(ctx, obj, opts...)
+	c.recordThrottled(err)
 
 	c.recordLatency(start, "Create", metrics.StatusLabel(err))
 	m.RecordAPICallDuration(ctx, "create", m.StatusTagKey(err), start)
@@ -93,6 +98,7 @@ func (c *Client) Delete(ctx context.Context, obj client.Object, opts ...client.D
 	namespacedName := getNamespacedName(obj)
 
 	if err := c.Client.Get(ctx, namespacedName, obj); err != nil {
+		c.recordThrottled(err)
 		switch {
 		case apierrors.IsNotFound(err):
 			// Object is already deleted
@@ -115,6 +121,7 @@ func (c *Client) Delete(ctx context.Context, obj client.Object, opts ...client.D
 	start := time.Now()
 	opts = append(opts, client.PropagationPolicy(metav1.DeletePropagationBackground))
 	err := c.Client.Delete(ctx, obj, opts...)
+	c.recordThrottled(err)
 
 	switch {
 	case err == nil:
@@ -171,6 +178,7 @@ func (c *Client) apply(ctx context.Context, obj client.Object, updateFn update,
 
 	for tryNum := 0; tryNum < c.MaxTries; tryNum++ {
 		err := c.Client.Get(ctx, namespacedName, workingObj)
+		c.recordThrottled(err)
 		if err != nil {
 			switch {
 			case apierrors.IsNotFound(err):
@@ -200,6 +208,7 @@ func (c *Client) apply(ctx context.Context, obj client.Object, updateFn update,
 
 		start := time.Now()
 		err = clientUpdateFn(ctx, newObj)
+		c.recordThrottled(err)
 
 		c.recordLatency(start, "update", metrics.StatusLabel(err))
 		m.RecordAPICallDuration(ctx, "update", m.StatusTagKey(err), start)
@@ -242,6 +251,7 @@ func (c *Client) Update(ctx context.Context, obj client.Object, opts ...client.U
 	oldV := resourceVersion(obj)
 	start := time.Now()
 	err := c.Client.Update(ctx, obj, opts...)
+	c.recordThrottled(err)
 
 	c.recordLatency(start, "update", metrics.StatusLabel(err))
 	m.RecordAPICallDuration(ctx, "update", m.StatusTagKey(err), start)
@@ -265,6 +275,35 @@ func (c *Client) Update(ctx context.Context, obj client.Object, opts ...client.U
 	return nil
 }
 
+// Get retrieves the object from the Kubernetes cluster.
+// Overrides the embedded client to count throttled requests.
+func (c *Client) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	err := c.Client.Get(ctx, key, obj, opts...)
+	c.recordThrottled(err)
+	return err
+}
+
+// Patch patches the object in the Kubernetes cluster.
+// Overrides the embedded client to count throttled requests.
+func (c *Client) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	err := c.Client.Patch(ctx, obj, patch, opts...)
+	c.recordThrottled(err)
+	return err
+}
+
+// Throttled returns the number of requests the API server rejected with
+// 429 Too Many Requests since the Client was created.
+func (c *Client) Throttled() int64 {
+	return c.throttled.Load()
+}
+
+// recordThrottled counts the error, if the API server throttled the request.
+func (c *Client) recordThrottled(err error) {
+	if apierrors.IsTooManyRequests(err) {
+		c.throttled.Add(1)
+	}
+}
+
 func (c *Client) recordLatency(start time.Time, lvs ...string) {
 	if c.latencyMetric == nil {
 		return
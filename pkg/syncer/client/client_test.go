@@ -194,3 +194,24 @@ func TestClient_Update(t *testing.T) {
 		})
 	}
 }
+
+func TestClient_Throttled(t *testing.T) {
+	tooManyRequests := apierrors.NewTooManyRequests("slow down", 1)
+	sc := syncerclient.New(syncertestfake.NewErrorClient(tooManyRequests), nil)
+	role := k8sobjects.RoleObject(core.Name("admin"), core.Namespace("billing"))
+
+	_ = sc.Create(context.Background(), role)
+	_ = sc.Update(context.Background(), role)
+	_ = sc.Patch(context.Background(), role, client.Apply)
+	_ = sc.Get(context.Background(), client.ObjectKeyFromObject(role), role)
+
+	if got, want := sc.Throttled(), int64(4); got != want {
+		t.Errorf("Throttled() = %d; want %d", got, want)
+	}
+
+	sc = syncerclient.New(syncertestfake.NewErrorClient(errors.New("some error")), nil)
+	_ = sc.Create(context.Background(), role)
+	if got, want := sc.Throttled(), int64(0); got != want {
+		t.Errorf("Throttled() = %d; want %d", got, want)
+	}
+}
@@ -50,6 +50,19 @@ func (h *ConflictHandler) HasConflictErrors() bool {
 func (h *ConflictHandler) ClearConflictErrorsWithKind(schema.GroupKind) {
 }
 
+// AddOwnershipTransfer is a fake implementation of AddOwnershipTransfer of conflict.Handler.
+func (h *ConflictHandler) AddOwnershipTransfer(core.ID, string) {
+}
+
+// ClearOwnershipTransfers is a fake implementation of ClearOwnershipTransfers of conflict.Handler.
+func (h *ConflictHandler) ClearOwnershipTransfers() {
+}
+
+// OwnershipTransfers is a fake implementation of OwnershipTransfers of conflict.Handler.
+func (h *ConflictHandler) OwnershipTransfers() []conflict.OwnershipTransfer {
+	return nil
+}
+
 var _ conflict.Handler = &ConflictHandler{}
 
 // NewConflictHandler initiates a fake implementation of conflict.Handler.
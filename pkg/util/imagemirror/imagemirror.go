@@ -0,0 +1,68 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package imagemirror rewrites container image references to pull from a
+// registry mirror, for clusters without access to the public registries.
+package imagemirror
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/name"
+)
+
+// Validate returns an error if the mirror is not a valid registry prefix, for
+// example "registry.example.com/config-sync".
+func Validate(mirror string) error {
+	trimmed := strings.TrimSuffix(mirror, "/")
+	if trimmed == "" {
+		return fmt.Errorf("invalid image registry mirror %q: must not be empty", mirror)
+	}
+	if strings.ContainsAny(trimmed, "@") {
+		return fmt.Errorf("invalid image registry mirror %q: must not contain a digest", mirror)
+	}
+	// Validate the mirror as the repository of an arbitrary image.
+	if _, err := name.NewRepository(trimmed + "/image"); err != nil {
+		return fmt.Errorf("invalid image registry mirror %q: %w", mirror, err)
+	}
+	return nil
+}
+
+// Rewrite replaces the registry and repository path of the image with the
+// mirror, keeping the image name, tag and digest.
+//
+// For example, with the mirror "registry.example.com/config-sync", the image
+// "gcr.io/config-management-release/reconciler:v1.20.0" is rewritten to
+// "registry.example.com/config-sync/reconciler:v1.20.0".
+//
+// The image is returned unchanged if the mirror or the image is empty.
+func Rewrite(image, mirror string) string {
+	mirror = strings.TrimSuffix(mirror, "/")
+	if mirror == "" || image == "" {
+		return image
+	}
+	// The repository ends before the digest, or before the tag, if any. The
+	// registry host may also contain a port separated by a colon.
+	repoEnd := len(image)
+	if i := strings.Index(image, "@"); i >= 0 {
+		repoEnd = i
+	}
+	if i := strings.LastIndex(image[:repoEnd], ":"); i > strings.LastIndex(image[:repoEnd], "/") {
+		repoEnd = i
+	}
+	repo := image[:repoEnd]
+	imageName := repo[strings.LastIndex(repo, "/")+1:]
+	return mirror + "/" + imageName + image[repoEnd:]
+}
@@ -0,0 +1,86 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package imagemirror
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRewrite(t *testing.T) {
+	testCases := []struct {
+		name   string
+		image  string
+		mirror string
+		want   string
+	}{
+		{
+			name:   "no mirror",
+			image:  "gcr.io/config-management-release/reconciler:v1.20.0",
+			mirror: "",
+			want:   "gcr.io/config-management-release/reconciler:v1.20.0",
+		},
+		{
+			name:   "tag",
+			image:  "gcr.io/config-management-release/reconciler:v1.20.0",
+			mirror: "registry.example.com/config-sync",
+			want:   "registry.example.com/config-sync/reconciler:v1.20.0",
+		},
+		{
+			name:   "trailing slash",
+			image:  "gcr.io/config-management-release/git-sync:v4.2.3-gke.5__linux_amd64",
+			mirror: "registry.example.com/",
+			want:   "registry.example.com/git-sync:v4.2.3-gke.5__linux_amd64",
+		},
+		{
+			name:   "digest",
+			image:  "gcr.io/config-management-release/otelcontribcol@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+			mirror: "registry.example.com:5000/cs",
+			want:   "registry.example.com:5000/cs/otelcontribcol@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+		},
+		{
+			name:   "tag and digest",
+			image:  "gcr.io/a/b/helm-sync:v1@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+			mirror: "mirror.local",
+			want:   "mirror.local/helm-sync:v1@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+		},
+		{
+			name:   "registry port without tag",
+			image:  "localhost:5000/oci-sync",
+			mirror: "mirror.local",
+			want:   "mirror.local/oci-sync",
+		},
+		{
+			name:   "no registry",
+			image:  "reconciler:latest",
+			mirror: "mirror.local",
+			want:   "mirror.local/reconciler:latest",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, Rewrite(tc.image, tc.mirror))
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	assert.NoError(t, Validate("registry.example.com/config-sync"))
+	assert.NoError(t, Validate("localhost:5000/"))
+	assert.Error(t, Validate("/"))
+	assert.Error(t, Validate("registry.example.com/Config-Sync"))
+	assert.Error(t, Validate("registry.example.com/cs@sha256:abc"))
+}
@@ -16,6 +16,7 @@ package fileobjects
 
 import (
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/klog/v2"
 	"kpt.dev/configsync/pkg/declared"
@@ -25,6 +26,7 @@ import (
 	"kpt.dev/configsync/pkg/reconciler/namespacecontroller"
 	"kpt.dev/configsync/pkg/status"
 	utildiscovery "kpt.dev/configsync/pkg/util/discovery"
+	"kpt.dev/configsync/pkg/validate/variables"
 )
 
 // RawVisitor is a function that validates or hydrates Raw objects.
@@ -36,7 +38,14 @@ type ObjectVisitor func(obj ast.FileObject) status.Error
 // Raw contains a collection of FileObjects that have just been parsed from a
 // Git repo for a cluster.
 type Raw struct {
-	ClusterName       string
+	ClusterName string
+	// ClusterLabels are the labels of the cluster read from the cluster or a
+	// local file. ClusterSelectors match them in addition to the labels of the
+	// Cluster object declared in the repository.
+	ClusterLabels labels.Set
+	// Variables are the cluster variables substituted in the objects which
+	// enable the substitution.
+	Variables         variables.Variables
 	Scope             declared.Scope
 	SyncName          string
 	PolicyDir         cmpath.Relative
@@ -0,0 +1,116 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	protoschema "k8s.io/kube-openapi/pkg/util/proto"
+)
+
+// crdResources are the OpenAPI schemas of the versions of CRDs.
+type crdResources map[schema.GroupVersionKind]protoschema.Schema
+
+var _ Resources = crdResources{}
+
+// newCRDResources returns the schemas of the versions of the CRDs.
+func newCRDResources(crds []*apiextensionsv1.CustomResourceDefinition) crdResources {
+	resources := make(crdResources)
+	for _, crd := range crds {
+		for _, version := range crd.Spec.Versions {
+			gvk := schema.GroupVersionKind{
+				Group:   crd.Spec.Group,
+				Version: version.Name,
+				Kind:    crd.Spec.Names.Kind,
+			}
+			var props *apiextensionsv1.JSONSchemaProps
+			if version.Schema != nil {
+				props = version.Schema.OpenAPIV3Schema
+			}
+			resources[gvk] = rootSchema(props, protoschema.NewPath(gvk.Kind))
+		}
+	}
+	return resources
+}
+
+// LookupResource implements Resources.
+func (r crdResources) LookupResource(gvk schema.GroupVersionKind) protoschema.Schema {
+	return r[gvk]
+}
+
+// rootSchema returns the schema of the custom resources. The type and object
+// metadata are implicitly allowed, and the object metadata is validated by the
+// API server rather than by the schema.
+func rootSchema(props *apiextensionsv1.JSONSchemaProps, path protoschema.Path) protoschema.Schema {
+	if props == nil {
+		return &protoschema.Arbitrary{BaseSchema: protoschema.BaseSchema{Path: path}}
+	}
+	s := convert(props, path)
+	kind, isKind := s.(*protoschema.Kind)
+	if !isKind {
+		return s
+	}
+	for _, field := range []string{"apiVersion", "kind"} {
+		if _, found := kind.Fields[field]; !found {
+			kind.Fields[field] = &protoschema.Primitive{
+				BaseSchema: protoschema.BaseSchema{Path: path.FieldPath(field)},
+				Type:       protoschema.String,
+			}
+		}
+	}
+	kind.Fields["metadata"] = &protoschema.Arbitrary{BaseSchema: protoschema.BaseSchema{Path: path.FieldPath("metadata")}}
+	return kind
+}
+
+// convert returns the schema declared by the structural schema of a CRD.
+// Unknown fields are allowed where the schema preserves them, and fields which
+// accept several types are not validated.
+func convert(props *apiextensionsv1.JSONSchemaProps, path protoschema.Path) protoschema.Schema {
+	base := protoschema.BaseSchema{Path: path, Description: props.Description}
+	if props.XIntOrString || props.XEmbeddedResource ||
+		(props.XPreserveUnknownFields != nil && *props.XPreserveUnknownFields) {
+		return &protoschema.Arbitrary{BaseSchema: base}
+	}
+	switch props.Type {
+	case "object":
+		if len(props.Properties) > 0 {
+			kind := &protoschema.Kind{
+				BaseSchema:     base,
+				Fields:         make(map[string]protoschema.Schema, len(props.Properties)),
+				RequiredFields: props.Required,
+			}
+			for name := range props.Properties {
+				field := props.Properties[name]
+				kind.Fields[name] = convert(&field, path.FieldPath(name))
+			}
+			return kind
+		}
+		subType := protoschema.Schema(&protoschema.Arbitrary{BaseSchema: protoschema.BaseSchema{Path: path}})
+		if props.AdditionalProperties != nil && props.AdditionalProperties.Schema != nil {
+			subType = convert(props.AdditionalProperties.Schema, path)
+		}
+		return &protoschema.Map{BaseSchema: base, SubType: subType}
+	case "array":
+		subType := protoschema.Schema(&protoschema.Arbitrary{BaseSchema: protoschema.BaseSchema{Path: path}})
+		if props.Items != nil && props.Items.Schema != nil {
+			subType = convert(props.Items.Schema, path)
+		}
+		return &protoschema.Array{BaseSchema: base, SubType: subType}
+	case protoschema.String, protoschema.Integer, protoschema.Number, protoschema.Boolean:
+		return &protoschema.Primitive{BaseSchema: base, Type: props.Type, Format: props.Format}
+	default:
+		return &protoschema.Arbitrary{BaseSchema: base}
+	}
+}
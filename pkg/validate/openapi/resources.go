@@ -0,0 +1,165 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package openapi validates the declared objects against the OpenAPI schemas
+// of their kinds, so that malformed objects are rejected before any object is
+// applied.
+package openapi
+
+import (
+	"fmt"
+	"sync"
+
+	// The openapi Document type does not satisfy the proto.Message interface in
+	// the new non-deprecated proto library.
+	"github.com/golang/protobuf/proto" //nolint:staticcheck
+	openapiv2 "github.com/google/gnostic-models/openapiv2"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/klog/v2"
+	protoschema "k8s.io/kube-openapi/pkg/util/proto"
+	"k8s.io/kubectl/pkg/util/openapi"
+)
+
+// Resources looks up the OpenAPI schemas of the resources.
+type Resources interface {
+	// LookupResource returns the schema of the resource, or nil if the
+	// resource is unknown.
+	LookupResource(gvk schema.GroupVersionKind) protoschema.Schema
+}
+
+// Source pulls the OpenAPI schemas of the resources served by the cluster.
+// It is implemented by declared.ValueConverter, so that the schemas pulled for
+// the declared fields are shared, rather than held twice in memory.
+type Source interface {
+	// Refresh pulls the schemas from the cluster again.
+	Refresh() error
+	// OpenAPIResources returns the schemas last pulled, or nil if none were
+	// pulled yet.
+	OpenAPIResources() openapi.Resources
+}
+
+// ClusterResources are the OpenAPI schemas of the resources served by the
+// cluster. The schemas are pulled from the discovery endpoint when they are
+// first looked up, and pulled again when a resource is unknown, as its CRD may
+// have been established since. A resource which is still unknown after the
+// schemas are pulled again is not looked up anymore until the schemas are
+// refreshed, so that the objects of kinds without schemas are validated by the
+// API server only, rather than pulling the schemas on every validation.
+type ClusterResources struct {
+	source Source
+
+	mux     sync.Mutex
+	unknown map[schema.GroupVersionKind]struct{}
+}
+
+var _ Resources = &ClusterResources{}
+
+// NewClusterResources returns the ClusterResources pulled with the given
+// discovery client.
+func NewClusterResources(client discovery.OpenAPISchemaInterface) *ClusterResources {
+	return NewSourceResources(&discoverySource{client: client})
+}
+
+// NewSourceResources returns the ClusterResources pulled from the given Source.
+func NewSourceResources(source Source) *ClusterResources {
+	return &ClusterResources{
+		source:  source,
+		unknown: make(map[schema.GroupVersionKind]struct{}),
+	}
+}
+
+// LookupResource implements Resources. The resource is unknown if the schemas
+// can't be pulled, so that the objects are validated by the API server only.
+func (r *ClusterResources) LookupResource(gvk schema.GroupVersionKind) protoschema.Schema {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	if _, unknown := r.unknown[gvk]; unknown {
+		return nil
+	}
+	if resources := r.source.OpenAPIResources(); resources != nil {
+		if s := resources.LookupResource(gvk); s != nil {
+			return s
+		}
+	}
+	if err := r.source.Refresh(); err != nil {
+		klog.Warningf("Failed to pull the OpenAPI schemas from the cluster: %v", err)
+	}
+	resources := r.source.OpenAPIResources()
+	if resources == nil {
+		return nil
+	}
+	s := resources.LookupResource(gvk)
+	if s == nil {
+		r.unknown[gvk] = struct{}{}
+	}
+	return s
+}
+
+// Refresh pulls the schemas from the cluster again, as the schemas of known
+// resources may have changed since, and looks up the unknown resources again.
+func (r *ClusterResources) Refresh() error {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	if err := r.source.Refresh(); err != nil {
+		return err
+	}
+	r.unknown = make(map[schema.GroupVersionKind]struct{})
+	return nil
+}
+
+// discoverySource is a Source pulling the schemas with a discovery client.
+type discoverySource struct {
+	client    discovery.OpenAPISchemaInterface
+	resources openapi.Resources
+}
+
+// Refresh implements Source.
+func (s *discoverySource) Refresh() error {
+	doc, err := s.client.OpenAPISchema()
+	if err != nil {
+		return err
+	}
+	resources, err := openapi.NewOpenAPIData(doc)
+	if err != nil {
+		return err
+	}
+	s.resources = resources
+	return nil
+}
+
+// OpenAPIResources implements Source.
+func (s *discoverySource) OpenAPIResources() openapi.Resources {
+	return s.resources
+}
+
+// FromBundle returns the OpenAPI schemas declared in an offline schema bundle,
+// an OpenAPI v2 document in JSON, YAML or protobuf, as served by the
+// /openapi/v2 endpoint of the API server.
+func FromBundle(data []byte, source string) (Resources, error) {
+	doc, err := openapiv2.ParseDocument(data)
+	if err != nil {
+		doc = &openapiv2.Document{}
+		if protoErr := proto.Unmarshal(data, doc); protoErr != nil {
+			return nil, fmt.Errorf("invalid OpenAPI v2 document in %s: %w", source, err)
+		}
+	}
+	resources, err := openapi.NewOpenAPIData(doc)
+	if err != nil {
+		return nil, fmt.Errorf("invalid OpenAPI v2 document in %s: %w", source, err)
+	}
+	return resources, nil
+}
@@ -0,0 +1,132 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/klog/v2"
+	"k8s.io/kube-openapi/pkg/util/proto/validation"
+	"kpt.dev/configsync/pkg/importer/analyzer/ast"
+	"kpt.dev/configsync/pkg/status"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Validator validates the fields, types and required properties of objects
+// against the OpenAPI schemas of their kinds.
+type Validator struct {
+	declared crdResources
+	cluster  Resources
+}
+
+// NewValidator returns a Validator which validates the objects against the
+// schemas of the CRDs declared in the source, which take precedence as they
+// are applied with the objects, and against the schemas known to the cluster.
+func NewValidator(cluster Resources, crds []*apiextensionsv1.CustomResourceDefinition) *Validator {
+	return &Validator{
+		declared: newCRDResources(crds),
+		cluster:  cluster,
+	}
+}
+
+// Validate returns an error for each field of the objects which doesn't match
+// the schema of their kind, and a warning for each unknown field. Unknown
+// fields don't block the objects, as the schemas may lag behind the API server,
+// which prunes or rejects the fields when the objects are applied. The objects
+// of unknown kinds are not validated.
+//
+// If an object doesn't match the schema served by the cluster, the schemas are
+// refreshed and the object validated again before reporting the errors, as its
+// CRD may have been updated since the schemas were pulled.
+func (v *Validator) Validate(objs []ast.FileObject) (errs, warnings status.MultiError) {
+	// stale are the objects which don't match the schemas of the cluster.
+	var stale []ast.FileObject
+	var staleErrs, staleWarnings status.MultiError
+	for _, obj := range objs {
+		objErrs, objWarnings, fromCluster := v.validate(obj)
+		if objErrs != nil && fromCluster {
+			stale = append(stale, obj)
+			staleErrs = status.Append(staleErrs, objErrs)
+			staleWarnings = status.Append(staleWarnings, objWarnings)
+			continue
+		}
+		errs = status.Append(errs, objErrs)
+		warnings = status.Append(warnings, objWarnings)
+	}
+	if r, ok := v.cluster.(refresher); ok && len(stale) > 0 {
+		klog.Info("Declared objects do not match the OpenAPI schemas of the cluster. Refreshing the schemas before validating them again")
+		if err := r.Refresh(); err != nil {
+			klog.Warningf("Failed to refresh the OpenAPI schemas: %v", err)
+		} else {
+			staleErrs, staleWarnings = nil, nil
+			for _, obj := range stale {
+				objErrs, objWarnings, _ := v.validate(obj)
+				staleErrs = status.Append(staleErrs, objErrs)
+				staleWarnings = status.Append(staleWarnings, objWarnings)
+			}
+		}
+	}
+	return status.Append(errs, staleErrs), status.Append(warnings, staleWarnings)
+}
+
+// validate returns the errors and warnings for the object, and whether it was
+// validated against the schemas of the cluster rather than a declared CRD.
+func (v *Validator) validate(obj ast.FileObject) (errs, warnings status.MultiError, fromCluster bool) {
+	gvk := obj.GetObjectKind().GroupVersionKind()
+	s := v.declared.LookupResource(gvk)
+	if s == nil && v.cluster != nil {
+		s = v.cluster.LookupResource(gvk)
+		fromCluster = s != nil
+	}
+	if s == nil {
+		return nil, nil, false
+	}
+	for _, err := range validation.ValidateModel(obj.Unstructured.Object, s, gvk.Kind) {
+		if isUnknownField(err) {
+			warnings = status.Append(warnings, SchemaError(obj, err))
+		} else {
+			errs = status.Append(errs, SchemaError(obj, err))
+		}
+	}
+	return errs, warnings, fromCluster
+}
+
+// refresher is implemented by the Resources which can pull the schemas again.
+type refresher interface {
+	Refresh() error
+}
+
+// isUnknownField returns whether the validation error reports a field which is
+// not declared in the schema.
+func isUnknownField(err error) bool {
+	if vErr, ok := err.(validation.ValidationError); ok {
+		err = vErr.Err
+	}
+	_, ok := err.(validation.UnknownFieldError)
+	return ok
+}
+
+// SchemaErrorCode is the error code for an object which doesn't match the
+// OpenAPI schema of its kind.
+const SchemaErrorCode = "1076"
+
+var schemaErrorBuilder = status.NewErrorBuilder(SchemaErrorCode)
+
+// SchemaError reports that a field of the object doesn't match the OpenAPI
+// schema of its kind.
+func SchemaError(obj client.Object, err error) status.Error {
+	return schemaErrorBuilder.
+		Sprintf("%s does not match the schema of its kind: %v", obj.GetObjectKind().GroupVersionKind().Kind, err).
+		BuildWithResources(obj)
+}
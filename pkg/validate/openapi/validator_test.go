@@ -0,0 +1,384 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto" //nolint:staticcheck
+	openapiv2 "github.com/google/gnostic-models/openapiv2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	protoschema "k8s.io/kube-openapi/pkg/util/proto"
+	"k8s.io/kubectl/pkg/util/openapi"
+	"k8s.io/utils/ptr"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/core/k8sobjects"
+	"kpt.dev/configsync/pkg/importer/analyzer/ast"
+	"kpt.dev/configsync/pkg/kinds"
+	"kpt.dev/configsync/pkg/status"
+	"kpt.dev/configsync/pkg/testing/openapitest"
+)
+
+var anvilGVK = schema.GroupVersionKind{Group: "acme.com", Version: "v1", Kind: "Anvil"}
+
+func anvilCRD() *apiextensionsv1.CustomResourceDefinition {
+	crd := k8sobjects.CRDV1ObjectForGVK(anvilGVK, apiextensionsv1.NamespaceScoped)
+	crd.Spec.Versions[0].Schema = &apiextensionsv1.CustomResourceValidation{
+		OpenAPIV3Schema: &apiextensionsv1.JSONSchemaProps{
+			Type:     "object",
+			Required: []string{"spec"},
+			Properties: map[string]apiextensionsv1.JSONSchemaProps{
+				"spec": {
+					Type:     "object",
+					Required: []string{"weight"},
+					Properties: map[string]apiextensionsv1.JSONSchemaProps{
+						"weight": {Type: "integer"},
+						"labels": {
+							Type:                 "object",
+							AdditionalProperties: &apiextensionsv1.JSONSchemaPropsOrBool{Schema: &apiextensionsv1.JSONSchemaProps{Type: "string"}},
+						},
+						"parts": {
+							Type:  "array",
+							Items: &apiextensionsv1.JSONSchemaPropsOrArray{Schema: &apiextensionsv1.JSONSchemaProps{Type: "string"}},
+						},
+						"size":   {XIntOrString: true},
+						"config": {Type: "object", XPreserveUnknownFields: ptr.To(true)},
+					},
+				},
+			},
+		},
+	}
+	return crd
+}
+
+func object(gvk schema.GroupVersionKind, fields map[string]interface{}) ast.FileObject {
+	obj := k8sobjects.Unstructured(gvk, core.Name("obj"), core.Namespace("foo"))
+	for k, v := range fields {
+		obj.Unstructured.Object[k] = v
+	}
+	return obj
+}
+
+func TestValidator(t *testing.T) {
+	doc, err := openapitest.Doc()
+	require.NoError(t, err)
+	cluster, err := openapi.NewOpenAPIData(doc)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name         string
+		objs         []ast.FileObject
+		wantMessages []string
+		wantWarnings []string
+	}{
+		{
+			name: "valid objects",
+			objs: []ast.FileObject{
+				object(kinds.Deployment(), map[string]interface{}{
+					"spec": map[string]interface{}{
+						"replicas": int64(1),
+						"selector": map[string]interface{}{
+							"matchLabels": map[string]interface{}{"app": "web"},
+						},
+						"template": map[string]interface{}{
+							"spec": map[string]interface{}{
+								"containers": []interface{}{
+									map[string]interface{}{"name": "web", "image": "nginx"},
+								},
+							},
+						},
+					},
+				}),
+				object(kinds.ConfigMap(), map[string]interface{}{
+					"data": map[string]interface{}{"key": "value"},
+				}),
+				object(anvilGVK, map[string]interface{}{
+					"spec": map[string]interface{}{
+						"weight": int64(10),
+						"labels": map[string]interface{}{"color": "red"},
+						"parts":  []interface{}{"head"},
+						"size":   "10%",
+						"config": map[string]interface{}{"anything": true},
+					},
+				}),
+			},
+		},
+		{
+			name: "objects of unknown kinds",
+			objs: []ast.FileObject{
+				object(schema.GroupVersionKind{Group: "acme.com", Version: "v2", Kind: "Anvil"}, map[string]interface{}{
+					"spec": "invalid",
+				}),
+			},
+		},
+		{
+			name: "invalid builtin object",
+			objs: []ast.FileObject{
+				object(kinds.Deployment(), map[string]interface{}{
+					"spec": map[string]interface{}{
+						"replicas": "one",
+						"paused":   "yes",
+						"unknown":  true,
+					},
+				}),
+			},
+			wantMessages: []string{
+				`ValidationError(Deployment.spec.paused): invalid type for io.k8s.api.apps.v1.DeploymentSpec.paused: got "string", expected "boolean"`,
+				`ValidationError(Deployment.spec.replicas): invalid type for io.k8s.api.apps.v1.DeploymentSpec.replicas: got "string", expected "integer"`,
+				`ValidationError(Deployment.spec): missing required field "selector" in io.k8s.api.apps.v1.DeploymentSpec`,
+				`ValidationError(Deployment.spec): missing required field "template" in io.k8s.api.apps.v1.DeploymentSpec`,
+			},
+			wantWarnings: []string{
+				`ValidationError(Deployment.spec): unknown field "unknown" in io.k8s.api.apps.v1.DeploymentSpec`,
+			},
+		},
+		{
+			name: "invalid custom resources",
+			objs: []ast.FileObject{
+				object(anvilGVK, nil),
+				object(anvilGVK, map[string]interface{}{
+					"spec": map[string]interface{}{
+						"weight": "heavy",
+						"labels": map[string]interface{}{"color": []interface{}{"red"}},
+						"parts":  "head",
+						"shape":  "round",
+					},
+				}),
+			},
+			wantMessages: []string{
+				`ValidationError(Anvil): missing required field "spec" in Anvil`,
+				`ValidationError(Anvil.spec.labels.color): invalid type for Anvil.spec.labels: got "array", expected "string"`,
+				`ValidationError(Anvil.spec.parts): invalid type for Anvil.spec.parts: got "string", expected "array"`,
+				`ValidationError(Anvil.spec.weight): invalid type for Anvil.spec.weight: got "string", expected "integer"`,
+			},
+			wantWarnings: []string{
+				`ValidationError(Anvil.spec): unknown field "shape" in Anvil.spec`,
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			v := NewValidator(cluster, []*apiextensionsv1.CustomResourceDefinition{anvilCRD()})
+			errs, warnings := v.Validate(tc.objs)
+			assertSchemaErrors(t, tc.wantMessages, errs)
+			assertSchemaErrors(t, tc.wantWarnings, warnings)
+		})
+	}
+}
+
+func assertSchemaErrors(t *testing.T, wantMessages []string, errs status.MultiError) {
+	t.Helper()
+	if len(wantMessages) == 0 {
+		require.NoError(t, errs)
+		return
+	}
+	require.Error(t, errs)
+	var got []string
+	for _, err := range errs.Errors() {
+		assert.Equal(t, SchemaErrorCode, err.Code())
+		got = append(got, err.Error())
+	}
+	require.Len(t, got, len(wantMessages))
+	for i, msg := range wantMessages {
+		assert.Contains(t, got[i], msg)
+	}
+}
+
+func TestValidatorDeclaredCRDTakesPrecedence(t *testing.T) {
+	doc, err := openapitest.Doc()
+	require.NoError(t, err)
+	cluster, err := openapi.NewOpenAPIData(doc)
+	require.NoError(t, err)
+
+	// The declared CRD replaces the schema of the ConfigMaps served by the
+	// cluster, for which data is not an integer.
+	gvk := kinds.ConfigMap()
+	crd := k8sobjects.CRDV1ObjectForGVK(gvk, apiextensionsv1.NamespaceScoped)
+	crd.Spec.Versions[0].Schema = &apiextensionsv1.CustomResourceValidation{
+		OpenAPIV3Schema: &apiextensionsv1.JSONSchemaProps{
+			Type:       "object",
+			Properties: map[string]apiextensionsv1.JSONSchemaProps{"data": {Type: "integer"}},
+		},
+	}
+	cm := object(gvk, map[string]interface{}{"data": int64(1)})
+	v := NewValidator(cluster, []*apiextensionsv1.CustomResourceDefinition{crd})
+	errs, warnings := v.Validate([]ast.FileObject{cm})
+	assert.NoError(t, errs)
+	assert.NoError(t, warnings)
+}
+
+// staleResources serves stale schemas until refreshed.
+type staleResources struct {
+	stale, fresh Resources
+	refreshes    int
+}
+
+func (r *staleResources) LookupResource(gvk schema.GroupVersionKind) protoschema.Schema {
+	if r.refreshes == 0 {
+		return r.stale.LookupResource(gvk)
+	}
+	return r.fresh.LookupResource(gvk)
+}
+
+func (r *staleResources) Refresh() error {
+	r.refreshes++
+	return nil
+}
+
+func TestValidatorRefreshesStaleSchemas(t *testing.T) {
+	doc, err := openapitest.Doc()
+	require.NoError(t, err)
+	fresh, err := openapi.NewOpenAPIData(doc)
+	require.NoError(t, err)
+	// The stale schema of the ConfigMaps declares data as an integer.
+	crd := k8sobjects.CRDV1ObjectForGVK(kinds.ConfigMap(), apiextensionsv1.NamespaceScoped)
+	crd.Spec.Versions[0].Schema = &apiextensionsv1.CustomResourceValidation{
+		OpenAPIV3Schema: &apiextensionsv1.JSONSchemaProps{
+			Type:       "object",
+			Properties: map[string]apiextensionsv1.JSONSchemaProps{"data": {Type: "integer"}},
+		},
+	}
+	stale := newCRDResources([]*apiextensionsv1.CustomResourceDefinition{crd})
+
+	testCases := []struct {
+		name          string
+		obj           ast.FileObject
+		wantRefreshes int
+		wantErr       bool
+	}{
+		{
+			name: "valid object",
+			obj: object(kinds.Deployment(), map[string]interface{}{
+				"spec": map[string]interface{}{"replicas": int64(1)},
+			}),
+		},
+		{
+			name: "object matching the refreshed schema",
+			obj: object(kinds.ConfigMap(), map[string]interface{}{
+				"data": map[string]interface{}{"key": "value"},
+			}),
+			wantRefreshes: 1,
+		},
+		{
+			name: "object matching neither schema",
+			obj: object(kinds.ConfigMap(), map[string]interface{}{
+				"data": true,
+			}),
+			wantRefreshes: 1,
+			wantErr:       true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cluster := &staleResources{stale: stale, fresh: fresh}
+			errs, _ := NewValidator(cluster, nil).Validate([]ast.FileObject{tc.obj})
+			assert.Equal(t, tc.wantRefreshes, cluster.refreshes)
+			if tc.wantErr {
+				assert.Error(t, errs)
+			} else {
+				assert.NoError(t, errs)
+			}
+		})
+	}
+}
+
+// fakeSchemaClient serves the OpenAPI schemas and counts the pulls.
+type fakeSchemaClient struct {
+	pulls int
+}
+
+func (c *fakeSchemaClient) OpenAPISchema() (*openapiv2.Document, error) {
+	c.pulls++
+	return openapitest.Doc()
+}
+
+func TestClusterResources(t *testing.T) {
+	client := &fakeSchemaClient{}
+	resources := NewClusterResources(client)
+
+	require.NotNil(t, resources.LookupResource(kinds.ConfigMap()))
+	require.NotNil(t, resources.LookupResource(kinds.Deployment()))
+	assert.Equal(t, 1, client.pulls)
+
+	// The schemas are pulled again for an unknown resource, but only once.
+	for range 3 {
+		assert.Nil(t, resources.LookupResource(anvilGVK))
+	}
+	assert.Equal(t, 2, client.pulls)
+	require.NotNil(t, resources.LookupResource(kinds.ConfigMap()))
+	assert.Equal(t, 2, client.pulls)
+
+	// Refreshing the schemas looks up the unknown resources again.
+	require.NoError(t, resources.Refresh())
+	assert.Equal(t, 3, client.pulls)
+	assert.Nil(t, resources.LookupResource(anvilGVK))
+	assert.Equal(t, 4, client.pulls)
+}
+
+func TestFromBundle(t *testing.T) {
+	cm := object(kinds.ConfigMap(), map[string]interface{}{
+		"unknown": true,
+	})
+	testCases := []struct {
+		name       string
+		data       func(t *testing.T) []byte
+		wantErrMsg string
+	}{
+		{
+			name: "protobuf",
+			data: func(t *testing.T) []byte {
+				doc, err := openapitest.Doc()
+				require.NoError(t, err)
+				data, err := proto.Marshal(doc)
+				require.NoError(t, err)
+				return data
+			},
+		},
+		{
+			name: "yaml",
+			data: func(t *testing.T) []byte {
+				doc, err := openapitest.Doc()
+				require.NoError(t, err)
+				data, err := doc.YAMLValue("")
+				require.NoError(t, err)
+				return data
+			},
+		},
+		{
+			name: "invalid",
+			data: func(*testing.T) []byte {
+				return []byte("swagger: [")
+			},
+			wantErrMsg: "invalid OpenAPI v2 document in bundle.json",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resources, err := FromBundle(tc.data(t), "bundle.json")
+			if tc.wantErrMsg != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.wantErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			_, warnings := NewValidator(resources, nil).Validate([]ast.FileObject{cm})
+			require.Error(t, warnings)
+			assert.Len(t, warnings.Errors(), 1)
+		})
+	}
+}
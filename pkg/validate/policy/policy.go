@@ -0,0 +1,251 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package policy implements the ValidationPolicies, which declare CEL rules
+// that the objects must satisfy to be synced.
+package policy
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/importer/analyzer/ast"
+	"kpt.dev/configsync/pkg/kinds"
+	"kpt.dev/configsync/pkg/status"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// Kind is the kind of the ValidationPolicy objects.
+	Kind = "ValidationPolicy"
+	// ConfigMapName is the name of the ConfigMap in the config-management-system
+	// namespace which declares the ValidationPolicies enforced by all the
+	// reconcilers. Each data value is a ValidationPolicy.
+	ConfigMapName = "config-sync-validation-policies"
+)
+
+// GroupVersionKind is the GroupVersionKind of the ValidationPolicy objects.
+// ValidationPolicies declared in the source of truth are used to validate the
+// other objects, and are never applied to the cluster.
+var GroupVersionKind = schema.GroupVersionKind{
+	Group:   configsync.GroupName,
+	Version: "v1alpha1",
+	Kind:    Kind,
+}
+
+// ValidationPolicy declares the CEL rules that the objects must satisfy.
+type ValidationPolicy struct {
+	// Name is the name of the ValidationPolicy.
+	Name string `json:"-"`
+	// Source is where the ValidationPolicy is declared, used in errors.
+	Source string `json:"-"`
+	// Spec is the spec of the ValidationPolicy.
+	Spec ValidationPolicySpec `json:"spec"`
+}
+
+// ValidationPolicySpec is the spec of a ValidationPolicy.
+type ValidationPolicySpec struct {
+	// Rules are the rules that the matching objects must satisfy.
+	Rules []Rule `json:"rules"`
+}
+
+// Rule is a CEL expression that the matching objects must satisfy.
+type Rule struct {
+	// Name is the name of the rule, unique within the ValidationPolicy.
+	Name string `json:"name"`
+	// Match selects the objects validated by the rule. All the objects are
+	// validated if empty.
+	Match Match `json:"match,omitempty"`
+	// Expression is the CEL expression evaluated against each matching
+	// object, bound to the `object` variable. The object violates the rule
+	// if the expression evaluates to false or fails to evaluate, so use
+	// `has()` to test optional fields.
+	Expression string `json:"expression"`
+	// Message is the message reported when an object violates the rule.
+	Message string `json:"message,omitempty"`
+}
+
+// Match selects objects by their API group and kind. An empty list or `*`
+// matches all values.
+type Match struct {
+	// APIGroups are the API groups of the matching objects. The core group
+	// is the empty string.
+	APIGroups []string `json:"apiGroups,omitempty"`
+	// Kinds are the kinds of the matching objects.
+	Kinds []string `json:"kinds,omitempty"`
+}
+
+// Extract removes the ValidationPolicy objects from the objects, and returns
+// the remaining objects and the parsed ValidationPolicies.
+func Extract(objs []ast.FileObject) ([]ast.FileObject, []*ValidationPolicy, status.MultiError) {
+	var remaining []ast.FileObject
+	var policies []*ValidationPolicy
+	var errs status.MultiError
+	for _, obj := range objs {
+		if obj.GetObjectKind().GroupVersionKind().GroupKind() != GroupVersionKind.GroupKind() {
+			remaining = append(remaining, obj)
+			continue
+		}
+		p, err := fromUnstructured(obj.Unstructured, obj.SlashPath())
+		if err != nil {
+			errs = status.Append(errs, InvalidPolicyError(obj.SlashPath(), err))
+			continue
+		}
+		policies = append(policies, p)
+	}
+	return remaining, policies, errs
+}
+
+// FromConfigMap returns the ValidationPolicies declared in the data of the
+// ConfigMap. The policies are ordered by their data key.
+func FromConfigMap(cm *corev1.ConfigMap) ([]*ValidationPolicy, status.MultiError) {
+	keys := make([]string, 0, len(cm.Data))
+	for k := range cm.Data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var policies []*ValidationPolicy
+	var errs status.MultiError
+	for _, k := range keys {
+		source := fmt.Sprintf("ConfigMap %s/%s key %s", cm.Namespace, cm.Name, k)
+		ps, err := decode([]byte(cm.Data[k]), source)
+		if err != nil {
+			errs = status.Append(errs, InvalidPolicyError(source, err))
+			continue
+		}
+		policies = append(policies, ps...)
+	}
+	return policies, errs
+}
+
+// FromFile returns the ValidationPolicies declared in a file, which may
+// contain ValidationPolicies or the ConfigMap which declares them, for example
+// exported from a cluster.
+func FromFile(data []byte, source string) ([]*ValidationPolicy, status.MultiError) {
+	objs, err := decodeObjects(data)
+	if err != nil {
+		return nil, InvalidPolicyError(source, err)
+	}
+	var policies []*ValidationPolicy
+	var errs status.MultiError
+	for _, u := range objs {
+		switch u.GroupVersionKind().GroupKind() {
+		case GroupVersionKind.GroupKind():
+			p, err := fromUnstructured(u, source)
+			if err != nil {
+				errs = status.Append(errs, InvalidPolicyError(source, err))
+				continue
+			}
+			policies = append(policies, p)
+		case kinds.ConfigMap().GroupKind():
+			cm := &corev1.ConfigMap{}
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, cm); err != nil {
+				errs = status.Append(errs, InvalidPolicyError(source, err))
+				continue
+			}
+			ps, err := FromConfigMap(cm)
+			errs = status.Append(errs, err)
+			policies = append(policies, ps...)
+		default:
+			errs = status.Append(errs, InvalidPolicyError(source,
+				fmt.Errorf("unexpected kind %s, want %s or ConfigMap", u.GetKind(), Kind)))
+		}
+	}
+	return policies, errs
+}
+
+// FromCluster returns the ValidationPolicies declared in the ConfigMap
+// config-management-system/config-sync-validation-policies, or nil if the
+// ConfigMap doesn't exist.
+func FromCluster(ctx context.Context, c client.Reader) ([]*ValidationPolicy, status.MultiError) {
+	cm := &corev1.ConfigMap{}
+	key := client.ObjectKey{Namespace: configsync.ControllerNamespace, Name: ConfigMapName}
+	if err := c.Get(ctx, key, cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, status.APIServerError(err, fmt.Sprintf("unable to get the ValidationPolicies in ConfigMap %s", key))
+	}
+	return FromConfigMap(cm)
+}
+
+// decode parses the ValidationPolicies in the YAML documents.
+func decode(data []byte, source string) ([]*ValidationPolicy, error) {
+	objs, err := decodeObjects(data)
+	if err != nil {
+		return nil, err
+	}
+	var policies []*ValidationPolicy
+	for _, u := range objs {
+		if gk := u.GroupVersionKind().GroupKind(); gk != GroupVersionKind.GroupKind() {
+			return nil, fmt.Errorf("unexpected kind %s, want %s", u.GetKind(), Kind)
+		}
+		p, err := fromUnstructured(u, source)
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, p)
+	}
+	return policies, nil
+}
+
+// decodeObjects parses the objects in the YAML or JSON documents, skipping
+// the empty documents.
+func decodeObjects(data []byte) ([]*unstructured.Unstructured, error) {
+	decoder := utilyaml.NewYAMLOrJSONDecoder(bytes.NewReader(data), 4096)
+	var objs []*unstructured.Unstructured
+	for {
+		u := &unstructured.Unstructured{}
+		if err := decoder.Decode(&u.Object); err != nil {
+			if err == io.EOF {
+				return objs, nil
+			}
+			return nil, err
+		}
+		if len(u.Object) > 0 {
+			objs = append(objs, u)
+		}
+	}
+}
+
+// fromUnstructured converts the object to a ValidationPolicy.
+func fromUnstructured(u *unstructured.Unstructured, source string) (*ValidationPolicy, error) {
+	p := &ValidationPolicy{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, p); err != nil {
+		return nil, err
+	}
+	p.Name = u.GetName()
+	p.Source = source
+	names := make(map[string]bool, len(p.Spec.Rules))
+	for _, r := range p.Spec.Rules {
+		if r.Name == "" || r.Expression == "" {
+			return nil, fmt.Errorf("ValidationPolicy %q: rules must have a name and an expression", p.Name)
+		}
+		if names[r.Name] {
+			return nil, fmt.Errorf("ValidationPolicy %q: duplicate rule %q", p.Name, r.Name)
+		}
+		names[r.Name] = true
+	}
+	return p, nil
+}
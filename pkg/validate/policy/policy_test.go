@@ -0,0 +1,150 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/core/k8sobjects"
+	"kpt.dev/configsync/pkg/importer/analyzer/ast"
+	syncerFake "kpt.dev/configsync/pkg/syncer/syncertest/fake"
+)
+
+const guardrailsPolicy = `apiVersion: configsync.gke.io/v1alpha1
+kind: ValidationPolicy
+metadata:
+  name: guardrails
+spec:
+  rules:
+  - name: no-load-balancer
+    match:
+      kinds: [Service]
+    expression: object.spec.type != 'LoadBalancer'
+`
+
+func TestExtract(t *testing.T) {
+	p := k8sobjects.UnstructuredAtPath(GroupVersionKind, "system/guardrails.yaml", core.Name("guardrails"))
+	p.Object["spec"] = map[string]interface{}{
+		"rules": []interface{}{
+			map[string]interface{}{"name": "all", "expression": "true"},
+		},
+	}
+	invalid := k8sobjects.UnstructuredAtPath(GroupVersionKind, "system/invalid.yaml", core.Name("invalid"))
+	invalid.Object["spec"] = map[string]interface{}{
+		"rules": []interface{}{map[string]interface{}{"name": "no-expression"}},
+	}
+	cm := k8sobjects.ConfigMap(core.Name("cm"))
+
+	remaining, policies, errs := Extract([]ast.FileObject{p, cm, invalid})
+	require.Error(t, errs)
+	assert.Equal(t, InvalidPolicyErrorCode, errs.Errors()[0].Code())
+	assert.Equal(t, []ast.FileObject{cm}, remaining)
+	require.Len(t, policies, 1)
+	assert.Equal(t, &ValidationPolicy{
+		Name:   "guardrails",
+		Source: "system/guardrails.yaml",
+		Spec:   ValidationPolicySpec{Rules: []Rule{{Name: "all", Expression: "true"}}},
+	}, policies[0])
+}
+
+func TestFromFile(t *testing.T) {
+	want := &ValidationPolicy{
+		Name: "guardrails",
+		Spec: ValidationPolicySpec{Rules: []Rule{{
+			Name:       "no-load-balancer",
+			Match:      Match{Kinds: []string{"Service"}},
+			Expression: "object.spec.type != 'LoadBalancer'",
+		}}},
+	}
+	testCases := []struct {
+		name       string
+		data       string
+		wantSource string
+		wantErrMsg string
+	}{
+		{
+			name:       "ValidationPolicy",
+			data:       "---\n" + guardrailsPolicy,
+			wantSource: "policy.yaml",
+		},
+		{
+			name: "ConfigMap",
+			data: `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config-sync-validation-policies
+  namespace: config-management-system
+data:
+  guardrails.yaml: |
+    apiVersion: configsync.gke.io/v1alpha1
+    kind: ValidationPolicy
+    metadata:
+      name: guardrails
+    spec:
+      rules:
+      - name: no-load-balancer
+        match:
+          kinds: [Service]
+        expression: object.spec.type != 'LoadBalancer'
+`,
+			wantSource: "ConfigMap config-management-system/config-sync-validation-policies key guardrails.yaml",
+		},
+		{
+			name:       "unexpected kind",
+			data:       "apiVersion: v1\nkind: Namespace\nmetadata:\n  name: foo\n",
+			wantErrMsg: "unexpected kind Namespace, want ValidationPolicy or ConfigMap",
+		},
+		{
+			name:       "duplicate rule",
+			data:       guardrailsPolicy + "  - name: no-load-balancer\n    expression: 'true'\n",
+			wantErrMsg: `duplicate rule "no-load-balancer"`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			policies, errs := FromFile([]byte(tc.data), "policy.yaml")
+			if tc.wantErrMsg != "" {
+				require.Error(t, errs)
+				assert.Equal(t, InvalidPolicyErrorCode, errs.Errors()[0].Code())
+				assert.Contains(t, errs.Error(), tc.wantErrMsg)
+				return
+			}
+			require.NoError(t, errs)
+			require.Len(t, policies, 1)
+			wantPolicy := *want
+			wantPolicy.Source = tc.wantSource
+			assert.Equal(t, &wantPolicy, policies[0])
+		})
+	}
+}
+
+func TestFromCluster(t *testing.T) {
+	policies, errs := FromCluster(context.Background(), syncerFake.NewClient(t, core.Scheme))
+	require.NoError(t, errs)
+	assert.Nil(t, policies)
+
+	cm := k8sobjects.ConfigMapObject(core.Name(ConfigMapName), core.Namespace(configsync.ControllerNamespace))
+	cm.Data = map[string]string{"b.yaml": guardrailsPolicy, "a.yaml": "kind: Namespace\n"}
+	policies, errs = FromCluster(context.Background(), syncerFake.NewClient(t, core.Scheme, cm))
+	require.Error(t, errs)
+	assert.Contains(t, errs.Error(), "key a.yaml: unexpected kind Namespace")
+	require.Len(t, policies, 1)
+	assert.Equal(t, "guardrails", policies[0].Name)
+}
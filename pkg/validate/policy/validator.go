@@ -0,0 +1,167 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"fmt"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"kpt.dev/configsync/pkg/importer/analyzer/ast"
+	"kpt.dev/configsync/pkg/status"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// objectVariable is the variable of the CEL expressions bound to the
+	// validated object.
+	objectVariable = "object"
+	// costLimit is the maximum cost of evaluating a rule against an object,
+	// which prevents expensive expressions from blocking the reconciler.
+	costLimit = 1000000
+	// wildcard matches all the API groups or kinds.
+	wildcard = "*"
+)
+
+// Validator evaluates the rules of ValidationPolicies against objects.
+type Validator struct {
+	rules []compiledRule
+}
+
+// compiledRule is a rule with its compiled CEL program.
+type compiledRule struct {
+	policy  *ValidationPolicy
+	rule    Rule
+	program cel.Program
+}
+
+// NewValidator compiles the rules of the ValidationPolicies. It returns an
+// error for each rule which fails to compile, or doesn't evaluate to a bool.
+func NewValidator(policies []*ValidationPolicy) (*Validator, status.MultiError) {
+	env, err := cel.NewEnv(cel.Variable(objectVariable, cel.DynType))
+	if err != nil {
+		return nil, status.InternalErrorf("unable to create the CEL environment: %v", err)
+	}
+	v := &Validator{}
+	var errs status.MultiError
+	for _, p := range policies {
+		for _, r := range p.Spec.Rules {
+			compiled, issues := env.Compile(r.Expression)
+			if issues != nil && issues.Err() != nil {
+				errs = status.Append(errs, InvalidRuleError(p, r, issues.Err()))
+				continue
+			}
+			if compiled.OutputType() != cel.BoolType && compiled.OutputType() != cel.DynType {
+				errs = status.Append(errs, InvalidRuleError(p, r,
+					fmt.Errorf("expression must evaluate to a bool, got %s", compiled.OutputType())))
+				continue
+			}
+			program, err := env.Program(compiled, cel.CostLimit(costLimit))
+			if err != nil {
+				errs = status.Append(errs, InvalidRuleError(p, r, err))
+				continue
+			}
+			v.rules = append(v.rules, compiledRule{policy: p, rule: r, program: program})
+		}
+	}
+	return v, errs
+}
+
+// Validate evaluates the rules against the matching objects, and returns an
+// error for each object which violates a rule.
+func (v *Validator) Validate(objs []ast.FileObject) status.MultiError {
+	var errs status.MultiError
+	for _, obj := range objs {
+		for _, r := range v.rules {
+			if !r.rule.Match.matches(obj) {
+				continue
+			}
+			out, _, err := r.program.Eval(map[string]interface{}{objectVariable: obj.Object})
+			switch {
+			case err != nil:
+				errs = status.Append(errs, ViolationError(obj, r.policy, r.rule, fmt.Sprintf("failed to evaluate: %v", err)))
+			case out != types.True:
+				if out.Type() != types.BoolType {
+					errs = status.Append(errs, ViolationError(obj, r.policy, r.rule,
+						fmt.Sprintf("expression evaluated to %s, want bool", out.Type().TypeName())))
+					continue
+				}
+				errs = status.Append(errs, ViolationError(obj, r.policy, r.rule, r.rule.Message))
+			}
+		}
+	}
+	return errs
+}
+
+// matches checks if the object matches the API groups and kinds.
+func (m Match) matches(obj client.Object) bool {
+	gvk := obj.GetObjectKind().GroupVersionKind()
+	return matchesAny(m.APIGroups, gvk.Group) && matchesAny(m.Kinds, gvk.Kind)
+}
+
+// matchesAny checks if the value is in the values, which match all the values
+// if empty or if they include the wildcard.
+func matchesAny(values []string, value string) bool {
+	if len(values) == 0 {
+		return true
+	}
+	for _, v := range values {
+		if v == wildcard || v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// ViolationErrorCode is the error code for an object which violates a rule of
+// a ValidationPolicy.
+const ViolationErrorCode = "1071"
+
+var violationErrorBuilder = status.NewErrorBuilder(ViolationErrorCode)
+
+// ViolationError reports that the object violates the rule of the
+// ValidationPolicy.
+func ViolationError(obj client.Object, p *ValidationPolicy, r Rule, message string) status.Error {
+	if message == "" {
+		message = fmt.Sprintf("failed expression: %s", r.Expression)
+	}
+	return violationErrorBuilder.
+		Sprintf("%s violates rule %q of ValidationPolicy %q (%s): %s",
+			obj.GetObjectKind().GroupVersionKind().Kind, r.Name, p.Name, p.Source, message).
+		BuildWithResources(obj)
+}
+
+// InvalidPolicyErrorCode is the error code for an invalid ValidationPolicy.
+const InvalidPolicyErrorCode = "1072"
+
+var invalidPolicyErrorBuilder = status.NewErrorBuilder(InvalidPolicyErrorCode)
+
+// InvalidPolicyError reports that the ValidationPolicy declared in the source
+// can't be parsed.
+func InvalidPolicyError(source string, err error) status.Error {
+	return invalidPolicyErrorBuilder.
+		Sprintf("invalid ValidationPolicy in %s", source).
+		Wrap(err).
+		Build()
+}
+
+// InvalidRuleError reports that the rule of the ValidationPolicy can't be
+// compiled.
+func InvalidRuleError(p *ValidationPolicy, r Rule, err error) status.Error {
+	return invalidPolicyErrorBuilder.
+		Sprintf("invalid rule %q of ValidationPolicy %q in %s", r.Name, p.Name, p.Source).
+		Wrap(err).
+		Build()
+}
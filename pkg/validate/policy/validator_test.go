@@ -0,0 +1,119 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/core/k8sobjects"
+	"kpt.dev/configsync/pkg/importer/analyzer/ast"
+	"kpt.dev/configsync/pkg/status"
+	"kpt.dev/configsync/pkg/testing/testerrors"
+)
+
+func TestValidator(t *testing.T) {
+	noLoadBalancer := Rule{
+		Name:       "no-load-balancer",
+		Match:      Match{APIGroups: []string{""}, Kinds: []string{"Service"}},
+		Expression: "!has(object.spec.type) || object.spec.type != 'LoadBalancer'",
+		Message:    "Services of type LoadBalancer are not allowed",
+	}
+	maxReplicas := Rule{
+		Name:       "max-replicas",
+		Match:      Match{APIGroups: []string{"apps"}, Kinds: []string{"*"}},
+		Expression: "object.spec.replicas <= 3",
+	}
+	guardrails := &ValidationPolicy{
+		Name:   "guardrails",
+		Source: "system/guardrails.yaml",
+		Spec:   ValidationPolicySpec{Rules: []Rule{noLoadBalancer, maxReplicas}},
+	}
+
+	lb := k8sobjects.ServiceObject(core.Name("lb"))
+	lb.Spec.Type = corev1.ServiceTypeLoadBalancer
+	loadBalancer := k8sobjects.FileObject(lb, "namespaces/foo/lb.yaml")
+	cip := k8sobjects.ServiceObject(core.Name("cluster-ip"))
+	cip.Spec.Type = corev1.ServiceTypeClusterIP
+	clusterIP := k8sobjects.FileObject(cip, "namespaces/foo/cluster-ip.yaml")
+	big := k8sobjects.DeploymentObject(core.Name("big"))
+	big.Spec.Replicas = ptr.To[int32](5)
+	bigDeployment := k8sobjects.FileObject(big, "namespaces/foo/big.yaml")
+	small := k8sobjects.DeploymentObject(core.Name("small"))
+	small.Spec.Replicas = ptr.To[int32](1)
+	smallDeployment := k8sobjects.FileObject(small, "namespaces/foo/small.yaml")
+	// The rule fails to evaluate without spec.replicas.
+	noReplicas := k8sobjects.FileObject(k8sobjects.DeploymentObject(core.Name("no-replicas")), "namespaces/foo/no-replicas.yaml")
+
+	testCases := []struct {
+		name     string
+		objs     []ast.FileObject
+		wantErrs status.MultiError
+	}{
+		{
+			name: "valid objects",
+			objs: []ast.FileObject{
+				clusterIP,
+				smallDeployment,
+				k8sobjects.ConfigMap(core.Name("cm")),
+			},
+		},
+		{
+			name: "violations",
+			objs: []ast.FileObject{
+				loadBalancer,
+				bigDeployment,
+				noReplicas,
+			},
+			wantErrs: status.Append(nil,
+				ViolationError(loadBalancer, guardrails, noLoadBalancer, noLoadBalancer.Message),
+				ViolationError(bigDeployment, guardrails, maxReplicas, ""),
+				ViolationError(noReplicas, guardrails, maxReplicas, "failed to evaluate: no such key: replicas"),
+			),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			v, errs := NewValidator([]*ValidationPolicy{guardrails})
+			require.NoError(t, errs)
+			testerrors.AssertEqual(t, tc.wantErrs, v.Validate(tc.objs))
+		})
+	}
+}
+
+func TestNewValidatorInvalidRules(t *testing.T) {
+	p := &ValidationPolicy{
+		Name:   "invalid",
+		Source: "system/invalid.yaml",
+		Spec: ValidationPolicySpec{Rules: []Rule{
+			{Name: "undeclared", Expression: "objects.spec.replicas <= 3"},
+			{Name: "not-bool", Expression: "'string'"},
+			{Name: "valid", Expression: "true"},
+		}},
+	}
+	v, errs := NewValidator([]*ValidationPolicy{p})
+	require.Error(t, errs)
+	require.Len(t, errs.Errors(), 2)
+	for _, err := range errs.Errors() {
+		assert.Equal(t, InvalidPolicyErrorCode, err.Code())
+	}
+	assert.Contains(t, errs.Errors()[0].Error(), `invalid rule "undeclared"`)
+	assert.Contains(t, errs.Errors()[1].Error(), "expression must evaluate to a bool, got string")
+	assert.Len(t, v.rules, 1)
+}
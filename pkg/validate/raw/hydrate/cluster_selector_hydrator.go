@@ -37,7 +37,7 @@ func ClusterSelectors(objs *fileobjects.Raw) status.MultiError {
 	if errs != nil {
 		return errs
 	}
-	activeSelectors, errs := set.activeSelectors()
+	activeSelectors, errs := set.activeSelectors(objs.ClusterLabels)
 	if errs != nil {
 		return errs
 	}
@@ -184,12 +184,15 @@ func (h *hydratorSet) clusterSelectorObject(object ast.FileObject) status.Error
 	return nil
 }
 
-func (h *hydratorSet) activeSelectors() (map[string]bool, status.MultiError) {
+// activeSelectors evaluates the ClusterSelectors against the labels of the
+// declared Cluster, merged with the live cluster labels which take precedence.
+func (h *hydratorSet) activeSelectors(liveLabels labels.Set) (map[string]bool, status.MultiError) {
 	activeSels := make(map[string]bool)
-	clusterLabels := labels.Set{}
+	var declaredLabels labels.Set
 	if h.cluster != nil {
-		clusterLabels = h.cluster.Labels
+		declaredLabels = h.cluster.Labels
 	}
+	clusterLabels := labels.Merge(declaredLabels, liveLabels)
 
 	var errs status.MultiError
 	for _, s := range h.selectors {
@@ -20,6 +20,7 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/labels"
 	"kpt.dev/configsync/pkg/core"
 	"kpt.dev/configsync/pkg/core/k8sobjects"
 	"kpt.dev/configsync/pkg/importer/analyzer/ast"
@@ -166,6 +167,47 @@ func TestClusterSelectors(t *testing.T) {
 				ClusterName: prodClusterName,
 			},
 		},
+		{
+			name: "Keep object selected by the live cluster labels without a declared Cluster",
+			objs: &fileobjects.Raw{
+				ClusterName:   prodClusterName,
+				ClusterLabels: labels.Set{"environment": "prod"},
+				Objects: []ast.FileObject{
+					k8sobjects.Role(withProdLegacyClusterSelector),
+					k8sobjects.Role(core.Name("dev"), withDevLegacyClusterSelector),
+					prodSelector,
+					devSelector,
+				},
+			},
+			want: &fileobjects.Raw{
+				ClusterName:   prodClusterName,
+				ClusterLabels: labels.Set{"environment": "prod"},
+				Objects: []ast.FileObject{
+					k8sobjects.Role(withProdLegacyClusterSelector),
+				},
+			},
+		},
+		{
+			name: "Live cluster labels override the labels of the declared Cluster",
+			objs: &fileobjects.Raw{
+				ClusterName:   prodClusterName,
+				ClusterLabels: labels.Set{"environment": "dev"},
+				Objects: []ast.FileObject{
+					k8sobjects.Namespace("namespaces/foo", withProdLegacyClusterSelector),
+					k8sobjects.Namespace("namespaces/bar", withDevLegacyClusterSelector),
+					prodCluster,
+					prodSelector,
+					devSelector,
+				},
+			},
+			want: &fileobjects.Raw{
+				ClusterName:   prodClusterName,
+				ClusterLabels: labels.Set{"environment": "dev"},
+				Objects: []ast.FileObject{
+					k8sobjects.Namespace("namespaces/bar", withDevLegacyClusterSelector),
+				},
+			},
+		},
 		{
 			name: "Keep object in namespace with stateActive inline cluster selector",
 			objs: &fileobjects.Raw{
@@ -102,6 +102,13 @@ func encodeDeclaredFields(converter *declared.ValueConverter, obj runtime.Object
 		}
 	}
 
+	// The source location annotations are removed before the object is
+	// applied, so they must not be declared fields.
+	if isUnstructured {
+		u = u.DeepCopy()
+		core.RemoveAnnotations(u, metadata.SourceLocationAnnotationKey, metadata.SourceChainAnnotationKey)
+		obj = u
+	}
 	val, err := converter.TypedValue(obj)
 	if err != nil {
 		return nil, err
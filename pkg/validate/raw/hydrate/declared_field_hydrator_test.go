@@ -131,6 +131,50 @@ func TestDeclaredFields(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "encode fields for Role without the source location annotations",
+			objs: &fileobjects.Raw{
+				Converter: converter,
+				Objects: []ast.FileObject{
+					k8sobjects.FileObject(&unstructured.Unstructured{
+						Object: map[string]interface{}{
+							"apiVersion": rbacv1.SchemeGroupVersion.String(),
+							"kind":       "Role",
+							"metadata": map[string]interface{}{
+								"name":      "hello",
+								"namespace": "world",
+								"annotations": map[string]interface{}{
+									metadata.SourcePathAnnotationKey:     "role.yaml",
+									metadata.SourceLocationAnnotationKey: "base/role.yaml:1:1",
+									metadata.SourceChainAnnotationKey:    "base/role.yaml",
+								},
+							},
+						},
+					}, "role.yaml"),
+				},
+			},
+			want: &fileobjects.Raw{
+				Converter: converter,
+				Objects: []ast.FileObject{
+					k8sobjects.FileObject(&unstructured.Unstructured{
+						Object: map[string]interface{}{
+							"apiVersion": rbacv1.SchemeGroupVersion.String(),
+							"kind":       "Role",
+							"metadata": map[string]interface{}{
+								"name":      "hello",
+								"namespace": "world",
+								"annotations": map[string]interface{}{
+									metadata.SourcePathAnnotationKey:     "role.yaml",
+									metadata.SourceLocationAnnotationKey: "base/role.yaml:1:1",
+									metadata.SourceChainAnnotationKey:    "base/role.yaml",
+									metadata.DeclaredFieldsKey:           `{"f:metadata":{"f:annotations":{"f:configmanagement.gke.io/source-path":{}},"f:labels":{}}}`,
+								},
+							},
+						},
+					}, "role.yaml"),
+				},
+			},
+		},
 		{
 			name: "encode fields for Custom Resource",
 			objs: &fileobjects.Raw{
@@ -15,18 +15,108 @@
 package hydrate
 
 import (
+	"fmt"
+	"strings"
+
 	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/importer/analyzer/ast"
+	"kpt.dev/configsync/pkg/importer/filesystem/cmpath"
 	"kpt.dev/configsync/pkg/metadata"
 	"kpt.dev/configsync/pkg/status"
 	"kpt.dev/configsync/pkg/validate/fileobjects"
+	"sigs.k8s.io/kustomize/api/resource"
+	"sigs.k8s.io/yaml"
 )
 
+// sourceChainSeparator separates the files in the SourceChainAnnotationKey
+// annotation.
+const sourceChainSeparator = " -> "
+
 // Filepath annotates the given Raw objects with the path from the Git repo
-// policy directory to the files which declare them.
+// policy directory to the files which declare them, and with their locations
+// in the source.
 func Filepath(objs *fileobjects.Raw) status.MultiError {
 	for _, obj := range objs.Objects {
 		path := objs.PolicyDir.Join(obj.Relative).SlashPath()
 		core.SetAnnotation(obj, metadata.SourcePathAnnotationKey, path)
+		setSourceLocation(obj, objs.PolicyDir, path)
 	}
 	return nil
 }
+
+// setSourceLocation annotates the object with its location and rendering chain
+// in the source. Objects rendered by Kustomize are located in the source files
+// recorded in their origin annotation, and the other objects in the files
+// which declare them. The chain includes the transformers only if the
+// kustomization enables the transformerAnnotations build metadata. The
+// location is not set if it is the same as the path, that is if neither the
+// origin nor the line of the object is known.
+// Both annotations are removed from the declared objects before they are
+// applied.
+func setSourceLocation(obj ast.FileObject, policyDir cmpath.Relative, path string) {
+	annotations := obj.GetAnnotations()
+	location := path
+	var chain []string
+	if origin := parseOrigin(annotations[metadata.KustomizeOrigin]); origin != nil {
+		location = originPath(origin, policyDir)
+		chain = append(chain, originString(origin, policyDir))
+		var transformations resource.Transformations
+		if err := yaml.Unmarshal([]byte(annotations[metadata.KustomizeTransformations]), &transformations); err == nil {
+			for _, t := range transformations {
+				chain = append(chain, originString(t, policyDir))
+			}
+		}
+	}
+	if line := annotations[metadata.SourceLineAnnotationKey]; line != "" {
+		location = fmt.Sprintf("%s:%s", location, line)
+	}
+	core.RemoveAnnotations(obj, metadata.SourceLineAnnotationKey)
+	if location != path {
+		core.SetAnnotation(obj, metadata.SourceLocationAnnotationKey, location)
+	}
+	if len(chain) > 0 {
+		core.SetAnnotation(obj, metadata.SourceChainAnnotationKey, strings.Join(chain, sourceChainSeparator))
+	}
+}
+
+// parseOrigin returns the origin recorded by Kustomize, or nil if the value is
+// empty or invalid.
+func parseOrigin(value string) *resource.Origin {
+	if value == "" {
+		return nil
+	}
+	origin := &resource.Origin{}
+	if err := yaml.Unmarshal([]byte(value), origin); err != nil {
+		return nil
+	}
+	return origin
+}
+
+// originPath returns the path of the file which declares the object or
+// configures the generator or transformer. Local paths are relative to the
+// policy directory, where Kustomize is run, and remote paths are prefixed
+// with their repository.
+func originPath(origin *resource.Origin, policyDir cmpath.Relative) string {
+	path := origin.Path
+	if origin.ConfiguredIn != "" {
+		path = origin.ConfiguredIn
+	}
+	if origin.Repo == "" {
+		return policyDir.Join(cmpath.RelativeSlash(path)).SlashPath()
+	}
+	remote := fmt.Sprintf("%s//%s", origin.Repo, path)
+	if origin.Ref != "" {
+		remote = fmt.Sprintf("%s?ref=%s", remote, origin.Ref)
+	}
+	return remote
+}
+
+// originString returns the path of the origin, followed by the kind and name
+// of the generator or transformer if it is configured by one.
+func originString(origin *resource.Origin, policyDir cmpath.Relative) string {
+	path := originPath(origin, policyDir)
+	if origin.ConfiguredBy.Kind == "" {
+		return path
+	}
+	return fmt.Sprintf("%s (%s %s)", path, origin.ConfiguredBy.Kind, origin.ConfiguredBy.Name)
+}
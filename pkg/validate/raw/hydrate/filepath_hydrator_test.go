@@ -82,6 +82,80 @@ func TestFilepath(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Set source location from line",
+			objs: &fileobjects.Raw{
+				PolicyDir: cmpath.RelativeSlash(dir),
+				Objects: []ast.FileObject{
+					k8sobjects.RoleAtPath("namespaces/role.yaml",
+						core.Name("writer"),
+						core.Annotation(metadata.SourceLineAnnotationKey, "7:1")),
+				},
+			},
+			want: &fileobjects.Raw{
+				PolicyDir: cmpath.RelativeSlash(dir),
+				Objects: []ast.FileObject{
+					k8sobjects.RoleAtPath("namespaces/role.yaml",
+						core.Name("writer"),
+						core.Annotation(metadata.SourcePathAnnotationKey, dir+"namespaces/role.yaml"),
+						core.Annotation(metadata.SourceLocationAnnotationKey, dir+"namespaces/role.yaml:7:1")),
+				},
+			},
+		},
+		{
+			name: "Set source location and chain from kustomize origin",
+			objs: &fileobjects.Raw{
+				PolicyDir: cmpath.RelativeSlash(dir + "overlay"),
+				Objects: []ast.FileObject{
+					k8sobjects.RoleAtPath("apps_v1_role_writer.yaml",
+						core.Name("writer"),
+						core.Annotation(metadata.KustomizeOrigin, "path: ../base/role.yaml\n"),
+						core.Annotation(metadata.KustomizeTransformations,
+							"- configuredIn: kustomization.yaml\n  configuredBy:\n    apiVersion: builtin\n    kind: NamespaceTransformer\n    name: namespace\n"),
+						core.Annotation(metadata.SourceLineAnnotationKey, "3:1")),
+				},
+			},
+			want: &fileobjects.Raw{
+				PolicyDir: cmpath.RelativeSlash(dir + "overlay"),
+				Objects: []ast.FileObject{
+					k8sobjects.RoleAtPath("apps_v1_role_writer.yaml",
+						core.Name("writer"),
+						core.Annotation(metadata.KustomizeOrigin, "path: ../base/role.yaml\n"),
+						core.Annotation(metadata.KustomizeTransformations,
+							"- configuredIn: kustomization.yaml\n  configuredBy:\n    apiVersion: builtin\n    kind: NamespaceTransformer\n    name: namespace\n"),
+						core.Annotation(metadata.SourcePathAnnotationKey, dir+"overlay/apps_v1_role_writer.yaml"),
+						core.Annotation(metadata.SourceLocationAnnotationKey, dir+"base/role.yaml:3:1"),
+						core.Annotation(metadata.SourceChainAnnotationKey,
+							dir+"base/role.yaml -> "+dir+"overlay/kustomization.yaml (NamespaceTransformer namespace)")),
+				},
+			},
+		},
+		{
+			name: "Set source location from remote kustomize origin",
+			objs: &fileobjects.Raw{
+				PolicyDir: cmpath.RelativeSlash(dir),
+				Objects: []ast.FileObject{
+					k8sobjects.RoleAtPath("apps_v1_role_writer.yaml",
+						core.Name("writer"),
+						core.Annotation(metadata.KustomizeOrigin,
+							"path: examples/role.yaml\nrepo: https://github.com/example/repo\nref: v1.0.0\n")),
+				},
+			},
+			want: &fileobjects.Raw{
+				PolicyDir: cmpath.RelativeSlash(dir),
+				Objects: []ast.FileObject{
+					k8sobjects.RoleAtPath("apps_v1_role_writer.yaml",
+						core.Name("writer"),
+						core.Annotation(metadata.KustomizeOrigin,
+							"path: examples/role.yaml\nrepo: https://github.com/example/repo\nref: v1.0.0\n"),
+						core.Annotation(metadata.SourcePathAnnotationKey, dir+"apps_v1_role_writer.yaml"),
+						core.Annotation(metadata.SourceLocationAnnotationKey,
+							"https://github.com/example/repo//examples/role.yaml?ref=v1.0.0"),
+						core.Annotation(metadata.SourceChainAnnotationKey,
+							"https://github.com/example/repo//examples/role.yaml?ref=v1.0.0")),
+				},
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -0,0 +1,39 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hydrate
+
+import (
+	"kpt.dev/configsync/pkg/metadata"
+	"kpt.dev/configsync/pkg/status"
+	"kpt.dev/configsync/pkg/validate/fileobjects"
+	"kpt.dev/configsync/pkg/validate/variables"
+)
+
+// Variables substitutes the `${var}` placeholders in the given Raw objects
+// which enable the substitution, with the cluster variables and the built-in
+// cluster-name variable.
+func Variables(objs *fileobjects.Raw) status.MultiError {
+	vars := objs.Variables.WithClusterName(objs.ClusterName)
+	var errs status.MultiError
+	for _, obj := range objs.Objects {
+		if !metadata.SubstituteVariablesEnabledFor(obj) {
+			continue
+		}
+		if undefined := vars.Substitute(obj.Unstructured.Object); len(undefined) > 0 {
+			errs = status.Append(errs, variables.UndefinedVariablesError(obj, undefined))
+		}
+	}
+	return errs
+}
@@ -0,0 +1,89 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hydrate
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/core/k8sobjects"
+	"kpt.dev/configsync/pkg/importer/analyzer/ast"
+	"kpt.dev/configsync/pkg/metadata"
+	"kpt.dev/configsync/pkg/status"
+	"kpt.dev/configsync/pkg/testing/testerrors"
+	"kpt.dev/configsync/pkg/validate/fileobjects"
+	"kpt.dev/configsync/pkg/validate/variables"
+)
+
+func TestVariables(t *testing.T) {
+	clusterVars := variables.Variables{"region": "us-east1"}
+	testCases := []struct {
+		name     string
+		objs     *fileobjects.Raw
+		want     *fileobjects.Raw
+		wantErrs status.MultiError
+	}{
+		{
+			name: "Substitute variables in objects which enable the substitution",
+			objs: &fileobjects.Raw{
+				ClusterName: "prod",
+				Variables:   clusterVars,
+				Objects: []ast.FileObject{
+					k8sobjects.Role(core.Name("enabled"), metadata.WithSubstituteVariables(),
+						core.Label("cluster", "${cluster-name}"), core.Label("region", "${region}")),
+					k8sobjects.Role(core.Name("disabled"), core.Label("region", "${region}")),
+				},
+			},
+			want: &fileobjects.Raw{
+				ClusterName: "prod",
+				Variables:   clusterVars,
+				Objects: []ast.FileObject{
+					k8sobjects.Role(core.Name("enabled"), metadata.WithSubstituteVariables(),
+						core.Label("cluster", "prod"), core.Label("region", "us-east1")),
+					k8sobjects.Role(core.Name("disabled"), core.Label("region", "${region}")),
+				},
+			},
+		},
+		{
+			name: "Error for undefined variables",
+			objs: &fileobjects.Raw{
+				Variables: clusterVars,
+				Objects: []ast.FileObject{
+					k8sobjects.Role(metadata.WithSubstituteVariables(), core.Label("cluster", "${cluster-name}")),
+				},
+			},
+			want: &fileobjects.Raw{
+				Variables: clusterVars,
+				Objects: []ast.FileObject{
+					k8sobjects.Role(metadata.WithSubstituteVariables(), core.Label("cluster", "${cluster-name}")),
+				},
+			},
+			wantErrs: variables.UndefinedVariablesError(
+				k8sobjects.Role(metadata.WithSubstituteVariables(), core.Label("cluster", "${cluster-name}")),
+				[]string{variables.ClusterName}),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			errs := Variables(tc.objs)
+			testerrors.AssertEqual(t, tc.wantErrs, errs)
+			if diff := cmp.Diff(tc.want, tc.objs, ast.CompareFileObject); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
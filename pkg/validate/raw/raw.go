@@ -72,6 +72,7 @@ func Hierarchical(objs *fileobjects.Raw) status.MultiError {
 		hydrate.ObjectNamespaces,
 		hydrate.ClusterSelectors,
 		hydrate.ClusterName,
+		hydrate.Variables,
 		hydrate.Filepath,
 		hydrate.HNCDepth,
 		hydrate.PreventDeletion,
@@ -121,6 +122,7 @@ func Unstructured(objs *fileobjects.Raw) status.MultiError {
 		hydrate.DeclaredVersion,
 		hydrate.ClusterSelectors,
 		hydrate.ClusterName,
+		hydrate.Variables,
 		hydrate.Filepath,
 		hydrate.PreventDeletion,
 	}
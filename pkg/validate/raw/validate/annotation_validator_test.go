@@ -63,6 +63,18 @@ func TestAnnotations(t *testing.T) {
 			name: "legal management annotation",
 			obj:  k8sobjects.RoleBinding(core.Annotation(csmetadata.ManagementModeAnnotationKey, "a")),
 		},
+		{
+			name: "legal ownership transfer annotation",
+			obj:  k8sobjects.RoleBinding(core.Annotation(csmetadata.OwnershipTransferAnnotationKey, "claim")),
+		},
+		{
+			name: "legal remediation priority annotation",
+			obj:  k8sobjects.RoleBinding(core.Annotation(csmetadata.RemediationPriorityAnnotationKey, "high")),
+		},
+		{
+			name: "legal drift prevention annotation",
+			obj:  k8sobjects.Namespace("namespaces/dev", core.Annotation(csmetadata.DriftPreventionAnnotationKey, "disabled")),
+		},
 		{
 			name:    "illegal ConfigManagement annotation",
 			obj:     k8sobjects.Role(core.Annotation(cmAnnotation, "a")),
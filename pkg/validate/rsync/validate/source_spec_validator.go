@@ -23,6 +23,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/validation"
 	"kpt.dev/configsync/pkg/api/configsync"
 	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
+	"kpt.dev/configsync/pkg/metadata"
 	"kpt.dev/configsync/pkg/reconcilermanager"
 	"kpt.dev/configsync/pkg/reposync"
 	"kpt.dev/configsync/pkg/rootsync"
@@ -34,6 +35,18 @@ import (
 // https://cloud.google.com/iam/docs/service-accounts#user-managed
 const gcpSASuffix = ".iam.gserviceaccount.com"
 
+// reservedPodLabels are the reconciler pod labels set by the
+// reconciler-manager, in addition to those with a Config Sync prefix.
+var reservedPodLabels = map[string]bool{
+	"app": true,
+}
+
+// reservedPodAnnotations are the reconciler pod annotations set by the
+// reconciler-manager, in addition to those with a Config Sync prefix.
+var reservedPodAnnotations = map[string]bool{
+	metadata.FleetWorkloadIdentityCredentials: true,
+}
+
 // HelmValuesFileDefaultDataKey is the default data key to use when
 // spec.helm.valuesFileRefs.dataKey is not specified.
 const HelmValuesFileDefaultDataKey = "values.yaml"
@@ -306,9 +319,53 @@ func OverrideSpec(override *v1beta1.OverrideSpec, syncKind string) status.Error
 			return OverrideResourceQuantityNegative("memoryLimit", syncKind)
 		}
 	}
+	if workers := override.RemediatorWorkers; workers != nil {
+		if workers.Min != nil && workers.Max != nil && *workers.Min > *workers.Max {
+			return OverrideRemediatorWorkersRange(syncKind)
+		}
+	}
+	if err := overrideLabels("nodeSelector", override.NodeSelector, syncKind); err != nil {
+		return err
+	}
+	if err := overrideLabels("podLabels", override.PodLabels, syncKind); err != nil {
+		return err
+	}
+	for key := range override.PodLabels {
+		if reservedPodLabels[key] || isConfigSyncKey(key) {
+			return OverrideReservedPodMetadata("podLabels", key, syncKind)
+		}
+	}
+	for key := range override.PodAnnotations {
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return OverrideInvalidPodMetadata("podAnnotations", key, strings.Join(errs, ", "), syncKind)
+		}
+		if reservedPodAnnotations[key] || isConfigSyncKey(key) {
+			return OverrideReservedPodMetadata("podAnnotations", key, syncKind)
+		}
+	}
 	return nil
 }
 
+// overrideLabels validates the keys and values of a label map override.
+func overrideLabels(fieldName string, labels map[string]string, syncKind string) status.Error {
+	for key, value := range labels {
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return OverrideInvalidPodMetadata(fieldName, key, strings.Join(errs, ", "), syncKind)
+		}
+		if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+			return OverrideInvalidPodMetadata(fieldName, key, strings.Join(errs, ", "), syncKind)
+		}
+	}
+	return nil
+}
+
+// isConfigSyncKey returns true if the label or annotation key uses one of the
+// prefixes reserved for Config Sync.
+func isConfigSyncKey(key string) bool {
+	return strings.HasPrefix(key, configsync.ConfigSyncPrefix) ||
+		strings.HasPrefix(key, metadata.ConfigManagementPrefix)
+}
+
 // ReconcilerName validates the reconciler name.
 func ReconcilerName(reconcilerName string) status.Error {
 	if errs := validation.IsDNS1123Subdomain(reconcilerName); errs != nil {
@@ -663,6 +720,31 @@ func OverrideRoleRefNamespace(syncKind string) status.Error {
 		Build()
 }
 
+// OverrideRemediatorWorkersRange reports that a RootSync or RepoSync has
+// `spec.override.remediatorWorkers.min` greater than
+// `spec.override.remediatorWorkers.max`.
+func OverrideRemediatorWorkersRange(syncKind string) status.Error {
+	return invalidSyncBuilder.
+		Sprintf("%s field 'spec.override.remediatorWorkers.min' must not be greater than 'spec.override.remediatorWorkers.max'", syncKind).
+		Build()
+}
+
+// OverrideInvalidPodMetadata reports that a RootSync or RepoSync has an invalid
+// key or value in a label or annotation map override.
+func OverrideInvalidPodMetadata(fieldName, key, reason, syncKind string) status.Error {
+	return invalidSyncBuilder.
+		Sprintf("%s field 'spec.override.%s' has an invalid entry %q: %s", syncKind, fieldName, key, reason).
+		Build()
+}
+
+// OverrideReservedPodMetadata reports that a RootSync or RepoSync overrides a
+// reconciler pod label or annotation which is reserved for Config Sync.
+func OverrideReservedPodMetadata(fieldName, key, syncKind string) status.Error {
+	return invalidSyncBuilder.
+		Sprintf("%s field 'spec.override.%s' must not contain %q, which is reserved for Config Sync", syncKind, fieldName, key).
+		Build()
+}
+
 // OverrideResourceQuantityNegative reports that a RootSync needs
 // `spec.override.roleRefs.namespace` when  `spec.override.roleRefs.kind` is
 // "Role".
@@ -21,6 +21,8 @@ import (
 
 	"github.com/stretchr/testify/require"
 	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/utils/ptr"
 	"kpt.dev/configsync/pkg/api/configsync"
 	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
 	"kpt.dev/configsync/pkg/core/k8sobjects"
@@ -546,6 +548,56 @@ func TestValidateRepoSyncSpec(t *testing.T) {
 			}),
 			wantErr: OverrideResourceQuantityNegative("memoryLimit", configsync.RepoSyncKind),
 		},
+		{
+			name: "valid spec.override.remediatorWorkers",
+			obj: repoSyncWithGit(func(rs *v1beta1.RepoSync) {
+				rs.Spec.SafeOverride().RemediatorWorkers = &v1beta1.RemediatorWorkersOverride{
+					Min: ptr.To(int64(2)),
+					Max: ptr.To(int64(8)),
+				}
+			}),
+		},
+		{
+			name: "invalid spec.override.remediatorWorkers",
+			obj: repoSyncWithGit(func(rs *v1beta1.RepoSync) {
+				rs.Spec.SafeOverride().RemediatorWorkers = &v1beta1.RemediatorWorkersOverride{
+					Min: ptr.To(int64(8)),
+					Max: ptr.To(int64(2)),
+				}
+			}),
+			wantErr: OverrideRemediatorWorkersRange(configsync.RepoSyncKind),
+		},
+		{
+			name: "valid spec.override pod scheduling",
+			obj: repoSyncWithGit(func(rs *v1beta1.RepoSync) {
+				rs.Spec.SafeOverride().NodeSelector = map[string]string{"cloud.google.com/gke-nodepool": "system"}
+				rs.Spec.SafeOverride().PodLabels = map[string]string{"team": "platform"}
+				rs.Spec.SafeOverride().PodAnnotations = map[string]string{"example.com/owner": "platform team"}
+			}),
+		},
+		{
+			name: "invalid spec.override.nodeSelector",
+			obj: repoSyncWithGit(func(rs *v1beta1.RepoSync) {
+				rs.Spec.SafeOverride().NodeSelector = map[string]string{"pool": "not a label value"}
+			}),
+			wantErr: OverrideInvalidPodMetadata("nodeSelector", "pool",
+				strings.Join(validation.IsValidLabelValue("not a label value"), ", "),
+				configsync.RepoSyncKind),
+		},
+		{
+			name: "reserved spec.override.podLabels",
+			obj: repoSyncWithGit(func(rs *v1beta1.RepoSync) {
+				rs.Spec.SafeOverride().PodLabels = map[string]string{"configsync.gke.io/sync-name": "other"}
+			}),
+			wantErr: OverrideReservedPodMetadata("podLabels", "configsync.gke.io/sync-name", configsync.RepoSyncKind),
+		},
+		{
+			name: "reserved spec.override.podAnnotations",
+			obj: repoSyncWithGit(func(rs *v1beta1.RepoSync) {
+				rs.Spec.SafeOverride().PodAnnotations = map[string]string{"config.kubernetes.io/fleet-workload-identity": "{}"}
+			}),
+			wantErr: OverrideReservedPodMetadata("podAnnotations", "config.kubernetes.io/fleet-workload-identity", configsync.RepoSyncKind),
+		},
 	}
 
 	for _, tc := range testCases {
@@ -18,18 +18,24 @@ import (
 	"context"
 
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/klog/v2"
 	"kpt.dev/configsync/pkg/declared"
 	"kpt.dev/configsync/pkg/importer/analyzer/ast"
+	"kpt.dev/configsync/pkg/importer/customresources"
 	"kpt.dev/configsync/pkg/importer/filesystem/cmpath"
 	"kpt.dev/configsync/pkg/reconciler/namespacecontroller"
 	"kpt.dev/configsync/pkg/status"
 	"kpt.dev/configsync/pkg/util/discovery"
 	"kpt.dev/configsync/pkg/validate/fileobjects"
 	"kpt.dev/configsync/pkg/validate/final"
+	"kpt.dev/configsync/pkg/validate/openapi"
+	"kpt.dev/configsync/pkg/validate/policy"
 	"kpt.dev/configsync/pkg/validate/raw"
 	"kpt.dev/configsync/pkg/validate/scoped"
 	"kpt.dev/configsync/pkg/validate/tree"
+	"kpt.dev/configsync/pkg/validate/variables"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -44,6 +50,15 @@ type Options struct {
 	// ClusterName is the spec.clusterName of the cluster's ConfigManagement. This
 	// is used when hydrating cluster selectors.
 	ClusterName string
+	// ClusterLabels are the labels of the cluster, read from the ConfigMap
+	// config-management-system/config-sync-cluster-labels or a local file.
+	// ClusterSelectors match them in addition to the labels of the Cluster
+	// object declared in the repository.
+	ClusterLabels labels.Set
+	// Variables are the cluster variables, read from the ConfigMap
+	// config-management-system/config-sync-cluster-vars or a local file. They
+	// are substituted in the objects which enable the substitution.
+	Variables variables.Variables
 	// Scope is the scope of the reconciler.
 	// `:root` represents the root-reconciler.
 	// The scope of the namespace reconciler is its namespace name.
@@ -91,11 +106,27 @@ type Options struct {
 	// MaxObjectCount is the maximum number of objects allowed in a single
 	// inventory. Validation is skipped when less than 1.
 	MaxObjectCount int
+	// ValidationPolicies are the ValidationPolicies declared outside of the
+	// source, for example in the cluster. They are enforced in addition to the
+	// ValidationPolicies declared in the source.
+	ValidationPolicies []*policy.ValidationPolicy
+	// Schemas are the OpenAPI schemas of the resources known to the cluster,
+	// or declared in an offline schema bundle. The final objects are validated
+	// against them and against the schemas of the CRDs declared in the source.
+	// Schema validation is skipped when nil.
+	Schemas openapi.Resources
 }
 
 // Hierarchical validates and hydrates the given FileObjects from a structured,
 // hierarchical repo.
 func Hierarchical(objs []ast.FileObject, opts Options) ([]ast.FileObject, status.MultiError) {
+	// The ValidationPolicies declared in the source are not applied, so they
+	// are removed before any validation.
+	objs, policies, errs := policy.Extract(objs)
+	if errs != nil {
+		return nil, errs
+	}
+
 	// First we perform initial validation which includes:
 	//   - checking for illegal metadata or resource kinds
 	//   - checking for illegal or invalid directories, namespaces, or names
@@ -105,6 +136,8 @@ func Hierarchical(objs []ast.FileObject, opts Options) ([]ast.FileObject, status
 	//   - adding metadata to resources (such as their filepath in the repo)
 	rawObjects := &fileobjects.Raw{
 		ClusterName:       opts.ClusterName,
+		ClusterLabels:     opts.ClusterLabels,
+		Variables:         opts.Variables,
 		Scope:             opts.Scope,
 		SyncName:          opts.SyncName,
 		PolicyDir:         opts.PolicyDir,
@@ -170,6 +203,19 @@ func Hierarchical(objs []ast.FileObject, opts Options) ([]ast.FileObject, status
 		return nil, status.Append(nonBlockingErrs, errs)
 	}
 
+	// Next we evaluate the rules of the ValidationPolicies, declared both in
+	// and outside of the source, against the final objects.
+	if errs = validatePolicies(finalObjects, opts.ValidationPolicies, policies); errs != nil {
+		return nil, status.Append(nonBlockingErrs, errs)
+	}
+
+	// Next we validate the fields of the final objects against the OpenAPI
+	// schemas of their kinds, so that malformed objects are rejected before
+	// any object is applied.
+	if errs = validateSchemas(finalObjects, opts); errs != nil {
+		return nil, status.Append(nonBlockingErrs, errs)
+	}
+
 	for _, visitor := range opts.Visitors {
 		finalObjects, errs = visitor(finalObjects)
 		if errs != nil {
@@ -183,6 +229,13 @@ func Hierarchical(objs []ast.FileObject, opts Options) ([]ast.FileObject, status
 // Unstructured validates and hydrates the given FileObjects from an
 // unstructured repo.
 func Unstructured(ctx context.Context, c client.Client, objs []ast.FileObject, opts Options) ([]ast.FileObject, status.MultiError) {
+	// The ValidationPolicies declared in the source are not applied, so they
+	// are removed before any validation.
+	objs, policies, errs := policy.Extract(objs)
+	if errs != nil {
+		return nil, errs
+	}
+
 	// First we perform initial validation which includes:
 	//   - checking for illegal metadata or resource kinds
 	//   - checking for illegal or invalid namespaces or names
@@ -192,6 +245,8 @@ func Unstructured(ctx context.Context, c client.Client, objs []ast.FileObject, o
 	//   - adding metadata to resources (such as their filepath in the repo)
 	rawObjects := &fileobjects.Raw{
 		ClusterName:              opts.ClusterName,
+		ClusterLabels:            opts.ClusterLabels,
+		Variables:                opts.Variables,
 		Scope:                    opts.Scope,
 		SyncName:                 opts.SyncName,
 		PolicyDir:                opts.PolicyDir,
@@ -249,6 +304,19 @@ func Unstructured(ctx context.Context, c client.Client, objs []ast.FileObject, o
 		return nil, status.Append(nonBlockingErrs, errs)
 	}
 
+	// Next we evaluate the rules of the ValidationPolicies, declared both in
+	// and outside of the source, against the final objects.
+	if errs := validatePolicies(finalObjects, opts.ValidationPolicies, policies); errs != nil {
+		return nil, status.Append(nonBlockingErrs, errs)
+	}
+
+	// Next we validate the fields of the final objects against the OpenAPI
+	// schemas of their kinds, so that malformed objects are rejected before
+	// any object is applied.
+	if errs := validateSchemas(finalObjects, opts); errs != nil {
+		return nil, status.Append(nonBlockingErrs, errs)
+	}
+
 	for _, visitor := range opts.Visitors {
 		var errs status.MultiError
 		finalObjects, errs = visitor(finalObjects)
@@ -259,3 +327,40 @@ func Unstructured(ctx context.Context, c client.Client, objs []ast.FileObject, o
 
 	return finalObjects, nonBlockingErrs
 }
+
+// validatePolicies evaluates the rules of the ValidationPolicies against the
+// objects.
+func validatePolicies(objs []ast.FileObject, policySets ...[]*policy.ValidationPolicy) status.MultiError {
+	var policies []*policy.ValidationPolicy
+	for _, ps := range policySets {
+		policies = append(policies, ps...)
+	}
+	if len(policies) == 0 {
+		return nil
+	}
+	validator, errs := policy.NewValidator(policies)
+	if errs != nil {
+		return errs
+	}
+	return validator.Validate(objs)
+}
+
+// validateSchemas validates the objects against the OpenAPI schemas of the
+// CRDs declared in the objects, and of the resources known to the cluster.
+// The unknown fields are logged as warnings, rather than returned.
+func validateSchemas(objs []ast.FileObject, opts Options) status.MultiError {
+	if opts.Schemas == nil {
+		return nil
+	}
+	crds, errs := customresources.GetCRDs(objs, opts.Scheme)
+	if errs != nil {
+		return errs
+	}
+	errs, warnings := openapi.NewValidator(opts.Schemas, crds).Validate(objs)
+	if warnings != nil {
+		for _, warning := range warnings.Errors() {
+			klog.Warning(warning)
+		}
+	}
+	return errs
+}
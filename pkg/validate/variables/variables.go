@@ -0,0 +1,229 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package variables implements the substitution of the `${var}` placeholders
+// in the objects with values local to each cluster.
+package variables
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/kinds"
+	"kpt.dev/configsync/pkg/status"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// ConfigMapName is the name of the ConfigMap in the config-management-system
+	// namespace which declares the variables of the cluster. Each data key is
+	// a variable name.
+	ConfigMapName = "config-sync-cluster-vars"
+	// ClusterName is the built-in variable set to the name of the cluster, if
+	// the cluster has a name.
+	ClusterName = "cluster-name"
+)
+
+var (
+	// namePattern matches the valid variable names, which are the valid
+	// ConfigMap data keys.
+	namePattern = regexp.MustCompile(`^[-._a-zA-Z0-9]+$`)
+	// placeholderPattern matches the `${var}` placeholders, and the `$${`
+	// escape sequence which is replaced with a literal `${`.
+	placeholderPattern = regexp.MustCompile(`\$\$\{|\$\{([-._a-zA-Z0-9]+)\}`)
+)
+
+// Variables maps the variable names to their values.
+type Variables map[string]string
+
+// FromConfigMap returns the variables declared in the data of the ConfigMap.
+func FromConfigMap(cm *corev1.ConfigMap) (Variables, status.Error) {
+	return validate(cm.Data, fmt.Sprintf("ConfigMap %s/%s", cm.Namespace, cm.Name))
+}
+
+// FromFile returns the variables declared in a file, which may contain a map
+// of variables or the ConfigMap which declares them, for example exported from
+// a cluster.
+func FromFile(data []byte, source string) (Variables, status.Error) {
+	var content map[string]interface{}
+	if err := yaml.Unmarshal(data, &content); err != nil {
+		return nil, InvalidVariablesError(source, err)
+	}
+	if content["kind"] == kinds.ConfigMap().Kind {
+		cm := &corev1.ConfigMap{}
+		if err := yaml.Unmarshal(data, cm); err != nil {
+			return nil, InvalidVariablesError(source, err)
+		}
+		return validate(cm.Data, source)
+	}
+	values := make(map[string]string, len(content))
+	for k, v := range content {
+		s, isString := v.(string)
+		if !isString {
+			return nil, InvalidVariablesError(source,
+				fmt.Errorf("the value of variable %q must be a string, got %T", k, v))
+		}
+		values[k] = s
+	}
+	return validate(values, source)
+}
+
+// FromCluster returns the variables declared in the ConfigMap
+// config-management-system/config-sync-cluster-vars, or nil if the ConfigMap
+// doesn't exist.
+func FromCluster(ctx context.Context, c client.Reader) (Variables, status.Error) {
+	cm := &corev1.ConfigMap{}
+	key := client.ObjectKey{Namespace: configsync.ControllerNamespace, Name: ConfigMapName}
+	if err := c.Get(ctx, key, cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, status.APIServerError(err, fmt.Sprintf("unable to get the cluster variables in ConfigMap %s", key))
+	}
+	return FromConfigMap(cm)
+}
+
+// WithClusterName returns a copy of the variables with the built-in
+// cluster-name variable, if the cluster has a name.
+func (v Variables) WithClusterName(clusterName string) Variables {
+	vars := make(Variables, len(v)+1)
+	for name, value := range v {
+		vars[name] = value
+	}
+	if clusterName != "" {
+		vars[ClusterName] = clusterName
+	}
+	return vars
+}
+
+// Substitute replaces the placeholders in the string values of the object,
+// except in the fields which identify the object, and returns the sorted names
+// of the undefined variables.
+func (v Variables) Substitute(obj map[string]interface{}) []string {
+	undefined := make(map[string]bool)
+	for field, value := range obj {
+		switch field {
+		case "apiVersion", "kind":
+			continue
+		case "metadata":
+			if metadata, isMap := value.(map[string]interface{}); isMap {
+				for k, mv := range metadata {
+					if k == "name" || k == "namespace" {
+						continue
+					}
+					metadata[k] = v.substituteValue(mv, undefined)
+				}
+				continue
+			}
+		}
+		obj[field] = v.substituteValue(value, undefined)
+	}
+	names := make([]string, 0, len(undefined))
+	for name := range undefined {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (v Variables) substituteValue(value interface{}, undefined map[string]bool) interface{} {
+	switch typed := value.(type) {
+	case string:
+		return v.substituteString(typed, undefined)
+	case map[string]interface{}:
+		for k, mv := range typed {
+			typed[k] = v.substituteValue(mv, undefined)
+		}
+		return typed
+	case []interface{}:
+		for i, item := range typed {
+			typed[i] = v.substituteValue(item, undefined)
+		}
+		return typed
+	default:
+		return value
+	}
+}
+
+func (v Variables) substituteString(s string, undefined map[string]bool) string {
+	return placeholderPattern.ReplaceAllStringFunc(s, func(match string) string {
+		if match == "$${" {
+			return "${"
+		}
+		name := match[2 : len(match)-1]
+		value, found := v[name]
+		if !found {
+			undefined[name] = true
+			return match
+		}
+		return value
+	})
+}
+
+// validate checks the variable names, and that the built-in variables aren't
+// overridden.
+func validate(values map[string]string, source string) (Variables, status.Error) {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if name == ClusterName {
+			return nil, InvalidVariablesError(source,
+				fmt.Errorf("the built-in variable %q can't be declared", ClusterName))
+		}
+		if !namePattern.MatchString(name) {
+			return nil, InvalidVariablesError(source,
+				fmt.Errorf("invalid variable name %q: must consist of alphanumeric characters, '-', '_' or '.'", name))
+		}
+	}
+	return Variables(values), nil
+}
+
+// UndefinedVariablesErrorCode is the error code for an object which references
+// undefined variables.
+const UndefinedVariablesErrorCode = "1074"
+
+var undefinedVariablesErrorBuilder = status.NewErrorBuilder(UndefinedVariablesErrorCode)
+
+// UndefinedVariablesError reports that the object references variables which
+// aren't declared for the cluster.
+func UndefinedVariablesError(obj client.Object, names []string) status.Error {
+	return undefinedVariablesErrorBuilder.
+		Sprintf("%s references undefined variables %q. Declare them in the ConfigMap %s/%s, or escape the placeholders as $${name}",
+			obj.GetObjectKind().GroupVersionKind().Kind, names, configsync.ControllerNamespace, ConfigMapName).
+		BuildWithResources(obj)
+}
+
+// InvalidVariablesErrorCode is the error code for invalid variable
+// declarations.
+const InvalidVariablesErrorCode = "1075"
+
+var invalidVariablesErrorBuilder = status.NewErrorBuilder(InvalidVariablesErrorCode)
+
+// InvalidVariablesError reports that the variables declared in the source
+// can't be used.
+func InvalidVariablesError(source string, err error) status.Error {
+	return invalidVariablesErrorBuilder.
+		Sprintf("invalid variables in %s", source).
+		Wrap(err).
+		Build()
+}
@@ -0,0 +1,176 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package variables
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubstitute(t *testing.T) {
+	vars := Variables{"project-id": "my-project", "region": "us-east1"}.WithClusterName("prod")
+	testCases := []struct {
+		name          string
+		obj           map[string]interface{}
+		want          map[string]interface{}
+		wantUndefined []string
+	}{
+		{
+			name: "nested values",
+			obj: map[string]interface{}{
+				"spec": map[string]interface{}{
+					"project": "${project-id}",
+					"zones":   []interface{}{"${region}-a", "${region}-b", int64(3)},
+					"url":     "https://${cluster-name}.${region}.example.com",
+				},
+			},
+			want: map[string]interface{}{
+				"spec": map[string]interface{}{
+					"project": "my-project",
+					"zones":   []interface{}{"us-east1-a", "us-east1-b", int64(3)},
+					"url":     "https://prod.us-east1.example.com",
+				},
+			},
+		},
+		{
+			name: "identifying fields are not substituted",
+			obj: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "ConfigMap",
+				"metadata": map[string]interface{}{
+					"name":        "${region}",
+					"namespace":   "${region}",
+					"labels":      map[string]interface{}{"region": "${region}"},
+					"annotations": map[string]interface{}{"project": "${project-id}"},
+				},
+			},
+			want: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "ConfigMap",
+				"metadata": map[string]interface{}{
+					"name":        "${region}",
+					"namespace":   "${region}",
+					"labels":      map[string]interface{}{"region": "us-east1"},
+					"annotations": map[string]interface{}{"project": "my-project"},
+				},
+			},
+		},
+		{
+			name: "escaped placeholders and other dollar signs",
+			obj: map[string]interface{}{
+				"data": map[string]interface{}{
+					"script": "echo $${HOME} $HOME ${not valid} $$ ${region}",
+				},
+			},
+			want: map[string]interface{}{
+				"data": map[string]interface{}{
+					"script": "echo ${HOME} $HOME ${not valid} $$ us-east1",
+				},
+			},
+		},
+		{
+			name: "undefined variables",
+			obj: map[string]interface{}{
+				"data": map[string]interface{}{
+					"a": "${zone}",
+					"b": "${tier}-${zone}-${region}",
+				},
+			},
+			want: map[string]interface{}{
+				"data": map[string]interface{}{
+					"a": "${zone}",
+					"b": "${tier}-${zone}-us-east1",
+				},
+			},
+			wantUndefined: []string{"tier", "zone"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			undefined := vars.Substitute(tc.obj)
+			assert.Equal(t, tc.want, tc.obj)
+			if tc.wantUndefined == nil {
+				assert.Empty(t, undefined)
+			} else {
+				assert.Equal(t, tc.wantUndefined, undefined)
+			}
+		})
+	}
+}
+
+func TestWithClusterName(t *testing.T) {
+	vars := Variables{"region": "us-east1"}
+	assert.Equal(t, Variables{"region": "us-east1"}, vars.WithClusterName(""))
+	assert.Equal(t, Variables{"region": "us-east1", ClusterName: "prod"}, vars.WithClusterName("prod"))
+	// The original variables are not modified.
+	assert.Equal(t, Variables{"region": "us-east1"}, vars)
+}
+
+func TestFromFile(t *testing.T) {
+	testCases := []struct {
+		name       string
+		data       string
+		want       Variables
+		wantErrMsg string
+	}{
+		{
+			name: "map of variables",
+			data: "project-id: my-project\nregion: us-east1\n",
+			want: Variables{"project-id": "my-project", "region": "us-east1"},
+		},
+		{
+			name: "ConfigMap",
+			data: `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config-sync-cluster-vars
+  namespace: config-management-system
+data:
+  region: us-east1
+`,
+			want: Variables{"region": "us-east1"},
+		},
+		{
+			name:       "non-string value",
+			data:       "replicas: 3\n",
+			wantErrMsg: `the value of variable "replicas" must be a string, got int64`,
+		},
+		{
+			name:       "invalid name",
+			data:       "project id: my-project\n",
+			wantErrMsg: `invalid variable name "project id"`,
+		},
+		{
+			name:       "built-in variable",
+			data:       "cluster-name: prod\n",
+			wantErrMsg: `the built-in variable "cluster-name" can't be declared`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := FromFile([]byte(tc.data), "vars.yaml")
+			if tc.wantErrMsg != "" {
+				require.Error(t, err)
+				assert.Equal(t, InvalidVariablesErrorCode, err.Code())
+				assert.Contains(t, err.Error(), tc.wantErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,253 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configuration
+
+import (
+	"sort"
+	"strings"
+
+	admissionv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"kpt.dev/configsync/pkg/metadata"
+)
+
+// DriftPrevention describes which managed objects the admission webhook
+// protects from drift.
+//
+// An override on either the RootSync or RepoSync which manages an object, or
+// on the Namespace of a namespaced object, takes precedence over the default.
+// When enabled by default, an object is protected unless drift prevention is
+// disabled for its RootSync or RepoSync, or for its Namespace. When disabled
+// by default, an object is protected if drift prevention is enabled for its
+// RootSync or RepoSync, or for its Namespace.
+type DriftPrevention struct {
+	// DefaultEnabled is whether drift prevention is enabled for RootSyncs,
+	// RepoSyncs and Namespaces which do not override it.
+	DefaultEnabled bool
+	// SyncOverrides are the ApplySet IDs of the RootSyncs and RepoSyncs which
+	// override DefaultEnabled.
+	SyncOverrides []string
+	// NamespaceOverrides are the names of the Namespaces which override
+	// DefaultEnabled.
+	NamespaceOverrides []string
+}
+
+// namespacesWebhookPrefix is the name prefix of the webhooks which match the
+// objects in the Namespaces with drift prevention enabled, when disabled by
+// default.
+const namespacesWebhookPrefix = "namespaces."
+
+// webhookSelectors are the selectors of one of the webhooks generated for
+// each GroupVersion. The API server calls a webhook if any of them matches,
+// so each webhook matches the objects of one of the conditions under which
+// drift prevention is enabled.
+type webhookSelectors struct {
+	// namespaced restricts the webhook to namespaced resources, because the
+	// namespaceSelector always matches cluster-scoped objects.
+	namespaced bool
+	// objectReqs are the requirements added to the objectSelector, which
+	// otherwise only matches the declared version label.
+	objectReqs []metav1.LabelSelectorRequirement
+	// namespaceSelector is the namespaceSelector of the webhook.
+	// It is empty, instead of nil, when matching all Namespaces, because that
+	// is how the API server defaults it.
+	namespaceSelector *metav1.LabelSelector
+}
+
+// selectors returns the selectors of the webhooks generated for each
+// GroupVersion.
+//
+// When enabled by default, a single webhook excludes the overriding RootSyncs,
+// RepoSyncs and Namespaces. When disabled by default, one webhook matches the
+// objects managed by the overriding RootSyncs and RepoSyncs, and another
+// matches the namespaced objects in the overriding Namespaces.
+func (dp DriftPrevention) selectors() []webhookSelectors {
+	syncs := sortedCopy(dp.SyncOverrides)
+	namespaces := sortedCopy(dp.NamespaceOverrides)
+	if dp.DefaultEnabled {
+		s := webhookSelectors{namespaceSelector: &metav1.LabelSelector{}}
+		if len(syncs) > 0 {
+			s.objectReqs = []metav1.LabelSelectorRequirement{
+				{Key: metadata.ApplySetPartOfLabel, Operator: metav1.LabelSelectorOpNotIn, Values: syncs},
+			}
+		}
+		if len(namespaces) > 0 {
+			s.namespaceSelector.MatchExpressions = []metav1.LabelSelectorRequirement{
+				{Key: corev1.LabelMetadataName, Operator: metav1.LabelSelectorOpNotIn, Values: namespaces},
+			}
+		}
+		return []webhookSelectors{s}
+	}
+	var result []webhookSelectors
+	if len(syncs) > 0 || len(namespaces) == 0 {
+		// Without any override, keep a webhook which matches nothing, so the
+		// configuration still lists the declared GroupVersions.
+		reqs := []metav1.LabelSelectorRequirement{
+			// The In operator requires at least one value, so use two
+			// contradicting requirements to match nothing.
+			{Key: metadata.ApplySetPartOfLabel, Operator: metav1.LabelSelectorOpExists},
+			{Key: metadata.ApplySetPartOfLabel, Operator: metav1.LabelSelectorOpDoesNotExist},
+		}
+		if len(syncs) > 0 {
+			reqs = []metav1.LabelSelectorRequirement{
+				{Key: metadata.ApplySetPartOfLabel, Operator: metav1.LabelSelectorOpIn, Values: syncs},
+			}
+		}
+		result = append(result, webhookSelectors{objectReqs: reqs, namespaceSelector: &metav1.LabelSelector{}})
+	}
+	if len(namespaces) > 0 {
+		result = append(result, webhookSelectors{
+			namespaced: true,
+			namespaceSelector: &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: corev1.LabelMetadataName, Operator: metav1.LabelSelectorOpIn, Values: namespaces},
+				},
+			},
+		})
+	}
+	return result
+}
+
+// Apply sets the webhooks of every GroupVersion in the configuration to match
+// the DriftPrevention. Returns true if the configuration was modified.
+func (dp DriftPrevention) Apply(cfg *admissionv1.ValidatingWebhookConfiguration) bool {
+	webhooks := withSelectors(cfg.Webhooks, dp.selectors())
+	if equality.Semantic.DeepEqual(cfg.Webhooks, webhooks) {
+		return false
+	}
+	cfg.Webhooks = webhooks
+	return true
+}
+
+// copySelectors returns the webhooks in the to list, with the drift
+// prevention selectors of the webhooks in the from list. All GroupVersions
+// share the same selectors, so the webhooks of the first GroupVersion in the
+// from list are used. The to list is returned unchanged if the from list has
+// no webhook for a GroupVersion.
+func copySelectors(from, to []admissionv1.ValidatingWebhook) []admissionv1.ValidatingWebhook {
+	var selectors []webhookSelectors
+	var first *schema.GroupVersion
+	seen := make(map[bool]bool)
+	for _, webhook := range from {
+		gv, ok := webhookGroupVersion(webhook)
+		if !ok || (first != nil && gv != *first) {
+			continue
+		}
+		first = &gv
+		namespaced := isNamespaced(webhook)
+		if seen[namespaced] {
+			continue
+		}
+		seen[namespaced] = true
+		selectors = append(selectors, webhookSelectors{
+			namespaced:        namespaced,
+			objectReqs:        webhook.ObjectSelector.MatchExpressions,
+			namespaceSelector: webhook.NamespaceSelector,
+		})
+	}
+	if len(selectors) == 0 {
+		return to
+	}
+	return withSelectors(to, selectors)
+}
+
+// withSelectors returns one webhook per selectors for each GroupVersion of the
+// webhooks, in order. The other fields of the webhooks are copied from the
+// first webhook of the GroupVersion.
+func withSelectors(webhooks []admissionv1.ValidatingWebhook, selectors []webhookSelectors) []admissionv1.ValidatingWebhook {
+	var result []admissionv1.ValidatingWebhook
+	seen := make(map[schema.GroupVersion]bool)
+	for _, webhook := range webhooks {
+		gv, ok := webhookGroupVersion(webhook)
+		if !ok {
+			result = append(result, webhook)
+			continue
+		}
+		if seen[gv] {
+			continue
+		}
+		seen[gv] = true
+		for _, s := range selectors {
+			result = append(result, s.apply(webhook))
+		}
+	}
+	return result
+}
+
+// apply returns a copy of the webhook of the GroupVersion with the selectors,
+// keeping the declared version objectSelector label.
+func (s webhookSelectors) apply(webhook admissionv1.ValidatingWebhook) admissionv1.ValidatingWebhook {
+	result := *webhook.DeepCopy()
+	result.Name = strings.TrimPrefix(result.Name, namespacesWebhookPrefix)
+	for i := range result.Rules {
+		switch {
+		case s.namespaced:
+			scope := admissionv1.NamespacedScope
+			result.Rules[i].Scope = &scope
+		case result.Rules[i].Scope != nil && *result.Rules[i].Scope != admissionv1.AllScopes:
+			// Leave an unset scope alone, since the API server defaults it to
+			// all scopes.
+			scope := admissionv1.AllScopes
+			result.Rules[i].Scope = &scope
+		}
+	}
+	if s.namespaced {
+		result.Name = namespacesWebhookPrefix + result.Name
+	}
+	result.ObjectSelector.MatchExpressions = (&metav1.LabelSelector{MatchExpressions: s.objectReqs}).DeepCopy().MatchExpressions
+	result.NamespaceSelector = s.namespaceSelector.DeepCopy()
+	return result
+}
+
+// webhookGroupVersion returns the GroupVersion matched by the webhook, from its
+// rule and declared version objectSelector label. Returns false if the webhook
+// has no declared version label.
+func webhookGroupVersion(webhook admissionv1.ValidatingWebhook) (schema.GroupVersion, bool) {
+	if webhook.ObjectSelector == nil {
+		return schema.GroupVersion{}, false
+	}
+	version, found := webhook.ObjectSelector.MatchLabels[metadata.DeclaredVersionLabel]
+	if !found {
+		return schema.GroupVersion{}, false
+	}
+	gv := schema.GroupVersion{Version: version}
+	if len(webhook.Rules) > 0 && len(webhook.Rules[0].APIGroups) > 0 {
+		gv.Group = webhook.Rules[0].APIGroups[0]
+	}
+	return gv, true
+}
+
+// isNamespaced returns whether the webhook only matches namespaced resources.
+func isNamespaced(webhook admissionv1.ValidatingWebhook) bool {
+	for _, rule := range webhook.Rules {
+		if rule.Scope == nil || *rule.Scope != admissionv1.NamespacedScope {
+			return false
+		}
+	}
+	return len(webhook.Rules) > 0
+}
+
+func sortedCopy(values []string) []string {
+	if len(values) == 0 {
+		return nil
+	}
+	result := make([]string, len(values))
+	copy(result, values)
+	sort.Strings(result)
+	return result
+}
@@ -0,0 +1,238 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configuration
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	admissionv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"kpt.dev/configsync/pkg/metadata"
+)
+
+func TestDriftPrevention_Apply(t *testing.T) {
+	namespacedScope := admissionv1.NamespacedScope
+	type wantWebhook struct {
+		namePrefix    string
+		scope         *admissionv1.ScopeType
+		objectReqs    []metav1.LabelSelectorRequirement
+		namespaceReqs []metav1.LabelSelectorRequirement
+	}
+	testCases := []struct {
+		name string
+		dp   DriftPrevention
+		// want are the webhooks expected for each GroupVersion.
+		want []wantWebhook
+	}{
+		{
+			name: "enabled by default without overrides",
+			dp:   DriftPrevention{DefaultEnabled: true},
+			want: []wantWebhook{{}},
+		},
+		{
+			name: "enabled by default with overrides",
+			dp: DriftPrevention{
+				DefaultEnabled:     true,
+				SyncOverrides:      []string{"sync-b", "sync-a"},
+				NamespaceOverrides: []string{"dev"},
+			},
+			want: []wantWebhook{{
+				objectReqs: []metav1.LabelSelectorRequirement{
+					{Key: metadata.ApplySetPartOfLabel, Operator: metav1.LabelSelectorOpNotIn, Values: []string{"sync-a", "sync-b"}},
+				},
+				namespaceReqs: []metav1.LabelSelectorRequirement{
+					{Key: corev1.LabelMetadataName, Operator: metav1.LabelSelectorOpNotIn, Values: []string{"dev"}},
+				},
+			}},
+		},
+		{
+			name: "disabled by default without overrides",
+			dp:   DriftPrevention{DefaultEnabled: false},
+			want: []wantWebhook{{
+				objectReqs: []metav1.LabelSelectorRequirement{
+					{Key: metadata.ApplySetPartOfLabel, Operator: metav1.LabelSelectorOpExists},
+					{Key: metadata.ApplySetPartOfLabel, Operator: metav1.LabelSelectorOpDoesNotExist},
+				},
+			}},
+		},
+		{
+			name: "disabled by default with sync overrides",
+			dp: DriftPrevention{
+				DefaultEnabled: false,
+				SyncOverrides:  []string{"sync-a"},
+			},
+			want: []wantWebhook{{
+				objectReqs: []metav1.LabelSelectorRequirement{
+					{Key: metadata.ApplySetPartOfLabel, Operator: metav1.LabelSelectorOpIn, Values: []string{"sync-a"}},
+				},
+			}},
+		},
+		{
+			name: "disabled by default with namespace overrides",
+			dp: DriftPrevention{
+				DefaultEnabled:     false,
+				NamespaceOverrides: []string{"prod-a"},
+			},
+			want: []wantWebhook{{
+				namePrefix: namespacesWebhookPrefix,
+				scope:      &namespacedScope,
+				namespaceReqs: []metav1.LabelSelectorRequirement{
+					{Key: corev1.LabelMetadataName, Operator: metav1.LabelSelectorOpIn, Values: []string{"prod-a"}},
+				},
+			}},
+		},
+		{
+			// Objects managed by sync-a are protected in any Namespace, and
+			// namespaced objects in prod-a and prod-b are protected whichever
+			// sync manages them.
+			name: "disabled by default with overrides",
+			dp: DriftPrevention{
+				DefaultEnabled:     false,
+				SyncOverrides:      []string{"sync-a"},
+				NamespaceOverrides: []string{"prod-b", "prod-a"},
+			},
+			want: []wantWebhook{
+				{
+					objectReqs: []metav1.LabelSelectorRequirement{
+						{Key: metadata.ApplySetPartOfLabel, Operator: metav1.LabelSelectorOpIn, Values: []string{"sync-a"}},
+					},
+				},
+				{
+					namePrefix: namespacesWebhookPrefix,
+					scope:      &namespacedScope,
+					namespaceReqs: []metav1.LabelSelectorRequirement{
+						{Key: corev1.LabelMetadataName, Operator: metav1.LabelSelectorOpIn, Values: []string{"prod-a", "prod-b"}},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gvs := []schema.GroupVersion{corev1.SchemeGroupVersion, rbacv1.SchemeGroupVersion}
+			cfg := &admissionv1.ValidatingWebhookConfiguration{}
+			for _, gv := range gvs {
+				cfg.Webhooks = append(cfg.Webhooks, toWebhook(gv))
+			}
+			if !tc.dp.Apply(cfg) {
+				t.Fatal("got Apply() = false, want true")
+			}
+			if len(cfg.Webhooks) != len(gvs)*len(tc.want) {
+				t.Fatalf("got %d webhooks, want %d", len(cfg.Webhooks), len(gvs)*len(tc.want))
+			}
+			for i, webhook := range cfg.Webhooks {
+				gv := gvs[i/len(tc.want)]
+				want := tc.want[i%len(tc.want)]
+				if got, want := webhook.Name, want.namePrefix+webhookName(gv); got != want {
+					t.Errorf("got name %q, want %q", got, want)
+				}
+				if diff := cmp.Diff(want.scope, webhook.Rules[0].Scope); diff != "" {
+					t.Errorf("rule scope diff (-want +got):\n%s", diff)
+				}
+				if diff := cmp.Diff(want.objectReqs, webhook.ObjectSelector.MatchExpressions); diff != "" {
+					t.Errorf("objectSelector.matchExpressions diff (-want +got):\n%s", diff)
+				}
+				if diff := cmp.Diff(want.namespaceReqs, webhook.NamespaceSelector.MatchExpressions); diff != "" {
+					t.Errorf("namespaceSelector.matchExpressions diff (-want +got):\n%s", diff)
+				}
+				// The declared version label must be kept.
+				if got := webhook.ObjectSelector.MatchLabels[metadata.DeclaredVersionLabel]; got != gv.Version {
+					t.Errorf("got %s label %q, want %q", metadata.DeclaredVersionLabel, got, gv.Version)
+				}
+			}
+			if tc.dp.Apply(cfg) {
+				t.Error("got second Apply() = true, want false")
+			}
+		})
+	}
+}
+
+func TestDriftPrevention_ApplyAfterDisablingNamespaces(t *testing.T) {
+	cfg := &admissionv1.ValidatingWebhookConfiguration{
+		Webhooks: []admissionv1.ValidatingWebhook{toWebhook(rbacv1.SchemeGroupVersion)},
+	}
+	DriftPrevention{NamespaceOverrides: []string{"prod"}}.Apply(cfg)
+
+	// Removing the last Namespace override replaces the namespaced webhook
+	// with one matching nothing, for all scopes.
+	dp := DriftPrevention{}
+	if !dp.Apply(cfg) {
+		t.Fatal("got Apply() = false, want true")
+	}
+	if len(cfg.Webhooks) != 1 {
+		t.Fatalf("got %d webhooks, want 1", len(cfg.Webhooks))
+	}
+	webhook := cfg.Webhooks[0]
+	if got, want := webhook.Name, webhookName(rbacv1.SchemeGroupVersion); got != want {
+		t.Errorf("got name %q, want %q", got, want)
+	}
+	if got := *webhook.Rules[0].Scope; got != admissionv1.AllScopes {
+		t.Errorf("got rule scope %q, want %q", got, admissionv1.AllScopes)
+	}
+	if dp.Apply(cfg) {
+		t.Error("got second Apply() = true, want false")
+	}
+}
+
+func TestMerge_KeepsDriftPreventionSelectors(t *testing.T) {
+	dp := DriftPrevention{DefaultEnabled: true, SyncOverrides: []string{"sync-a"}}
+	left := &admissionv1.ValidatingWebhookConfiguration{
+		Webhooks: []admissionv1.ValidatingWebhook{
+			toWebhook(rbacv1.SchemeGroupVersion),
+		},
+	}
+	dp.Apply(left)
+	right := &admissionv1.ValidatingWebhookConfiguration{
+		Webhooks: []admissionv1.ValidatingWebhook{
+			toWebhook(corev1.SchemeGroupVersion),
+		},
+	}
+
+	got := Merge(left, right)
+	if len(got.Webhooks) != 2 {
+		t.Fatalf("got %d webhooks, want 2", len(got.Webhooks))
+	}
+	if dp.Apply(got) {
+		t.Error("Merge() did not keep the drift prevention selectors")
+	}
+}
+
+func TestMerge_KeepsNamespacedDriftPreventionWebhooks(t *testing.T) {
+	dp := DriftPrevention{SyncOverrides: []string{"sync-a"}, NamespaceOverrides: []string{"prod"}}
+	left := &admissionv1.ValidatingWebhookConfiguration{
+		Webhooks: []admissionv1.ValidatingWebhook{
+			toWebhook(rbacv1.SchemeGroupVersion),
+		},
+	}
+	dp.Apply(left)
+	right := &admissionv1.ValidatingWebhookConfiguration{
+		Webhooks: []admissionv1.ValidatingWebhook{
+			toWebhook(corev1.SchemeGroupVersion),
+		},
+	}
+
+	got := Merge(left, right)
+	if len(got.Webhooks) != 4 {
+		t.Fatalf("got %d webhooks, want 4", len(got.Webhooks))
+	}
+	if dp.Apply(got) {
+		t.Error("Merge() did not keep the drift prevention webhooks")
+	}
+}
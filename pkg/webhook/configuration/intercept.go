@@ -0,0 +1,139 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configuration
+
+import (
+	"fmt"
+
+	admissionv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Request are the attributes of an admission request which decide whether the
+// API server sends it to a webhook.
+type Request struct {
+	// Operation is the operation being performed.
+	Operation admissionv1.OperationType
+	// GroupVersion is the group and version of the object.
+	GroupVersion schema.GroupVersion
+	// Namespaced is true if the object is namespaced.
+	Namespaced bool
+	// Labels are the labels of the old and new versions of the object, if any.
+	Labels []map[string]string
+	// MatchNamespace is true if the namespaceSelector of the webhooks applies
+	// to the request, that is if the object is namespaced or a Namespace.
+	MatchNamespace bool
+	// NamespaceLabels are the labels of the Namespace of the object, or of the
+	// object itself if it is a Namespace.
+	NamespaceLabels map[string]string
+}
+
+// Intercepts returns whether the API server sends the request to any webhook
+// of the configuration, by evaluating their rules and selectors like the API
+// server does. A nil configuration intercepts nothing.
+func Intercepts(cfg *admissionv1.ValidatingWebhookConfiguration, r Request) (bool, error) {
+	if cfg == nil {
+		return false, nil
+	}
+	for _, webhook := range cfg.Webhooks {
+		matches, err := webhookMatches(webhook, r)
+		if err != nil {
+			return false, fmt.Errorf("invalid webhook %s: %w", webhook.Name, err)
+		}
+		if matches {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func webhookMatches(webhook admissionv1.ValidatingWebhook, r Request) (bool, error) {
+	equivalent := webhook.MatchPolicy == nil || *webhook.MatchPolicy == admissionv1.Equivalent
+	ruleMatches := false
+	for _, rule := range webhook.Rules {
+		if ruleMatchesRequest(rule, r, equivalent) {
+			ruleMatches = true
+			break
+		}
+	}
+	if !ruleMatches {
+		return false, nil
+	}
+
+	if webhook.ObjectSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(webhook.ObjectSelector)
+		if err != nil {
+			return false, fmt.Errorf("invalid objectSelector: %w", err)
+		}
+		// The objectSelector matches if either the old or new object matches.
+		objectMatches := false
+		for _, objLabels := range r.Labels {
+			if selector.Matches(labels.Set(objLabels)) {
+				objectMatches = true
+				break
+			}
+		}
+		if !objectMatches {
+			return false, nil
+		}
+	}
+
+	if webhook.NamespaceSelector != nil && r.MatchNamespace {
+		selector, err := metav1.LabelSelectorAsSelector(webhook.NamespaceSelector)
+		if err != nil {
+			return false, fmt.Errorf("invalid namespaceSelector: %w", err)
+		}
+		if !selector.Matches(labels.Set(r.NamespaceLabels)) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// ruleMatchesRequest returns whether the rule matches the operation, group,
+// version and scope of the request. With the Equivalent match policy, the API
+// server also sends the requests for the other versions of a resource, so the
+// version is not compared.
+func ruleMatchesRequest(rule admissionv1.RuleWithOperations, r Request, equivalent bool) bool {
+	if !containsOrWildcard(rule.Operations, r.Operation, admissionv1.OperationAll) {
+		return false
+	}
+	if !containsOrWildcard(rule.APIGroups, r.GroupVersion.Group, "*") {
+		return false
+	}
+	if !equivalent && !containsOrWildcard(rule.APIVersions, r.GroupVersion.Version, "*") {
+		return false
+	}
+	if rule.Scope != nil {
+		switch *rule.Scope {
+		case admissionv1.NamespacedScope:
+			return r.Namespaced
+		case admissionv1.ClusterScope:
+			return !r.Namespaced
+		}
+	}
+	return true
+}
+
+func containsOrWildcard[T comparable](values []T, value, wildcard T) bool {
+	for _, v := range values {
+		if v == value || v == wildcard {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,141 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configuration
+
+import (
+	"testing"
+
+	admissionv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"kpt.dev/configsync/pkg/metadata"
+)
+
+func TestIntercepts(t *testing.T) {
+	// A namespaced Role managed by sync-a in the prod Namespace.
+	role := Request{
+		Operation:    admissionv1.Update,
+		GroupVersion: rbacv1.SchemeGroupVersion,
+		Namespaced:   true,
+		Labels: []map[string]string{{
+			metadata.DeclaredVersionLabel: "v1",
+			metadata.ApplySetPartOfLabel:  "sync-a",
+		}},
+		MatchNamespace:  true,
+		NamespaceLabels: map[string]string{corev1.LabelMetadataName: "prod"},
+	}
+	// A cluster-scoped ClusterRole managed by sync-b.
+	clusterRole := Request{
+		Operation:    admissionv1.Update,
+		GroupVersion: rbacv1.SchemeGroupVersion,
+		Labels: []map[string]string{{
+			metadata.DeclaredVersionLabel: "v1",
+			metadata.ApplySetPartOfLabel:  "sync-b",
+		}},
+	}
+	undeclaredVersion := role
+	undeclaredVersion.Labels = []map[string]string{{metadata.ApplySetPartOfLabel: "sync-a"}}
+
+	testCases := []struct {
+		name string
+		dp   DriftPrevention
+		req  Request
+		want bool
+	}{
+		{
+			name: "enabled by default",
+			dp:   DriftPrevention{DefaultEnabled: true},
+			req:  role,
+			want: true,
+		},
+		{
+			name: "enabled by default, but disabled for the sync",
+			dp:   DriftPrevention{DefaultEnabled: true, SyncOverrides: []string{"sync-a"}},
+			req:  role,
+		},
+		{
+			name: "enabled by default, but disabled for the namespace",
+			dp:   DriftPrevention{DefaultEnabled: true, NamespaceOverrides: []string{"prod"}},
+			req:  role,
+		},
+		{
+			name: "enabled by default, cluster-scoped object ignores the namespace overrides",
+			dp:   DriftPrevention{DefaultEnabled: true, NamespaceOverrides: []string{"prod"}},
+			req:  clusterRole,
+			want: true,
+		},
+		{
+			name: "disabled by default",
+			dp:   DriftPrevention{},
+			req:  role,
+		},
+		{
+			name: "disabled by default, but enabled for the sync",
+			dp:   DriftPrevention{SyncOverrides: []string{"sync-a"}, NamespaceOverrides: []string{"dev"}},
+			req:  role,
+			want: true,
+		},
+		{
+			name: "disabled by default, but enabled for the namespace",
+			dp:   DriftPrevention{SyncOverrides: []string{"sync-b"}, NamespaceOverrides: []string{"prod"}},
+			req:  role,
+			want: true,
+		},
+		{
+			name: "disabled by default, cluster-scoped object ignores the namespace overrides",
+			dp:   DriftPrevention{NamespaceOverrides: []string{"prod"}},
+			req:  clusterRole,
+		},
+		{
+			name: "disabled by default, but enabled for the sync of a cluster-scoped object",
+			dp:   DriftPrevention{SyncOverrides: []string{"sync-b"}, NamespaceOverrides: []string{"prod"}},
+			req:  clusterRole,
+			want: true,
+		},
+		{
+			name: "version not declared",
+			dp:   DriftPrevention{DefaultEnabled: true},
+			req:  undeclaredVersion,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := &admissionv1.ValidatingWebhookConfiguration{
+				Webhooks: []admissionv1.ValidatingWebhook{
+					toWebhook(corev1.SchemeGroupVersion),
+					toWebhook(rbacv1.SchemeGroupVersion),
+				},
+			}
+			tc.dp.Apply(cfg)
+			got, err := Intercepts(cfg, tc.req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tc.want {
+				t.Errorf("got Intercepts() = %t, want %t", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestIntercepts_NilConfiguration(t *testing.T) {
+	got, err := Intercepts(nil, Request{Operation: admissionv1.Create})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got {
+		t.Error("got Intercepts() = true, want false")
+	}
+}
@@ -37,6 +37,8 @@ import (
 //     GroupVersion.
 //  2. Webhooks are sorted by the GroupVersion they match.
 //  3. All invalid webhooks are removed.
+//  4. All webhooks keep the drift prevention selectors of left, which may
+//     split a GroupVersion into several webhooks.
 //
 // Cannot return error or panic as we never want this to get stuck.
 //
@@ -93,8 +95,7 @@ func Merge(left, right *admissionv1.ValidatingWebhookConfiguration) *admissionv1
 		versionJ := webhooks[j].ObjectSelector.MatchLabels[metadata.DeclaredVersionLabel]
 		return versionI < versionJ
 	})
-
-	left.Webhooks = webhooks
+	left.Webhooks = copySelectors(left.Webhooks, webhooks)
 	return left
 }
 
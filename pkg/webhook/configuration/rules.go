@@ -132,7 +132,8 @@ func selectorFor(version string) *metav1.LabelSelector {
 func webhookName(gv schema.GroupVersion) string {
 	// Each Webhook in the WebhookConfiguration needs a unqiue name. We have
 	// exactly one Webhook for each GroupVersion, so including both in the name
-	// guarantees name uniqueness.
+	// guarantees name uniqueness. The extra Webhooks added for drift
+	// prevention have a distinct prefix.
 	if gv.Group != "" {
 		return fmt.Sprintf("%s.%s.%s", strings.ToLower(gv.Group), strings.ToLower(gv.Version), Name)
 	}
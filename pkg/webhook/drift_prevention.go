@@ -0,0 +1,146 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+
+	admissionv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/klog/v2"
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
+	"kpt.dev/configsync/pkg/applyset"
+	"kpt.dev/configsync/pkg/declared"
+	csmetadata "kpt.dev/configsync/pkg/metadata"
+	"kpt.dev/configsync/pkg/status"
+	"kpt.dev/configsync/pkg/webhook/configuration"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	ctrlhandler "sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// driftPreventionController keeps the selectors of the
+// ValidatingWebhookConfiguration up to date with the
+// `configsync.gke.io/drift-prevention` annotations on the RootSyncs, RepoSyncs
+// and Namespaces on the cluster.
+type driftPreventionController struct {
+	client client.Client
+	// defaultEnabled is whether drift prevention is enabled for RootSyncs,
+	// RepoSyncs and Namespaces without the annotation.
+	defaultEnabled bool
+}
+
+var _ reconcile.Reconciler = &driftPreventionController{}
+
+// AddDriftPreventionController adds the controller which scopes the admission
+// webhook to the RootSyncs, RepoSyncs and Namespaces with drift prevention
+// enabled to the passed manager.
+func AddDriftPreventionController(mgr manager.Manager, defaultEnabled bool) error {
+	c := &driftPreventionController{
+		client:         mgr.GetClient(),
+		defaultEnabled: defaultEnabled,
+	}
+	// All events trigger reconciliation of the one ValidatingWebhookConfiguration.
+	toConfiguration := ctrlhandler.EnqueueRequestsFromMapFunc(func(context.Context, client.Object) []reconcile.Request {
+		return []reconcile.Request{{NamespacedName: client.ObjectKey{Name: configuration.Name}}}
+	})
+	isConfiguration := predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		return obj.GetName() == configuration.Name
+	})
+	return controllerruntime.NewControllerManagedBy(mgr).
+		Named("drift-prevention").
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: 1,
+		}).
+		For(&admissionv1.ValidatingWebhookConfiguration{}, builder.WithPredicates(isConfiguration)).
+		Watches(&v1beta1.RootSync{}, toConfiguration).
+		Watches(&v1beta1.RepoSync{}, toConfiguration).
+		Watches(&corev1.Namespace{}, toConfiguration, builder.WithPredicates(predicate.AnnotationChangedPredicate{})).
+		Complete(c)
+}
+
+// Reconcile updates the selectors of the ValidatingWebhookConfiguration, if
+// they have changed.
+func (c *driftPreventionController) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	dp, err := c.driftPrevention(ctx)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	cfg := &admissionv1.ValidatingWebhookConfiguration{}
+	if err := c.client.Get(ctx, req.NamespacedName, cfg); err != nil {
+		if apierrors.IsNotFound(err) {
+			// The configuration will be reconciled when it is created.
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, status.APIServerErrorf(err, "failed to get ValidatingWebhookConfiguration: %s", req.Name)
+	}
+	if !dp.Apply(cfg) {
+		return reconcile.Result{}, nil
+	}
+	klog.Infof("Updating admission webhook drift prevention selectors: syncs: %v, namespaces: %v",
+		dp.SyncOverrides, dp.NamespaceOverrides)
+	if err := c.client.Update(ctx, cfg, client.FieldOwner(configsync.FieldManager)); err != nil {
+		return reconcile.Result{}, status.APIServerErrorf(err, "failed to update ValidatingWebhookConfiguration: %s", req.Name)
+	}
+	return reconcile.Result{}, nil
+}
+
+// driftPrevention lists the RootSyncs, RepoSyncs and Namespaces which
+// override the default drift prevention setting.
+func (c *driftPreventionController) driftPrevention(ctx context.Context) (configuration.DriftPrevention, error) {
+	dp := configuration.DriftPrevention{DefaultEnabled: c.defaultEnabled}
+
+	rootSyncs := &v1beta1.RootSyncList{}
+	if err := c.client.List(ctx, rootSyncs); err != nil {
+		return dp, status.APIServerError(err, "failed to list RootSyncs")
+	}
+	for i := range rootSyncs.Items {
+		rs := &rootSyncs.Items[i]
+		if csmetadata.DriftPreventionEnabledFor(rs, c.defaultEnabled) != c.defaultEnabled {
+			dp.SyncOverrides = append(dp.SyncOverrides, applyset.IDFromSync(rs.Name, declared.RootScope))
+		}
+	}
+
+	repoSyncs := &v1beta1.RepoSyncList{}
+	if err := c.client.List(ctx, repoSyncs); err != nil {
+		return dp, status.APIServerError(err, "failed to list RepoSyncs")
+	}
+	for i := range repoSyncs.Items {
+		rs := &repoSyncs.Items[i]
+		if csmetadata.DriftPreventionEnabledFor(rs, c.defaultEnabled) != c.defaultEnabled {
+			dp.SyncOverrides = append(dp.SyncOverrides, applyset.IDFromSync(rs.Name, declared.Scope(rs.Namespace)))
+		}
+	}
+
+	namespaces := &corev1.NamespaceList{}
+	if err := c.client.List(ctx, namespaces); err != nil {
+		return dp, status.APIServerError(err, "failed to list Namespaces")
+	}
+	for i := range namespaces.Items {
+		ns := &namespaces.Items[i]
+		if csmetadata.DriftPreventionEnabledFor(ns, c.defaultEnabled) != c.defaultEnabled {
+			dp.NamespaceOverrides = append(dp.NamespaceOverrides, ns.Name)
+		}
+	}
+	return dp, nil
+}
@@ -0,0 +1,110 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/applyset"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/core/k8sobjects"
+	"kpt.dev/configsync/pkg/declared"
+	csmetadata "kpt.dev/configsync/pkg/metadata"
+	syncertestfake "kpt.dev/configsync/pkg/syncer/syncertest/fake"
+	"kpt.dev/configsync/pkg/webhook/configuration"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestDriftPreventionController_Reconcile(t *testing.T) {
+	webhookCfg := &admissionv1.ValidatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: configuration.Name},
+		Webhooks: []admissionv1.ValidatingWebhook{{
+			Name: "v1." + configuration.Name,
+			ObjectSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{csmetadata.DeclaredVersionLabel: "v1"},
+			},
+		}},
+	}
+	fakeClient := syncertestfake.NewClient(t, core.Scheme,
+		webhookCfg,
+		k8sobjects.RootSyncObjectV1Beta1("root-sync"),
+		k8sobjects.RootSyncObjectV1Beta1("dev-root-sync",
+			csmetadata.WithDriftPrevention(csmetadata.DriftPreventionDisabled)),
+		k8sobjects.RepoSyncObjectV1Beta1("prod", "repo-sync",
+			csmetadata.WithDriftPrevention(csmetadata.DriftPreventionEnabled)),
+		k8sobjects.RepoSyncObjectV1Beta1("dev", "repo-sync",
+			csmetadata.WithDriftPrevention(csmetadata.DriftPreventionDisabled)),
+		k8sobjects.NamespaceObject("prod"),
+		k8sobjects.NamespaceObject("dev",
+			csmetadata.WithDriftPrevention(csmetadata.DriftPreventionDisabled)),
+	)
+	c := &driftPreventionController{
+		client:         fakeClient,
+		defaultEnabled: true,
+	}
+
+	ctx := context.Background()
+	req := reconcile.Request{NamespacedName: client.ObjectKey{Name: configuration.Name}}
+	_, err := c.Reconcile(ctx, req)
+	require.NoError(t, err)
+
+	got := &admissionv1.ValidatingWebhookConfiguration{}
+	require.NoError(t, fakeClient.Get(ctx, req.NamespacedName, got))
+	require.Len(t, got.Webhooks, 1)
+	assert.Equal(t, "v1", got.Webhooks[0].ObjectSelector.MatchLabels[csmetadata.DeclaredVersionLabel])
+	wantSyncs := []string{
+		applyset.IDFromSync("dev-root-sync", declared.RootScope),
+		applyset.IDFromSync("repo-sync", declared.Scope("dev")),
+	}
+	require.Len(t, got.Webhooks[0].ObjectSelector.MatchExpressions, 1)
+	objectReq := got.Webhooks[0].ObjectSelector.MatchExpressions[0]
+	assert.Equal(t, csmetadata.ApplySetPartOfLabel, objectReq.Key)
+	assert.Equal(t, metav1.LabelSelectorOpNotIn, objectReq.Operator)
+	assert.ElementsMatch(t, wantSyncs, objectReq.Values)
+	assert.Equal(t, &metav1.LabelSelector{
+		MatchExpressions: []metav1.LabelSelectorRequirement{
+			{Key: corev1.LabelMetadataName, Operator: metav1.LabelSelectorOpNotIn, Values: []string{"dev"}},
+		},
+	}, got.Webhooks[0].NamespaceSelector)
+
+	// Re-enabling drift prevention for the dev Namespace removes it from the
+	// namespaceSelector.
+	ns := k8sobjects.NamespaceObject("dev")
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(ns), ns))
+	core.RemoveAnnotations(ns, csmetadata.DriftPreventionAnnotationKey)
+	require.NoError(t, fakeClient.Update(ctx, ns, client.FieldOwner(configsync.FieldManager)))
+	_, err = c.Reconcile(ctx, req)
+	require.NoError(t, err)
+	require.NoError(t, fakeClient.Get(ctx, req.NamespacedName, got))
+	assert.Empty(t, got.Webhooks[0].NamespaceSelector.MatchExpressions)
+}
+
+func TestDriftPreventionController_ReconcileWithoutConfiguration(t *testing.T) {
+	c := &driftPreventionController{
+		client:         syncertestfake.NewClient(t, core.Scheme),
+		defaultEnabled: true,
+	}
+	req := reconcile.Request{NamespacedName: client.ObjectKey{Name: configuration.Name}}
+	_, err := c.Reconcile(context.Background(), req)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,307 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"fmt"
+	"strings"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/klog/v2"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/declared"
+	"kpt.dev/configsync/pkg/kinds"
+	csmetadata "kpt.dev/configsync/pkg/metadata"
+	"kpt.dev/configsync/pkg/syncer/differ"
+	"kpt.dev/configsync/pkg/webhook/configuration"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	"sigs.k8s.io/structured-merge-diff/v4/fieldpath"
+)
+
+// Rule identifies the webhook rule which decided the outcome of a request.
+type Rule string
+
+const (
+	// RuleValidateManager is the rule enforced by diff.ValidateManager: a Config
+	// Sync reconciler may only modify objects managed by itself, or by a
+	// reconciler with lower precedence.
+	RuleValidateManager Rule = "ValidateManager"
+	// RuleResourceGroupInventory prevents users from modifying ResourceGroups
+	// generated by Config Sync.
+	RuleResourceGroupInventory Rule = "ResourceGroupInventory"
+	// RuleManagedObjectCreate prevents users from creating objects which are
+	// marked as managed by Config Sync.
+	RuleManagedObjectCreate Rule = "ManagedObjectCreate"
+	// RuleManagedObjectDelete prevents users from deleting objects which are
+	// managed by Config Sync.
+	RuleManagedObjectDelete Rule = "ManagedObjectDelete"
+	// RuleConfigSyncMetadata prevents users from modifying Config Sync labels
+	// and annotations.
+	RuleConfigSyncMetadata Rule = "ConfigSyncMetadata"
+	// RuleDeclaredFields prevents users from modifying fields declared in the
+	// source of truth.
+	RuleDeclaredFields Rule = "DeclaredFields"
+	// RuleIgnoreMutation allows users to modify objects with the
+	// `client.lifecycle.config.k8s.io/mutation: ignore` annotation.
+	RuleIgnoreMutation Rule = "IgnoreMutation"
+	// RuleUnmanagedObject allows users to modify objects which are not managed
+	// by Config Sync.
+	RuleUnmanagedObject Rule = "UnmanagedObject"
+	// RuleNotIntercepted allows requests which the API server doesn't send to
+	// the webhook, such as changes to objects for which drift prevention is
+	// disabled.
+	RuleNotIntercepted Rule = "NotIntercepted"
+)
+
+// Explanation describes how the webhook handles an admission request.
+type Explanation struct {
+	// Object is the group, kind, namespace and name of the object.
+	Object string `json:"object,omitempty"`
+	// Username is the name of the user making the request.
+	Username string `json:"username"`
+	// Operation is the operation being performed.
+	Operation admissionv1.Operation `json:"operation"`
+	// Managed is true if the object is managed by Config Sync.
+	Managed bool `json:"managed"`
+	// Manager is the value of the `configsync.gke.io/manager` annotation.
+	Manager string `json:"manager,omitempty"`
+	// Reconciler is the name of the reconciler which manages the object.
+	Reconciler string `json:"reconciler,omitempty"`
+	// DeclaredFields are the fields listed in the
+	// `configsync.gke.io/declared-fields` annotation.
+	DeclaredFields []string `json:"declaredFields,omitempty"`
+	// ChangedFields are the fields which would be added, modified or removed by
+	// an update.
+	ChangedFields []string `json:"changedFields,omitempty"`
+	// BlockedFields are the changed fields which caused the request to be
+	// denied.
+	BlockedFields []string `json:"blockedFields,omitempty"`
+	// Allowed is true if the request would be admitted.
+	Allowed bool `json:"allowed"`
+	// Rule is the rule which decided the outcome of the request, if any.
+	Rule Rule `json:"rule,omitempty"`
+	// Reason is the reason the request would be denied.
+	Reason metav1.StatusReason `json:"reason,omitempty"`
+	// Message is a human-readable description of the outcome.
+	Message string `json:"message,omitempty"`
+	// Error is set if the request could not be evaluated. The webhook admits
+	// requests it cannot evaluate.
+	Error string `json:"error,omitempty"`
+}
+
+// describe populates the fields which describe the object's management.
+func (e *Explanation) describe(oldObj, newObj client.Object) {
+	obj := oldObj
+	if obj == nil {
+		obj = newObj
+	}
+	e.Object = core.GKNN(obj)
+	e.Managed = differ.ManagedByConfigSync(oldObj) || differ.ManagedByConfigSync(newObj)
+	e.Manager = objectManager(oldObj, newObj)
+	if e.Manager != "" {
+		syncScope, syncName := declared.ManagerScopeAndName(e.Manager)
+		if syncScope.Validate() == nil {
+			e.Reconciler = declared.ReconcilerNameFromScope(syncScope, syncName)
+		}
+	}
+	if _, found := obj.GetAnnotations()[csmetadata.DeclaredFieldsKey]; found {
+		declaredSet, err := DeclaredFields(obj)
+		if err != nil {
+			klog.Warningf("Failed to decode declared fields for object %q: %v", core.GKNN(obj), err)
+		} else {
+			e.DeclaredFields = fieldPaths(declaredSet)
+		}
+	}
+}
+
+// deny marks the request as denied by the specified rule.
+func (e *Explanation) deny(rule Rule, reason metav1.StatusReason, message string) {
+	e.Allowed = false
+	e.Rule = rule
+	e.Reason = reason
+	e.Message = message
+}
+
+// fieldPaths returns the paths in the set as strings.
+func fieldPaths(set *fieldpath.Set) []string {
+	var paths []string
+	set.Iterate(func(path fieldpath.Path) {
+		paths = append(paths, path.String())
+	})
+	return paths
+}
+
+// metadataFieldPaths returns the paths in the set of metadata fields, as
+// returned by ConfigSyncMetadata, as strings.
+func metadataFieldPaths(set *fieldpath.Set) []string {
+	var paths []string
+	set.Iterate(func(path fieldpath.Path) {
+		paths = append(paths, append(fieldpath.Path{metadataPath}, path...).String())
+	})
+	return paths
+}
+
+// ExplainRequest describes an admission request to explain.
+type ExplainRequest struct {
+	// Username is the name of the user making the request.
+	// Config Sync reconcilers are identified by their service account username,
+	// e.g. `system:serviceaccount:config-management-system:root-reconciler`.
+	Username string `json:"username"`
+	// Groups are the groups of the user making the request. Defaults to the
+	// service account groups, if Username is a Config Sync service account.
+	Groups []string `json:"groups,omitempty"`
+	// Operation is the operation being performed. Defaults to CREATE if only
+	// Object is specified, DELETE if only OldObject is specified, and UPDATE if
+	// both are specified.
+	Operation admissionv1.Operation `json:"operation,omitempty"`
+	// Object is the new version of the object. Required for CREATE and UPDATE.
+	Object *unstructured.Unstructured `json:"object,omitempty"`
+	// OldObject is the version of the object in the cluster. Required for
+	// UPDATE and DELETE.
+	OldObject *unstructured.Unstructured `json:"oldObject,omitempty"`
+}
+
+// AdmissionRequest returns the admission request the API server would send to
+// the webhook.
+func (r *ExplainRequest) AdmissionRequest() (admission.Request, error) {
+	if r.Username == "" {
+		return admission.Request{}, fmt.Errorf("username is required")
+	}
+	op := r.Operation
+	if op == "" {
+		switch {
+		case r.OldObject == nil:
+			op = admissionv1.Create
+		case r.Object == nil:
+			op = admissionv1.Delete
+		default:
+			op = admissionv1.Update
+		}
+	}
+	switch op {
+	case admissionv1.Create:
+		if r.Object == nil {
+			return admission.Request{}, fmt.Errorf("object is required for %s", op)
+		}
+	case admissionv1.Update:
+		if r.Object == nil || r.OldObject == nil {
+			return admission.Request{}, fmt.Errorf("object and oldObject are required for %s", op)
+		}
+	case admissionv1.Delete:
+		if r.OldObject == nil {
+			return admission.Request{}, fmt.Errorf("oldObject is required for %s", op)
+		}
+	default:
+		return admission.Request{}, fmt.Errorf("unsupported operation: %s", op)
+	}
+
+	userInfo := authenticationv1.UserInfo{
+		Username: r.Username,
+		Groups:   r.Groups,
+	}
+	if len(userInfo.Groups) == 0 && strings.HasPrefix(r.Username, saNamespaceGroupPrefix) {
+		userInfo.Groups = []string{saGroup, saNamespaceGroup}
+	}
+
+	req := admission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{
+			Operation: op,
+			UserInfo:  userInfo,
+		},
+	}
+	// Favor the original version of the object (unless this is a CREATE in which
+	// case there is only the new version).
+	obj := r.OldObject
+	if op == admissionv1.Create {
+		obj = r.Object
+	}
+	gvk := obj.GroupVersionKind()
+	req.Kind = metav1.GroupVersionKind{Group: gvk.Group, Version: gvk.Version, Kind: gvk.Kind}
+	req.Name = obj.GetName()
+	req.Namespace = obj.GetNamespace()
+	if op != admissionv1.Delete {
+		req.Object = runtime.RawExtension{Object: r.Object}
+	}
+	if op != admissionv1.Create {
+		req.OldObject = runtime.RawExtension{Object: r.OldObject}
+	}
+	return req, nil
+}
+
+// ExplainIntercepted is like Explain, but first evaluates the rules and
+// selectors of the webhook configuration, which decide whether the API server
+// sends the request to the webhook at all, as drift prevention may be disabled
+// for the object by the default or by the drift prevention annotation of its
+// RootSync, RepoSync or Namespace. namespace is the Namespace of a namespaced
+// object, or nil if not found. A nil configuration intercepts nothing.
+func (v *Validator) ExplainIntercepted(req admission.Request, cfg *admissionregistrationv1.ValidatingWebhookConfiguration, namespace *corev1.Namespace) *Explanation {
+	exp := v.Explain(req)
+	if exp.Error != "" {
+		return exp
+	}
+	oldObj, newObj, err := convertObjects(req)
+	if err != nil {
+		exp.Error = err.Error()
+		return exp
+	}
+	r := configuration.Request{
+		Operation:    admissionregistrationv1.OperationType(req.Operation),
+		GroupVersion: schema.GroupVersion{Group: req.Kind.Group, Version: req.Kind.Version},
+	}
+	for _, obj := range []client.Object{oldObj, newObj} {
+		if obj != nil {
+			r.Labels = append(r.Labels, obj.GetLabels())
+		}
+	}
+	switch {
+	case req.Kind.Group == kinds.Namespace().Group && req.Kind.Kind == kinds.Namespace().Kind:
+		// The namespaceSelector matches the labels of the Namespace itself.
+		obj := oldObj
+		if obj == nil {
+			obj = newObj
+		}
+		r.MatchNamespace = true
+		r.NamespaceLabels = obj.GetLabels()
+	case req.Namespace != "":
+		r.Namespaced = true
+		r.MatchNamespace = true
+		if namespace != nil {
+			r.NamespaceLabels = namespace.GetLabels()
+		}
+	}
+	intercepted, err := configuration.Intercepts(cfg, r)
+	if err != nil {
+		exp.Error = err.Error()
+		return exp
+	}
+	if !intercepted {
+		exp.Allowed = true
+		exp.BlockedFields = nil
+		exp.Rule = RuleNotIntercepted
+		exp.Reason = ""
+		exp.Message = fmt.Sprintf("the admission webhook does not intercept %s requests for %q, "+
+			"because drift prevention is disabled for it, or its version is not declared in any source",
+			req.Operation, exp.Object)
+	}
+	return exp
+}
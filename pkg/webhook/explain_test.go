@@ -0,0 +1,365 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionv1 "k8s.io/api/admission/v1"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/core/k8sobjects"
+	csmetadata "kpt.dev/configsync/pkg/metadata"
+	"kpt.dev/configsync/pkg/webhook/configuration"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const roleDeclaredFields = `{"f:metadata":{"f:labels":{"f:app.kubernetes.io/managed-by":{}},"f:annotations":{"f:configmanagement.gke.io/managed":{},"f:configsync.gke.io/manager":{}}},"f:rules":{}}`
+
+var roleDeclaredFieldPaths = []string{
+	".rules",
+	".metadata.annotations.configmanagement.gke.io/managed",
+	".metadata.annotations.configsync.gke.io/manager",
+	".metadata.labels.app.kubernetes.io/managed-by",
+}
+
+func managedRole(opts ...core.MetaMutator) *rbacv1.Role {
+	opts = append([]core.MetaMutator{
+		core.Name("hello"),
+		core.Namespace("world"),
+		core.Label(csmetadata.ManagedByKey, csmetadata.ManagedByValue),
+		csmetadata.WithManagementMode(csmetadata.ManagementEnabled),
+		core.Annotation(csmetadata.ResourceIDKey, "rbac.authorization.k8s.io_role_world_hello"),
+		core.Annotation(csmetadata.ResourceManagerKey, rootSyncManagerAnnotation(rootSyncName)),
+		core.Annotation(csmetadata.DeclaredFieldsKey, roleDeclaredFields),
+	}, opts...)
+	return k8sobjects.RoleObject(opts...)
+}
+
+func toUnstructured(t *testing.T, obj client.Object) *unstructured.Unstructured {
+	t.Helper()
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	require.NoError(t, err)
+	return &unstructured.Unstructured{Object: u}
+}
+
+func TestExplainRequest_AdmissionRequest(t *testing.T) {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(rbacv1.SchemeGroupVersion.WithKind("Role"))
+	obj.SetName("hello")
+	obj.SetNamespace("world")
+
+	testCases := []struct {
+		name          string
+		req           ExplainRequest
+		wantOperation admissionv1.Operation
+		wantGroups    []string
+		wantErr       bool
+	}{
+		{
+			name:          "create by default with only object",
+			req:           ExplainRequest{Username: "bob@acme.com", Object: obj},
+			wantOperation: admissionv1.Create,
+		},
+		{
+			name:          "delete by default with only old object",
+			req:           ExplainRequest{Username: "bob@acme.com", OldObject: obj},
+			wantOperation: admissionv1.Delete,
+		},
+		{
+			name:          "update by default with both objects",
+			req:           ExplainRequest{Username: "bob@acme.com", Object: obj, OldObject: obj},
+			wantOperation: admissionv1.Update,
+		},
+		{
+			name:          "reconciler service account groups by default",
+			req:           ExplainRequest{Username: saNamespaceGroupPrefix + "root-reconciler", Object: obj},
+			wantOperation: admissionv1.Create,
+			wantGroups:    []string{saGroup, saNamespaceGroup},
+		},
+		{
+			name:    "update without old object",
+			req:     ExplainRequest{Username: "bob@acme.com", Operation: admissionv1.Update, Object: obj},
+			wantErr: true,
+		},
+		{
+			name:    "missing username",
+			req:     ExplainRequest{Object: obj},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req, err := tc.req.AdmissionRequest()
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantOperation, req.Operation)
+			assert.Equal(t, tc.wantGroups, req.UserInfo.Groups)
+			assert.Equal(t, metav1.GroupVersionKind{Group: rbacv1.GroupName, Version: "v1", Kind: "Role"}, req.Kind)
+			assert.Equal(t, "hello", req.Name)
+			assert.Equal(t, "world", req.Namespace)
+		})
+	}
+}
+
+func TestValidator_Explain(t *testing.T) {
+	rules := []rbacv1.PolicyRule{{APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"get"}}}
+	oldRole := managedRole(setRules(rules))
+	changedRules := managedRole(setRules(append(rules, rbacv1.PolicyRule{APIGroups: []string{""}, Resources: []string{"secrets"}, Verbs: []string{"get"}})))
+	changedLabel := managedRole(setRules(rules), core.Label("team", "dev"))
+	changedManager := managedRole(setRules(rules), core.Annotation(csmetadata.ResourceManagerKey, "other"))
+	ignored := managedRole(setRules(rules), core.Annotation(csmetadata.LifecycleMutationAnnotation, csmetadata.IgnoreMutation))
+	ignoredChanged := managedRole(setRules(append(rules, rbacv1.PolicyRule{APIGroups: []string{""}, Resources: []string{"secrets"}, Verbs: []string{"get"}})),
+		core.Annotation(csmetadata.LifecycleMutationAnnotation, csmetadata.IgnoreMutation))
+
+	testCases := []struct {
+		name string
+		req  ExplainRequest
+		want Explanation
+	}{
+		{
+			name: "user modifies declared field",
+			req: ExplainRequest{
+				Username:  "bob@acme.com",
+				Object:    toUnstructured(t, changedRules),
+				OldObject: toUnstructured(t, oldRole),
+			},
+			want: Explanation{
+				Object:         "rbac.authorization.k8s.io_role_world_hello",
+				Username:       "bob@acme.com",
+				Operation:      admissionv1.Update,
+				Managed:        true,
+				Manager:        ":root_my-root-sync",
+				Reconciler:     "root-reconciler-my-root-sync",
+				DeclaredFields: roleDeclaredFieldPaths,
+				ChangedFields:  []string{".rules"},
+				BlockedFields:  []string{".rules"},
+				Allowed:        false,
+				Rule:           RuleDeclaredFields,
+				Reason:         metav1.StatusReasonForbidden,
+			},
+		},
+		{
+			name: "user modifies undeclared field",
+			req: ExplainRequest{
+				Username:  "bob@acme.com",
+				Object:    toUnstructured(t, changedLabel),
+				OldObject: toUnstructured(t, oldRole),
+			},
+			want: Explanation{
+				Object:         "rbac.authorization.k8s.io_role_world_hello",
+				Username:       "bob@acme.com",
+				Operation:      admissionv1.Update,
+				Managed:        true,
+				Manager:        ":root_my-root-sync",
+				Reconciler:     "root-reconciler-my-root-sync",
+				DeclaredFields: roleDeclaredFieldPaths,
+				ChangedFields:  []string{".metadata.labels.team"},
+				Allowed:        true,
+			},
+		},
+		{
+			name: "user modifies Config Sync metadata",
+			req: ExplainRequest{
+				Username:  "bob@acme.com",
+				Object:    toUnstructured(t, changedManager),
+				OldObject: toUnstructured(t, oldRole),
+			},
+			want: Explanation{
+				Object:         "rbac.authorization.k8s.io_role_world_hello",
+				Username:       "bob@acme.com",
+				Operation:      admissionv1.Update,
+				Managed:        true,
+				Manager:        ":root_my-root-sync",
+				Reconciler:     "root-reconciler-my-root-sync",
+				DeclaredFields: roleDeclaredFieldPaths,
+				ChangedFields:  []string{".metadata.annotations.configsync.gke.io/manager"},
+				BlockedFields:  []string{".metadata.annotations.configsync.gke.io/manager"},
+				Allowed:        false,
+				Rule:           RuleConfigSyncMetadata,
+				Reason:         metav1.StatusReasonForbidden,
+			},
+		},
+		{
+			name: "user modifies object with mutation ignored",
+			req: ExplainRequest{
+				Username:  "bob@acme.com",
+				Object:    toUnstructured(t, ignoredChanged),
+				OldObject: toUnstructured(t, ignored),
+			},
+			want: Explanation{
+				Object:         "rbac.authorization.k8s.io_role_world_hello",
+				Username:       "bob@acme.com",
+				Operation:      admissionv1.Update,
+				Managed:        true,
+				Manager:        ":root_my-root-sync",
+				Reconciler:     "root-reconciler-my-root-sync",
+				DeclaredFields: roleDeclaredFieldPaths,
+				ChangedFields:  []string{".rules"},
+				Allowed:        true,
+				Rule:           RuleIgnoreMutation,
+			},
+		},
+		{
+			name: "user deletes managed object",
+			req: ExplainRequest{
+				Username:  "bob@acme.com",
+				OldObject: toUnstructured(t, oldRole),
+			},
+			want: Explanation{
+				Object:         "rbac.authorization.k8s.io_role_world_hello",
+				Username:       "bob@acme.com",
+				Operation:      admissionv1.Delete,
+				Managed:        true,
+				Manager:        ":root_my-root-sync",
+				Reconciler:     "root-reconciler-my-root-sync",
+				DeclaredFields: roleDeclaredFieldPaths,
+				Allowed:        false,
+				Rule:           RuleManagedObjectDelete,
+				Reason:         metav1.StatusReasonUnauthorized,
+			},
+		},
+		{
+			name: "namespace reconciler deletes object managed by root reconciler",
+			req: ExplainRequest{
+				Username:  configSyncNamespaceReconciler("bookstore", repoSyncName).Username,
+				OldObject: toUnstructured(t, oldRole),
+			},
+			want: Explanation{
+				Object:         "rbac.authorization.k8s.io_role_world_hello",
+				Username:       configSyncNamespaceReconciler("bookstore", repoSyncName).Username,
+				Operation:      admissionv1.Delete,
+				Managed:        true,
+				Manager:        ":root_my-root-sync",
+				Reconciler:     "root-reconciler-my-root-sync",
+				DeclaredFields: roleDeclaredFieldPaths,
+				Allowed:        false,
+				Rule:           RuleValidateManager,
+				Reason:         metav1.StatusReasonUnauthorized,
+			},
+		},
+	}
+
+	v := validatorForTest(t)
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req, err := tc.req.AdmissionRequest()
+			require.NoError(t, err)
+			got := *v.Explain(req)
+			if !tc.want.Allowed || tc.want.Rule != "" {
+				assert.NotEmpty(t, got.Message)
+			}
+			// The message is tested by TestValidator_Handle.
+			got.Message = ""
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestValidator_ExplainIntercepted(t *testing.T) {
+	role := managedRole(core.Label(csmetadata.DeclaredVersionLabel, "v1"))
+	// The API server sets the name label on every Namespace.
+	namespace := k8sobjects.NamespaceObject("world", core.Label(corev1.LabelMetadataName, "world"))
+
+	// The webhook for rbac.authorization.k8s.io/v1, like the one the reconciler
+	// adds to the configuration.
+	newConfiguration := func(dp configuration.DriftPrevention) *admissionregistrationv1.ValidatingWebhookConfiguration {
+		cfg := &admissionregistrationv1.ValidatingWebhookConfiguration{
+			Webhooks: []admissionregistrationv1.ValidatingWebhook{{
+				Name: "rbac.authorization.k8s.io.v1." + configuration.Name,
+				Rules: []admissionregistrationv1.RuleWithOperations{{
+					Operations: []admissionregistrationv1.OperationType{
+						admissionregistrationv1.Create, admissionregistrationv1.Update, admissionregistrationv1.Delete,
+					},
+					Rule: admissionregistrationv1.Rule{
+						APIGroups:   []string{rbacv1.GroupName},
+						APIVersions: []string{"v1"},
+						Resources:   []string{"*"},
+					},
+				}},
+				ObjectSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{csmetadata.DeclaredVersionLabel: "v1"},
+				},
+			}},
+		}
+		dp.Apply(cfg)
+		return cfg
+	}
+
+	testCases := []struct {
+		name     string
+		cfg      *admissionregistrationv1.ValidatingWebhookConfiguration
+		wantRule Rule
+		wantOK   bool
+	}{
+		{
+			name:     "no webhook configuration",
+			wantRule: RuleNotIntercepted,
+			wantOK:   true,
+		},
+		{
+			name:     "drift prevention enabled",
+			cfg:      newConfiguration(configuration.DriftPrevention{DefaultEnabled: true}),
+			wantRule: RuleManagedObjectDelete,
+		},
+		{
+			name:     "drift prevention disabled for the namespace",
+			cfg:      newConfiguration(configuration.DriftPrevention{DefaultEnabled: true, NamespaceOverrides: []string{"world"}}),
+			wantRule: RuleNotIntercepted,
+			wantOK:   true,
+		},
+		{
+			name:     "drift prevention disabled, but enabled for the namespace",
+			cfg:      newConfiguration(configuration.DriftPrevention{NamespaceOverrides: []string{"world"}}),
+			wantRule: RuleManagedObjectDelete,
+		},
+		{
+			name:     "drift prevention disabled, but enabled for another namespace",
+			cfg:      newConfiguration(configuration.DriftPrevention{NamespaceOverrides: []string{"other"}}),
+			wantRule: RuleNotIntercepted,
+			wantOK:   true,
+		},
+	}
+
+	v := validatorForTest(t)
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			explainReq := &ExplainRequest{
+				Username:  "bob@acme.com",
+				OldObject: toUnstructured(t, role),
+			}
+			req, err := explainReq.AdmissionRequest()
+			require.NoError(t, err)
+			got := v.ExplainIntercepted(req, tc.cfg, namespace)
+			assert.Empty(t, got.Error)
+			assert.Equal(t, tc.wantRule, got.Rule)
+			assert.Equal(t, tc.wantOK, got.Allowed)
+			assert.NotEmpty(t, got.Message)
+		})
+	}
+}
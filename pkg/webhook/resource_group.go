@@ -20,7 +20,6 @@ import (
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
-	"k8s.io/klog/v2"
 	"kpt.dev/configsync/pkg/api/kpt.dev/v1alpha1"
 	"kpt.dev/configsync/pkg/applier"
 	"sigs.k8s.io/cli-utils/pkg/common"
@@ -36,21 +35,19 @@ func isResourceGroupRequest(req admission.Request) bool {
 	return gk == v1alpha1.SchemeGroupVersionKind().GroupKind()
 }
 
-// handleResourceGroupRequest handles the request with following rules:
+// explainResourceGroupRequest evaluates the request with following rules:
 // If the ResourceGroup CR is generated by ConfigSync, users can't modify it.
 // If the ResourceGroup CR is not generated by ConfigSync, users can modify it.
-func handleResourceGroupRequest(req admission.Request) admission.Response {
+func explainResourceGroupRequest(exp *Explanation, req admission.Request) {
 	fromConfigSync, err := fromConfigSync(req)
 	if err != nil {
-		klog.Errorf("Unable to read labels from new object: %v", req.Object.Object)
-		return allow()
+		exp.Error = fmt.Sprintf("Unable to read labels from new object: %v", req.Object.Object)
+		return
 	}
 	if fromConfigSync {
-		klog.Errorf("%s is not authorized to modify %s generated by Config Sync", req.UserInfo.Username, v1alpha1.SchemeGroupVersionKind().GroupKind())
-		return deny(metav1.StatusReasonUnauthorized, fmt.Sprintf("%s is not authorized to modify %s generated by Config Sync", req.UserInfo.Username, v1alpha1.SchemeGroupVersionKind().GroupKind()))
+		exp.deny(RuleResourceGroupInventory, metav1.StatusReasonUnauthorized, fmt.Sprintf("%s is not authorized to modify %s generated by Config Sync", req.UserInfo.Username, v1alpha1.SchemeGroupVersionKind().GroupKind()))
 	}
 	// Allow ResourceGroups that are not generated by ConfigSync.
-	return allow()
 }
 
 var metadataAccessor = meta.NewAccessor()
@@ -39,7 +39,7 @@ import (
 
 // AddValidator adds the admission webhook validator to the passed manager.
 func AddValidator(mgr manager.Manager) error {
-	handler, err := handler(mgr.GetConfig())
+	handler, err := NewValidator(mgr.GetConfig())
 	if err != nil {
 		return err
 	}
@@ -57,8 +57,10 @@ type Validator struct {
 
 var _ admission.Handler = &Validator{}
 
-// Handler returns a Validator which satisfies the admission.Handler interface.
-func handler(cfg *rest.Config) (*Validator, error) {
+// NewValidator returns a Validator which satisfies the admission.Handler
+// interface. The schemas of the declared fields are pulled with the given
+// config.
+func NewValidator(cfg *rest.Config) (*Validator, error) {
 	dc, err := discovery.NewDiscoveryClientForConfig(cfg)
 	if err != nil {
 		return nil, err
@@ -72,122 +74,148 @@ func handler(cfg *rest.Config) (*Validator, error) {
 
 // Handle implements admission.Handler
 func (v *Validator) Handle(_ context.Context, req admission.Request) admission.Response {
+	exp := v.Explain(req)
+	switch {
+	case exp.Error != "":
+		// Fail open if the request could not be evaluated.
+		klog.Error(exp.Error)
+	case !exp.Allowed:
+		klog.Error(exp.Message)
+		return deny(exp.Reason, exp.Message)
+	case exp.Rule == RuleUnmanagedObject:
+		// The webhook should be configured to only intercept resources which are
+		// managed by Config Sync.
+		klog.Warning(exp.Message)
+	}
+	return allow()
+}
+
+// Explain evaluates the admission request and returns an Explanation of
+// whether it would be admitted and which rule decided it.
+func (v *Validator) Explain(req admission.Request) *Explanation {
+	exp := &Explanation{
+		Username:  req.UserInfo.Username,
+		Operation: req.Operation,
+		Allowed:   true,
+	}
+
 	// An admission request for a sub-resource (such as a Scale) will not include
 	// the full parent for us to validate until the admission chain is fixed:
 	// https://github.com/kubernetes/enhancements/pull/1600
 	// Until then, we will not configure the webhook to intercept subresources so
 	// this block should never be reached.
 	if req.SubResource != "" {
-		klog.Errorf("Unable to review admission request for sub-resource: %v", req)
-		return allow()
+		exp.Error = fmt.Sprintf("Unable to review admission request for sub-resource: %v", req)
+		return exp
 	}
 
 	// Convert to client.Objects for convenience.
 	oldObj, newObj, err := convertObjects(req)
 	if err != nil {
-		klog.Error(err.Error())
-		return allow()
+		exp.Error = err.Error()
+		return exp
+	}
+	if oldObj == nil && newObj == nil {
+		exp.Error = fmt.Sprintf("Unable to review admission request without an object: %v", req)
+		return exp
 	}
+	exp.describe(oldObj, newObj)
 
 	// Check UserInfo for Config Sync service account and handle if found.
 	if isConfigSyncSA(req.UserInfo) {
 		username := configSyncSAName(req.UserInfo)
-		manager := objectManager(oldObj, newObj)
 		id := objectID(oldObj, newObj)
 		// TODO: validate managed=enabled?
-		err = diff.ValidateManager(username, manager, id, req.Operation)
-		if err != nil {
-			klog.Error(err.Error())
-			return deny(metav1.StatusReasonUnauthorized, err.Error())
+		if err := diff.ValidateManager(username, exp.Manager, id, req.Operation); err != nil {
+			exp.deny(RuleValidateManager, metav1.StatusReasonUnauthorized, err.Error())
 		}
-		return allow()
+		return exp
 	}
 
 	// Handle the requests for ResourceGroup CRs.
 	if isResourceGroupRequest(req) {
-		return handleResourceGroupRequest(req)
+		explainResourceGroupRequest(exp, req)
+		return exp
 	}
 
 	username := req.UserInfo.Username
 	switch req.Operation {
 	case admissionv1.Create:
-		return v.handleCreate(newObj, username)
+		v.explainCreate(exp, newObj, username)
 	case admissionv1.Delete:
-		return v.handleDelete(oldObj, username)
+		v.explainDelete(exp, oldObj, username)
 	case admissionv1.Update:
-		return v.handleUpdate(oldObj, newObj, username)
+		v.explainUpdate(exp, oldObj, newObj, username)
 	default:
-		klog.Errorf("Unsupported operation: %v from %s", req.Operation, username)
-		return allow()
+		exp.Error = fmt.Sprintf("Unsupported operation: %v from %s", req.Operation, username)
 	}
+	return exp
 }
 
-func (v *Validator) handleCreate(newObj client.Object, username string) admission.Response {
+func (v *Validator) explainCreate(exp *Explanation, newObj client.Object, username string) {
 	if differ.ManagedByConfigSync(newObj) {
-		klog.Errorf("%s is not authorized to create managed resource %q", username, core.GKNN(newObj))
-		return deny(metav1.StatusReasonUnauthorized, fmt.Sprintf("%s is not authorized to create managed resource %q", username, core.GKNN(newObj)))
+		exp.deny(RuleManagedObjectCreate, metav1.StatusReasonUnauthorized, fmt.Sprintf("%s is not authorized to create managed resource %q", username, core.GKNN(newObj)))
 	}
-	return allow()
 }
 
-func (v *Validator) handleDelete(oldObj client.Object, username string) admission.Response {
+func (v *Validator) explainDelete(exp *Explanation, oldObj client.Object, username string) {
 	// This means a delete request was previously made and accepted, but removal of the API object is not yet complete.
 	// See http://b/199235728#comment16 for more details.
 	if oldObj.GetDeletionTimestamp() != nil {
-		return allow()
+		return
 	}
 	if differ.ManagedByConfigSync(oldObj) {
-		klog.Errorf("%s is not authorized to delete managed resource %q", username, core.GKNN(oldObj))
-		return deny(metav1.StatusReasonUnauthorized, fmt.Sprintf("%s is not authorized to delete managed resource %q", username, core.GKNN(oldObj)))
+		exp.deny(RuleManagedObjectDelete, metav1.StatusReasonUnauthorized, fmt.Sprintf("%s is not authorized to delete managed resource %q", username, core.GKNN(oldObj)))
 	}
-	return allow()
 }
 
-func (v *Validator) handleUpdate(oldObj, newObj client.Object, username string) admission.Response {
+func (v *Validator) explainUpdate(exp *Explanation, oldObj, newObj client.Object, username string) {
 	if !differ.ManagedByConfigSync(oldObj) && !differ.ManagedByConfigSync(newObj) {
 		// Both oldObj and newObj are not managed by Config Sync.
-		// The webhook should be configured to only intercept resources which are
-		// managed by Config Sync.
-		klog.Warningf("Received admission request from %s for unmanaged object %q", username, core.GKNN(newObj))
-		return allow()
+		exp.Rule = RuleUnmanagedObject
+		exp.Message = fmt.Sprintf("Received admission request from %s for unmanaged object %q", username, core.GKNN(newObj))
+		return
 	}
 
 	// Build a diff set between old and new objects.
 	diffSet, err := v.differ.FieldDiff(oldObj, newObj)
 	if err != nil {
-		klog.Errorf("Failed to generate field diff set for object %q: %v", core.GKNN(oldObj), err)
-		return allow()
+		exp.Error = fmt.Sprintf("Failed to generate field diff set for object %q: %v", core.GKNN(oldObj), err)
+		return
 	}
+	exp.ChangedFields = fieldPaths(diffSet)
 
 	// If the diff set includes any ConfigSync labels or annotations, reject the
 	// request immediately.
 	if csSet := ConfigSyncMetadata(diffSet); !csSet.Empty() {
-		klog.Errorf("%s cannot modify Config Sync metadata of object %q: %s", username, core.GKNN(oldObj), csSet.String())
-		return deny(metav1.StatusReasonForbidden, fmt.Sprintf("%s cannot modify Config Sync metadata of object %q: %s", username, core.GKNN(oldObj), csSet.String()))
+		exp.BlockedFields = metadataFieldPaths(csSet)
+		exp.deny(RuleConfigSyncMetadata, metav1.StatusReasonForbidden, fmt.Sprintf("%s cannot modify Config Sync metadata of object %q: %s", username, core.GKNN(oldObj), csSet.String()))
+		return
 	}
 
 	if oldObj.GetAnnotations()[csmetadata.LifecycleMutationAnnotation] == csmetadata.IgnoreMutation {
 		// We ignore user modifications to this resource. Per the above check, we
 		// know that this annotation has not been altered.
-		return allow()
+		exp.Rule = RuleIgnoreMutation
+		exp.Message = fmt.Sprintf("Config Sync ignores changes to object %q: %s=%s", core.GKNN(oldObj), csmetadata.LifecycleMutationAnnotation, csmetadata.IgnoreMutation)
+		return
 	}
 
 	// Use the ConfigSync declared fields annotation to build the set of fields
 	// which should not be modified.
 	declaredSet, err := DeclaredFields(oldObj)
 	if err != nil {
-		klog.Errorf("Failed to decoded declared fields for object %q: %v", core.GKNN(oldObj), err)
-		return allow()
+		exp.Error = fmt.Sprintf("Failed to decoded declared fields for object %q: %v", core.GKNN(oldObj), err)
+		return
 	}
 
 	// If the diff set and declared set have any fields in common, reject the
 	// request. Otherwise allow it.
 	invalidSet := diffSet.Intersection(declaredSet)
 	if !invalidSet.Empty() {
-		klog.Errorf("%s cannot modify fields of object %q managed by Config Sync: %s", username, core.GKNN(oldObj), invalidSet.String())
-		return deny(metav1.StatusReasonForbidden, fmt.Sprintf("%s cannot modify fields of object %q managed by Config Sync: %s", username, core.GKNN(oldObj), invalidSet.String()))
+		exp.BlockedFields = fieldPaths(invalidSet)
+		exp.deny(RuleDeclaredFields, metav1.StatusReasonForbidden, fmt.Sprintf("%s cannot modify fields of object %q managed by Config Sync: %s", username, core.GKNN(oldObj), invalidSet.String()))
 	}
-	return allow()
 }
 
 func convertObjects(req admission.Request) (client.Object, client.Object, error) {